@@ -0,0 +1,9 @@
+package api
+
+import _ "embed"
+
+// OpenAPI is api/openapi.yaml, which is generated from the protos along with
+// the rest of the code.
+//
+//go:embed openapi.yaml
+var OpenAPI []byte
@@ -0,0 +1,203 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/andrewstucki/vanguard-playground/internal/client"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1/playgroundv1connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const defaultAdminPort = 8082
+
+var adminPort int
+
+// adminCmd represents the admin command group
+func adminCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "admin",
+		Short: "Inspect and manage workflow instances through the admin listener, authenticating with --token set to the admin token",
+	}
+
+	cmd.PersistentFlags().IntVar(&adminPort, "admin-port", defaultAdminPort, "Port of the admin listener, replacing the port of the selected context's admin server")
+
+	cmd.AddCommand(adminListCmd())
+	cmd.AddCommand(adminGetCmd())
+	cmd.AddCommand(adminTerminateCmd())
+	cmd.AddCommand(adminPurgeCmd())
+	cmd.AddCommand(adminRetryCmd())
+
+	return cmd
+}
+
+func newAdminClient() playgroundv1connect.AdminServiceClient {
+	c, err := client.NewAdminClient(append(clientOptions(), client.WithBaseURL(adminServerURL))...)
+	if err != nil {
+		fail(err)
+	}
+	return c
+}
+
+// parseWorkflowStatuses parses the names of workflow statuses given on the
+// command line, with or without their WORKFLOW_STATUS_ prefix.
+func parseWorkflowStatuses(names []string) []playgroundv1.WorkflowStatus {
+	var statuses []playgroundv1.WorkflowStatus
+	for _, name := range names {
+		value, ok := playgroundv1.WorkflowStatus_value["WORKFLOW_STATUS_"+strings.TrimPrefix(strings.ToUpper(name), "WORKFLOW_STATUS_")]
+		if !ok {
+			failUsage("unknown status %s", name)
+		}
+		statuses = append(statuses, playgroundv1.WorkflowStatus(value))
+	}
+	return statuses
+}
+
+func printWorkflowInstance(instance *playgroundv1.WorkflowInstance) {
+	fmt.Printf("instance: %s, workflow: %s, tenant: %s, operation: %s, status: %s, updated: %s\n",
+		instance.InstanceId,
+		instance.Name,
+		instance.TenantId,
+		instance.OperationId,
+		strings.TrimPrefix(instance.Status.String(), "WORKFLOW_STATUS_"),
+		instance.UpdateTime.AsTime().Local().Format("2006-01-02 15:04:05"),
+	)
+	if instance.Failure != "" {
+		fmt.Printf("  failure: %s\n", instance.Failure)
+	}
+}
+
+func adminListCmd() *cobra.Command {
+	var statuses []string
+	var name string
+	var limit int32
+
+	cmd := &cobra.Command{
+		Use:  "list",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			response, err := newAdminClient().ListWorkflowInstances(cmd.Context(), connect.NewRequest(&playgroundv1.ListWorkflowInstancesRequest{
+				Statuses: parseWorkflowStatuses(statuses),
+				Name:     name,
+				PageSize: limit,
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				for _, instance := range response.Msg.Instances {
+					printWorkflowInstance(instance)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&statuses, "status", nil, "Only list instances in these statuses, such as running or failed")
+	cmd.Flags().StringVar(&name, "name", "", "Only list instances of this workflow")
+	cmd.Flags().Int32Var(&limit, "limit", 0, "Most instances to list, 100 when unset")
+
+	return cmd
+}
+
+func adminGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "get [flags] <instance-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			response, err := newAdminClient().GetWorkflowInstance(cmd.Context(), connect.NewRequest(&playgroundv1.GetWorkflowInstanceRequest{
+				InstanceId: args[0],
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				printWorkflowInstance(response.Msg.Instance)
+				if response.Msg.CurrentStep != "" {
+					fmt.Printf("  current step: %s\n", response.Msg.CurrentStep)
+				}
+				if response.Msg.Input != nil {
+					fmt.Printf("  input: %s\n", protojson.Format(response.Msg.Input))
+				}
+				if response.Msg.Output != nil {
+					fmt.Printf("  output: %s\n", protojson.Format(response.Msg.Output))
+				}
+				fmt.Println("  history:")
+				for _, event := range response.Msg.History {
+					fmt.Printf("    %d %s %s", event.EventId, event.Time.AsTime().Local().Format("15:04:05.000"), event.Type)
+					if event.Name != "" {
+						fmt.Printf(" %s", event.Name)
+					}
+					if event.Failure != "" {
+						fmt.Printf(" (%s)", event.Failure)
+					}
+					fmt.Println()
+				}
+			})
+		},
+	}
+}
+
+func adminTerminateCmd() *cobra.Command {
+	var reason string
+
+	cmd := &cobra.Command{
+		Use:  "terminate [flags] <instance-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			_, err := newAdminClient().TerminateWorkflow(cmd.Context(), connect.NewRequest(&playgroundv1.TerminateWorkflowRequest{
+				InstanceId: args[0],
+				Reason:     reason,
+			}))
+			if err != nil {
+				fail(err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&reason, "reason", "", "Why the instance is being terminated")
+
+	return cmd
+}
+
+func adminPurgeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "purge [flags] <instance-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			_, err := newAdminClient().PurgeWorkflow(cmd.Context(), connect.NewRequest(&playgroundv1.PurgeWorkflowRequest{
+				InstanceId: args[0],
+			}))
+			if err != nil {
+				fail(err)
+			}
+		},
+	}
+}
+
+func adminRetryCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "retry [flags] <instance-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			response, err := newAdminClient().RetryWorkflow(cmd.Context(), connect.NewRequest(&playgroundv1.RetryWorkflowRequest{
+				InstanceId: args[0],
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				fmt.Printf("instance: %s\n", response.Msg.InstanceId)
+			})
+		},
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(adminCmd())
+}
@@ -0,0 +1,185 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+
+	"connectrpc.com/connect"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+)
+
+const uploadChunkBytes = 32 * 1024
+
+// attachmentCmd represents the attachment command group
+func attachmentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attachment",
+		Short: "Upload, download, list and delete message attachments",
+	}
+
+	cmd.AddCommand(attachmentUploadCmd())
+	cmd.AddCommand(attachmentDownloadCmd())
+	cmd.AddCommand(attachmentListCmd())
+	cmd.AddCommand(attachmentDeleteCmd())
+
+	return cmd
+}
+
+func attachmentUploadCmd() *cobra.Command {
+	var contentType string
+
+	cmd := &cobra.Command{
+		Use:  "upload [flags] <message-id> <file>",
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			attachment, err := uploadAttachment(cmd, args[0], args[1], contentType)
+			if err != nil {
+				fail(err)
+			}
+			render(attachment, func(bool) {
+				fmt.Printf("attachment: %+v\n", attachment)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&contentType, "content-type", "", "Content type of the file, guessed from its extension by default")
+
+	return cmd
+}
+
+func uploadAttachment(cmd *cobra.Command, messageID, path, contentType string) (*playgroundv1.Attachment, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(path))
+	}
+	checksum := sha256.Sum256(data)
+
+	client := newClient()
+	stream := client.UploadAttachment(cmd.Context())
+	request := &playgroundv1.UploadAttachmentRequest{
+		MessageId: messageID,
+		Filename:  filepath.Base(path),
+		Sha256:    hex.EncodeToString(checksum[:]),
+	}
+	for offset := 0; offset == 0 || offset < len(data); offset += uploadChunkBytes {
+		end := min(offset+uploadChunkBytes, len(data))
+		request.File = &httpbody.HttpBody{
+			ContentType: contentType,
+			Data:        data[offset:end],
+		}
+		if err := stream.Send(request); err != nil {
+			// the server's error is returned by CloseAndReceive
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+		// only the first request needs to carry the metadata
+		request = &playgroundv1.UploadAttachmentRequest{}
+	}
+
+	response, err := stream.CloseAndReceive()
+	if err != nil {
+		return nil, err
+	}
+	return response.Msg.Attachment, nil
+}
+
+func attachmentDownloadCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:  "download [flags] <message-id> <attachment-id>",
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			var writer io.Writer = os.Stdout
+			if output != "" {
+				file, err := os.Create(output)
+				if err != nil {
+					fail(err)
+				}
+				defer file.Close()
+				writer = file
+			}
+
+			client := newClient()
+			stream, err := client.DownloadAttachment(cmd.Context(), connect.NewRequest(&playgroundv1.DownloadAttachmentRequest{
+				MessageId:    args[0],
+				AttachmentId: args[1],
+			}))
+			if err != nil {
+				fail(err)
+			}
+			defer stream.Close()
+
+			for stream.Receive() {
+				if _, err := writer.Write(stream.Msg().File.GetData()); err != nil {
+					fail(err)
+				}
+			}
+			if err := stream.Err(); err != nil {
+				fail(err)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&output, "output-file", "", "File to write the attachment to instead of stdout")
+
+	return cmd
+}
+
+func attachmentListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "list [flags] <message-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.GetMessage(cmd.Context(), connect.NewRequest(&playgroundv1.GetMessageRequest{
+				MessageId: args[0],
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				for _, attachment := range response.Msg.Message.Attachments {
+					fmt.Printf("attachment: %+v\n", attachment)
+				}
+			})
+		},
+	}
+}
+
+func attachmentDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "delete [flags] <message-id> <attachment-id>",
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			_, err := client.DeleteAttachment(cmd.Context(), connect.NewRequest(&playgroundv1.DeleteAttachmentRequest{
+				MessageId:    args[0],
+				AttachmentId: args[1],
+			}))
+			if err != nil {
+				fail(err)
+			}
+		},
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(attachmentCmd())
+}
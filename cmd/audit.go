@@ -0,0 +1,87 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// auditCmd represents the audit command
+func auditCmd() *cobra.Command {
+	var since time.Duration
+	var start, end string
+	var tenantID string
+	var after int64
+	var limit int32
+
+	cmd := &cobra.Command{
+		Use:   "audit [flags]",
+		Short: "List the audit log, authenticating with --token set to the admin token",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			request := &playgroundv1.ListAuditEventsRequest{
+				TenantId:    tenantID,
+				AfterOffset: after,
+				PageSize:    limit,
+			}
+			if since > 0 {
+				request.StartTime = timestamppb.New(time.Now().Add(-since))
+			}
+			if start != "" {
+				request.StartTime = parseTime("start", start)
+			}
+			if end != "" {
+				request.EndTime = parseTime("end", end)
+			}
+
+			client := newClient()
+			response, err := client.ListAuditEvents(cmd.Context(), connect.NewRequest(request))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				for _, event := range response.Msg.Events {
+					fmt.Printf("%d %s %s %s %s resource: %q, outcome: %s, peer: %s, digest: %s\n",
+						event.Offset,
+						event.CreateTime.AsTime().Format("2006-01-02T15:04:05.000Z07:00"),
+						event.Principal,
+						event.TenantId,
+						event.Procedure,
+						event.ResourceId,
+						event.Outcome,
+						event.Peer,
+						event.RequestDigest,
+					)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().DurationVar(&since, "since", 0, "Only list events from this long ago onwards")
+	cmd.Flags().StringVar(&start, "start", "", "Only list events at or after this RFC 3339 time")
+	cmd.Flags().StringVar(&end, "end", "", "Only list events before this RFC 3339 time")
+	cmd.Flags().StringVar(&tenantID, "tenant-id", "", "Only list events of this tenant")
+	cmd.Flags().Int64Var(&after, "after", 0, "Only list events after this offset")
+	cmd.Flags().Int32Var(&limit, "limit", 0, "Most events to list, 100 when unset")
+
+	return cmd
+}
+
+func parseTime(flag, value string) *timestamppb.Timestamp {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		failUsage("--%s must be an RFC 3339 time: %v", flag, err)
+	}
+	return timestamppb.New(parsed)
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd())
+}
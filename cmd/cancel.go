@@ -0,0 +1,37 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"connectrpc.com/connect"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/spf13/cobra"
+)
+
+// cancelCmd represents the cancel command
+func cancelCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "cancel [flags] <message-id> <operation-id>",
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.CancelSend(cmd.Context(), connect.NewRequest(&playgroundv1.CancelSendRequest{
+				MessageId:   args[0],
+				OperationId: args[1],
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				fmt.Printf("state: %+v\n", response.Msg.State)
+			})
+		},
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(cancelCmd())
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// configEnv overrides where the config file is read from and written to.
+const configEnv = "VANGUARD_CONFIG"
+
+// cliConfig is the config file, which holds the contexts the CLI can target.
+type cliConfig struct {
+	CurrentContext string                 `yaml:"current-context,omitempty"`
+	Contexts       map[string]*cliContext `yaml:"contexts,omitempty"`
+}
+
+// cliContext is a server to target and how to call it. Flags given on the
+// command line take precedence over all of it.
+type cliContext struct {
+	Server      string     `yaml:"server,omitempty"`
+	AdminServer string     `yaml:"admin-server,omitempty"`
+	Tenant      string     `yaml:"tenant,omitempty"`
+	Token       string     `yaml:"token,omitempty"`
+	Protocol    string     `yaml:"protocol,omitempty"`
+	Output      string     `yaml:"output,omitempty"`
+	TLS         contextTLS `yaml:"tls,omitempty"`
+}
+
+// contextTLS configures TLS for servers with https URLs.
+type contextTLS struct {
+	CAFile             string `yaml:"ca-file,omitempty" json:"caFile,omitempty"`
+	CertFile           string `yaml:"cert-file,omitempty" json:"certFile,omitempty"`
+	KeyFile            string `yaml:"key-file,omitempty" json:"keyFile,omitempty"`
+	ServerName         string `yaml:"server-name,omitempty" json:"serverName,omitempty"`
+	InsecureSkipVerify bool   `yaml:"insecure-skip-verify,omitempty" json:"insecureSkipVerify,omitempty"`
+}
+
+// configPath returns where the config file lives, under the user's config
+// directory unless VANGUARD_CONFIG says otherwise.
+func configPath() (string, error) {
+	if path := os.Getenv(configEnv); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "vanguard-playground", "config.yaml"), nil
+}
+
+// loadConfig reads the config file, which is empty until a context is set.
+func loadConfig() (*cliConfig, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+	config := &cliConfig{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// save writes the config file. It holds tokens, so only the user may read
+// it, and it is replaced whole so that a failed write leaves the old one.
+func (c *cliConfig) save() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	var data bytes.Buffer
+	encoder := yaml.NewEncoder(&data)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(c); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), ".config-*.yaml")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data.Bytes()); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// validateServerURL checks the URL of a server in a context.
+func validateServerURL(server string) error {
+	parsed, err := url.Parse(server)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid server URL %q, use http://host:port or https://host:port", server)
+	}
+	return nil
+}
+
+// applyContext fills in whatever the command line leaves unset from the
+// selected context: the one --context names, or else the config file's
+// current one.
+func applyContext(cmd *cobra.Command) error {
+	serverURL = fmt.Sprintf("http://localhost:%d", port)
+	adminServerURL = fmt.Sprintf("http://localhost:%d", adminPort)
+
+	config, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	name := contextFlag
+	if name == "" {
+		name = config.CurrentContext
+	}
+	if name == "" {
+		return nil
+	}
+	selected, ok := config.Contexts[name]
+	if !ok {
+		return fmt.Errorf("context %q not found, see context list", name)
+	}
+
+	// --port and --admin-port replace the port of the context's servers,
+	// keeping their scheme and host so that their TLS settings still apply
+	flags := cmd.Flags()
+	if selected.Server != "" {
+		if serverURL, err = contextServerURL(flags.Changed("port"), selected.Server, port); err != nil {
+			return err
+		}
+	}
+	if selected.AdminServer != "" {
+		if adminServerURL, err = contextServerURL(flags.Changed("admin-port"), selected.AdminServer, adminPort); err != nil {
+			return err
+		}
+	}
+	// tenants and tokens from the environment beat the context's too
+	if tenant == "" && !flags.Changed("tenant") {
+		tenant = selected.Tenant
+	}
+	if token == "" && !flags.Changed("token") {
+		token = selected.Token
+	}
+	if selected.Protocol != "" && !flags.Changed("protocol") {
+		protocol = selected.Protocol
+	}
+	if selected.Output != "" && !flags.Changed("output") {
+		outputFlag = selected.Output
+	}
+	tlsConfig, err = selected.TLS.config()
+	return err
+}
+
+// contextServerURL returns the URL of a context's server, with its port
+// replaced when the flag for it is set.
+func contextServerURL(changed bool, server string, port int) (string, error) {
+	if !changed {
+		return server, nil
+	}
+	parsed, err := url.Parse(server)
+	if err != nil {
+		return "", fmt.Errorf("invalid server URL %q of the context: %w", server, err)
+	}
+	parsed.Host = net.JoinHostPort(parsed.Hostname(), strconv.Itoa(port))
+	return parsed.String(), nil
+}
+
+// config builds the TLS config of a context, nil when it sets nothing.
+func (t contextTLS) config() (*tls.Config, error) {
+	if t == (contextTLS{}) {
+		return nil, nil
+	}
+	config := &tls.Config{
+		ServerName:         t.ServerName,
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+	if t.CAFile != "" {
+		pem, err := os.ReadFile(t.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in %s", t.CAFile)
+		}
+	}
+	if t.CertFile != "" || t.KeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return config, nil
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"context"
+	"encoding/pem"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+
+	"github.com/andrewstucki/vanguard-playground/internal/client"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1/playgroundv1connect"
+)
+
+// tlsMessageService answers GetMessage over TLS.
+type tlsMessageService struct {
+	playgroundv1connect.UnimplementedMessageServiceHandler
+}
+
+func (tlsMessageService) GetMessage(_ context.Context, req *connect.Request[playgroundv1.GetMessageRequest]) (*connect.Response[playgroundv1.GetMessageResponse], error) {
+	return connect.NewResponse(&playgroundv1.GetMessageResponse{
+		Message: &playgroundv1.Message{MessageId: req.Msg.MessageId, Text: "over TLS"},
+	}), nil
+}
+
+func TestContextTLSWithPort(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle(playgroundv1connect.NewMessageServiceHandler(tlsMessageService{}))
+	server := httptest.NewUnstartedServer(mux)
+	server.EnableHTTP2 = true
+	// the handshakes of the untrusted case fail on purpose
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	address, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(configEnv, filepath.Join(dir, "config.yaml"))
+	config := &cliConfig{
+		CurrentContext: "remote",
+		Contexts: map[string]*cliContext{
+			// the context's port is wrong, --port gives the right one
+			"remote":    {Server: "https://" + address.Hostname() + ":1", TLS: contextTLS{CAFile: caFile}},
+			"untrusted": {Server: "https://" + address.Hostname() + ":1"},
+		},
+	}
+	if err := config.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name     string
+		context  string
+		protocol client.Protocol
+		ok       bool
+	}{
+		{name: "connect", context: "remote", protocol: client.ProtocolConnect, ok: true},
+		{name: "grpc", context: "remote", protocol: client.ProtocolGRPC, ok: true},
+		{name: "without the context's CA", context: "untrusted", protocol: client.ProtocolConnect},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().AddFlagSet(rootCmd.PersistentFlags())
+			if err := cmd.ParseFlags([]string{"--context", test.context, "--port", address.Port(), "--protocol", string(test.protocol)}); err != nil {
+				t.Fatal(err)
+			}
+			if err := applyContext(cmd); err != nil {
+				t.Fatal(err)
+			}
+			if want := "https://" + address.Host; serverURL != want {
+				t.Fatalf("expected --port to replace the port of the context's server %s, got %s", want, serverURL)
+			}
+
+			response, err := newClient().GetMessage(context.Background(), connect.NewRequest(&playgroundv1.GetMessageRequest{MessageId: "m"}))
+			if !test.ok {
+				if err == nil {
+					t.Fatal("expected a server the context doesn't trust to be refused")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if response.Msg.Message.Text != "over TLS" {
+				t.Errorf("expected the message over TLS, got %v", response.Msg.Message)
+			}
+		})
+	}
+}
+
+func TestContextServerURL(t *testing.T) {
+	for _, test := range []struct {
+		server  string
+		changed bool
+		want    string
+	}{
+		{server: "https://api.example.com:8443", want: "https://api.example.com:8443"},
+		{server: "https://api.example.com:8443", changed: true, want: "https://api.example.com:9000"},
+		{server: "https://api.example.com", changed: true, want: "https://api.example.com:9000"},
+		{server: "http://[::1]:8081/prefix", changed: true, want: "http://[::1]:9000/prefix"},
+	} {
+		got, err := contextServerURL(test.changed, test.server, 9000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.want {
+			t.Errorf("expected %s with --port set: %t to be %s, got %s", test.server, test.changed, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/andrewstucki/vanguard-playground/internal/client"
+	"github.com/spf13/cobra"
+)
+
+// contextCmd represents the context command group
+func contextCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "context",
+		Short: "Manage the contexts of the config file, each a server to target and how to call it",
+		// these commands edit the contexts, so they don't need a valid one
+		PersistentPreRunE: validateOutput,
+	}
+
+	cmd.AddCommand(contextListCmd())
+	cmd.AddCommand(contextUseCmd())
+	cmd.AddCommand(contextSetCmd())
+	cmd.AddCommand(contextDeleteCmd())
+
+	return cmd
+}
+
+// contextView is how contexts are listed, without their tokens.
+type contextView struct {
+	Name        string      `json:"name"`
+	Current     bool        `json:"current"`
+	Server      string      `json:"server,omitempty"`
+	AdminServer string      `json:"adminServer,omitempty"`
+	Tenant      string      `json:"tenant,omitempty"`
+	HasToken    bool        `json:"hasToken"`
+	Protocol    string      `json:"protocol,omitempty"`
+	Output      string      `json:"output,omitempty"`
+	TLS         *contextTLS `json:"tls,omitempty"`
+}
+
+func mustLoadConfig() *cliConfig {
+	config, err := loadConfig()
+	if err != nil {
+		fail(err)
+	}
+	return config
+}
+
+func contextListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "list",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			config := mustLoadConfig()
+			names := make([]string, 0, len(config.Contexts))
+			for name := range config.Contexts {
+				names = append(names, name)
+			}
+			slices.Sort(names)
+
+			result := struct {
+				Contexts []contextView `json:"contexts"`
+			}{Contexts: []contextView{}}
+			for _, name := range names {
+				c := config.Contexts[name]
+				result.Contexts = append(result.Contexts, contextView{
+					Name:        name,
+					Current:     name == config.CurrentContext,
+					Server:      c.Server,
+					AdminServer: c.AdminServer,
+					Tenant:      c.Tenant,
+					HasToken:    c.Token != "",
+					Protocol:    c.Protocol,
+					Output:      c.Output,
+				})
+				if c.TLS != (contextTLS{}) {
+					result.Contexts[len(result.Contexts)-1].TLS = &c.TLS
+				}
+			}
+
+			render(result, func(wide bool) {
+				headers := []string{"CURRENT", "NAME", "SERVER", "TENANT", "PROTOCOL", "OUTPUT"}
+				if wide {
+					headers = append(headers, "ADMIN SERVER", "TOKEN", "CA FILE", "CERT FILE", "INSECURE")
+				}
+				t := newTable(headers...)
+				for _, view := range result.Contexts {
+					current := ""
+					if view.Current {
+						current = "*"
+					}
+					cells := []string{current, view.Name, view.Server, view.Tenant, view.Protocol, view.Output}
+					if wide {
+						var tlsSettings contextTLS
+						if view.TLS != nil {
+							tlsSettings = *view.TLS
+						}
+						cells = append(cells, view.AdminServer, formatBool(view.HasToken), tlsSettings.CAFile, tlsSettings.CertFile, formatBool(tlsSettings.InsecureSkipVerify))
+					}
+					t.row(cells...)
+				}
+				t.flush()
+			})
+		},
+	}
+}
+
+func formatBool(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+func contextUseCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "use <name>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			config := mustLoadConfig()
+			if _, ok := config.Contexts[args[0]]; !ok {
+				failUsage("context %q not found", args[0])
+			}
+			config.CurrentContext = args[0]
+			if err := config.save(); err != nil {
+				fail(err)
+			}
+			fmt.Printf("switched to context %q\n", args[0])
+		},
+	}
+}
+
+func contextSetCmd() *cobra.Command {
+	var update cliContext
+	var use bool
+
+	cmd := &cobra.Command{
+		Use:   "set [flags] <name>",
+		Short: "Create or update a context, changing only what is given: --tenant, --token and --protocol are stored too",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			flags := cmd.Flags()
+			config := mustLoadConfig()
+			if config.Contexts == nil {
+				config.Contexts = map[string]*cliContext{}
+			}
+			c, ok := config.Contexts[args[0]]
+			if !ok {
+				c = &cliContext{}
+				config.Contexts[args[0]] = c
+			}
+
+			if flags.Changed("server") {
+				if err := validateServerURL(update.Server); err != nil {
+					failUsage("%v", err)
+				}
+				c.Server = update.Server
+			}
+			if flags.Changed("admin-server") {
+				if err := validateServerURL(update.AdminServer); err != nil {
+					failUsage("%v", err)
+				}
+				c.AdminServer = update.AdminServer
+			}
+			if flags.Changed("tenant") {
+				c.Tenant = tenant
+			}
+			if flags.Changed("token") {
+				c.Token = token
+			}
+			if flags.Changed("protocol") {
+				if !slices.Contains(client.Protocols, client.Protocol(protocol)) {
+					failUsage("unsupported protocol %q, use connect, grpc or grpcweb", protocol)
+				}
+				c.Protocol = protocol
+			}
+			if flags.Changed("default-output") {
+				if _, err := parseOutput(update.Output); err != nil {
+					failUsage("%v", err)
+				}
+				c.Output = update.Output
+			}
+			if flags.Changed("ca-file") {
+				c.TLS.CAFile = update.TLS.CAFile
+			}
+			if flags.Changed("cert-file") {
+				c.TLS.CertFile = update.TLS.CertFile
+			}
+			if flags.Changed("key-file") {
+				c.TLS.KeyFile = update.TLS.KeyFile
+			}
+			if flags.Changed("server-name") {
+				c.TLS.ServerName = update.TLS.ServerName
+			}
+			if flags.Changed("insecure-skip-verify") {
+				c.TLS.InsecureSkipVerify = update.TLS.InsecureSkipVerify
+			}
+			if _, err := c.TLS.config(); err != nil {
+				failUsage("%v", err)
+			}
+
+			if use || config.CurrentContext == "" {
+				config.CurrentContext = args[0]
+			}
+			if err := config.save(); err != nil {
+				fail(err)
+			}
+			fmt.Printf("context %q set\n", args[0])
+		},
+	}
+
+	cmd.Flags().StringVar(&update.Server, "server", "", "URL of the server's main listener, such as https://playground.example.com")
+	cmd.Flags().StringVar(&update.AdminServer, "admin-server", "", "URL of the server's admin listener")
+	cmd.Flags().StringVar(&update.Output, "default-output", "", "Output format when --output isn't given")
+	cmd.Flags().StringVar(&update.TLS.CAFile, "ca-file", "", "PEM file of the certificate authorities to trust instead of the system's")
+	cmd.Flags().StringVar(&update.TLS.CertFile, "cert-file", "", "PEM file of the client certificate to present")
+	cmd.Flags().StringVar(&update.TLS.KeyFile, "key-file", "", "PEM file of the client certificate's key")
+	cmd.Flags().StringVar(&update.TLS.ServerName, "server-name", "", "Name to verify the server's certificate against instead of its host")
+	cmd.Flags().BoolVar(&update.TLS.InsecureSkipVerify, "insecure-skip-verify", false, "Skip verifying the server's certificate")
+	cmd.Flags().BoolVar(&use, "use", false, "Switch to the context too, as happens anyway when there is no current one")
+
+	return cmd
+}
+
+func contextDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "delete <name>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			config := mustLoadConfig()
+			if _, ok := config.Contexts[args[0]]; !ok {
+				failUsage("context %q not found", args[0])
+			}
+			delete(config.Contexts, args[0])
+			if config.CurrentContext == args[0] {
+				config.CurrentContext = ""
+			}
+			if err := config.save(); err != nil {
+				fail(err)
+			}
+			fmt.Printf("context %q deleted\n", args[0])
+		},
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(contextCmd())
+}
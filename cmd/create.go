@@ -6,31 +6,59 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"connectrpc.com/connect"
 	"github.com/spf13/cobra"
 
-	"github.com/andrewstucki/vanguard-playground/internal/client"
 	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
 )
 
 // createCmd represents the create command
 func createCmd() *cobra.Command {
-	return &cobra.Command{
+	var labels map[string]string
+	var contentType string
+	var payloadFile string
+
+	cmd := &cobra.Command{
 		Use:  "create [flags] <text>",
 		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			client := client.NewClient(port)
+			value, ok := playgroundv1.ContentType_value[strings.ToUpper(contentType)]
+			if !ok {
+				failUsage("unknown content type %s", contentType)
+			}
+
+			var payload []byte
+			if payloadFile != "" {
+				var err error
+				payload, err = os.ReadFile(payloadFile)
+				if err != nil {
+					fail(err)
+				}
+			}
+
+			client := newClient()
 			response, err := client.CreateMessage(cmd.Context(), connect.NewRequest(&playgroundv1.CreateMessageRequest{
-				Text: args[0],
+				Text:        args[0],
+				Labels:      labels,
+				ContentType: playgroundv1.ContentType(value),
+				Payload:     payload,
 			}))
 			if err != nil {
-				fmt.Println("error:", err)
-				os.Exit(1)
+				fail(err)
 			}
-			fmt.Printf("created message with ID: %s\n", response.Msg.MessageId)
+			render(response.Msg, func(bool) {
+				fmt.Printf("created message with ID: %s\n", response.Msg.MessageId)
+			})
 		},
 	}
+
+	cmd.Flags().StringToStringVarP(&labels, "label", "l", nil, "Label to attach as key=value")
+	cmd.Flags().StringVar(&contentType, "content-type", "plain", "Content type of the text: plain, markdown or json")
+	cmd.Flags().StringVar(&payloadFile, "payload-file", "", "File to attach as the message's binary payload")
+
+	return cmd
 }
 
 func init() {
@@ -0,0 +1,130 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/spf13/cobra"
+)
+
+// dlqCmd represents the dlq command group
+func dlqCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dlq",
+		Short: "Inspect and redrive permanently failed sends",
+	}
+
+	cmd.AddCommand(dlqListCmd())
+	cmd.AddCommand(dlqShowCmd())
+	cmd.AddCommand(dlqRedriveCmd())
+	cmd.AddCommand(dlqPurgeCmd())
+
+	return cmd
+}
+
+func dlqListCmd() *cobra.Command {
+	var messageID string
+
+	cmd := &cobra.Command{
+		Use: "list",
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.ListDeadLetters(cmd.Context(), connect.NewRequest(&playgroundv1.ListDeadLettersRequest{
+				MessageId: messageID,
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				for _, deadLetter := range response.Msg.DeadLetters {
+					fmt.Printf("dead letter: %s, operation: %s, message: %s, step: %s, attempts: %d, error: %s\n",
+						deadLetter.DeadLetterId, deadLetter.OperationId, deadLetter.MessageId, deadLetter.Step, len(deadLetter.Attempts), deadLetter.LastError)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&messageID, "message", "m", "", "Only list dead letters for this message")
+
+	return cmd
+}
+
+func dlqShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "show [flags] <dead-letter-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.GetDeadLetter(cmd.Context(), connect.NewRequest(&playgroundv1.GetDeadLetterRequest{
+				DeadLetterId: args[0],
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				fmt.Printf("dead letter: %+v\n", response.Msg.DeadLetter)
+			})
+		},
+	}
+}
+
+func dlqRedriveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "redrive [flags] <dead-letter-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.RedriveDeadLetter(cmd.Context(), connect.NewRequest(&playgroundv1.RedriveDeadLetterRequest{
+				DeadLetterId: args[0],
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				fmt.Printf("operation: %+v, message: %+v\n", response.Msg.OperationId, response.Msg.MessageId)
+			})
+		},
+	}
+}
+
+func dlqPurgeCmd() *cobra.Command {
+	var all bool
+
+	cmd := &cobra.Command{
+		Use: "purge [flags] [dead-letter-id...]",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && !all {
+				return errors.New("specify dead letter IDs to purge or pass --all")
+			}
+			if len(args) > 0 && all {
+				return errors.New("--all cannot be combined with dead letter IDs")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.PurgeDeadLetters(cmd.Context(), connect.NewRequest(&playgroundv1.PurgeDeadLettersRequest{
+				DeadLetterIds: args,
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				fmt.Printf("purged %d dead letters\n", response.Msg.Purged)
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Purge every dead letter")
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(dlqCmd())
+}
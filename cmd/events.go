@@ -0,0 +1,53 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"connectrpc.com/connect"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/spf13/cobra"
+)
+
+// eventsCmd represents the events command
+func eventsCmd() *cobra.Command {
+	var after int64
+	var types []string
+
+	cmd := &cobra.Command{
+		Use:  "events [flags]",
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			stream, err := client.StreamEvents(cmd.Context(), connect.NewRequest(&playgroundv1.StreamEventsRequest{
+				AfterOffset: after,
+				Types:       types,
+			}))
+			if err != nil {
+				fail(err)
+			}
+			defer stream.Close()
+
+			for stream.Receive() {
+				event := stream.Msg().Event
+				render(event, func(bool) {
+					fmt.Printf("%d %s %s %s\n", event.Offset, event.Time.AsTime().Format("2006-01-02T15:04:05.000Z07:00"), event.Type, event.Subject)
+				})
+			}
+			if err := stream.Err(); err != nil {
+				fail(err)
+			}
+		},
+	}
+
+	cmd.Flags().Int64Var(&after, "after", 0, "Only stream events after this offset")
+	cmd.Flags().StringSliceVar(&types, "type", nil, "Only stream events of these types")
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(eventsCmd())
+}
@@ -4,11 +4,7 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
-	"fmt"
-	"os"
-
 	"connectrpc.com/connect"
-	"github.com/andrewstucki/vanguard-playground/internal/client"
 	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
 	"github.com/spf13/cobra"
 )
@@ -19,15 +15,16 @@ func getCmd() *cobra.Command {
 		Use:  "get [flags] <message-id>",
 		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			client := client.NewClient(port)
+			client := newClient()
 			response, err := client.GetMessage(cmd.Context(), connect.NewRequest(&playgroundv1.GetMessageRequest{
 				MessageId: args[0],
 			}))
 			if err != nil {
-				fmt.Println("error:", err)
-				os.Exit(1)
+				fail(err)
 			}
-			fmt.Printf("message: %+v\n", response.Msg.Message)
+			render(response.Msg, func(wide bool) {
+				printMessages([]*playgroundv1.Message{response.Msg.Message}, wide)
+			})
 		},
 	}
 }
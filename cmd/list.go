@@ -4,31 +4,34 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
-	"fmt"
-	"os"
-
 	"connectrpc.com/connect"
-	"github.com/andrewstucki/vanguard-playground/internal/client"
 	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
 	"github.com/spf13/cobra"
 )
 
 // listCmd represents the list command
 func listCmd() *cobra.Command {
-	return &cobra.Command{
+	var labels []string
+
+	cmd := &cobra.Command{
 		Use: "list",
 		Run: func(cmd *cobra.Command, args []string) {
-			client := client.NewClient(port)
-			response, err := client.ListMessages(cmd.Context(), connect.NewRequest(&playgroundv1.ListMessagesRequest{}))
+			client := newClient()
+			response, err := client.ListMessages(cmd.Context(), connect.NewRequest(&playgroundv1.ListMessagesRequest{
+				Labels: labels,
+			}))
 			if err != nil {
-				fmt.Println("error:", err)
-				os.Exit(1)
-			}
-			for _, message := range response.Msg.Messages {
-				fmt.Printf("message: %+v\n", message)
+				fail(err)
 			}
+			render(response.Msg, func(wide bool) {
+				printMessages(response.Msg.Messages, wide)
+			})
 		},
 	}
+
+	cmd.Flags().StringArrayVarP(&labels, "label", "l", nil, "Only list messages with this key=value label")
+
+	return cmd
 }
 
 func init() {
@@ -0,0 +1,52 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+)
+
+// printMessages prints messages as a table, with their labels sorted by key.
+// The wide format adds the full text, the payload and the attachments.
+func printMessages(messages []*playgroundv1.Message, wide bool) {
+	headers := []string{"ID", "CONTENT TYPE", "LABELS", "CREATED", "TEXT"}
+	if wide {
+		headers = append(headers, "UPDATED", "PAYLOAD", "ATTACHMENTS")
+	}
+
+	t := newTable(headers...)
+	for _, message := range messages {
+		var labels []string
+		for key, value := range message.Labels {
+			labels = append(labels, key+"="+value)
+		}
+		slices.Sort(labels)
+
+		row := []string{
+			message.MessageId,
+			message.ContentType.String(),
+			strings.Join(labels, ","),
+			formatTime(message.CreateTime),
+			truncate(message.Text, wide),
+		}
+		if wide {
+			var attachments []string
+			for _, attachment := range message.Attachments {
+				attachments = append(attachments, fmt.Sprintf("%s (%d bytes)", attachment.Filename, attachment.SizeBytes))
+			}
+			payload := ""
+			if len(message.Payload) > 0 {
+				payload = strconv.Itoa(len(message.Payload)) + " bytes"
+			}
+			row = append(row, formatTime(message.UpdateTime), payload, strings.Join(attachments, ","))
+		}
+		t.row(row...)
+	}
+	t.flush()
+}
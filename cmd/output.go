@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gopkg.in/yaml.v3"
+)
+
+// The formats commands print their results in, selected with --output.
+const (
+	outputTable          = "table"
+	outputWide           = "wide"
+	outputJSON           = "json"
+	outputYAML           = "yaml"
+	outputTemplatePrefix = "go-template="
+)
+
+// Exit codes. Calls the server fails exit with exitCodeBase plus the Connect
+// code of the error, so that scripts can tell, say, not found (15) from
+// unavailable (24). These never change once released.
+const (
+	exitFailure      = 1
+	exitUsage        = 2
+	exitNotSucceeded = 3
+	exitCodeBase     = 10
+)
+
+const exitCodesHelp = `Exit codes:
+  0   success
+  1   failure that isn't the server's, such as a file that can't be read
+  2   invalid flags or arguments
+  3   with --wait, the operation finished without succeeding
+  10+ the call failed, with 10 added to its Connect code: 13 invalid
+      argument, 14 deadline exceeded (including --wait timing out), 15 not found, 16 already exists,
+      17 permission denied, 18 resource exhausted, 19 failed precondition,
+      20 aborted, 23 internal, 24 unavailable, 26 unauthenticated`
+
+var outputFlag string
+
+// outputTemplate is the parsed template of --output go-template=...
+var outputTemplate *template.Template
+
+// validateOutput checks --output before any command runs, so that a bad
+// format fails before the call it would print rather than after.
+func validateOutput(*cobra.Command, []string) error {
+	parsed, err := parseOutput(outputFlag)
+	if err != nil {
+		return err
+	}
+	outputTemplate = parsed
+	return nil
+}
+
+// parseOutput checks an output format, returning its parsed template when
+// it is go-template=...
+func parseOutput(format string) (*template.Template, error) {
+	switch format {
+	case outputTable, outputWide, outputJSON, outputYAML:
+		return nil, nil
+	}
+	text, ok := strings.CutPrefix(format, outputTemplatePrefix)
+	if !ok {
+		return nil, fmt.Errorf("unknown output format %q, use table, wide, json, yaml or go-template=...", format)
+	}
+	parsed, err := template.New("output").Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid output template: %w", err)
+	}
+	return parsed, nil
+}
+
+// exitCode maps an error to the exit code the process ends with.
+func exitCode(err error) int {
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		return exitCodeBase + int(connectErr.Code())
+	}
+	return exitFailure
+}
+
+// fail prints an error to stderr and exits with its exit code.
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "error:", err)
+	os.Exit(exitCode(err))
+}
+
+// failUsage reports invalid flags or arguments.
+func failUsage(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "error: "+format+"\n", args...)
+	os.Exit(exitUsage)
+}
+
+// render prints the result of a command in the selected output format. The
+// table and wide formats are printed by printTable, which is told whether the
+// wide one was selected. Results other than protobuf messages are encoded
+// with encoding/json, and should carry json tags.
+func render(result any, printTable func(wide bool)) {
+	if err := renderTo(os.Stdout, result, printTable); err != nil {
+		fail(err)
+	}
+}
+
+func renderTo(w io.Writer, result any, printTable func(wide bool)) error {
+	switch outputFlag {
+	case outputTable, outputWide:
+		printTable(outputFlag == outputWide)
+		return nil
+	}
+
+	data, err := marshalJSON(result, false)
+	if err != nil {
+		return err
+	}
+
+	switch outputFlag {
+	case outputJSON:
+		data, err = marshalJSON(result, true)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	case outputYAML:
+		// JSON is YAML, so decoding it as a node keeps the field order that
+		// decoding it into a map would lose
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return err
+		}
+		blockStyle(&node)
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&node); err != nil {
+			return err
+		}
+		return encoder.Close()
+	}
+
+	var value any
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	if err := outputTemplate.Execute(w, value); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w)
+	return err
+}
+
+// marshalJSON encodes a result as JSON, with protojson when it is a protobuf
+// message.
+func marshalJSON(result any, indent bool) ([]byte, error) {
+	if msg, ok := result.(proto.Message); ok {
+		if indent {
+			return protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(msg)
+		}
+		return protojson.Marshal(msg)
+	}
+	if indent {
+		return json.MarshalIndent(result, "", "  ")
+	}
+	return json.Marshal(result)
+}
+
+// blockStyle clears the flow and quoting styles decoding JSON gives nodes,
+// so that they are encoded as plain block YAML.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// table prints rows aligned in columns under a header.
+type table struct {
+	w *tabwriter.Writer
+}
+
+func newTable(headers ...string) *table {
+	t := &table{w: tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)}
+	t.row(headers...)
+	return t
+}
+
+func (t *table) row(cells ...string) {
+	for i, cell := range cells {
+		if cell == "" {
+			cell = "-"
+		}
+		// tabs and newlines would break the columns
+		cells[i] = strings.NewReplacer("\t", " ", "\n", " ", "\r", " ").Replace(cell)
+	}
+	fmt.Fprintln(t.w, strings.Join(cells, "\t"))
+}
+
+func (t *table) flush() {
+	_ = t.w.Flush()
+}
+
+// truncate shortens text for the narrow table format.
+func truncate(text string, wide bool) string {
+	const maxWidth = 40
+	if wide || len([]rune(text)) <= maxWidth {
+		return text
+	}
+	return string([]rune(text)[:maxWidth-3]) + "..."
+}
+
+// formatTime formats a timestamp for tables, blank when unset.
+func formatTime(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Local().Format(time.DateTime)
+}
@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1/playgroundv1connect"
+)
+
+// cliArgsEnv makes the test binary run the CLI with the JSON encoded
+// arguments it holds instead of the tests, so that tests can see what the
+// CLI prints and the code it exits with.
+const cliArgsEnv = "VANGUARD_TEST_CLI_ARGS"
+
+func TestMain(m *testing.M) {
+	if encoded, ok := os.LookupEnv(cliArgsEnv); ok {
+		var args []string
+		if err := json.Unmarshal([]byte(encoded), &args); err != nil {
+			panic(err)
+		}
+		rootCmd.SetArgs(args)
+		Execute()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runCLI runs the CLI against the server at serverURL, returning what it
+// printed and its exit code.
+func runCLI(t *testing.T, serverURL string, args ...string) (string, string, int) {
+	t.Helper()
+	address, err := url.Parse(serverURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := json.Marshal(append(args, "--port", address.Port()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(os.Args[0])
+	cmd.Env = append(os.Environ(),
+		cliArgsEnv+"="+string(encoded),
+		configEnv+"="+filepath.Join(t.TempDir(), "config.yaml"),
+		"VANGUARD_CONTEXT=",
+		"VANGUARD_TENANT=",
+		"VANGUARD_TOKEN=",
+	)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			t.Fatal(err)
+		}
+	}
+	return stdout.String(), stderr.String(), cmd.ProcessState.ExitCode()
+}
+
+// cliMessageService answers the CLI with canned messages and operations.
+type cliMessageService struct {
+	playgroundv1connect.UnimplementedMessageServiceHandler
+	// state is the state MessageStatus reports for every operation
+	state playgroundv1.MessageState
+}
+
+func (cliMessageService) GetMessage(_ context.Context, req *connect.Request[playgroundv1.GetMessageRequest]) (*connect.Response[playgroundv1.GetMessageResponse], error) {
+	switch req.Msg.MessageId {
+	case "missing":
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("message not found"))
+	case "busy":
+		return nil, connect.NewError(connect.CodeUnavailable, errors.New("try again"))
+	}
+	return connect.NewResponse(&playgroundv1.GetMessageResponse{
+		Message: &playgroundv1.Message{
+			MessageId: req.Msg.MessageId,
+			Text:      "a message whose text is long enough to be truncated in the table",
+			Labels:    map[string]string{"team": "core"},
+		},
+	}), nil
+}
+
+func (cliMessageService) SendMessage(_ context.Context, req *connect.Request[playgroundv1.SendMessageRequest]) (*connect.Response[playgroundv1.SendMessageResponse], error) {
+	return connect.NewResponse(&playgroundv1.SendMessageResponse{MessageId: req.Msg.MessageId, OperationId: "op"}), nil
+}
+
+func (s cliMessageService) MessageStatus(context.Context, *connect.Request[playgroundv1.MessageStatusRequest]) (*connect.Response[playgroundv1.MessageStatusResponse], error) {
+	return connect.NewResponse(&playgroundv1.MessageStatusResponse{State: s.state.String(), CurrentStep: "deliver"}), nil
+}
+
+// newCLIServer serves a cliMessageService.
+func newCLIServer(t *testing.T, service cliMessageService) string {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.Handle(playgroundv1connect.NewMessageServiceHandler(service))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestOutputFormats(t *testing.T) {
+	server := newCLIServer(t, cliMessageService{})
+
+	for _, test := range []struct {
+		output string
+		want   []string
+		absent []string
+	}{
+		{
+			output: "table",
+			want:   []string{"ID", "TEXT", "m1", "team=core", "a message whose text is long enough", "..."},
+			absent: []string{"UPDATED", "in the table"},
+		},
+		{
+			output: "wide",
+			want:   []string{"ID", "UPDATED", "ATTACHMENTS", "in the table"},
+		},
+		{
+			output: "yaml",
+			want:   []string{"message:\n  messageId: m1\n", "    team: core\n"},
+			absent: []string{"{"},
+		},
+		{
+			output: "go-template={{.message.messageId}}/{{.message.labels.team}}",
+			want:   []string{"m1/core\n"},
+		},
+	} {
+		t.Run(test.output, func(t *testing.T) {
+			stdout, stderr, code := runCLI(t, server, "get", "m1", "--output", test.output)
+			if code != 0 {
+				t.Fatalf("expected success, got %d: %s", code, stderr)
+			}
+			for _, want := range test.want {
+				if !strings.Contains(stdout, want) {
+					t.Errorf("expected the output to contain %q, got:\n%s", want, stdout)
+				}
+			}
+			for _, absent := range test.absent {
+				if strings.Contains(stdout, absent) {
+					t.Errorf("expected the output not to contain %q, got:\n%s", absent, stdout)
+				}
+			}
+		})
+	}
+
+	// protojson varies its spacing on purpose, so json is checked decoded
+	stdout, stderr, code := runCLI(t, server, "get", "m1", "-o", "json")
+	if code != 0 {
+		t.Fatalf("expected success, got %d: %s", code, stderr)
+	}
+	var decoded struct {
+		Message struct {
+			MessageID string            `json:"messageId"`
+			Labels    map[string]string `json:"labels"`
+		} `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(stdout), &decoded); err != nil {
+		t.Fatalf("expected -o json to print JSON, got %v:\n%s", err, stdout)
+	}
+	if decoded.Message.MessageID != "m1" || decoded.Message.Labels["team"] != "core" {
+		t.Errorf("expected the message as JSON, got:\n%s", stdout)
+	}
+	if !strings.Contains(stdout, "\n  ") {
+		t.Errorf("expected -o json to be indented, got:\n%s", stdout)
+	}
+}
+
+func TestExitCodes(t *testing.T) {
+	server := newCLIServer(t, cliMessageService{})
+
+	for _, test := range []struct {
+		name string
+		args []string
+		code int
+	}{
+		{name: "success", args: []string{"get", "m1"}, code: 0},
+		{name: "not found", args: []string{"get", "missing"}, code: 15},
+		{name: "unavailable", args: []string{"get", "busy", "--call-timeout", "2s"}, code: 24},
+		{name: "unknown output format", args: []string{"get", "m1", "-o", "xml"}, code: exitUsage},
+		{name: "invalid output template", args: []string{"get", "m1", "-o", "go-template={{"}, code: exitUsage},
+		{name: "missing argument", args: []string{"get"}, code: exitUsage},
+		{name: "unknown flag", args: []string{"get", "m1", "--bogus"}, code: exitUsage},
+		{name: "unreadable file", args: []string{"attachment", "upload", "m1", filepath.Join(t.TempDir(), "missing.txt")}, code: exitFailure},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, stderr, code := runCLI(t, server, test.args...)
+			if code != test.code {
+				t.Errorf("expected exit code %d, got %d: %s", test.code, code, stderr)
+			}
+			if code != 0 && !strings.Contains(strings.ToLower(stderr), "error") {
+				t.Errorf("expected an error on stderr, got %q", stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/spf13/cobra"
+)
+
+// recipientCmd represents the recipient command group
+func recipientCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recipient",
+		Short: "Manage message recipients",
+	}
+
+	cmd.AddCommand(recipientCreateCmd())
+	cmd.AddCommand(recipientGetCmd())
+	cmd.AddCommand(recipientListCmd())
+	cmd.AddCommand(recipientDeleteCmd())
+
+	return cmd
+}
+
+func recipientCreateCmd() *cobra.Command {
+	var channel string
+
+	cmd := &cobra.Command{
+		Use:  "create [flags] <address>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			value, ok := playgroundv1.Channel_value[strings.ToUpper(channel)]
+			if !ok {
+				failUsage("unknown channel %s", channel)
+			}
+
+			client := newClient()
+			response, err := client.CreateRecipient(cmd.Context(), connect.NewRequest(&playgroundv1.CreateRecipientRequest{
+				Address: args[0],
+				Channel: playgroundv1.Channel(value),
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				fmt.Printf("recipient: %+v\n", response.Msg.RecipientId)
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&channel, "channel", "c", "email", "Channel to deliver over: email, sms or push")
+
+	return cmd
+}
+
+func recipientGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "get [flags] <recipient-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.GetRecipient(cmd.Context(), connect.NewRequest(&playgroundv1.GetRecipientRequest{
+				RecipientId: args[0],
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				fmt.Printf("recipient: %+v\n", response.Msg.Recipient)
+			})
+		},
+	}
+}
+
+func recipientListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use: "list",
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.ListRecipients(cmd.Context(), connect.NewRequest(&playgroundv1.ListRecipientsRequest{}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				for _, recipient := range response.Msg.Recipients {
+					fmt.Printf("recipient: %+v\n", recipient)
+				}
+			})
+		},
+	}
+}
+
+func recipientDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "delete [flags] <recipient-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			_, err := client.DeleteRecipient(cmd.Context(), connect.NewRequest(&playgroundv1.DeleteRecipientRequest{
+				RecipientId: args[0],
+			}))
+			if err != nil {
+				fail(err)
+			}
+		},
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(recipientCmd())
+}
@@ -4,24 +4,81 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"crypto/tls"
 	"os"
+	"time"
 
+	"github.com/andrewstucki/vanguard-playground/internal/client"
 	"github.com/spf13/cobra"
 )
 
-var port int
+var (
+	port        int
+	tenant      string
+	token       string
+	compression string
+	protocol    string
+	callTimeout time.Duration
+	contextFlag string
+
+	// serverURL and adminServerURL are where the listeners are, from the
+	// selected context with its ports replaced by --port and --admin-port, or
+	// on localhost without one
+	serverURL      string
+	adminServerURL string
+	tlsConfig      *tls.Config
+)
 
 var rootCmd = &cobra.Command{
-	Use: "vanguard-playground",
+	Use:  "vanguard-playground",
+	Long: "Run the playground server and call it.\n\n" + exitCodesHelp,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyContext(cmd); err != nil {
+			return err
+		}
+		return validateOutput(cmd, args)
+	},
 }
 
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
-		os.Exit(1)
+		// cobra already printed the error, which is always about the
+		// command line since commands exit themselves when they fail
+		os.Exit(exitUsage)
+	}
+}
+
+// clientOptions are the options of every client the commands create.
+func clientOptions() []client.Option {
+	return []client.Option{
+		client.WithProtocol(client.Protocol(protocol)),
+		client.WithTimeout(callTimeout),
+		client.WithToken(token),
+		client.WithCompression(compression),
+		client.WithTLSConfig(tlsConfig),
+	}
+}
+
+// newClient returns a client for the server on behalf of the selected tenant.
+func newClient() *client.Client {
+	c, err := client.NewClient(append(clientOptions(),
+		client.WithBaseURL(serverURL),
+		client.WithTenant(tenant),
+	)...)
+	if err != nil {
+		failUsage("%v", err)
 	}
+	return c
 }
 
 func init() {
-	rootCmd.PersistentFlags().IntVarP(&port, "port", "p", 8081, "Port for the server")
+	rootCmd.PersistentFlags().StringVar(&contextFlag, "context", os.Getenv("VANGUARD_CONTEXT"), "Context of the config file to target, the current one when unset")
+	rootCmd.PersistentFlags().IntVarP(&port, "port", "p", 8081, "Port for the server, replacing the port of the selected context's server")
+	rootCmd.PersistentFlags().StringVar(&tenant, "tenant", os.Getenv("VANGUARD_TENANT"), "Tenant to act on behalf of, the default tenant when unset")
+	rootCmd.PersistentFlags().StringVar(&token, "token", os.Getenv("VANGUARD_TOKEN"), "Tenant or admin bearer token")
+	rootCmd.PersistentFlags().StringVar(&protocol, "protocol", string(client.ProtocolConnect), "Protocol to call the server with: connect, grpc or grpcweb")
+	rootCmd.PersistentFlags().DurationVar(&callTimeout, "call-timeout", 30*time.Second, "Deadline of each call, none when 0")
+	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", outputTable, "Output format: table, wide, json, yaml or go-template=...")
+	rootCmd.PersistentFlags().StringVar(&compression, "compression", "", "Compress requests with gzip or zstd, uncompressed when unset")
 }
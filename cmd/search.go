@@ -0,0 +1,62 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"connectrpc.com/connect"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/spf13/cobra"
+)
+
+// searchCmd represents the search command
+func searchCmd() *cobra.Command {
+	var pageSize int32
+	var pageToken string
+
+	cmd := &cobra.Command{
+		Use:  "search [flags] <query>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.SearchMessages(cmd.Context(), connect.NewRequest(&playgroundv1.SearchMessagesRequest{
+				Q:         args[0],
+				PageSize:  pageSize,
+				PageToken: pageToken,
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(wide bool) {
+				headers := []string{"ID", "RANK", "SNIPPET"}
+				if wide {
+					headers = append(headers, "CREATED", "TEXT")
+				}
+				t := newTable(headers...)
+				for _, result := range response.Msg.Results {
+					row := []string{result.Message.MessageId, strconv.FormatFloat(result.Rank, 'f', 4, 64), truncate(result.Snippet, wide)}
+					if wide {
+						row = append(row, formatTime(result.Message.CreateTime), result.Message.Text)
+					}
+					t.row(row...)
+				}
+				t.flush()
+				if response.Msg.NextPageToken != "" {
+					fmt.Printf("\nnext page: %s\n", response.Msg.NextPageToken)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().Int32Var(&pageSize, "page-size", 0, "Maximum number of results to return")
+	cmd.Flags().StringVar(&pageToken, "page-token", "", "Page token from a previous search")
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd())
+}
@@ -4,37 +4,66 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
-	"fmt"
-	"os"
+	"time"
 
 	"connectrpc.com/connect"
-	"github.com/andrewstucki/vanguard-playground/internal/client"
 	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // sendCmd represents the send command
 func sendCmd() *cobra.Command {
-	var simulateFailure bool
+	var fault playgroundv1.FaultSpec
+	var latency time.Duration
+	var templateID string
+	var variables map[string]string
+	var recipients []string
+	var wait waitFlags
 
 	cmd := &cobra.Command{
 		Use:  "send [flags] <message-id>",
 		Args: cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			client := client.NewClient(port)
-			response, err := client.SendMessage(cmd.Context(), connect.NewRequest(&playgroundv1.SendMessageRequest{
-				MessageId:       args[0],
-				SimulateFailure: simulateFailure,
-			}))
+			request := &playgroundv1.SendMessageRequest{
+				MessageId:  args[0],
+				TemplateId: templateID,
+				Variables:  variables,
+				Recipients: recipients,
+			}
+			if latency > 0 {
+				fault.Latency = durationpb.New(latency)
+			}
+			if cmd.Flags().Changed("fail-attempts") || latency > 0 || fault.NonRetryable || fault.Panic || fault.CrashAfterCommit {
+				request.Fault = &fault
+			}
+
+			client := newClient()
+			response, err := client.SendMessage(cmd.Context(), connect.NewRequest(request))
 			if err != nil {
-				fmt.Println("error:", err)
-				os.Exit(1)
+				fail(err)
+			}
+			if wait.wait {
+				waitForOperation(cmd.Context(), client, wait, response.Msg.MessageId, response.Msg.OperationId)
+				return
 			}
-			fmt.Printf("operation: %+v, message: %+v\n", response.Msg.OperationId, response.Msg.MessageId)
+			render(response.Msg, func(bool) {
+				t := newTable("OPERATION", "MESSAGE")
+				t.row(response.Msg.OperationId, response.Msg.MessageId)
+				t.flush()
+			})
 		},
 	}
 
-	cmd.Flags().BoolVarP(&simulateFailure, "fail", "f", false, "Simulate failure")
+	cmd.Flags().StringVarP(&templateID, "template", "t", "", "Render the message from this template instead of its text")
+	cmd.Flags().StringToStringVar(&variables, "var", nil, "Template variable as name=value")
+	cmd.Flags().StringSliceVarP(&recipients, "recipient", "r", nil, "Recipient to deliver to, each as its own operation")
+	cmd.Flags().Uint32Var(&fault.FailAttempts, "fail-attempts", 0, "Fail the first N attempts of each step with a retryable error")
+	cmd.Flags().DurationVar(&latency, "latency", 0, "Latency to add before each step attempt, at most 5s")
+	cmd.Flags().BoolVarP(&fault.NonRetryable, "fail", "f", false, "Fail with a non-retryable error")
+	cmd.Flags().BoolVar(&fault.Panic, "panic", false, "Panic inside each step attempt")
+	cmd.Flags().BoolVar(&fault.CrashAfterCommit, "crash-after-commit", false, "Crash the server after a step commits")
+	wait.register(cmd)
 
 	return cmd
 }
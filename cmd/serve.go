@@ -6,6 +6,7 @@ package cmd
 import (
 	"os"
 	"os/signal"
+	"slices"
 	"syscall"
 
 	"github.com/andrewstucki/vanguard-playground/internal/server"
@@ -14,14 +15,67 @@ import (
 
 func serveCmd() *cobra.Command {
 	var useMemoryDB bool
+	var databaseURL string
+	var allowFaultInjection bool
+	var allowPrivateWebhooks bool
+	var maxMessageBytes int
+	var maxAttachmentBytes int64
+	var blobDir string
+	var tenancy string
+	var adminToken string
+	var adminPort int
+	var cors server.CORSConfig
+	var compressMinBytes int
 
 	cmd := &cobra.Command{
 		Use: "serve",
 		Run: func(cmd *cobra.Command, args []string) {
+			if !useMemoryDB && blobDir == "" {
+				failUsage("--blob-dir is required with a persistent database, as attachment content has to outlive the server")
+			}
+			if tenancy != "" && !slices.Contains(server.TenancyModes, server.TenancyMode(tenancy)) {
+				failUsage("unknown tenancy mode %s, expected one of %v", tenancy, server.TenancyModes)
+			}
+
 			ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
 			defer cancel()
 
-			err := server.Run(ctx, port, !useMemoryDB)
+			// SIGHUP reloads the configuration files
+			hangups := make(chan os.Signal, 1)
+			signal.Notify(hangups, syscall.SIGHUP)
+			defer signal.Stop(hangups)
+			reload := make(chan struct{})
+			go func() {
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-hangups:
+						select {
+						case reload <- struct{}{}:
+						case <-ctx.Done():
+							return
+						}
+					}
+				}
+			}()
+
+			err := server.Run(ctx, server.Config{
+				Port:                 port,
+				Persistent:           !useMemoryDB,
+				DatabaseURL:          databaseURL,
+				AllowFaultInjection:  allowFaultInjection,
+				AllowPrivateWebhooks: allowPrivateWebhooks,
+				MaxMessageBytes:      maxMessageBytes,
+				MaxAttachmentBytes:   maxAttachmentBytes,
+				BlobDir:              blobDir,
+				Tenancy:              server.TenancyMode(tenancy),
+				AdminToken:           adminToken,
+				AdminPort:            adminPort,
+				CORS:                 cors,
+				Reload:               reload,
+				CompressMinBytes:     compressMinBytes,
+			})
 			if err != nil {
 				os.Exit(1)
 			}
@@ -29,14 +83,26 @@ func serveCmd() *cobra.Command {
 	}
 
 	cmd.Flags().BoolVarP(&useMemoryDB, "memory", "M", false, "Use in-memory database")
+	cmd.Flags().StringVar(&databaseURL, "database-url", os.Getenv("VANGUARD_DATABASE_URL"), "URL of the libsql database, a sqld server on localhost:8080 by default or a local file with file:<path>")
+	cmd.Flags().IntVar(&maxMessageBytes, "max-message-bytes", server.DefaultMaxMessageBytes, "Maximum combined size of a message's text and payload")
+	cmd.Flags().Int64Var(&maxAttachmentBytes, "max-attachment-bytes", server.DefaultMaxAttachmentBytes, "Maximum size of a single attachment")
+	cmd.Flags().StringVar(&blobDir, "blob-dir", os.Getenv("VANGUARD_BLOB_DIR"), "Directory to store attachment content in, which a persistent database requires and its workers share, a temporary directory removed on shutdown with --memory")
+	cmd.Flags().IntVar(&adminPort, "admin-port", defaultAdminPort, "Port for the admin listener serving the AdminService, disabled when 0")
+	cmd.Flags().StringVar(&adminToken, "admin-token", os.Getenv("VANGUARD_ADMIN_TOKEN"), "Token required to administer tenants, the audit log and workflows, which are disabled when unset")
+	cmd.Flags().StringVar(&tenancy, "tenancy", os.Getenv("VANGUARD_TENANCY"), "What calls without a tenant token act as: token refuses them, single makes them the default tenant, open lets the X-Tenant-ID header pick a tenant, single when unset")
+	cmd.Flags().StringSliceVar(&cors.AllowedOrigins, "cors-origin", nil, "Origin browsers may call the server from, * for any, CORS is off when none are allowed")
+	cmd.Flags().StringVar(&cors.OriginsFile, "cors-origins-file", "", "File listing more allowed origins one per line, read again on SIGHUP")
+	cmd.Flags().StringSliceVar(&cors.AllowedMethods, "cors-method", server.DefaultCORSMethods, "Method browsers may call with")
+	cmd.Flags().StringSliceVar(&cors.AllowedHeaders, "cors-header", server.DefaultCORSHeaders, "Request header browsers may send, * for any")
+	cmd.Flags().BoolVar(&cors.AllowCredentials, "cors-allow-credentials", false, "Let browsers send credentials with cross-origin calls, which needs the origins listed rather than *")
+	cmd.Flags().DurationVar(&cors.MaxAge, "cors-max-age", 0, "How long browsers may cache preflight responses, two hours when 0")
+	cmd.Flags().IntVar(&compressMinBytes, "compress-min-bytes", server.DefaultCompressMinBytes, "Size below which responses are sent uncompressed")
+	cmd.Flags().BoolVar(&allowFaultInjection, "allow-fault-injection", false, "Honor fault specs on send requests")
+	cmd.Flags().BoolVar(&allowPrivateWebhooks, "allow-private-webhooks", false, "Deliver webhooks to loopback, private and link-local addresses, for receivers on the same host or network")
 
 	return cmd
 }
 
-// // serveCmd represents the serve command
-// var serveCmd =
-// }
-
 func init() {
 	rootCmd.AddCommand(serveCmd())
 }
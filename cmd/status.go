@@ -5,32 +5,89 @@ package cmd
 
 import (
 	"fmt"
-	"os"
+	"strconv"
 
 	"connectrpc.com/connect"
-	"github.com/andrewstucki/vanguard-playground/internal/client"
 	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
 	"github.com/spf13/cobra"
 )
 
 // statusCmd represents the status command
 func statusCmd() *cobra.Command {
-	return &cobra.Command{
+	var wait waitFlags
+
+	cmd := &cobra.Command{
 		Use:  "status [flags] <message-id> <operation-id>",
 		Args: cobra.ExactArgs(2),
 		Run: func(cmd *cobra.Command, args []string) {
-			client := client.NewClient(port)
+			client := newClient()
+			if wait.wait {
+				waitForOperation(cmd.Context(), client, wait, args[0], args[1])
+				return
+			}
 			response, err := client.MessageStatus(cmd.Context(), connect.NewRequest(&playgroundv1.MessageStatusRequest{
 				MessageId:   args[0],
 				OperationId: args[1],
 			}))
 			if err != nil {
-				fmt.Println("error:", err)
-				os.Exit(1)
+				fail(err)
 			}
-			fmt.Printf("state: %+v\n", response.Msg.State)
+			render(response.Msg, func(wide bool) {
+				printStatus(response.Msg, wide)
+			})
 		},
 	}
+
+	wait.register(cmd)
+
+	return cmd
+}
+
+// printStatus prints the state of an operation followed by its steps, and
+// its compensations and recipients when it has any. The wide format lists
+// every attempt of each step rather than only the last error.
+func printStatus(status *playgroundv1.MessageStatusResponse, wide bool) {
+	t := newTable("STATE", "STEP")
+	t.row(status.State, status.CurrentStep)
+	t.flush()
+
+	fmt.Println()
+	if wide {
+		t = newTable("STEP", "ATTEMPT", "STARTED", "ERROR")
+		for _, step := range status.Steps {
+			for _, attempt := range step.Attempts {
+				t.row(step.Step, strconv.Itoa(int(attempt.Attempt)), formatTime(attempt.CreateTime), attempt.Error)
+			}
+		}
+	} else {
+		t = newTable("STEP", "ATTEMPTS", "LAST ERROR")
+		for _, step := range status.Steps {
+			lastError := ""
+			if len(step.Attempts) > 0 {
+				lastError = step.Attempts[len(step.Attempts)-1].Error
+			}
+			t.row(step.Step, strconv.Itoa(len(step.Attempts)), truncate(lastError, wide))
+		}
+	}
+	t.flush()
+
+	if len(status.Compensations) > 0 {
+		fmt.Println()
+		t = newTable("COMPENSATED", "ATTEMPT", "ERROR")
+		for _, compensation := range status.Compensations {
+			t.row(compensation.Step, strconv.Itoa(int(compensation.Attempt)), truncate(compensation.Error, wide))
+		}
+		t.flush()
+	}
+
+	if len(status.Recipients) > 0 {
+		fmt.Println()
+		t = newTable("CHANNEL", "ADDRESS", "STATE", "STEP", "OPERATION")
+		for _, recipient := range status.Recipients {
+			t.row(recipient.Recipient.GetChannel().String(), recipient.Recipient.GetAddress(), recipient.State, recipient.CurrentStep, recipient.OperationId)
+		}
+		t.flush()
+	}
 }
 
 func init() {
@@ -0,0 +1,143 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"connectrpc.com/connect"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// templateCmd represents the template command group
+func templateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Manage message templates",
+	}
+
+	cmd.AddCommand(templateCreateCmd())
+	cmd.AddCommand(templateGetCmd())
+	cmd.AddCommand(templateListCmd())
+	cmd.AddCommand(templateUpdateCmd())
+	cmd.AddCommand(templateDeleteCmd())
+
+	return cmd
+}
+
+func templateCreateCmd() *cobra.Command {
+	var variables []string
+
+	cmd := &cobra.Command{
+		Use:  "create [flags] <name> <body>",
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.CreateTemplate(cmd.Context(), connect.NewRequest(&playgroundv1.CreateTemplateRequest{
+				Name:      args[0],
+				Body:      args[1],
+				Variables: variables,
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				fmt.Printf("template: %+v\n", response.Msg.TemplateId)
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&variables, "var", nil, "Variable declared by the template, referenced in the body as {{.name}}")
+
+	return cmd
+}
+
+func templateGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "get [flags] <template-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.GetTemplate(cmd.Context(), connect.NewRequest(&playgroundv1.GetTemplateRequest{
+				TemplateId: args[0],
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				fmt.Printf("template: %+v\n", response.Msg.Template)
+			})
+		},
+	}
+}
+
+func templateListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use: "list",
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.ListTemplates(cmd.Context(), connect.NewRequest(&playgroundv1.ListTemplatesRequest{}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				for _, template := range response.Msg.Templates {
+					fmt.Printf("template: %+v\n", template)
+				}
+			})
+		},
+	}
+}
+
+func templateUpdateCmd() *cobra.Command {
+	var variables []string
+
+	cmd := &cobra.Command{
+		Use:  "update [flags] <template-id> <name> <body>",
+		Args: cobra.ExactArgs(3),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.UpdateTemplate(cmd.Context(), connect.NewRequest(&playgroundv1.UpdateTemplateRequest{
+				TemplateId: args[0],
+				Name:       args[1],
+				Body:       args[2],
+				Variables:  variables,
+				// the command replaces the whole template
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name", "body", "variables"}},
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				fmt.Printf("template: %+v\n", response.Msg.Template)
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&variables, "var", nil, "Variable declared by the template, referenced in the body as {{.name}}")
+
+	return cmd
+}
+
+func templateDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "delete [flags] <template-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			_, err := client.DeleteTemplate(cmd.Context(), connect.NewRequest(&playgroundv1.DeleteTemplateRequest{
+				TemplateId: args[0],
+			}))
+			if err != nil {
+				fail(err)
+			}
+		},
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(templateCmd())
+}
@@ -0,0 +1,167 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"connectrpc.com/connect"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// tenantCmd represents the tenant command group
+func tenantCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tenant",
+		Short: "Administer tenants, authenticating with --token set to the admin token",
+	}
+
+	cmd.AddCommand(tenantCreateCmd())
+	cmd.AddCommand(tenantGetCmd())
+	cmd.AddCommand(tenantListCmd())
+	cmd.AddCommand(tenantUpdateCmd())
+
+	return cmd
+}
+
+func printTenant(tenant *playgroundv1.Tenant) {
+	fmt.Printf("tenant: %s, name: %q, messages: %d/%s, sends: %d/%s\n",
+		tenant.TenantId, tenant.DisplayName,
+		tenant.Usage.GetMessages(), formatLimit(tenant.Quota.GetMaxMessages()),
+		tenant.Usage.GetSends(), formatLimit(tenant.Quota.GetMaxSends()),
+	)
+}
+
+func formatLimit(limit int64) string {
+	if limit == 0 {
+		return "unlimited"
+	}
+	return fmt.Sprint(limit)
+}
+
+func tenantCreateCmd() *cobra.Command {
+	var displayName string
+	var maxMessages, maxSends int64
+
+	cmd := &cobra.Command{
+		Use:  "create [flags] <tenant-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.CreateTenant(cmd.Context(), connect.NewRequest(&playgroundv1.CreateTenantRequest{
+				TenantId:    args[0],
+				DisplayName: displayName,
+				Quota: &playgroundv1.TenantQuota{
+					MaxMessages: maxMessages,
+					MaxSends:    maxSends,
+				},
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				printTenant(response.Msg.Tenant)
+				fmt.Printf("token: %s\n", response.Msg.Token)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&displayName, "display-name", "", "Human readable name of the tenant")
+	cmd.Flags().Int64Var(&maxMessages, "max-messages", 0, "Most messages the tenant can store at once, unlimited when 0")
+	cmd.Flags().Int64Var(&maxSends, "max-sends", 0, "Most sends the tenant can make, unlimited when 0")
+
+	return cmd
+}
+
+func tenantGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "get [flags] <tenant-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.GetTenant(cmd.Context(), connect.NewRequest(&playgroundv1.GetTenantRequest{
+				TenantId: args[0],
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				printTenant(response.Msg.Tenant)
+			})
+		},
+	}
+}
+
+func tenantListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use: "list",
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.ListTenants(cmd.Context(), connect.NewRequest(&playgroundv1.ListTenantsRequest{}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				for _, tenant := range response.Msg.Tenants {
+					printTenant(tenant)
+				}
+			})
+		},
+	}
+}
+
+func tenantUpdateCmd() *cobra.Command {
+	var displayName string
+	var maxMessages, maxSends int64
+
+	cmd := &cobra.Command{
+		Use:  "update [flags] <tenant-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			// only the flags that were given are updated
+			mask := &fieldmaskpb.FieldMask{}
+			for flag, path := range map[string]string{
+				"display-name": "display_name",
+				"max-messages": "quota.max_messages",
+				"max-sends":    "quota.max_sends",
+			} {
+				if cmd.Flags().Changed(flag) {
+					mask.Paths = append(mask.Paths, path)
+				}
+			}
+			if len(mask.Paths) == 0 {
+				failUsage("nothing to update")
+			}
+
+			client := newClient()
+			response, err := client.UpdateTenant(cmd.Context(), connect.NewRequest(&playgroundv1.UpdateTenantRequest{
+				TenantId:    args[0],
+				DisplayName: displayName,
+				Quota: &playgroundv1.TenantQuota{
+					MaxMessages: maxMessages,
+					MaxSends:    maxSends,
+				},
+				UpdateMask: mask,
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				printTenant(response.Msg.Tenant)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&displayName, "display-name", "", "Human readable name of the tenant")
+	cmd.Flags().Int64Var(&maxMessages, "max-messages", 0, "Most messages the tenant can store at once, unlimited when 0")
+	cmd.Flags().Int64Var(&maxSends, "max-sends", 0, "Most sends the tenant can make, unlimited when 0")
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(tenantCmd())
+}
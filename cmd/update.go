@@ -0,0 +1,83 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+)
+
+// updateCmd represents the update command
+func updateCmd() *cobra.Command {
+	var text string
+	var labels map[string]string
+	var contentType string
+	var payloadFile string
+
+	cmd := &cobra.Command{
+		Use:  "update [flags] <message-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			request := &playgroundv1.UpdateMessageRequest{
+				MessageId:  args[0],
+				UpdateMask: &fieldmaskpb.FieldMask{},
+			}
+			if cmd.Flags().Changed("text") {
+				request.Text = text
+				request.UpdateMask.Paths = append(request.UpdateMask.Paths, "text")
+			}
+			if cmd.Flags().Changed("label") {
+				request.Labels = labels
+				request.UpdateMask.Paths = append(request.UpdateMask.Paths, "labels")
+			}
+			if cmd.Flags().Changed("content-type") {
+				value, ok := playgroundv1.ContentType_value[strings.ToUpper(contentType)]
+				if !ok {
+					failUsage("unknown content type %s", contentType)
+				}
+				request.ContentType = playgroundv1.ContentType(value)
+				request.UpdateMask.Paths = append(request.UpdateMask.Paths, "content_type")
+			}
+			if cmd.Flags().Changed("payload-file") {
+				if payloadFile != "" {
+					payload, err := os.ReadFile(payloadFile)
+					if err != nil {
+						fail(err)
+					}
+					request.Payload = payload
+				}
+				request.UpdateMask.Paths = append(request.UpdateMask.Paths, "payload")
+			}
+			if len(request.UpdateMask.Paths) == 0 {
+				failUsage("nothing to update, set at least one of --text, --label, --content-type and --payload-file")
+			}
+
+			client := newClient()
+			response, err := client.UpdateMessage(cmd.Context(), connect.NewRequest(request))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(wide bool) {
+				printMessages([]*playgroundv1.Message{response.Msg.Message}, wide)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&text, "text", "", "Text of the message")
+	cmd.Flags().StringToStringVarP(&labels, "label", "l", nil, "Label as key=value, replacing all of the message's labels")
+	cmd.Flags().StringVar(&contentType, "content-type", "", "Content type of the text: plain, markdown or json")
+	cmd.Flags().StringVar(&payloadFile, "payload-file", "", "File to use as the message's binary payload, removing it when empty")
+
+	return cmd
+}
+
+func init() {
+	rootCmd.AddCommand(updateCmd())
+}
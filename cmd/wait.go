@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/andrewstucki/vanguard-playground/internal/client"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/spf13/cobra"
+)
+
+// waitFlags are the flags of the commands that can wait for an operation.
+type waitFlags struct {
+	wait    bool
+	timeout time.Duration
+}
+
+func (f *waitFlags) register(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&f.wait, "wait", "w", false, "Wait for the operation to finish, exiting non-zero unless it succeeds")
+	cmd.Flags().DurationVar(&f.timeout, "timeout", 5*time.Minute, "How long --wait waits, forever when 0")
+}
+
+// waitForOperation follows an operation until it is terminal, showing its
+// progress on stderr when that is a terminal, then prints its final status
+// and exits non-zero unless it succeeded.
+func waitForOperation(ctx context.Context, c *client.Client, flags waitFlags, messageID, operationID string) {
+	if flags.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, flags.timeout)
+		defer cancel()
+	}
+
+	spin := startSpinner(operationID)
+	status, err := c.WatchOperation(ctx, messageID, operationID, spin.update)
+	spin.stop()
+
+	if status != nil {
+		render(status, func(wide bool) {
+			printStatus(status, wide)
+		})
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		state := "unknown"
+		if status != nil {
+			state = status.State
+		}
+		fail(connect.NewError(connect.CodeDeadlineExceeded, fmt.Errorf("operation %s still %s after %s", operationID, state, flags.timeout)))
+	case err != nil:
+		fail(err)
+	case status.State != playgroundv1.MessageState_SUCCEEDED.String():
+		fmt.Fprintf(os.Stderr, "error: operation %s finished %s\n", operationID, status.State)
+		os.Exit(exitNotSucceeded)
+	}
+}
+
+// spinner shows the progress of an operation on a single line of stderr
+// while it is waited for. It stays silent when stderr isn't a terminal, so
+// that redirected output holds only the final status.
+type spinner struct {
+	operationID string
+	started     time.Time
+	done        chan struct{}
+	stopped     chan struct{}
+
+	mu     sync.Mutex
+	status *playgroundv1.MessageStatusResponse
+}
+
+var spinnerFrames = []rune("⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏")
+
+func startSpinner(operationID string) *spinner {
+	if !isTerminal(os.Stderr) {
+		return nil
+	}
+	s := &spinner{
+		operationID: operationID,
+		started:     time.Now(),
+		done:        make(chan struct{}),
+		stopped:     make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+func (s *spinner) run() {
+	defer close(s.stopped)
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for frame := 0; ; frame++ {
+		fmt.Fprintf(os.Stderr, "\r\033[K%c %s", spinnerFrames[frame%len(spinnerFrames)], s.line())
+		select {
+		case <-s.done:
+			fmt.Fprint(os.Stderr, "\r\033[K")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// line describes the operation as of the last status polled.
+func (s *spinner) line() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elapsed := time.Since(s.started).Truncate(100 * time.Millisecond)
+	if s.status == nil {
+		return fmt.Sprintf("waiting for %s (%s)", s.operationID, elapsed)
+	}
+	attempts, total := 0, 0
+	for _, step := range s.status.Steps {
+		total += len(step.Attempts)
+		if step.Step == s.status.CurrentStep {
+			attempts = len(step.Attempts)
+		}
+	}
+	return fmt.Sprintf("%s %s, attempt %d, %d attempts in all (%s)", s.status.State, s.status.CurrentStep, attempts, total, elapsed)
+}
+
+// update records the last status polled. It does nothing on a nil spinner,
+// the one startSpinner returns when stderr isn't a terminal.
+func (s *spinner) update(status *playgroundv1.MessageStatusResponse) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+// stop clears the spinner's line.
+func (s *spinner) stop() {
+	if s == nil {
+		return
+	}
+	close(s.done)
+	<-s.stopped
+}
+
+// isTerminal reports whether f is a terminal rather than a file or pipe.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
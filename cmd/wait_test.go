@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+)
+
+func TestWait(t *testing.T) {
+	for _, test := range []struct {
+		state  playgroundv1.MessageState
+		args   []string
+		code   int
+		stderr string
+	}{
+		{state: playgroundv1.MessageState_SUCCEEDED, code: 0},
+		{state: playgroundv1.MessageState_FAILED, code: exitNotSucceeded, stderr: "operation op finished FAILED"},
+		{state: playgroundv1.MessageState_CANCELED, code: exitNotSucceeded, stderr: "operation op finished CANCELED"},
+		{state: playgroundv1.MessageState_PARTIALLY_SUCCEEDED, code: exitNotSucceeded, stderr: "finished PARTIALLY_SUCCEEDED"},
+		// the status last polled is printed before giving up on it
+		{state: playgroundv1.MessageState_SENDING, args: []string{"--timeout", "500ms"}, code: exitCodeBase + int(connect.CodeDeadlineExceeded), stderr: "operation op still SENDING after 500ms"},
+	} {
+		t.Run(test.state.String(), func(t *testing.T) {
+			server := newCLIServer(t, cliMessageService{state: test.state})
+
+			start := time.Now()
+			stdout, stderr, code := runCLI(t, server, append([]string{"send", "m1", "--wait", "-o", "go-template={{.state}}"}, test.args...)...)
+			if code != test.code {
+				t.Errorf("expected exit code %d, got %d: %s", test.code, code, stderr)
+			}
+			if strings.TrimSpace(stdout) != test.state.String() {
+				t.Errorf("expected the last status to be printed, got %q", stdout)
+			}
+			if !strings.Contains(stderr, test.stderr) {
+				t.Errorf("expected stderr to contain %q, got %q", test.stderr, stderr)
+			}
+			if elapsed := time.Since(start); elapsed > 10*time.Second {
+				t.Errorf("expected --timeout to bound the wait, took %s", elapsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+/*
+Copyright © 2025 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"connectrpc.com/connect"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// webhookCmd represents the webhook command group
+func webhookCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "webhook",
+		Short: "Manage webhook subscriptions",
+	}
+
+	cmd.AddCommand(webhookCreateCmd())
+	cmd.AddCommand(webhookGetCmd())
+	cmd.AddCommand(webhookListCmd())
+	cmd.AddCommand(webhookUpdateCmd())
+	cmd.AddCommand(webhookRotateSecretCmd())
+	cmd.AddCommand(webhookEnableCmd())
+	cmd.AddCommand(webhookDeleteCmd())
+	cmd.AddCommand(webhookDeliveriesCmd())
+	cmd.AddCommand(webhookTestCmd())
+
+	return cmd
+}
+
+// parseStates parses the names of message states given on the command line.
+func parseStates(names []string) []playgroundv1.MessageState {
+	var states []playgroundv1.MessageState
+	for _, name := range names {
+		value, ok := playgroundv1.MessageState_value[strings.ToUpper(name)]
+		if !ok {
+			failUsage("unknown state %s", name)
+		}
+		states = append(states, playgroundv1.MessageState(value))
+	}
+	return states
+}
+
+func printWebhook(subscription *playgroundv1.WebhookSubscription) {
+	fmt.Printf("webhook: %s, url: %s, states: %v", subscription.SubscriptionId, subscription.Url, subscription.States)
+	if subscription.ConsecutiveFailures > 0 {
+		fmt.Printf(", failures: %d", subscription.ConsecutiveFailures)
+	}
+	if subscription.DisableTime != nil {
+		fmt.Printf(", disabled: %s", subscription.DisableTime.AsTime().Local().Format("2006-01-02 15:04:05"))
+	}
+	fmt.Println()
+}
+
+func webhookCreateCmd() *cobra.Command {
+	var states []string
+	var secret string
+
+	cmd := &cobra.Command{
+		Use:  "create [flags] <url>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.CreateWebhookSubscription(cmd.Context(), connect.NewRequest(&playgroundv1.CreateWebhookSubscriptionRequest{
+				Url:    args[0],
+				States: parseStates(states),
+				Secret: secret,
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				printWebhook(response.Msg.Subscription)
+				fmt.Printf("secret: %s\n", response.Msg.Subscription.Secret)
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&states, "state", nil, "Terminal state to deliver, all of them when unset")
+	cmd.Flags().StringVar(&secret, "secret", "", "Signing secret of the form whsec_<base64>, generated when unset")
+
+	return cmd
+}
+
+func webhookGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "get [flags] <subscription-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.GetWebhookSubscription(cmd.Context(), connect.NewRequest(&playgroundv1.GetWebhookSubscriptionRequest{
+				SubscriptionId: args[0],
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				printWebhook(response.Msg.Subscription)
+			})
+		},
+	}
+}
+
+func webhookListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use: "list",
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.ListWebhookSubscriptions(cmd.Context(), connect.NewRequest(&playgroundv1.ListWebhookSubscriptionsRequest{}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				for _, subscription := range response.Msg.Subscriptions {
+					printWebhook(subscription)
+				}
+			})
+		},
+	}
+}
+
+func webhookUpdateCmd() *cobra.Command {
+	var states []string
+
+	cmd := &cobra.Command{
+		Use:  "update [flags] <subscription-id> <url>",
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.UpdateWebhookSubscription(cmd.Context(), connect.NewRequest(&playgroundv1.UpdateWebhookSubscriptionRequest{
+				SubscriptionId: args[0],
+				Url:            args[1],
+				States:         parseStates(states),
+				// the command replaces the whole subscription, so leaving out
+				// --state delivers every state again
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"url", "states"}},
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				printWebhook(response.Msg.Subscription)
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&states, "state", nil, "Terminal state to deliver, all of them when unset")
+
+	return cmd
+}
+
+func webhookRotateSecretCmd() *cobra.Command {
+	var secret string
+
+	cmd := &cobra.Command{
+		Use:  "rotate-secret [flags] <subscription-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.UpdateWebhookSubscription(cmd.Context(), connect.NewRequest(&playgroundv1.UpdateWebhookSubscriptionRequest{
+				SubscriptionId: args[0],
+				Secret:         secret,
+				UpdateMask:     &fieldmaskpb.FieldMask{Paths: []string{"secret"}},
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				printWebhook(response.Msg.Subscription)
+				fmt.Printf("secret: %s\n", response.Msg.Subscription.Secret)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&secret, "secret", "", "New signing secret of the form whsec_<base64>, generated when unset")
+
+	return cmd
+}
+
+func webhookEnableCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "enable [flags] <subscription-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.UpdateWebhookSubscription(cmd.Context(), connect.NewRequest(&playgroundv1.UpdateWebhookSubscriptionRequest{
+				SubscriptionId: args[0],
+				Disabled:       false,
+				UpdateMask:     &fieldmaskpb.FieldMask{Paths: []string{"disabled"}},
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				printWebhook(response.Msg.Subscription)
+			})
+		},
+	}
+}
+
+func webhookDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "delete [flags] <subscription-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			_, err := client.DeleteWebhookSubscription(cmd.Context(), connect.NewRequest(&playgroundv1.DeleteWebhookSubscriptionRequest{
+				SubscriptionId: args[0],
+			}))
+			if err != nil {
+				fail(err)
+			}
+		},
+	}
+}
+
+func webhookDeliveriesCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "deliveries [flags] <subscription-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.ListWebhookDeliveries(cmd.Context(), connect.NewRequest(&playgroundv1.ListWebhookDeliveriesRequest{
+				SubscriptionId: args[0],
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				for _, delivery := range response.Msg.Deliveries {
+					fmt.Printf("delivery: %s, operation: %s, state: %s, attempts: %d", delivery.DeliveryId, delivery.OperationId, delivery.State, delivery.Attempts)
+					if delivery.LastStatusCode != 0 {
+						fmt.Printf(", status: %d", delivery.LastStatusCode)
+					}
+					if delivery.LastError != "" {
+						fmt.Printf(", error: %s", delivery.LastError)
+					}
+					if delivery.NextAttemptTime != nil {
+						fmt.Printf(", next attempt: %s", delivery.NextAttemptTime.AsTime().Local().Format("2006-01-02 15:04:05"))
+					}
+					fmt.Println()
+				}
+			})
+		},
+	}
+}
+
+func webhookTestCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:  "test [flags] <subscription-id>",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := newClient()
+			response, err := client.TestWebhook(cmd.Context(), connect.NewRequest(&playgroundv1.TestWebhookRequest{
+				SubscriptionId: args[0],
+			}))
+			if err != nil {
+				fail(err)
+			}
+			render(response.Msg, func(bool) {
+				fmt.Printf("status: %d, latency: %s\n", response.Msg.StatusCode, response.Msg.Latency.AsDuration())
+			})
+			if response.Msg.Error != "" {
+				fail(errors.New(response.Msg.Error))
+			}
+		},
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(webhookCmd())
+}
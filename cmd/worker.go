@@ -14,18 +14,39 @@ import (
 
 // workerCmd represents the serve command
 func workerCmd() *cobra.Command {
-	return &cobra.Command{
+	var databaseURL string
+	var blobDir string
+	var maxAttachmentBytes int64
+	var allowPrivateWebhooks bool
+
+	cmd := &cobra.Command{
 		Use: "worker",
 		Run: func(cmd *cobra.Command, args []string) {
+			if blobDir == "" {
+				failUsage("--blob-dir is required, pass the directory the server stores attachment content in")
+			}
+
 			ctx, cancel := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
 			defer cancel()
 
-			err := server.RunWorker(ctx)
+			err := server.RunWorker(ctx, server.Config{
+				DatabaseURL:          databaseURL,
+				BlobDir:              blobDir,
+				MaxAttachmentBytes:   maxAttachmentBytes,
+				AllowPrivateWebhooks: allowPrivateWebhooks,
+			})
 			if err != nil {
 				os.Exit(1)
 			}
 		},
 	}
+
+	cmd.Flags().StringVar(&databaseURL, "database-url", os.Getenv("VANGUARD_DATABASE_URL"), "URL of the libsql database the server uses, a sqld server on localhost:8080 by default")
+	cmd.Flags().StringVar(&blobDir, "blob-dir", os.Getenv("VANGUARD_BLOB_DIR"), "Directory the server stores attachment content in, which the worker reads")
+	cmd.Flags().Int64Var(&maxAttachmentBytes, "max-attachment-bytes", server.DefaultMaxAttachmentBytes, "Maximum size of a single attachment, as the server is given")
+	cmd.Flags().BoolVar(&allowPrivateWebhooks, "allow-private-webhooks", false, "Deliver webhooks to loopback, private and link-local addresses, as the server is given")
+
+	return cmd
 }
 
 func init() {
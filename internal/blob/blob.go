@@ -0,0 +1,30 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned when opening a blob that does not exist.
+var ErrNotFound = errors.New("blob not found")
+
+// Store stores attachment content by key.
+type Store interface {
+	// Create returns a writer for a new blob with the given key. The blob
+	// only becomes visible once the writer is committed.
+	Create(ctx context.Context, key string) (Writer, error)
+	// Open returns a reader for the blob with the given key, or ErrNotFound.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the blob with the given key if it exists.
+	Delete(ctx context.Context, key string) error
+}
+
+// Writer writes the content of a new blob.
+type Writer interface {
+	io.Writer
+	// Commit stores the written content under the blob's key.
+	Commit() error
+	// Abort discards the written content. It is a no-op after Commit.
+	Abort() error
+}
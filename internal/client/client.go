@@ -1,21 +1,286 @@
 package client
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
+	"time"
 
+	"connectrpc.com/connect"
+	"github.com/andrewstucki/vanguard-playground/internal/compression"
 	"github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1/playgroundv1connect"
 )
 
+// TenantHeader selects the tenant of a request that carries no tenant token.
+const TenantHeader = "X-Tenant-ID"
+
+// DefaultBaseURL is where a server started with default flags listens.
+const DefaultBaseURL = "http://localhost:8081"
+
+// Protocol is the wire protocol a Client speaks.
+type Protocol string
+
+const (
+	ProtocolConnect Protocol = "connect"
+	ProtocolGRPC    Protocol = "grpc"
+	ProtocolGRPCWeb Protocol = "grpcweb"
+)
+
+// Protocols are the protocols a Client can speak.
+var Protocols = []Protocol{ProtocolConnect, ProtocolGRPC, ProtocolGRPCWeb}
+
 type Client struct {
 	playgroundv1connect.MessageServiceClient
+	playgroundv1connect.TenantServiceClient
+	playgroundv1connect.AuditServiceClient
+
+	pollInterval time.Duration
 }
 
-func NewClient(port int) *Client {
-	return &Client{
-		MessageServiceClient: playgroundv1connect.NewMessageServiceClient(
-			http.DefaultClient,
-			fmt.Sprintf("http://localhost:%d", port),
+type options struct {
+	baseURL      string
+	protocol     Protocol
+	httpClient   connect.HTTPClient
+	transport    http.RoundTripper
+	tlsConfig    *tls.Config
+	timeout      time.Duration
+	retry        RetryPolicy
+	pollInterval time.Duration
+	tenant       string
+	token        string
+	compression  string
+}
+
+// Option configures a Client.
+type Option func(*options)
+
+// WithBaseURL talks to the server at the given URL, DefaultBaseURL when
+// unset.
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithProtocol speaks the given protocol, Connect when unset. gRPC needs
+// HTTP/2, which the default transport speaks over both TLS and cleartext.
+func WithProtocol(protocol Protocol) Option {
+	return func(o *options) {
+		o.protocol = protocol
+	}
+}
+
+// WithHTTPClient sends requests with the given client instead of one built
+// for the protocol. It takes precedence over WithTransport.
+func WithHTTPClient(httpClient connect.HTTPClient) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithTransport sends requests with the given transport, for instance to
+// configure TLS or proxies.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = transport
+	}
+}
+
+// WithTLSConfig configures TLS on the default transport, for servers with
+// private certificate authorities or that require client certificates. It
+// has no effect along with WithHTTPClient or WithTransport.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(o *options) {
+		o.tlsConfig = config
+	}
+}
+
+// WithTimeout gives every unary call a deadline, including its retries.
+// Calls whose context already has a deadline keep it, so single calls can
+// set their own.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = policy
+	}
+}
+
+// WithPollInterval sets how often SendAndWait checks on the operation it
+// waits for, DefaultPollInterval when unset.
+func WithPollInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.pollInterval = interval
+	}
+}
+
+// WithTenant sends every request on behalf of the given tenant.
+func WithTenant(tenant string) Option {
+	return func(o *options) {
+		o.tenant = tenant
+	}
+}
+
+// WithToken authenticates every request with the given bearer token, either
+// a tenant token or the admin token.
+func WithToken(token string) Option {
+	return func(o *options) {
+		o.token = token
+	}
+}
+
+// WithCompression compresses requests with the named algorithm, gzip or
+// zstd. Responses are compressed whenever the server finds it worthwhile,
+// with or without it.
+func WithCompression(name string) Option {
+	return func(o *options) {
+		o.compression = name
+	}
+}
+
+// headerInterceptor adds the tenant and authorization headers to outgoing
+// requests.
+type headerInterceptor struct {
+	tenant string
+	token  string
+}
+
+func (i *headerInterceptor) apply(header http.Header) {
+	if i.tenant != "" {
+		header.Set(TenantHeader, i.tenant)
+	}
+	if i.token != "" {
+		header.Set("Authorization", "Bearer "+i.token)
+	}
+}
+
+func (i *headerInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		i.apply(req.Header())
+		return next(ctx, req)
+	}
+}
+
+func (i *headerInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		conn := next(ctx, spec)
+		i.apply(conn.RequestHeader())
+		return conn
+	}
+}
+
+func (i *headerInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+func newOptions(opts []Option) (options, error) {
+	o := options{
+		baseURL:      DefaultBaseURL,
+		protocol:     ProtocolConnect,
+		retry:        DefaultRetryPolicy,
+		pollInterval: DefaultPollInterval,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	switch o.compression {
+	case "", compression.Gzip, compression.Zstd:
+	default:
+		return options{}, fmt.Errorf("unsupported compression %q, use %s or %s", o.compression, compression.Gzip, compression.Zstd)
+	}
+
+	if o.httpClient == nil {
+		transport := o.transport
+		if transport == nil {
+			transport = defaultTransport(o.protocol, o.tlsConfig)
+		}
+		o.httpClient = &http.Client{Transport: transport}
+	}
+	return o, nil
+}
+
+// defaultTransport returns the transport of clients that aren't given one.
+// gRPC only runs over HTTP/2, so its transport speaks HTTP/2 without TLS too.
+func defaultTransport(protocol Protocol, tlsConfig *tls.Config) http.RoundTripper {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	if protocol == ProtocolGRPC {
+		transport.Protocols = new(http.Protocols)
+		transport.Protocols.SetHTTP2(true)
+		transport.Protocols.SetUnencryptedHTTP2(true)
+	}
+	return transport
+}
+
+// clientOptions returns the options every service client is built with.
+func (o options) clientOptions() (connect.ClientOption, error) {
+	clientOpts := []connect.ClientOption{
+		// the deadline goes first so that it bounds every retry
+		connect.WithInterceptors(
+			&timeoutInterceptor{timeout: o.timeout},
+			&retryInterceptor{policy: o.retry},
+			&headerInterceptor{tenant: o.tenant, token: o.token},
 		),
+		connect.WithAcceptCompression(compression.Zstd, compression.NewZstdDecompressor, compression.NewZstdCompressor),
+	}
+
+	switch o.protocol {
+	case ProtocolConnect:
+		// reads without side effects go out as GET, which caches can store
+		clientOpts = append(clientOpts, connect.WithHTTPGet())
+	case ProtocolGRPC:
+		clientOpts = append(clientOpts, connect.WithGRPC())
+	case ProtocolGRPCWeb:
+		clientOpts = append(clientOpts, connect.WithGRPCWeb())
+	default:
+		return nil, fmt.Errorf("unsupported protocol %q, use %s, %s or %s", o.protocol, ProtocolConnect, ProtocolGRPC, ProtocolGRPCWeb)
+	}
+
+	if o.compression != "" {
+		clientOpts = append(clientOpts, connect.WithSendCompression(o.compression))
+	}
+	return connect.WithClientOptions(clientOpts...), nil
+}
+
+// NewClient returns a client for the services of the server's main listener.
+func NewClient(opts ...Option) (*Client, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	clientOpts, err := o.clientOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		MessageServiceClient: playgroundv1connect.NewMessageServiceClient(o.httpClient, o.baseURL, clientOpts),
+		TenantServiceClient:  playgroundv1connect.NewTenantServiceClient(o.httpClient, o.baseURL, clientOpts),
+		AuditServiceClient:   playgroundv1connect.NewAuditServiceClient(o.httpClient, o.baseURL, clientOpts),
+		pollInterval:         o.pollInterval,
+	}, nil
+}
+
+// NewAdminClient returns a client for the AdminService, which is served on
+// the admin listener, so its base URL differs from the one NewClient talks
+// to.
+func NewAdminClient(opts ...Option) (playgroundv1connect.AdminServiceClient, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	clientOpts, err := o.clientOptions()
+	if err != nil {
+		return nil, err
 	}
+	return playgroundv1connect.NewAdminServiceClient(o.httpClient, o.baseURL, clientOpts), nil
 }
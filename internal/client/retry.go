@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// RetryPolicy sets how calls that find the server unavailable are retried.
+// Only procedures declared idempotent or free of side effects are retried,
+// as others might have taken effect before the error.
+type RetryPolicy struct {
+	// MaxAttempts bounds the attempts of a call including the first, so that
+	// 1 never retries
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry, which doubles for
+	// each one after it
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy makes up to four attempts over a few seconds.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    4,
+	InitialBackoff: 200 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+}
+
+// backoff returns how long to wait after the given attempt failed. The delay
+// is jittered so that clients failing together don't retry together, and is
+// never shorter than a delay the server asked for.
+func (p RetryPolicy) backoff(attempt int, err error) time.Duration {
+	delay := p.InitialBackoff
+	for range attempt - 1 {
+		delay *= 2
+		if delay >= p.MaxBackoff {
+			delay = p.MaxBackoff
+			break
+		}
+	}
+	delay = delay/2 + rand.N(delay/2+1)
+
+	var connectErr *connect.Error
+	if errors.As(err, &connectErr) {
+		for _, detail := range connectErr.Details() {
+			if value, valueErr := detail.Value(); valueErr == nil {
+				if info, ok := value.(*errdetails.RetryInfo); ok && info.GetRetryDelay().AsDuration() > delay {
+					delay = info.GetRetryDelay().AsDuration()
+				}
+			}
+		}
+	}
+	return delay
+}
+
+// retryInterceptor retries unary calls of idempotent procedures that fail
+// with Unavailable. Streams aren't retried, as their messages can't be
+// replayed.
+type retryInterceptor struct {
+	policy RetryPolicy
+}
+
+func (i *retryInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if req.Spec().IdempotencyLevel == connect.IdempotencyUnknown {
+			return next(ctx, req)
+		}
+		for attempt := 1; ; attempt++ {
+			res, err := next(ctx, req)
+			if err == nil || connect.CodeOf(err) != connect.CodeUnavailable || attempt >= i.policy.MaxAttempts {
+				return res, err
+			}
+			timer := time.NewTimer(i.policy.backoff(attempt, err))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, err
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+func (i *retryInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *retryInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+// timeoutInterceptor gives unary calls without a deadline the configured
+// one. Streams run for as long as their callers want.
+type timeoutInterceptor struct {
+	timeout time.Duration
+}
+
+func (i *timeoutInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if _, ok := ctx.Deadline(); ok || i.timeout <= 0 {
+			return next(ctx, req)
+		}
+		ctx, cancel := context.WithTimeout(ctx, i.timeout)
+		defer cancel()
+		return next(ctx, req)
+	}
+}
+
+func (i *timeoutInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *timeoutInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
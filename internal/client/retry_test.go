@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1/playgroundv1connect"
+)
+
+// flakyMessageService fails its first failures calls with code, asking to be
+// retried after retryDelay when it's set.
+type flakyMessageService struct {
+	playgroundv1connect.UnimplementedMessageServiceHandler
+	code       connect.Code
+	failures   int64
+	retryDelay time.Duration
+
+	calls atomic.Int64
+}
+
+func (s *flakyMessageService) fail() error {
+	if s.calls.Add(1) > s.failures {
+		return nil
+	}
+	err := connect.NewError(s.code, errors.New("try again"))
+	if s.retryDelay > 0 {
+		if detail, detailErr := connect.NewErrorDetail(&errdetails.RetryInfo{RetryDelay: durationpb.New(s.retryDelay)}); detailErr == nil {
+			err.AddDetail(detail)
+		}
+	}
+	return err
+}
+
+func (s *flakyMessageService) GetMessage(_ context.Context, req *connect.Request[playgroundv1.GetMessageRequest]) (*connect.Response[playgroundv1.GetMessageResponse], error) {
+	if err := s.fail(); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&playgroundv1.GetMessageResponse{Message: &playgroundv1.Message{MessageId: req.Msg.MessageId}}), nil
+}
+
+func (s *flakyMessageService) CreateMessage(context.Context, *connect.Request[playgroundv1.CreateMessageRequest]) (*connect.Response[playgroundv1.CreateMessageResponse], error) {
+	if err := s.fail(); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&playgroundv1.CreateMessageResponse{MessageId: "m"}), nil
+}
+
+// flakyClient returns a client of a flaky service.
+func flakyClient(t *testing.T, service *flakyMessageService, opts ...Option) *Client {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.Handle(playgroundv1connect.NewMessageServiceHandler(service))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(append([]Option{
+		WithBaseURL(server.URL),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 4, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}),
+	}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestRetries(t *testing.T) {
+	get := func(c *Client) error {
+		_, err := c.GetMessage(context.Background(), connect.NewRequest(&playgroundv1.GetMessageRequest{MessageId: "m"}))
+		return err
+	}
+	create := func(c *Client) error {
+		_, err := c.CreateMessage(context.Background(), connect.NewRequest(&playgroundv1.CreateMessageRequest{Text: "hello"}))
+		return err
+	}
+
+	for _, test := range []struct {
+		name     string
+		call     func(*Client) error
+		code     connect.Code
+		failures int64
+		calls    int64
+		want     connect.Code
+	}{
+		{name: "unavailable read", call: get, code: connect.CodeUnavailable, failures: 2, calls: 3},
+		{name: "unavailable read past the attempts", call: get, code: connect.CodeUnavailable, failures: 10, calls: 4, want: connect.CodeUnavailable},
+		{name: "other errors", call: get, code: connect.CodeInternal, failures: 1, calls: 1, want: connect.CodeInternal},
+		{name: "unavailable write", call: create, code: connect.CodeUnavailable, failures: 1, calls: 1, want: connect.CodeUnavailable},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			service := &flakyMessageService{code: test.code, failures: test.failures}
+			err := test.call(flakyClient(t, service))
+			switch {
+			case test.want == 0 && err != nil:
+				t.Errorf("expected the call to succeed, got %v", err)
+			case test.want != 0 && connect.CodeOf(err) != test.want:
+				t.Errorf("expected %s, got %v", test.want, err)
+			}
+			if calls := service.calls.Load(); calls != test.calls {
+				t.Errorf("expected %d calls, got %d", test.calls, calls)
+			}
+		})
+	}
+}
+
+func TestRetryHonoursRetryInfo(t *testing.T) {
+	service := &flakyMessageService{code: connect.CodeUnavailable, failures: 1, retryDelay: 300 * time.Millisecond}
+	c := flakyClient(t, service)
+
+	start := time.Now()
+	if _, err := c.GetMessage(context.Background(), connect.NewRequest(&playgroundv1.GetMessageRequest{MessageId: "m"})); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < service.retryDelay {
+		t.Errorf("expected the retry to wait the %s the server asked for, took %s", service.retryDelay, elapsed)
+	}
+}
+
+func TestRetryStopsAtTheDeadline(t *testing.T) {
+	service := &flakyMessageService{code: connect.CodeUnavailable, failures: 10, retryDelay: 5 * time.Second}
+	c := flakyClient(t, service, WithTimeout(200*time.Millisecond))
+
+	start := time.Now()
+	_, err := c.GetMessage(context.Background(), connect.NewRequest(&playgroundv1.GetMessageRequest{MessageId: "m"}))
+	if connect.CodeOf(err) != connect.CodeUnavailable {
+		t.Errorf("expected the last error to be returned, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the timeout to cut the wait for a retry short, took %s", elapsed)
+	}
+	if calls := service.calls.Load(); calls != 1 {
+		t.Errorf("expected no retry past the deadline, got %d calls", calls)
+	}
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+)
+
+// DefaultPollInterval is how often SendAndWait checks on an operation.
+const DefaultPollInterval = 500 * time.Millisecond
+
+// Terminal reports whether an operation in the given state is done.
+func Terminal(state string) bool {
+	return state != playgroundv1.MessageState_SENDING.String()
+}
+
+// SendAndWait sends a message and waits for the operation to finish,
+// returning its final status. A send that fails still returns its status,
+// whose state tells how it went, so only errors of the calls themselves are
+// returned as errors.
+func (c *Client) SendAndWait(ctx context.Context, messageID string) (*playgroundv1.MessageStatusResponse, error) {
+	sent, err := c.SendMessage(ctx, connect.NewRequest(&playgroundv1.SendMessageRequest{
+		MessageId: messageID,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return c.WaitForOperation(ctx, sent.Msg.MessageId, sent.Msg.OperationId)
+}
+
+// WaitForOperation polls the status of an operation until it is terminal.
+// When the context ends first, the last status seen is returned along with
+// the context's error.
+func (c *Client) WaitForOperation(ctx context.Context, messageID, operationID string) (*playgroundv1.MessageStatusResponse, error) {
+	return c.WatchOperation(ctx, messageID, operationID, nil)
+}
+
+// WatchOperation is WaitForOperation that also hands every status it polls,
+// the terminal one included, to progress when it isn't nil.
+func (c *Client) WatchOperation(ctx context.Context, messageID, operationID string, progress func(*playgroundv1.MessageStatusResponse)) (*playgroundv1.MessageStatusResponse, error) {
+	ticker := time.NewTicker(c.pollInterval)
+	defer ticker.Stop()
+
+	var last *playgroundv1.MessageStatusResponse
+	for {
+		status, err := c.MessageStatus(ctx, connect.NewRequest(&playgroundv1.MessageStatusRequest{
+			MessageId:   messageID,
+			OperationId: operationID,
+		}))
+		if err != nil {
+			if ctx.Err() != nil {
+				return last, ctx.Err()
+			}
+			return nil, err
+		}
+		last = status.Msg
+		if progress != nil {
+			progress(last)
+		}
+		if Terminal(last.State) {
+			return last, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return last, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
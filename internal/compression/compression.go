@@ -0,0 +1,47 @@
+package compression
+
+import (
+	"connectrpc.com/connect"
+	"github.com/klauspost/compress/zstd"
+)
+
+// The compression algorithms the server and client negotiate, in the order
+// the server prefers them.
+const (
+	Zstd = "zstd"
+	Gzip = "gzip"
+)
+
+// Names are the supported compression algorithms, most preferred first.
+var Names = []string{Zstd, Gzip}
+
+// NewZstdCompressor returns a zstd encoder for Connect's compressor pools.
+func NewZstdCompressor() connect.Compressor {
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		// the options are fixed, so this can only fail on a bad build
+		panic(err)
+	}
+	return encoder
+}
+
+// zstdDecompressor adapts a zstd decoder to Connect's pools, which close
+// decompressors before reusing them, something a closed zstd decoder can't do.
+type zstdDecompressor struct {
+	*zstd.Decoder
+}
+
+func (d zstdDecompressor) Close() error {
+	return d.Reset(nil)
+}
+
+// NewZstdDecompressor returns a zstd decoder for Connect's decompressor
+// pools. It decodes synchronously, so pooled decoders hold no goroutines.
+func NewZstdDecompressor() connect.Decompressor {
+	decoder, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		// the options are fixed, so this can only fail on a bad build
+		panic(err)
+	}
+	return zstdDecompressor{decoder}
+}
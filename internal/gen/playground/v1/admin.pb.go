@@ -0,0 +1,916 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: playground/v1/admin.proto
+
+package playgroundv1
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// WorkflowStatus mirrors the runtime status of a durable task orchestration.
+type WorkflowStatus int32
+
+const (
+	WorkflowStatus_WORKFLOW_STATUS_RUNNING          WorkflowStatus = 0
+	WorkflowStatus_WORKFLOW_STATUS_COMPLETED        WorkflowStatus = 1
+	WorkflowStatus_WORKFLOW_STATUS_CONTINUED_AS_NEW WorkflowStatus = 2
+	WorkflowStatus_WORKFLOW_STATUS_FAILED           WorkflowStatus = 3
+	WorkflowStatus_WORKFLOW_STATUS_CANCELED         WorkflowStatus = 4
+	WorkflowStatus_WORKFLOW_STATUS_TERMINATED       WorkflowStatus = 5
+	WorkflowStatus_WORKFLOW_STATUS_PENDING          WorkflowStatus = 6
+	WorkflowStatus_WORKFLOW_STATUS_SUSPENDED        WorkflowStatus = 7
+)
+
+// Enum value maps for WorkflowStatus.
+var (
+	WorkflowStatus_name = map[int32]string{
+		0: "WORKFLOW_STATUS_RUNNING",
+		1: "WORKFLOW_STATUS_COMPLETED",
+		2: "WORKFLOW_STATUS_CONTINUED_AS_NEW",
+		3: "WORKFLOW_STATUS_FAILED",
+		4: "WORKFLOW_STATUS_CANCELED",
+		5: "WORKFLOW_STATUS_TERMINATED",
+		6: "WORKFLOW_STATUS_PENDING",
+		7: "WORKFLOW_STATUS_SUSPENDED",
+	}
+	WorkflowStatus_value = map[string]int32{
+		"WORKFLOW_STATUS_RUNNING":          0,
+		"WORKFLOW_STATUS_COMPLETED":        1,
+		"WORKFLOW_STATUS_CONTINUED_AS_NEW": 2,
+		"WORKFLOW_STATUS_FAILED":           3,
+		"WORKFLOW_STATUS_CANCELED":         4,
+		"WORKFLOW_STATUS_TERMINATED":       5,
+		"WORKFLOW_STATUS_PENDING":          6,
+		"WORKFLOW_STATUS_SUSPENDED":        7,
+	}
+)
+
+func (x WorkflowStatus) Enum() *WorkflowStatus {
+	p := new(WorkflowStatus)
+	*p = x
+	return p
+}
+
+func (x WorkflowStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkflowStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_playground_v1_admin_proto_enumTypes[0].Descriptor()
+}
+
+func (WorkflowStatus) Type() protoreflect.EnumType {
+	return &file_playground_v1_admin_proto_enumTypes[0]
+}
+
+func (x WorkflowStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkflowStatus.Descriptor instead.
+func (WorkflowStatus) EnumDescriptor() ([]byte, []int) {
+	return file_playground_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+type WorkflowInstance struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	InstanceId string                 `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	// the name the workflow is registered under, such as SendMessageState
+	Name     string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TenantId string `protobuf:"bytes,3,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// the send operation the instance runs
+	OperationId string         `protobuf:"bytes,4,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	Status      WorkflowStatus `protobuf:"varint,5,opt,name=status,proto3,enum=playground.v1.WorkflowStatus" json:"status,omitempty"`
+	// why the instance failed, when it did
+	Failure       string                 `protobuf:"bytes,6,opt,name=failure,proto3" json:"failure,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowInstance) Reset() {
+	*x = WorkflowInstance{}
+	mi := &file_playground_v1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowInstance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowInstance) ProtoMessage() {}
+
+func (x *WorkflowInstance) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowInstance.ProtoReflect.Descriptor instead.
+func (*WorkflowInstance) Descriptor() ([]byte, []int) {
+	return file_playground_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WorkflowInstance) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *WorkflowInstance) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkflowInstance) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *WorkflowInstance) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+func (x *WorkflowInstance) GetStatus() WorkflowStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WorkflowStatus_WORKFLOW_STATUS_RUNNING
+}
+
+func (x *WorkflowInstance) GetFailure() string {
+	if x != nil {
+		return x.Failure
+	}
+	return ""
+}
+
+func (x *WorkflowInstance) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *WorkflowInstance) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+// WorkflowHistoryEvent is one entry of the durable history of an instance.
+type WorkflowHistoryEvent struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	EventId int32                  `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// the kind of event, such as taskScheduled or executionCompleted
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// the task, timer or event the entry is about, when there is one
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// the error of failed tasks and instances
+	Failure       string                 `protobuf:"bytes,4,opt,name=failure,proto3" json:"failure,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowHistoryEvent) Reset() {
+	*x = WorkflowHistoryEvent{}
+	mi := &file_playground_v1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowHistoryEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowHistoryEvent) ProtoMessage() {}
+
+func (x *WorkflowHistoryEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowHistoryEvent.ProtoReflect.Descriptor instead.
+func (*WorkflowHistoryEvent) Descriptor() ([]byte, []int) {
+	return file_playground_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WorkflowHistoryEvent) GetEventId() int32 {
+	if x != nil {
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *WorkflowHistoryEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *WorkflowHistoryEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkflowHistoryEvent) GetFailure() string {
+	if x != nil {
+		return x.Failure
+	}
+	return ""
+}
+
+func (x *WorkflowHistoryEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type ListWorkflowInstancesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// only list instances in one of these statuses
+	Statuses []WorkflowStatus `protobuf:"varint,1,rep,packed,name=statuses,proto3,enum=playground.v1.WorkflowStatus" json:"statuses,omitempty"`
+	// only list instances of this workflow
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// the most instances to return, defaulting to 100
+	PageSize      int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkflowInstancesRequest) Reset() {
+	*x = ListWorkflowInstancesRequest{}
+	mi := &file_playground_v1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkflowInstancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkflowInstancesRequest) ProtoMessage() {}
+
+func (x *ListWorkflowInstancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkflowInstancesRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkflowInstancesRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListWorkflowInstancesRequest) GetStatuses() []WorkflowStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListWorkflowInstancesRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListWorkflowInstancesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListWorkflowInstancesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instances     []*WorkflowInstance    `protobuf:"bytes,1,rep,name=instances,proto3" json:"instances,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkflowInstancesResponse) Reset() {
+	*x = ListWorkflowInstancesResponse{}
+	mi := &file_playground_v1_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkflowInstancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkflowInstancesResponse) ProtoMessage() {}
+
+func (x *ListWorkflowInstancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkflowInstancesResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkflowInstancesResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListWorkflowInstancesResponse) GetInstances() []*WorkflowInstance {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+type GetWorkflowInstanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InstanceId    string                 `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWorkflowInstanceRequest) Reset() {
+	*x = GetWorkflowInstanceRequest{}
+	mi := &file_playground_v1_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWorkflowInstanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWorkflowInstanceRequest) ProtoMessage() {}
+
+func (x *GetWorkflowInstanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWorkflowInstanceRequest.ProtoReflect.Descriptor instead.
+func (*GetWorkflowInstanceRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetWorkflowInstanceRequest) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+type GetWorkflowInstanceResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Instance *WorkflowInstance      `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	Input    *structpb.Value        `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	// only set once the instance completed
+	Output *structpb.Value `protobuf:"bytes,3,opt,name=output,proto3" json:"output,omitempty"`
+	// the step that is running, or was running when the instance stopped
+	CurrentStep   string                  `protobuf:"bytes,4,opt,name=current_step,json=currentStep,proto3" json:"current_step,omitempty"`
+	History       []*WorkflowHistoryEvent `protobuf:"bytes,5,rep,name=history,proto3" json:"history,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWorkflowInstanceResponse) Reset() {
+	*x = GetWorkflowInstanceResponse{}
+	mi := &file_playground_v1_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWorkflowInstanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWorkflowInstanceResponse) ProtoMessage() {}
+
+func (x *GetWorkflowInstanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWorkflowInstanceResponse.ProtoReflect.Descriptor instead.
+func (*GetWorkflowInstanceResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetWorkflowInstanceResponse) GetInstance() *WorkflowInstance {
+	if x != nil {
+		return x.Instance
+	}
+	return nil
+}
+
+func (x *GetWorkflowInstanceResponse) GetInput() *structpb.Value {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *GetWorkflowInstanceResponse) GetOutput() *structpb.Value {
+	if x != nil {
+		return x.Output
+	}
+	return nil
+}
+
+func (x *GetWorkflowInstanceResponse) GetCurrentStep() string {
+	if x != nil {
+		return x.CurrentStep
+	}
+	return ""
+}
+
+func (x *GetWorkflowInstanceResponse) GetHistory() []*WorkflowHistoryEvent {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+type TerminateWorkflowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InstanceId    string                 `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TerminateWorkflowRequest) Reset() {
+	*x = TerminateWorkflowRequest{}
+	mi := &file_playground_v1_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TerminateWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerminateWorkflowRequest) ProtoMessage() {}
+
+func (x *TerminateWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerminateWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*TerminateWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TerminateWorkflowRequest) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *TerminateWorkflowRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type TerminateWorkflowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TerminateWorkflowResponse) Reset() {
+	*x = TerminateWorkflowResponse{}
+	mi := &file_playground_v1_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TerminateWorkflowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TerminateWorkflowResponse) ProtoMessage() {}
+
+func (x *TerminateWorkflowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TerminateWorkflowResponse.ProtoReflect.Descriptor instead.
+func (*TerminateWorkflowResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_admin_proto_rawDescGZIP(), []int{7}
+}
+
+type PurgeWorkflowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InstanceId    string                 `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeWorkflowRequest) Reset() {
+	*x = PurgeWorkflowRequest{}
+	mi := &file_playground_v1_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeWorkflowRequest) ProtoMessage() {}
+
+func (x *PurgeWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*PurgeWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PurgeWorkflowRequest) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+type PurgeWorkflowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeWorkflowResponse) Reset() {
+	*x = PurgeWorkflowResponse{}
+	mi := &file_playground_v1_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeWorkflowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeWorkflowResponse) ProtoMessage() {}
+
+func (x *PurgeWorkflowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeWorkflowResponse.ProtoReflect.Descriptor instead.
+func (*PurgeWorkflowResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_admin_proto_rawDescGZIP(), []int{9}
+}
+
+type RetryWorkflowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InstanceId    string                 `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryWorkflowRequest) Reset() {
+	*x = RetryWorkflowRequest{}
+	mi := &file_playground_v1_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryWorkflowRequest) ProtoMessage() {}
+
+func (x *RetryWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*RetryWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RetryWorkflowRequest) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+type RetryWorkflowResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the new instance running the workflow
+	InstanceId    string `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryWorkflowResponse) Reset() {
+	*x = RetryWorkflowResponse{}
+	mi := &file_playground_v1_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryWorkflowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryWorkflowResponse) ProtoMessage() {}
+
+func (x *RetryWorkflowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryWorkflowResponse.ProtoReflect.Descriptor instead.
+func (*RetryWorkflowResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RetryWorkflowResponse) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+var File_playground_v1_admin_proto protoreflect.FileDescriptor
+
+const file_playground_v1_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x19playground/v1/admin.proto\x12\rplayground.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bbuf/validate/validate.proto\"\xd2\x02\n" +
+	"\x10WorkflowInstance\x12\x1f\n" +
+	"\vinstance_id\x18\x01 \x01(\tR\n" +
+	"instanceId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\ttenant_id\x18\x03 \x01(\tR\btenantId\x12!\n" +
+	"\foperation_id\x18\x04 \x01(\tR\voperationId\x125\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1d.playground.v1.WorkflowStatusR\x06status\x12\x18\n" +
+	"\afailure\x18\x06 \x01(\tR\afailure\x12;\n" +
+	"\vcreate_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\"\xa3\x01\n" +
+	"\x14WorkflowHistoryEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\x05R\aeventId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x18\n" +
+	"\afailure\x18\x04 \x01(\tR\afailure\x12.\n" +
+	"\x04time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\"\xa0\x01\n" +
+	"\x1cListWorkflowInstancesRequest\x12C\n" +
+	"\bstatuses\x18\x01 \x03(\x0e2\x1d.playground.v1.WorkflowStatusB\b\xbaH\x05\x92\x01\x02\x18\x01R\bstatuses\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12'\n" +
+	"\tpage_size\x18\x03 \x01(\x05B\n" +
+	"\xbaH\a\x1a\x05\x18\xe8\a(\x00R\bpageSize\"^\n" +
+	"\x1dListWorkflowInstancesResponse\x12=\n" +
+	"\tinstances\x18\x01 \x03(\v2\x1f.playground.v1.WorkflowInstanceR\tinstances\"E\n" +
+	"\x1aGetWorkflowInstanceRequest\x12'\n" +
+	"\vinstance_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\n" +
+	"instanceId\"\x9a\x02\n" +
+	"\x1bGetWorkflowInstanceResponse\x12;\n" +
+	"\binstance\x18\x01 \x01(\v2\x1f.playground.v1.WorkflowInstanceR\binstance\x12,\n" +
+	"\x05input\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x05input\x12.\n" +
+	"\x06output\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\x06output\x12!\n" +
+	"\fcurrent_step\x18\x04 \x01(\tR\vcurrentStep\x12=\n" +
+	"\ahistory\x18\x05 \x03(\v2#.playground.v1.WorkflowHistoryEventR\ahistory\"e\n" +
+	"\x18TerminateWorkflowRequest\x12'\n" +
+	"\vinstance_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\n" +
+	"instanceId\x12 \n" +
+	"\x06reason\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\x80\bR\x06reason\"\x1b\n" +
+	"\x19TerminateWorkflowResponse\"?\n" +
+	"\x14PurgeWorkflowRequest\x12'\n" +
+	"\vinstance_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\n" +
+	"instanceId\"\x17\n" +
+	"\x15PurgeWorkflowResponse\"?\n" +
+	"\x14RetryWorkflowRequest\x12'\n" +
+	"\vinstance_id\x18\x01 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\n" +
+	"instanceId\"8\n" +
+	"\x15RetryWorkflowResponse\x12\x1f\n" +
+	"\vinstance_id\x18\x01 \x01(\tR\n" +
+	"instanceId*\x88\x02\n" +
+	"\x0eWorkflowStatus\x12\x1b\n" +
+	"\x17WORKFLOW_STATUS_RUNNING\x10\x00\x12\x1d\n" +
+	"\x19WORKFLOW_STATUS_COMPLETED\x10\x01\x12$\n" +
+	" WORKFLOW_STATUS_CONTINUED_AS_NEW\x10\x02\x12\x1a\n" +
+	"\x16WORKFLOW_STATUS_FAILED\x10\x03\x12\x1c\n" +
+	"\x18WORKFLOW_STATUS_CANCELED\x10\x04\x12\x1e\n" +
+	"\x1aWORKFLOW_STATUS_TERMINATED\x10\x05\x12\x1b\n" +
+	"\x17WORKFLOW_STATUS_PENDING\x10\x06\x12\x1d\n" +
+	"\x19WORKFLOW_STATUS_SUSPENDED\x10\a2\xfa\x05\n" +
+	"\fAdminService\x12\x92\x01\n" +
+	"\x15ListWorkflowInstances\x12+.playground.v1.ListWorkflowInstancesRequest\x1a,.playground.v1.ListWorkflowInstancesResponse\"\x1e\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/admin/workflows\x90\x02\x01\x12\x9a\x01\n" +
+	"\x13GetWorkflowInstance\x12).playground.v1.GetWorkflowInstanceRequest\x1a*.playground.v1.GetWorkflowInstanceResponse\",\x82\xd3\xe4\x93\x02#\x12!/v1/admin/workflows/{instance_id}\x90\x02\x01\x12\x9e\x01\n" +
+	"\x11TerminateWorkflow\x12'.playground.v1.TerminateWorkflowRequest\x1a(.playground.v1.TerminateWorkflowResponse\"6\x82\xd3\xe4\x93\x020:\x01*\"+/v1/admin/workflows/{instance_id}:terminate\x12\x85\x01\n" +
+	"\rPurgeWorkflow\x12#.playground.v1.PurgeWorkflowRequest\x1a$.playground.v1.PurgeWorkflowResponse\")\x82\xd3\xe4\x93\x02#*!/v1/admin/workflows/{instance_id}\x12\x8e\x01\n" +
+	"\rRetryWorkflow\x12#.playground.v1.RetryWorkflowRequest\x1a$.playground.v1.RetryWorkflowResponse\"2\x82\xd3\xe4\x93\x02,:\x01*\"'/v1/admin/workflows/{instance_id}:retryB\xc9\x01\n" +
+	"\x11com.playground.v1B\n" +
+	"AdminProtoP\x01ZSgithub.com/andrewstucki/vanguard-playground/internal/gen/playground/v1;playgroundv1\xa2\x02\x03PXX\xaa\x02\rPlayground.V1\xca\x02\rPlayground\\V1\xe2\x02\x19Playground\\V1\\GPBMetadata\xea\x02\x0ePlayground::V1b\x06proto3"
+
+var (
+	file_playground_v1_admin_proto_rawDescOnce sync.Once
+	file_playground_v1_admin_proto_rawDescData []byte
+)
+
+func file_playground_v1_admin_proto_rawDescGZIP() []byte {
+	file_playground_v1_admin_proto_rawDescOnce.Do(func() {
+		file_playground_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_playground_v1_admin_proto_rawDesc), len(file_playground_v1_admin_proto_rawDesc)))
+	})
+	return file_playground_v1_admin_proto_rawDescData
+}
+
+var file_playground_v1_admin_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_playground_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_playground_v1_admin_proto_goTypes = []any{
+	(WorkflowStatus)(0),                   // 0: playground.v1.WorkflowStatus
+	(*WorkflowInstance)(nil),              // 1: playground.v1.WorkflowInstance
+	(*WorkflowHistoryEvent)(nil),          // 2: playground.v1.WorkflowHistoryEvent
+	(*ListWorkflowInstancesRequest)(nil),  // 3: playground.v1.ListWorkflowInstancesRequest
+	(*ListWorkflowInstancesResponse)(nil), // 4: playground.v1.ListWorkflowInstancesResponse
+	(*GetWorkflowInstanceRequest)(nil),    // 5: playground.v1.GetWorkflowInstanceRequest
+	(*GetWorkflowInstanceResponse)(nil),   // 6: playground.v1.GetWorkflowInstanceResponse
+	(*TerminateWorkflowRequest)(nil),      // 7: playground.v1.TerminateWorkflowRequest
+	(*TerminateWorkflowResponse)(nil),     // 8: playground.v1.TerminateWorkflowResponse
+	(*PurgeWorkflowRequest)(nil),          // 9: playground.v1.PurgeWorkflowRequest
+	(*PurgeWorkflowResponse)(nil),         // 10: playground.v1.PurgeWorkflowResponse
+	(*RetryWorkflowRequest)(nil),          // 11: playground.v1.RetryWorkflowRequest
+	(*RetryWorkflowResponse)(nil),         // 12: playground.v1.RetryWorkflowResponse
+	(*timestamppb.Timestamp)(nil),         // 13: google.protobuf.Timestamp
+	(*structpb.Value)(nil),                // 14: google.protobuf.Value
+}
+var file_playground_v1_admin_proto_depIdxs = []int32{
+	0,  // 0: playground.v1.WorkflowInstance.status:type_name -> playground.v1.WorkflowStatus
+	13, // 1: playground.v1.WorkflowInstance.create_time:type_name -> google.protobuf.Timestamp
+	13, // 2: playground.v1.WorkflowInstance.update_time:type_name -> google.protobuf.Timestamp
+	13, // 3: playground.v1.WorkflowHistoryEvent.time:type_name -> google.protobuf.Timestamp
+	0,  // 4: playground.v1.ListWorkflowInstancesRequest.statuses:type_name -> playground.v1.WorkflowStatus
+	1,  // 5: playground.v1.ListWorkflowInstancesResponse.instances:type_name -> playground.v1.WorkflowInstance
+	1,  // 6: playground.v1.GetWorkflowInstanceResponse.instance:type_name -> playground.v1.WorkflowInstance
+	14, // 7: playground.v1.GetWorkflowInstanceResponse.input:type_name -> google.protobuf.Value
+	14, // 8: playground.v1.GetWorkflowInstanceResponse.output:type_name -> google.protobuf.Value
+	2,  // 9: playground.v1.GetWorkflowInstanceResponse.history:type_name -> playground.v1.WorkflowHistoryEvent
+	3,  // 10: playground.v1.AdminService.ListWorkflowInstances:input_type -> playground.v1.ListWorkflowInstancesRequest
+	5,  // 11: playground.v1.AdminService.GetWorkflowInstance:input_type -> playground.v1.GetWorkflowInstanceRequest
+	7,  // 12: playground.v1.AdminService.TerminateWorkflow:input_type -> playground.v1.TerminateWorkflowRequest
+	9,  // 13: playground.v1.AdminService.PurgeWorkflow:input_type -> playground.v1.PurgeWorkflowRequest
+	11, // 14: playground.v1.AdminService.RetryWorkflow:input_type -> playground.v1.RetryWorkflowRequest
+	4,  // 15: playground.v1.AdminService.ListWorkflowInstances:output_type -> playground.v1.ListWorkflowInstancesResponse
+	6,  // 16: playground.v1.AdminService.GetWorkflowInstance:output_type -> playground.v1.GetWorkflowInstanceResponse
+	8,  // 17: playground.v1.AdminService.TerminateWorkflow:output_type -> playground.v1.TerminateWorkflowResponse
+	10, // 18: playground.v1.AdminService.PurgeWorkflow:output_type -> playground.v1.PurgeWorkflowResponse
+	12, // 19: playground.v1.AdminService.RetryWorkflow:output_type -> playground.v1.RetryWorkflowResponse
+	15, // [15:20] is the sub-list for method output_type
+	10, // [10:15] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_playground_v1_admin_proto_init() }
+func file_playground_v1_admin_proto_init() {
+	if File_playground_v1_admin_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_playground_v1_admin_proto_rawDesc), len(file_playground_v1_admin_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_playground_v1_admin_proto_goTypes,
+		DependencyIndexes: file_playground_v1_admin_proto_depIdxs,
+		EnumInfos:         file_playground_v1_admin_proto_enumTypes,
+		MessageInfos:      file_playground_v1_admin_proto_msgTypes,
+	}.Build()
+	File_playground_v1_admin_proto = out.File
+	file_playground_v1_admin_proto_goTypes = nil
+	file_playground_v1_admin_proto_depIdxs = nil
+}
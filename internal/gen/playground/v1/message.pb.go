@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.36.6
+// 	protoc-gen-go v1.36.9
 // 	protoc        (unknown)
 // source: playground/v1/message.proto
 
@@ -10,8 +10,12 @@ import (
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
 	_ "github.com/andrewstucki/protoc-states/gen/state/v1"
 	_ "google.golang.org/genproto/googleapis/api/annotations"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -24,12 +28,65 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ContentType int32
+
+const (
+	ContentType_PLAIN    ContentType = 0
+	ContentType_MARKDOWN ContentType = 1
+	ContentType_JSON     ContentType = 2
+)
+
+// Enum value maps for ContentType.
+var (
+	ContentType_name = map[int32]string{
+		0: "PLAIN",
+		1: "MARKDOWN",
+		2: "JSON",
+	}
+	ContentType_value = map[string]int32{
+		"PLAIN":    0,
+		"MARKDOWN": 1,
+		"JSON":     2,
+	}
+)
+
+func (x ContentType) Enum() *ContentType {
+	p := new(ContentType)
+	*p = x
+	return p
+}
+
+func (x ContentType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ContentType) Descriptor() protoreflect.EnumDescriptor {
+	return file_playground_v1_message_proto_enumTypes[0].Descriptor()
+}
+
+func (ContentType) Type() protoreflect.EnumType {
+	return &file_playground_v1_message_proto_enumTypes[0]
+}
+
+func (x ContentType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ContentType.Descriptor instead.
+func (ContentType) EnumDescriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{0}
+}
+
 type MessageState int32
 
 const (
 	MessageState_SENDING   MessageState = 0
 	MessageState_FAILED    MessageState = 1
 	MessageState_SUCCEEDED MessageState = 2
+	MessageState_CANCELED  MessageState = 3
+	// only reported for sends to multiple recipients where some, but not
+	// all, of the recipients succeeded
+	MessageState_PARTIALLY_SUCCEEDED MessageState = 4
 )
 
 // Enum value maps for MessageState.
@@ -38,11 +95,15 @@ var (
 		0: "SENDING",
 		1: "FAILED",
 		2: "SUCCEEDED",
+		3: "CANCELED",
+		4: "PARTIALLY_SUCCEEDED",
 	}
 	MessageState_value = map[string]int32{
-		"SENDING":   0,
-		"FAILED":    1,
-		"SUCCEEDED": 2,
+		"SENDING":             0,
+		"FAILED":              1,
+		"SUCCEEDED":           2,
+		"CANCELED":            3,
+		"PARTIALLY_SUCCEEDED": 4,
 	}
 )
 
@@ -57,11 +118,11 @@ func (x MessageState) String() string {
 }
 
 func (MessageState) Descriptor() protoreflect.EnumDescriptor {
-	return file_playground_v1_message_proto_enumTypes[0].Descriptor()
+	return file_playground_v1_message_proto_enumTypes[1].Descriptor()
 }
 
 func (MessageState) Type() protoreflect.EnumType {
-	return &file_playground_v1_message_proto_enumTypes[0]
+	return &file_playground_v1_message_proto_enumTypes[1]
 }
 
 func (x MessageState) Number() protoreflect.EnumNumber {
@@ -70,13 +131,119 @@ func (x MessageState) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use MessageState.Descriptor instead.
 func (MessageState) EnumDescriptor() ([]byte, []int) {
-	return file_playground_v1_message_proto_rawDescGZIP(), []int{0}
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{1}
+}
+
+type Channel int32
+
+const (
+	Channel_EMAIL Channel = 0
+	Channel_SMS   Channel = 1
+	Channel_PUSH  Channel = 2
+)
+
+// Enum value maps for Channel.
+var (
+	Channel_name = map[int32]string{
+		0: "EMAIL",
+		1: "SMS",
+		2: "PUSH",
+	}
+	Channel_value = map[string]int32{
+		"EMAIL": 0,
+		"SMS":   1,
+		"PUSH":  2,
+	}
+)
+
+func (x Channel) Enum() *Channel {
+	p := new(Channel)
+	*p = x
+	return p
+}
+
+func (x Channel) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Channel) Descriptor() protoreflect.EnumDescriptor {
+	return file_playground_v1_message_proto_enumTypes[2].Descriptor()
+}
+
+func (Channel) Type() protoreflect.EnumType {
+	return &file_playground_v1_message_proto_enumTypes[2]
+}
+
+func (x Channel) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Channel.Descriptor instead.
+func (Channel) EnumDescriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{2}
+}
+
+type WebhookDeliveryState int32
+
+const (
+	WebhookDeliveryState_PENDING   WebhookDeliveryState = 0
+	WebhookDeliveryState_DELIVERED WebhookDeliveryState = 1
+	// every attempt failed
+	WebhookDeliveryState_ABANDONED WebhookDeliveryState = 2
+)
+
+// Enum value maps for WebhookDeliveryState.
+var (
+	WebhookDeliveryState_name = map[int32]string{
+		0: "PENDING",
+		1: "DELIVERED",
+		2: "ABANDONED",
+	}
+	WebhookDeliveryState_value = map[string]int32{
+		"PENDING":   0,
+		"DELIVERED": 1,
+		"ABANDONED": 2,
+	}
+)
+
+func (x WebhookDeliveryState) Enum() *WebhookDeliveryState {
+	p := new(WebhookDeliveryState)
+	*p = x
+	return p
+}
+
+func (x WebhookDeliveryState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookDeliveryState) Descriptor() protoreflect.EnumDescriptor {
+	return file_playground_v1_message_proto_enumTypes[3].Descriptor()
+}
+
+func (WebhookDeliveryState) Type() protoreflect.EnumType {
+	return &file_playground_v1_message_proto_enumTypes[3]
+}
+
+func (x WebhookDeliveryState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookDeliveryState.Descriptor instead.
+func (WebhookDeliveryState) EnumDescriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{3}
 }
 
 type Message struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
-	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	MessageId   string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Text        string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	CreateTime  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	Labels      map[string]string      `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ContentType ContentType            `protobuf:"varint,6,opt,name=content_type,json=contentType,proto3,enum=playground.v1.ContentType" json:"content_type,omitempty"`
+	Payload     []byte                 `protobuf:"bytes,7,opt,name=payload,proto3" json:"payload,omitempty"`
+	// only populated by GetMessage
+	Attachments   []*Attachment `protobuf:"bytes,8,rep,name=attachments,proto3" json:"attachments,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -125,9 +292,56 @@ func (x *Message) GetText() string {
 	return ""
 }
 
+func (x *Message) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Message) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+func (x *Message) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Message) GetContentType() ContentType {
+	if x != nil {
+		return x.ContentType
+	}
+	return ContentType_PLAIN
+}
+
+func (x *Message) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Message) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
 type CreateMessageRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the combined size of text and payload is limited by the server's
+	// configured maximum message size
+	Text          string            `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Labels        map[string]string `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ContentType   ContentType       `protobuf:"varint,3,opt,name=content_type,json=contentType,proto3,enum=playground.v1.ContentType" json:"content_type,omitempty"`
+	Payload       []byte            `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -169,6 +383,27 @@ func (x *CreateMessageRequest) GetText() string {
 	return ""
 }
 
+func (x *CreateMessageRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *CreateMessageRequest) GetContentType() ContentType {
+	if x != nil {
+		return x.ContentType
+	}
+	return ContentType_PLAIN
+}
+
+func (x *CreateMessageRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
 type CreateMessageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
@@ -213,27 +448,36 @@ func (x *CreateMessageResponse) GetMessageId() string {
 	return ""
 }
 
-type GetMessageRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+// UpdateMessageRequest replaces the fields named by update_mask, or every
+// field that is set when it is empty, leaving the rest as they are.
+// Operations already sending the message keep the text they started with.
+type UpdateMessageRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	MessageId   string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Text        string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Labels      map[string]string      `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ContentType ContentType            `protobuf:"varint,4,opt,name=content_type,json=contentType,proto3,enum=playground.v1.ContentType" json:"content_type,omitempty"`
+	Payload     []byte                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	// any of text, labels, content_type and payload
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,6,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMessageRequest) Reset() {
-	*x = GetMessageRequest{}
+func (x *UpdateMessageRequest) Reset() {
+	*x = UpdateMessageRequest{}
 	mi := &file_playground_v1_message_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMessageRequest) String() string {
+func (x *UpdateMessageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMessageRequest) ProtoMessage() {}
+func (*UpdateMessageRequest) ProtoMessage() {}
 
-func (x *GetMessageRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateMessageRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_playground_v1_message_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -245,39 +489,74 @@ func (x *GetMessageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMessageRequest.ProtoReflect.Descriptor instead.
-func (*GetMessageRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateMessageRequest.ProtoReflect.Descriptor instead.
+func (*UpdateMessageRequest) Descriptor() ([]byte, []int) {
 	return file_playground_v1_message_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetMessageRequest) GetMessageId() string {
+func (x *UpdateMessageRequest) GetMessageId() string {
 	if x != nil {
 		return x.MessageId
 	}
 	return ""
 }
 
-type GetMessageResponse struct {
+func (x *UpdateMessageRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *UpdateMessageRequest) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *UpdateMessageRequest) GetContentType() ContentType {
+	if x != nil {
+		return x.ContentType
+	}
+	return ContentType_PLAIN
+}
+
+func (x *UpdateMessageRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *UpdateMessageRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateMessageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMessageResponse) Reset() {
-	*x = GetMessageResponse{}
+func (x *UpdateMessageResponse) Reset() {
+	*x = UpdateMessageResponse{}
 	mi := &file_playground_v1_message_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMessageResponse) String() string {
+func (x *UpdateMessageResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMessageResponse) ProtoMessage() {}
+func (*UpdateMessageResponse) ProtoMessage() {}
 
-func (x *GetMessageResponse) ProtoReflect() protoreflect.Message {
+func (x *UpdateMessageResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_playground_v1_message_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -289,38 +568,39 @@ func (x *GetMessageResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMessageResponse.ProtoReflect.Descriptor instead.
-func (*GetMessageResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateMessageResponse.ProtoReflect.Descriptor instead.
+func (*UpdateMessageResponse) Descriptor() ([]byte, []int) {
 	return file_playground_v1_message_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetMessageResponse) GetMessage() *Message {
+func (x *UpdateMessageResponse) GetMessage() *Message {
 	if x != nil {
 		return x.Message
 	}
 	return nil
 }
 
-type ListMessagesRequest struct {
+type GetMessageRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListMessagesRequest) Reset() {
-	*x = ListMessagesRequest{}
+func (x *GetMessageRequest) Reset() {
+	*x = GetMessageRequest{}
 	mi := &file_playground_v1_message_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListMessagesRequest) String() string {
+func (x *GetMessageRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListMessagesRequest) ProtoMessage() {}
+func (*GetMessageRequest) ProtoMessage() {}
 
-func (x *ListMessagesRequest) ProtoReflect() protoreflect.Message {
+func (x *GetMessageRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_playground_v1_message_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -332,32 +612,39 @@ func (x *ListMessagesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListMessagesRequest.ProtoReflect.Descriptor instead.
-func (*ListMessagesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetMessageRequest.ProtoReflect.Descriptor instead.
+func (*GetMessageRequest) Descriptor() ([]byte, []int) {
 	return file_playground_v1_message_proto_rawDescGZIP(), []int{5}
 }
 
-type ListMessagesResponse struct {
+func (x *GetMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type GetMessageResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListMessagesResponse) Reset() {
-	*x = ListMessagesResponse{}
+func (x *GetMessageResponse) Reset() {
+	*x = GetMessageResponse{}
 	mi := &file_playground_v1_message_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListMessagesResponse) String() string {
+func (x *GetMessageResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListMessagesResponse) ProtoMessage() {}
+func (*GetMessageResponse) ProtoMessage() {}
 
-func (x *ListMessagesResponse) ProtoReflect() protoreflect.Message {
+func (x *GetMessageResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_playground_v1_message_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -369,39 +656,41 @@ func (x *ListMessagesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListMessagesResponse.ProtoReflect.Descriptor instead.
-func (*ListMessagesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetMessageResponse.ProtoReflect.Descriptor instead.
+func (*GetMessageResponse) Descriptor() ([]byte, []int) {
 	return file_playground_v1_message_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ListMessagesResponse) GetMessages() []*Message {
+func (x *GetMessageResponse) GetMessage() *Message {
 	if x != nil {
-		return x.Messages
+		return x.Message
 	}
 	return nil
 }
 
-type DeleteMessageRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+type ListMessagesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// only list messages with every one of these labels, each given as
+	// key=value
+	Labels        []string `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteMessageRequest) Reset() {
-	*x = DeleteMessageRequest{}
+func (x *ListMessagesRequest) Reset() {
+	*x = ListMessagesRequest{}
 	mi := &file_playground_v1_message_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteMessageRequest) String() string {
+func (x *ListMessagesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteMessageRequest) ProtoMessage() {}
+func (*ListMessagesRequest) ProtoMessage() {}
 
-func (x *DeleteMessageRequest) ProtoReflect() protoreflect.Message {
+func (x *ListMessagesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_playground_v1_message_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -413,38 +702,39 @@ func (x *DeleteMessageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteMessageRequest.ProtoReflect.Descriptor instead.
-func (*DeleteMessageRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListMessagesRequest) Descriptor() ([]byte, []int) {
 	return file_playground_v1_message_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *DeleteMessageRequest) GetMessageId() string {
+func (x *ListMessagesRequest) GetLabels() []string {
 	if x != nil {
-		return x.MessageId
+		return x.Labels
 	}
-	return ""
+	return nil
 }
 
-type DeleteMessageResponse struct {
+type ListMessagesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteMessageResponse) Reset() {
-	*x = DeleteMessageResponse{}
+func (x *ListMessagesResponse) Reset() {
+	*x = ListMessagesResponse{}
 	mi := &file_playground_v1_message_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteMessageResponse) String() string {
+func (x *ListMessagesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteMessageResponse) ProtoMessage() {}
+func (*ListMessagesResponse) ProtoMessage() {}
 
-func (x *DeleteMessageResponse) ProtoReflect() protoreflect.Message {
+func (x *ListMessagesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_playground_v1_message_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -456,35 +746,3669 @@ func (x *DeleteMessageResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
+// Deprecated: Use ListMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListMessagesResponse) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type SearchMessagesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// an FTS5 query over message text, e.g. `hello world`, `"exact phrase"`,
+	// `hel*` or `hello OR goodbye`
+	Q string `protobuf:"bytes,1,opt,name=q,proto3" json:"q,omitempty"`
+	// defaults to 20
+	PageSize      int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMessagesRequest) Reset() {
+	*x = SearchMessagesRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMessagesRequest) ProtoMessage() {}
+
+func (x *SearchMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMessagesRequest.ProtoReflect.Descriptor instead.
+func (*SearchMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SearchMessagesRequest) GetQ() string {
+	if x != nil {
+		return x.Q
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchMessagesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type SearchMessagesResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Results []*SearchResult        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// set when there are more results, to pass as the next request's
+	// page_token
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMessagesResponse) Reset() {
+	*x = SearchMessagesResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMessagesResponse) ProtoMessage() {}
+
+func (x *SearchMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMessagesResponse.ProtoReflect.Descriptor instead.
+func (*SearchMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchMessagesResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchMessagesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type SearchResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Message *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// the best matching part of the text, with matches wrapped in <mark> tags
+	Snippet string `protobuf:"bytes,2,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	// the bm25 rank of the match, where lower is better
+	Rank          float64 `protobuf:"fixed64,3,opt,name=rank,proto3" json:"rank,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_playground_v1_message_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchResult) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *SearchResult) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *SearchResult) GetRank() float64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+type DeleteMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMessageRequest) Reset() {
+	*x = DeleteMessageRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMessageRequest) ProtoMessage() {}
+
+func (x *DeleteMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMessageRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMessageRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type DeleteMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMessageResponse) Reset() {
+	*x = DeleteMessageResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMessageResponse) ProtoMessage() {}
+
+func (x *DeleteMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
 // Deprecated: Use DeleteMessageResponse.ProtoReflect.Descriptor instead.
 func (*DeleteMessageResponse) Descriptor() ([]byte, []int) {
-	return file_playground_v1_message_proto_rawDescGZIP(), []int{8}
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{13}
+}
+
+// FaultSpec describes faults to inject into a send's workflow steps. It is
+// only honored when the server is started with --allow-fault-injection.
+type FaultSpec struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// fail the first N attempts of each step with a retryable error
+	FailAttempts uint32 `protobuf:"varint,1,opt,name=fail_attempts,json=failAttempts,proto3" json:"fail_attempts,omitempty"`
+	// delay added before each step attempt runs, capped so that every attempt
+	// of a step still fits in its retry timeout, the shortest being 30s over 3
+	// attempts
+	Latency *durationpb.Duration `protobuf:"bytes,2,opt,name=latency,proto3" json:"latency,omitempty"`
+	// fail the step with an error that is never retried
+	NonRetryable bool `protobuf:"varint,3,opt,name=non_retryable,json=nonRetryable,proto3" json:"non_retryable,omitempty"`
+	// panic inside the step
+	Panic bool `protobuf:"varint,4,opt,name=panic,proto3" json:"panic,omitempty"`
+	// crash the process after the step commits but before it completes
+	CrashAfterCommit bool `protobuf:"varint,5,opt,name=crash_after_commit,json=crashAfterCommit,proto3" json:"crash_after_commit,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *FaultSpec) Reset() {
+	*x = FaultSpec{}
+	mi := &file_playground_v1_message_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FaultSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FaultSpec) ProtoMessage() {}
+
+func (x *FaultSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FaultSpec.ProtoReflect.Descriptor instead.
+func (*FaultSpec) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *FaultSpec) GetFailAttempts() uint32 {
+	if x != nil {
+		return x.FailAttempts
+	}
+	return 0
+}
+
+func (x *FaultSpec) GetLatency() *durationpb.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+func (x *FaultSpec) GetNonRetryable() bool {
+	if x != nil {
+		return x.NonRetryable
+	}
+	return false
+}
+
+func (x *FaultSpec) GetPanic() bool {
+	if x != nil {
+		return x.Panic
+	}
+	return false
+}
+
+func (x *FaultSpec) GetCrashAfterCommit() bool {
+	if x != nil {
+		return x.CrashAfterCommit
+	}
+	return false
+}
+
+type SendMessageState struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	OperationId string                 `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	State       MessageState           `protobuf:"varint,3,opt,name=state,proto3,enum=playground.v1.MessageState" json:"state,omitempty"`
+	Fault       *FaultSpec             `protobuf:"bytes,4,opt,name=fault,proto3" json:"fault,omitempty"`
+	// set by the deliver step and checked by the confirm step
+	ReceiptId  string            `protobuf:"bytes,5,opt,name=receipt_id,json=receiptId,proto3" json:"receipt_id,omitempty"`
+	TemplateId string            `protobuf:"bytes,6,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Variables  map[string]string `protobuf:"bytes,7,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// the recipient this operation delivers to, if the send had recipients
+	RecipientId string `protobuf:"bytes,8,opt,name=recipient_id,json=recipientId,proto3" json:"recipient_id,omitempty"`
+	// the tenant that owns the operation, which every step is scoped to
+	TenantId string `protobuf:"bytes,9,opt,name=tenant_id,json=tenantId,proto3" json:"tenant_id,omitempty"`
+	// identifies the workflow run, so that a run started again for the same
+	// operation counts its attempts afresh
+	RunId         string `protobuf:"bytes,10,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendMessageState) Reset() {
+	*x = SendMessageState{}
+	mi := &file_playground_v1_message_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMessageState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageState) ProtoMessage() {}
+
+func (x *SendMessageState) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageState.ProtoReflect.Descriptor instead.
+func (*SendMessageState) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SendMessageState) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+func (x *SendMessageState) GetState() MessageState {
+	if x != nil {
+		return x.State
+	}
+	return MessageState_SENDING
+}
+
+func (x *SendMessageState) GetFault() *FaultSpec {
+	if x != nil {
+		return x.Fault
+	}
+	return nil
+}
+
+func (x *SendMessageState) GetReceiptId() string {
+	if x != nil {
+		return x.ReceiptId
+	}
+	return ""
+}
+
+func (x *SendMessageState) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *SendMessageState) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *SendMessageState) GetRecipientId() string {
+	if x != nil {
+		return x.RecipientId
+	}
+	return ""
+}
+
+func (x *SendMessageState) GetTenantId() string {
+	if x != nil {
+		return x.TenantId
+	}
+	return ""
+}
+
+func (x *SendMessageState) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+type SendMessageRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	MessageId string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Fault     *FaultSpec             `protobuf:"bytes,3,opt,name=fault,proto3" json:"fault,omitempty"`
+	// when set, the sent text is rendered from this template instead of
+	// using the message's text
+	TemplateId string `protobuf:"bytes,4,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	// values for each of the template's declared variables
+	Variables map[string]string `protobuf:"bytes,5,rep,name=variables,proto3" json:"variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// IDs of the recipients to deliver to, each as its own operation under
+	// the returned one
+	Recipients    []string `protobuf:"bytes,6,rep,name=recipients,proto3" json:"recipients,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendMessageRequest) Reset() {
+	*x = SendMessageRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageRequest) ProtoMessage() {}
+
+func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
+func (*SendMessageRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SendMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetFault() *FaultSpec {
+	if x != nil {
+		return x.Fault
+	}
+	return nil
+}
+
+func (x *SendMessageRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetVariables() map[string]string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *SendMessageRequest) GetRecipients() []string {
+	if x != nil {
+		return x.Recipients
+	}
+	return nil
+}
+
+type SendMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	OperationId   string                 `protobuf:"bytes,2,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendMessageResponse) Reset() {
+	*x = SendMessageResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendMessageResponse) ProtoMessage() {}
+
+func (x *SendMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendMessageResponse.ProtoReflect.Descriptor instead.
+func (*SendMessageResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SendMessageResponse) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *SendMessageResponse) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+type MessageStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	OperationId   string                 `protobuf:"bytes,2,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageStatusRequest) Reset() {
+	*x = MessageStatusRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageStatusRequest) ProtoMessage() {}
+
+func (x *MessageStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageStatusRequest.ProtoReflect.Descriptor instead.
+func (*MessageStatusRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MessageStatusRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *MessageStatusRequest) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+type StepHistory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Step          string                 `protobuf:"bytes,1,opt,name=step,proto3" json:"step,omitempty"`
+	Attempts      []*OperationAttempt    `protobuf:"bytes,2,rep,name=attempts,proto3" json:"attempts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StepHistory) Reset() {
+	*x = StepHistory{}
+	mi := &file_playground_v1_message_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StepHistory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StepHistory) ProtoMessage() {}
+
+func (x *StepHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StepHistory.ProtoReflect.Descriptor instead.
+func (*StepHistory) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *StepHistory) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *StepHistory) GetAttempts() []*OperationAttempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
+type MessageStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	State string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	// the step the operation is currently on, or last ran if it is terminal
+	CurrentStep string `protobuf:"bytes,2,opt,name=current_step,json=currentStep,proto3" json:"current_step,omitempty"`
+	// every step of the workflow in order, with the attempts made so far
+	Steps []*StepHistory `protobuf:"bytes,3,rep,name=steps,proto3" json:"steps,omitempty"`
+	// compensating actions run after the operation failed or was canceled
+	Compensations []*OperationAttempt `protobuf:"bytes,4,rep,name=compensations,proto3" json:"compensations,omitempty"`
+	// the operation for each recipient of a send to multiple recipients,
+	// whose states make up this operation's state
+	Recipients []*RecipientStatus `protobuf:"bytes,5,rep,name=recipients,proto3" json:"recipients,omitempty"`
+	// the message's attachments at the time it was sent
+	Attachments   []*Attachment `protobuf:"bytes,6,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageStatusResponse) Reset() {
+	*x = MessageStatusResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageStatusResponse) ProtoMessage() {}
+
+func (x *MessageStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageStatusResponse.ProtoReflect.Descriptor instead.
+func (*MessageStatusResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *MessageStatusResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *MessageStatusResponse) GetCurrentStep() string {
+	if x != nil {
+		return x.CurrentStep
+	}
+	return ""
+}
+
+func (x *MessageStatusResponse) GetSteps() []*StepHistory {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *MessageStatusResponse) GetCompensations() []*OperationAttempt {
+	if x != nil {
+		return x.Compensations
+	}
+	return nil
+}
+
+func (x *MessageStatusResponse) GetRecipients() []*RecipientStatus {
+	if x != nil {
+		return x.Recipients
+	}
+	return nil
+}
+
+func (x *MessageStatusResponse) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+type RecipientStatus struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recipient     *Recipient             `protobuf:"bytes,1,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	OperationId   string                 `protobuf:"bytes,2,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	State         string                 `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	CurrentStep   string                 `protobuf:"bytes,4,opt,name=current_step,json=currentStep,proto3" json:"current_step,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecipientStatus) Reset() {
+	*x = RecipientStatus{}
+	mi := &file_playground_v1_message_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecipientStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecipientStatus) ProtoMessage() {}
+
+func (x *RecipientStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecipientStatus.ProtoReflect.Descriptor instead.
+func (*RecipientStatus) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RecipientStatus) GetRecipient() *Recipient {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
+func (x *RecipientStatus) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+func (x *RecipientStatus) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *RecipientStatus) GetCurrentStep() string {
+	if x != nil {
+		return x.CurrentStep
+	}
+	return ""
+}
+
+type CancelSendRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	OperationId   string                 `protobuf:"bytes,2,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelSendRequest) Reset() {
+	*x = CancelSendRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelSendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelSendRequest) ProtoMessage() {}
+
+func (x *CancelSendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelSendRequest.ProtoReflect.Descriptor instead.
+func (*CancelSendRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CancelSendRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *CancelSendRequest) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+type CancelSendResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelSendResponse) Reset() {
+	*x = CancelSendResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelSendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelSendResponse) ProtoMessage() {}
+
+func (x *CancelSendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelSendResponse.ProtoReflect.Descriptor instead.
+func (*CancelSendResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CancelSendResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+type OperationAttempt struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Step  string                 `protobuf:"bytes,1,opt,name=step,proto3" json:"step,omitempty"`
+	// counts the attempts at the step within a workflow run
+	Attempt    int32                  `protobuf:"varint,2,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	Error      string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// the workflow run that made the attempt, which only differs between the
+	// attempts of an operation whose workflow was retried
+	RunId         string `protobuf:"bytes,5,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationAttempt) Reset() {
+	*x = OperationAttempt{}
+	mi := &file_playground_v1_message_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationAttempt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationAttempt) ProtoMessage() {}
+
+func (x *OperationAttempt) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationAttempt.ProtoReflect.Descriptor instead.
+func (*OperationAttempt) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *OperationAttempt) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *OperationAttempt) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *OperationAttempt) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *OperationAttempt) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *OperationAttempt) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+type DeadLetter struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetterId string                 `protobuf:"bytes,1,opt,name=dead_letter_id,json=deadLetterId,proto3" json:"dead_letter_id,omitempty"`
+	OperationId  string                 `protobuf:"bytes,2,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	MessageId    string                 `protobuf:"bytes,3,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Step         string                 `protobuf:"bytes,4,opt,name=step,proto3" json:"step,omitempty"`
+	Input        *SendMessageState      `protobuf:"bytes,5,opt,name=input,proto3" json:"input,omitempty"`
+	LastError    string                 `protobuf:"bytes,6,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	Attempts     []*OperationAttempt    `protobuf:"bytes,7,rep,name=attempts,proto3" json:"attempts,omitempty"`
+	// the operation started by the most recent redrive, if any
+	RedriveOperationId string                 `protobuf:"bytes,8,opt,name=redrive_operation_id,json=redriveOperationId,proto3" json:"redrive_operation_id,omitempty"`
+	CreateTime         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DeadLetter) Reset() {
+	*x = DeadLetter{}
+	mi := &file_playground_v1_message_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetter) ProtoMessage() {}
+
+func (x *DeadLetter) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetter.ProtoReflect.Descriptor instead.
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeadLetter) GetDeadLetterId() string {
+	if x != nil {
+		return x.DeadLetterId
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetInput() *SendMessageState {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetAttempts() []*OperationAttempt {
+	if x != nil {
+		return x.Attempts
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetRedriveOperationId() string {
+	if x != nil {
+		return x.RedriveOperationId
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+type ListDeadLettersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersRequest) Reset() {
+	*x = ListDeadLettersRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersRequest) ProtoMessage() {}
+
+func (x *ListDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListDeadLettersRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+type ListDeadLettersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetters   []*DeadLetter          `protobuf:"bytes,1,rep,name=dead_letters,json=deadLetters,proto3" json:"dead_letters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersResponse) Reset() {
+	*x = ListDeadLettersResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersResponse) ProtoMessage() {}
+
+func (x *ListDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListDeadLettersResponse) GetDeadLetters() []*DeadLetter {
+	if x != nil {
+		return x.DeadLetters
+	}
+	return nil
+}
+
+type GetDeadLetterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetterId  string                 `protobuf:"bytes,1,opt,name=dead_letter_id,json=deadLetterId,proto3" json:"dead_letter_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeadLetterRequest) Reset() {
+	*x = GetDeadLetterRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeadLetterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeadLetterRequest) ProtoMessage() {}
+
+func (x *GetDeadLetterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeadLetterRequest.ProtoReflect.Descriptor instead.
+func (*GetDeadLetterRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetDeadLetterRequest) GetDeadLetterId() string {
+	if x != nil {
+		return x.DeadLetterId
+	}
+	return ""
+}
+
+type GetDeadLetterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetter    *DeadLetter            `protobuf:"bytes,1,opt,name=dead_letter,json=deadLetter,proto3" json:"dead_letter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeadLetterResponse) Reset() {
+	*x = GetDeadLetterResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeadLetterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeadLetterResponse) ProtoMessage() {}
+
+func (x *GetDeadLetterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeadLetterResponse.ProtoReflect.Descriptor instead.
+func (*GetDeadLetterResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetDeadLetterResponse) GetDeadLetter() *DeadLetter {
+	if x != nil {
+		return x.DeadLetter
+	}
+	return nil
+}
+
+type RedriveDeadLetterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetterId  string                 `protobuf:"bytes,1,opt,name=dead_letter_id,json=deadLetterId,proto3" json:"dead_letter_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedriveDeadLetterRequest) Reset() {
+	*x = RedriveDeadLetterRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedriveDeadLetterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedriveDeadLetterRequest) ProtoMessage() {}
+
+func (x *RedriveDeadLetterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedriveDeadLetterRequest.ProtoReflect.Descriptor instead.
+func (*RedriveDeadLetterRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RedriveDeadLetterRequest) GetDeadLetterId() string {
+	if x != nil {
+		return x.DeadLetterId
+	}
+	return ""
+}
+
+type RedriveDeadLetterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	OperationId   string                 `protobuf:"bytes,2,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedriveDeadLetterResponse) Reset() {
+	*x = RedriveDeadLetterResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedriveDeadLetterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedriveDeadLetterResponse) ProtoMessage() {}
+
+func (x *RedriveDeadLetterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedriveDeadLetterResponse.ProtoReflect.Descriptor instead.
+func (*RedriveDeadLetterResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *RedriveDeadLetterResponse) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *RedriveDeadLetterResponse) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+type PurgeDeadLettersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// when empty, every dead letter is purged
+	DeadLetterIds []string `protobuf:"bytes,1,rep,name=dead_letter_ids,json=deadLetterIds,proto3" json:"dead_letter_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeadLettersRequest) Reset() {
+	*x = PurgeDeadLettersRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeadLettersRequest) ProtoMessage() {}
+
+func (x *PurgeDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*PurgeDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PurgeDeadLettersRequest) GetDeadLetterIds() []string {
+	if x != nil {
+		return x.DeadLetterIds
+	}
+	return nil
+}
+
+type PurgeDeadLettersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Purged        int64                  `protobuf:"varint,1,opt,name=purged,proto3" json:"purged,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeDeadLettersResponse) Reset() {
+	*x = PurgeDeadLettersResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeDeadLettersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeDeadLettersResponse) ProtoMessage() {}
+
+func (x *PurgeDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*PurgeDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PurgeDeadLettersResponse) GetPurged() int64 {
+	if x != nil {
+		return x.Purged
+	}
+	return 0
+}
+
+type Template struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Name       string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// a Go text/template body referencing its variables as {{.name}}
+	Body          string   `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	Variables     []string `protobuf:"bytes,4,rep,name=variables,proto3" json:"variables,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Template) Reset() {
+	*x = Template{}
+	mi := &file_playground_v1_message_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Template) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Template) ProtoMessage() {}
+
+func (x *Template) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Template.ProtoReflect.Descriptor instead.
+func (*Template) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Template) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *Template) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Template) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *Template) GetVariables() []string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+type CreateTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Body          string                 `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
+	Variables     []string               `protobuf:"bytes,3,rep,name=variables,proto3" json:"variables,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTemplateRequest) Reset() {
+	*x = CreateTemplateRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTemplateRequest) ProtoMessage() {}
+
+func (x *CreateTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreateTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CreateTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTemplateRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *CreateTemplateRequest) GetVariables() []string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+type CreateTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId    string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTemplateResponse) Reset() {
+	*x = CreateTemplateResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTemplateResponse) ProtoMessage() {}
+
+func (x *CreateTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTemplateResponse.ProtoReflect.Descriptor instead.
+func (*CreateTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CreateTemplateResponse) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+type GetTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId    string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTemplateRequest) Reset() {
+	*x = GetTemplateRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTemplateRequest) ProtoMessage() {}
+
+func (x *GetTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTemplateRequest.ProtoReflect.Descriptor instead.
+func (*GetTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetTemplateRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+type GetTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *Template              `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTemplateResponse) Reset() {
+	*x = GetTemplateResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTemplateResponse) ProtoMessage() {}
+
+func (x *GetTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTemplateResponse.ProtoReflect.Descriptor instead.
+func (*GetTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetTemplateResponse) GetTemplate() *Template {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type ListTemplatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTemplatesRequest) Reset() {
+	*x = ListTemplatesRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTemplatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesRequest) ProtoMessage() {}
+
+func (x *ListTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{39}
+}
+
+type ListTemplatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Templates     []*Template            `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTemplatesResponse) Reset() {
+	*x = ListTemplatesResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTemplatesResponse) ProtoMessage() {}
+
+func (x *ListTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListTemplatesResponse) GetTemplates() []*Template {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+// UpdateTemplateRequest replaces the fields named by update_mask, or every
+// field that is set when it is empty, leaving the rest as they are.
+type UpdateTemplateRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Name       string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Body       string                 `protobuf:"bytes,3,opt,name=body,proto3" json:"body,omitempty"`
+	Variables  []string               `protobuf:"bytes,4,rep,name=variables,proto3" json:"variables,omitempty"`
+	// any of name, body and variables
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,5,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTemplateRequest) Reset() {
+	*x = UpdateTemplateRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTemplateRequest) ProtoMessage() {}
+
+func (x *UpdateTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTemplateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *UpdateTemplateRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *UpdateTemplateRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateTemplateRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *UpdateTemplateRequest) GetVariables() []string {
+	if x != nil {
+		return x.Variables
+	}
+	return nil
+}
+
+func (x *UpdateTemplateRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type UpdateTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Template      *Template              `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTemplateResponse) Reset() {
+	*x = UpdateTemplateResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTemplateResponse) ProtoMessage() {}
+
+func (x *UpdateTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTemplateResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *UpdateTemplateResponse) GetTemplate() *Template {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+type DeleteTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId    string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTemplateRequest) Reset() {
+	*x = DeleteTemplateRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *DeleteTemplateRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+type DeleteTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTemplateResponse) Reset() {
+	*x = DeleteTemplateResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTemplateResponse) ProtoMessage() {}
+
+func (x *DeleteTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTemplateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{44}
+}
+
+type Recipient struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecipientId   string                 `protobuf:"bytes,1,opt,name=recipient_id,json=recipientId,proto3" json:"recipient_id,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Channel       Channel                `protobuf:"varint,3,opt,name=channel,proto3,enum=playground.v1.Channel" json:"channel,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Recipient) Reset() {
+	*x = Recipient{}
+	mi := &file_playground_v1_message_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Recipient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Recipient) ProtoMessage() {}
+
+func (x *Recipient) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Recipient.ProtoReflect.Descriptor instead.
+func (*Recipient) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *Recipient) GetRecipientId() string {
+	if x != nil {
+		return x.RecipientId
+	}
+	return ""
+}
+
+func (x *Recipient) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *Recipient) GetChannel() Channel {
+	if x != nil {
+		return x.Channel
+	}
+	return Channel_EMAIL
+}
+
+type CreateRecipientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Channel       Channel                `protobuf:"varint,2,opt,name=channel,proto3,enum=playground.v1.Channel" json:"channel,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRecipientRequest) Reset() {
+	*x = CreateRecipientRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRecipientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRecipientRequest) ProtoMessage() {}
+
+func (x *CreateRecipientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRecipientRequest.ProtoReflect.Descriptor instead.
+func (*CreateRecipientRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CreateRecipientRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *CreateRecipientRequest) GetChannel() Channel {
+	if x != nil {
+		return x.Channel
+	}
+	return Channel_EMAIL
+}
+
+type CreateRecipientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecipientId   string                 `protobuf:"bytes,1,opt,name=recipient_id,json=recipientId,proto3" json:"recipient_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRecipientResponse) Reset() {
+	*x = CreateRecipientResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRecipientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRecipientResponse) ProtoMessage() {}
+
+func (x *CreateRecipientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRecipientResponse.ProtoReflect.Descriptor instead.
+func (*CreateRecipientResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CreateRecipientResponse) GetRecipientId() string {
+	if x != nil {
+		return x.RecipientId
+	}
+	return ""
+}
+
+type GetRecipientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecipientId   string                 `protobuf:"bytes,1,opt,name=recipient_id,json=recipientId,proto3" json:"recipient_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecipientRequest) Reset() {
+	*x = GetRecipientRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecipientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecipientRequest) ProtoMessage() {}
+
+func (x *GetRecipientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecipientRequest.ProtoReflect.Descriptor instead.
+func (*GetRecipientRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetRecipientRequest) GetRecipientId() string {
+	if x != nil {
+		return x.RecipientId
+	}
+	return ""
+}
+
+type GetRecipientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recipient     *Recipient             `protobuf:"bytes,1,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecipientResponse) Reset() {
+	*x = GetRecipientResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecipientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecipientResponse) ProtoMessage() {}
+
+func (x *GetRecipientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecipientResponse.ProtoReflect.Descriptor instead.
+func (*GetRecipientResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetRecipientResponse) GetRecipient() *Recipient {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
+type ListRecipientsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecipientsRequest) Reset() {
+	*x = ListRecipientsRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecipientsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecipientsRequest) ProtoMessage() {}
+
+func (x *ListRecipientsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecipientsRequest.ProtoReflect.Descriptor instead.
+func (*ListRecipientsRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{50}
+}
+
+type ListRecipientsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recipients    []*Recipient           `protobuf:"bytes,1,rep,name=recipients,proto3" json:"recipients,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRecipientsResponse) Reset() {
+	*x = ListRecipientsResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRecipientsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRecipientsResponse) ProtoMessage() {}
+
+func (x *ListRecipientsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRecipientsResponse.ProtoReflect.Descriptor instead.
+func (*ListRecipientsResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ListRecipientsResponse) GetRecipients() []*Recipient {
+	if x != nil {
+		return x.Recipients
+	}
+	return nil
+}
+
+type DeleteRecipientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecipientId   string                 `protobuf:"bytes,1,opt,name=recipient_id,json=recipientId,proto3" json:"recipient_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRecipientRequest) Reset() {
+	*x = DeleteRecipientRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRecipientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRecipientRequest) ProtoMessage() {}
+
+func (x *DeleteRecipientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRecipientRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRecipientRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DeleteRecipientRequest) GetRecipientId() string {
+	if x != nil {
+		return x.RecipientId
+	}
+	return ""
+}
+
+type DeleteRecipientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRecipientResponse) Reset() {
+	*x = DeleteRecipientResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRecipientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRecipientResponse) ProtoMessage() {}
+
+func (x *DeleteRecipientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRecipientResponse.ProtoReflect.Descriptor instead.
+func (*DeleteRecipientResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{53}
+}
+
+type Attachment struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	AttachmentId string                 `protobuf:"bytes,1,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	MessageId    string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Filename     string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType  string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	SizeBytes    int64                  `protobuf:"varint,5,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// hex encoded SHA-256 of the content
+	Sha256        string                 `protobuf:"bytes,6,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_playground_v1_message_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *Attachment) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *Attachment) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *Attachment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *Attachment) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *Attachment) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+// UploadAttachmentRequest is streamed in chunks. The message_id, filename and
+// sha256 are taken from the first request, and the file's content type from
+// the first chunk that sets one. REST uploads can't set sha256 and send a
+// Content-Digest header with a sha-256 digest instead.
+type UploadAttachmentRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	MessageId string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	Filename  string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	// when set, the upload is rejected unless the content has this hex
+	// encoded SHA-256
+	Sha256        string             `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	File          *httpbody.HttpBody `protobuf:"bytes,4,opt,name=file,proto3" json:"file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentRequest) Reset() {
+	*x = UploadAttachmentRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentRequest) ProtoMessage() {}
+
+func (x *UploadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *UploadAttachmentRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *UploadAttachmentRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *UploadAttachmentRequest) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *UploadAttachmentRequest) GetFile() *httpbody.HttpBody {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+type UploadAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachment    *Attachment            `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentResponse) Reset() {
+	*x = UploadAttachmentResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentResponse) ProtoMessage() {}
+
+func (x *UploadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *UploadAttachmentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+type DownloadAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	AttachmentId  string                 `protobuf:"bytes,2,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentRequest) Reset() {
+	*x = DownloadAttachmentRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentRequest) ProtoMessage() {}
+
+func (x *DownloadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *DownloadAttachmentRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *DownloadAttachmentRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+type DownloadAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *httpbody.HttpBody     `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentResponse) Reset() {
+	*x = DownloadAttachmentResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentResponse) ProtoMessage() {}
+
+func (x *DownloadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *DownloadAttachmentResponse) GetFile() *httpbody.HttpBody {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+type DeleteAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	AttachmentId  string                 `protobuf:"bytes,2,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttachmentRequest) Reset() {
+	*x = DeleteAttachmentRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttachmentRequest) ProtoMessage() {}
+
+func (x *DeleteAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *DeleteAttachmentRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *DeleteAttachmentRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+type DeleteAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttachmentResponse) Reset() {
+	*x = DeleteAttachmentResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttachmentResponse) ProtoMessage() {}
+
+func (x *DeleteAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DeleteAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{60}
+}
+
+// OperationEvent describes a send operation in an Event.
+type OperationEvent struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	MessageId   string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	OperationId string                 `protobuf:"bytes,2,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	State       MessageState           `protobuf:"varint,3,opt,name=state,proto3,enum=playground.v1.MessageState" json:"state,omitempty"`
+	// set when the operation redrives a dead-lettered one
+	RedriveOf     string `protobuf:"bytes,4,opt,name=redrive_of,json=redriveOf,proto3" json:"redrive_of,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OperationEvent) Reset() {
+	*x = OperationEvent{}
+	mi := &file_playground_v1_message_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OperationEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperationEvent) ProtoMessage() {}
+
+func (x *OperationEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperationEvent.ProtoReflect.Descriptor instead.
+func (*OperationEvent) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *OperationEvent) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *OperationEvent) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+func (x *OperationEvent) GetState() MessageState {
+	if x != nil {
+		return x.State
+	}
+	return MessageState_SENDING
+}
+
+func (x *OperationEvent) GetRedriveOf() string {
+	if x != nil {
+		return x.RedriveOf
+	}
+	return ""
+}
+
+// Event is a change to a message or send operation, recorded in the same
+// transaction as the change itself.
+type Event struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// position of the event in the stream, increasing with every event
+	Offset  int64  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	EventId string `protobuf:"bytes,2,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// one of playground.v1.message.created, playground.v1.message.updated,
+	// playground.v1.message.deleted, playground.v1.message.sent,
+	// playground.v1.operation.state_changed, playground.v1.attachment.created
+	// or playground.v1.attachment.deleted
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// ID of the message, operation or attachment the event is about
+	Subject string                 `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	Time    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*Event_Message
+	//	*Event_Operation
+	//	*Event_Attachment
+	Data          isEvent_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_playground_v1_message_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *Event) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *Event) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Event) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *Event) GetData() isEvent_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Event) GetMessage() *Message {
+	if x != nil {
+		if x, ok := x.Data.(*Event_Message); ok {
+			return x.Message
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetOperation() *OperationEvent {
+	if x != nil {
+		if x, ok := x.Data.(*Event_Operation); ok {
+			return x.Operation
+		}
+	}
+	return nil
+}
+
+func (x *Event) GetAttachment() *Attachment {
+	if x != nil {
+		if x, ok := x.Data.(*Event_Attachment); ok {
+			return x.Attachment
+		}
+	}
+	return nil
+}
+
+type isEvent_Data interface {
+	isEvent_Data()
+}
+
+type Event_Message struct {
+	Message *Message `protobuf:"bytes,6,opt,name=message,proto3,oneof"`
+}
+
+type Event_Operation struct {
+	Operation *OperationEvent `protobuf:"bytes,7,opt,name=operation,proto3,oneof"`
+}
+
+type Event_Attachment struct {
+	Attachment *Attachment `protobuf:"bytes,8,opt,name=attachment,proto3,oneof"`
+}
+
+func (*Event_Message) isEvent_Data() {}
+
+func (*Event_Operation) isEvent_Data() {}
+
+func (*Event_Attachment) isEvent_Data() {}
+
+type StreamEventsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// resume the stream after this offset, or start from the beginning when 0
+	AfterOffset int64 `protobuf:"varint,1,opt,name=after_offset,json=afterOffset,proto3" json:"after_offset,omitempty"`
+	// only stream events of these types
+	Types         []string `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEventsRequest) Reset() {
+	*x = StreamEventsRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsRequest) ProtoMessage() {}
+
+func (x *StreamEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsRequest.ProtoReflect.Descriptor instead.
+func (*StreamEventsRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *StreamEventsRequest) GetAfterOffset() int64 {
+	if x != nil {
+		return x.AfterOffset
+	}
+	return 0
+}
+
+func (x *StreamEventsRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type StreamEventsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Event *Event                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	// the event as a structured mode CloudEvents JSON document followed by a
+	// newline, which is what the REST route streams
+	CloudEvent    *httpbody.HttpBody `protobuf:"bytes,2,opt,name=cloud_event,json=cloudEvent,proto3" json:"cloud_event,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamEventsResponse) Reset() {
+	*x = StreamEventsResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamEventsResponse) ProtoMessage() {}
+
+func (x *StreamEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamEventsResponse.ProtoReflect.Descriptor instead.
+func (*StreamEventsResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *StreamEventsResponse) GetEvent() *Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *StreamEventsResponse) GetCloudEvent() *httpbody.HttpBody {
+	if x != nil {
+		return x.CloudEvent
+	}
+	return nil
+}
+
+// WebhookSubscription receives a signed POST of the operation.state_changed
+// CloudEvent whenever a send operation reaches one of its states.
+type WebhookSubscription struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId string                 `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	Url            string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	// the terminal states to deliver, or all of them when empty
+	States []MessageState `protobuf:"varint,3,rep,packed,name=states,proto3,enum=playground.v1.MessageState" json:"states,omitempty"`
+	// the signing secret, only returned when the subscription is created or
+	// its secret is replaced
+	Secret     string                 `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	CreateTime *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// how many delivery attempts in a row have failed
+	ConsecutiveFailures int32 `protobuf:"varint,6,opt,name=consecutive_failures,json=consecutiveFailures,proto3" json:"consecutive_failures,omitempty"`
+	// set once so many attempts in a row failed that the subscription was
+	// disabled. Nothing is delivered to a disabled subscription until it is
+	// enabled again by updating disabled to false.
+	DisableTime   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=disable_time,json=disableTime,proto3" json:"disable_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookSubscription) Reset() {
+	*x = WebhookSubscription{}
+	mi := &file_playground_v1_message_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookSubscription) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookSubscription) ProtoMessage() {}
+
+func (x *WebhookSubscription) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookSubscription.ProtoReflect.Descriptor instead.
+func (*WebhookSubscription) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *WebhookSubscription) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *WebhookSubscription) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookSubscription) GetStates() []MessageState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *WebhookSubscription) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *WebhookSubscription) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *WebhookSubscription) GetConsecutiveFailures() int32 {
+	if x != nil {
+		return x.ConsecutiveFailures
+	}
+	return 0
+}
+
+func (x *WebhookSubscription) GetDisableTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DisableTime
+	}
+	return nil
+}
+
+type CreateWebhookSubscriptionRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Url    string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	States []MessageState         `protobuf:"varint,2,rep,packed,name=states,proto3,enum=playground.v1.MessageState" json:"states,omitempty"`
+	// a secret of the form whsec_<base64> is generated when this is empty
+	Secret        string `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookSubscriptionRequest) Reset() {
+	*x = CreateWebhookSubscriptionRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookSubscriptionRequest) ProtoMessage() {}
+
+func (x *CreateWebhookSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *CreateWebhookSubscriptionRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateWebhookSubscriptionRequest) GetStates() []MessageState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *CreateWebhookSubscriptionRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type CreateWebhookSubscriptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscription  *WebhookSubscription   `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateWebhookSubscriptionResponse) Reset() {
+	*x = CreateWebhookSubscriptionResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWebhookSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWebhookSubscriptionResponse) ProtoMessage() {}
+
+func (x *CreateWebhookSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWebhookSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*CreateWebhookSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *CreateWebhookSubscriptionResponse) GetSubscription() *WebhookSubscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type GetWebhookSubscriptionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId string                 `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetWebhookSubscriptionRequest) Reset() {
+	*x = GetWebhookSubscriptionRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhookSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookSubscriptionRequest) ProtoMessage() {}
+
+func (x *GetWebhookSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*GetWebhookSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *GetWebhookSubscriptionRequest) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+type GetWebhookSubscriptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscription  *WebhookSubscription   `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhookSubscriptionResponse) Reset() {
+	*x = GetWebhookSubscriptionResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhookSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookSubscriptionResponse) ProtoMessage() {}
+
+func (x *GetWebhookSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*GetWebhookSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *GetWebhookSubscriptionResponse) GetSubscription() *WebhookSubscription {
+	if x != nil {
+		return x.Subscription
+	}
+	return nil
+}
+
+type ListWebhookSubscriptionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookSubscriptionsRequest) Reset() {
+	*x = ListWebhookSubscriptionsRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookSubscriptionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookSubscriptionsRequest) ProtoMessage() {}
+
+func (x *ListWebhookSubscriptionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookSubscriptionsRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookSubscriptionsRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{70}
+}
+
+type ListWebhookSubscriptionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscriptions []*WebhookSubscription `protobuf:"bytes,1,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookSubscriptionsResponse) Reset() {
+	*x = ListWebhookSubscriptionsResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookSubscriptionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookSubscriptionsResponse) ProtoMessage() {}
+
+func (x *ListWebhookSubscriptionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookSubscriptionsResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookSubscriptionsResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ListWebhookSubscriptionsResponse) GetSubscriptions() []*WebhookSubscription {
+	if x != nil {
+		return x.Subscriptions
+	}
+	return nil
+}
+
+// UpdateWebhookSubscriptionRequest replaces the fields named by update_mask,
+// or every field that is set when it is empty, leaving the rest as they are.
+type UpdateWebhookSubscriptionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId string                 `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	Url            string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	States         []MessageState         `protobuf:"varint,3,rep,packed,name=states,proto3,enum=playground.v1.MessageState" json:"states,omitempty"`
+	// replaces the signing secret, which is only returned in the response
+	// when it changes. Naming secret in update_mask without setting it
+	// generates a new one.
+	Secret string `protobuf:"bytes,5,opt,name=secret,proto3" json:"secret,omitempty"`
+	// false enables a subscription that was disabled after failed deliveries
+	Disabled bool `protobuf:"varint,6,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	// any of url, states, secret and disabled
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWebhookSubscriptionRequest) Reset() {
+	*x = UpdateWebhookSubscriptionRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateWebhookSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateWebhookSubscriptionRequest) ProtoMessage() {}
+
+func (x *UpdateWebhookSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateWebhookSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*UpdateWebhookSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *UpdateWebhookSubscriptionRequest) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
+	}
+	return ""
+}
+
+func (x *UpdateWebhookSubscriptionRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *UpdateWebhookSubscriptionRequest) GetStates() []MessageState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *UpdateWebhookSubscriptionRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *UpdateWebhookSubscriptionRequest) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
 }
 
-type SendMessageState struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	OperationId     string                 `protobuf:"bytes,1,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
-	SimulateFailure bool                   `protobuf:"varint,2,opt,name=simulate_failure,json=simulateFailure,proto3" json:"simulate_failure,omitempty"`
-	State           MessageState           `protobuf:"varint,3,opt,name=state,proto3,enum=playground.v1.MessageState" json:"state,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *UpdateWebhookSubscriptionRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
 }
 
-func (x *SendMessageState) Reset() {
-	*x = SendMessageState{}
-	mi := &file_playground_v1_message_proto_msgTypes[9]
+type UpdateWebhookSubscriptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subscription  *WebhookSubscription   `protobuf:"bytes,1,opt,name=subscription,proto3" json:"subscription,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateWebhookSubscriptionResponse) Reset() {
+	*x = UpdateWebhookSubscriptionResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendMessageState) String() string {
+func (x *UpdateWebhookSubscriptionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendMessageState) ProtoMessage() {}
+func (*UpdateWebhookSubscriptionResponse) ProtoMessage() {}
 
-func (x *SendMessageState) ProtoReflect() protoreflect.Message {
-	mi := &file_playground_v1_message_proto_msgTypes[9]
+func (x *UpdateWebhookSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -495,55 +4419,132 @@ func (x *SendMessageState) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendMessageState.ProtoReflect.Descriptor instead.
-func (*SendMessageState) Descriptor() ([]byte, []int) {
-	return file_playground_v1_message_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use UpdateWebhookSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*UpdateWebhookSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *SendMessageState) GetOperationId() string {
+func (x *UpdateWebhookSubscriptionResponse) GetSubscription() *WebhookSubscription {
 	if x != nil {
-		return x.OperationId
+		return x.Subscription
 	}
-	return ""
+	return nil
+}
+
+type DeleteWebhookSubscriptionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId string                 `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookSubscriptionRequest) Reset() {
+	*x = DeleteWebhookSubscriptionRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookSubscriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *SendMessageState) GetSimulateFailure() bool {
+func (*DeleteWebhookSubscriptionRequest) ProtoMessage() {}
+
+func (x *DeleteWebhookSubscriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[74]
 	if x != nil {
-		return x.SimulateFailure
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *SendMessageState) GetState() MessageState {
+// Deprecated: Use DeleteWebhookSubscriptionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookSubscriptionRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *DeleteWebhookSubscriptionRequest) GetSubscriptionId() string {
 	if x != nil {
-		return x.State
+		return x.SubscriptionId
 	}
-	return MessageState_SENDING
+	return ""
 }
 
-type SendMessageRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	MessageId       string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
-	SimulateFailure bool                   `protobuf:"varint,2,opt,name=simulate_failure,json=simulateFailure,proto3" json:"simulate_failure,omitempty"`
+type DeleteWebhookSubscriptionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteWebhookSubscriptionResponse) Reset() {
+	*x = DeleteWebhookSubscriptionResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteWebhookSubscriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteWebhookSubscriptionResponse) ProtoMessage() {}
+
+func (x *DeleteWebhookSubscriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteWebhookSubscriptionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookSubscriptionResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{75}
+}
+
+type WebhookDelivery struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryId     string                 `protobuf:"bytes,1,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	SubscriptionId string                 `protobuf:"bytes,2,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	// the event that was delivered
+	EventId     string               `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	OperationId string               `protobuf:"bytes,4,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	State       WebhookDeliveryState `protobuf:"varint,5,opt,name=state,proto3,enum=playground.v1.WebhookDeliveryState" json:"state,omitempty"`
+	Attempts    int32                `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// the HTTP status of the most recent attempt, if it got a response
+	LastStatusCode  int32                  `protobuf:"varint,7,opt,name=last_status_code,json=lastStatusCode,proto3" json:"last_status_code,omitempty"`
+	LastError       string                 `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	NextAttemptTime *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=next_attempt_time,json=nextAttemptTime,proto3" json:"next_attempt_time,omitempty"`
+	CreateTime      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
-func (x *SendMessageRequest) Reset() {
-	*x = SendMessageRequest{}
-	mi := &file_playground_v1_message_proto_msgTypes[10]
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_playground_v1_message_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendMessageRequest) String() string {
+func (x *WebhookDelivery) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendMessageRequest) ProtoMessage() {}
+func (*WebhookDelivery) ProtoMessage() {}
 
-func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_playground_v1_message_proto_msgTypes[10]
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -554,48 +4555,110 @@ func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
-func (*SendMessageRequest) Descriptor() ([]byte, []int) {
-	return file_playground_v1_message_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *SendMessageRequest) GetMessageId() string {
+func (x *WebhookDelivery) GetDeliveryId() string {
 	if x != nil {
-		return x.MessageId
+		return x.DeliveryId
 	}
 	return ""
 }
 
-func (x *SendMessageRequest) GetSimulateFailure() bool {
+func (x *WebhookDelivery) GetSubscriptionId() string {
 	if x != nil {
-		return x.SimulateFailure
+		return x.SubscriptionId
 	}
-	return false
+	return ""
 }
 
-type SendMessageResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
-	OperationId   string                 `protobuf:"bytes,2,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *WebhookDelivery) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
 }
 
-func (x *SendMessageResponse) Reset() {
-	*x = SendMessageResponse{}
-	mi := &file_playground_v1_message_proto_msgTypes[11]
+func (x *WebhookDelivery) GetOperationId() string {
+	if x != nil {
+		return x.OperationId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetState() WebhookDeliveryState {
+	if x != nil {
+		return x.State
+	}
+	return WebhookDeliveryState_PENDING
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastStatusCode() int32 {
+	if x != nil {
+		return x.LastStatusCode
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetNextAttemptTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptTime
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+type ListWebhookDeliveriesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId string                 `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SendMessageResponse) String() string {
+func (x *ListWebhookDeliveriesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SendMessageResponse) ProtoMessage() {}
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
 
-func (x *SendMessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_playground_v1_message_proto_msgTypes[11]
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -606,48 +4669,40 @@ func (x *SendMessageResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SendMessageResponse.ProtoReflect.Descriptor instead.
-func (*SendMessageResponse) Descriptor() ([]byte, []int) {
-	return file_playground_v1_message_proto_rawDescGZIP(), []int{11}
-}
-
-func (x *SendMessageResponse) GetMessageId() string {
-	if x != nil {
-		return x.MessageId
-	}
-	return ""
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *SendMessageResponse) GetOperationId() string {
+func (x *ListWebhookDeliveriesRequest) GetSubscriptionId() string {
 	if x != nil {
-		return x.OperationId
+		return x.SubscriptionId
 	}
 	return ""
 }
 
-type MessageStatusRequest struct {
+type ListWebhookDeliveriesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	MessageId     string                 `protobuf:"bytes,1,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
-	OperationId   string                 `protobuf:"bytes,2,opt,name=operation_id,json=operationId,proto3" json:"operation_id,omitempty"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MessageStatusRequest) Reset() {
-	*x = MessageStatusRequest{}
-	mi := &file_playground_v1_message_proto_msgTypes[12]
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MessageStatusRequest) String() string {
+func (x *ListWebhookDeliveriesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MessageStatusRequest) ProtoMessage() {}
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
 
-func (x *MessageStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_playground_v1_message_proto_msgTypes[12]
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -658,47 +4713,87 @@ func (x *MessageStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MessageStatusRequest.ProtoReflect.Descriptor instead.
-func (*MessageStatusRequest) Descriptor() ([]byte, []int) {
-	return file_playground_v1_message_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *MessageStatusRequest) GetMessageId() string {
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
 	if x != nil {
-		return x.MessageId
+		return x.Deliveries
 	}
-	return ""
+	return nil
 }
 
-func (x *MessageStatusRequest) GetOperationId() string {
+type TestWebhookRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SubscriptionId string                 `protobuf:"bytes,1,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TestWebhookRequest) Reset() {
+	*x = TestWebhookRequest{}
+	mi := &file_playground_v1_message_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TestWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TestWebhookRequest) ProtoMessage() {}
+
+func (x *TestWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[79]
 	if x != nil {
-		return x.OperationId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TestWebhookRequest.ProtoReflect.Descriptor instead.
+func (*TestWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *TestWebhookRequest) GetSubscriptionId() string {
+	if x != nil {
+		return x.SubscriptionId
 	}
 	return ""
 }
 
-type MessageStatusResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	State         string                 `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+type TestWebhookResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// the HTTP status returned by the receiver, if it responded
+	StatusCode    int32                `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	Error         string               `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Latency       *durationpb.Duration `protobuf:"bytes,3,opt,name=latency,proto3" json:"latency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MessageStatusResponse) Reset() {
-	*x = MessageStatusResponse{}
-	mi := &file_playground_v1_message_proto_msgTypes[13]
+func (x *TestWebhookResponse) Reset() {
+	*x = TestWebhookResponse{}
+	mi := &file_playground_v1_message_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MessageStatusResponse) String() string {
+func (x *TestWebhookResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MessageStatusResponse) ProtoMessage() {}
+func (*TestWebhookResponse) ProtoMessage() {}
 
-func (x *MessageStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_playground_v1_message_proto_msgTypes[13]
+func (x *TestWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_playground_v1_message_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -709,57 +4804,151 @@ func (x *MessageStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MessageStatusResponse.ProtoReflect.Descriptor instead.
-func (*MessageStatusResponse) Descriptor() ([]byte, []int) {
-	return file_playground_v1_message_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use TestWebhookResponse.ProtoReflect.Descriptor instead.
+func (*TestWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_playground_v1_message_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *MessageStatusResponse) GetState() string {
+func (x *TestWebhookResponse) GetStatusCode() int32 {
 	if x != nil {
-		return x.State
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *TestWebhookResponse) GetError() string {
+	if x != nil {
+		return x.Error
 	}
 	return ""
 }
 
+func (x *TestWebhookResponse) GetLatency() *durationpb.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
 var File_playground_v1_message_proto protoreflect.FileDescriptor
 
 const file_playground_v1_message_proto_rawDesc = "" +
 	"\n" +
-	"\x1bplayground/v1/message.proto\x12\rplayground.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1bbuf/validate/validate.proto\x1a\x14state/v1/state.proto\"<\n" +
+	"\x1bplayground/v1/message.proto\x12\rplayground.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x19google/api/httpbody.proto\x1a\x1egoogle/protobuf/duration.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bbuf/validate/validate.proto\x1a\x14state/v1/state.proto\"\xc3\x03\n" +
 	"\aMessage\x12\x1d\n" +
 	"\n" +
 	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x12\n" +
-	"\x04text\x18\x02 \x01(\tR\x04text\"6\n" +
-	"\x14CreateMessageRequest\x12\x1e\n" +
-	"\x04text\x18\x01 \x01(\tB\n" +
-	"\xbaH\a\xc8\x01\x01r\x02\x18@R\x04text\"6\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12;\n" +
+	"\vcreate_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\x12:\n" +
+	"\x06labels\x18\x05 \x03(\v2\".playground.v1.Message.LabelsEntryR\x06labels\x12=\n" +
+	"\fcontent_type\x18\x06 \x01(\x0e2\x1a.playground.v1.ContentTypeR\vcontentType\x12\x18\n" +
+	"\apayload\x18\a \x01(\fR\apayload\x12;\n" +
+	"\vattachments\x18\b \x03(\v2\x19.playground.v1.AttachmentR\vattachments\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe7\x02\n" +
+	"\x14CreateMessageRequest\x12 \n" +
+	"\x04text\x18\x01 \x01(\tB\f\xbaH\t\xc8\x01\x01r\x04(\x80\x80@R\x04text\x12\x83\x01\n" +
+	"\x06labels\x18\x02 \x03(\v2/.playground.v1.CreateMessageRequest.LabelsEntryB:\xbaH7\x9a\x014\x10@\")r'\x10\x01\x18?2!^[a-z0-9]([a-z0-9._-]*[a-z0-9])?$*\x05r\x03\x18\x80\x02R\x06labels\x12G\n" +
+	"\fcontent_type\x18\x03 \x01(\x0e2\x1a.playground.v1.ContentTypeB\b\xbaH\x05\x82\x01\x02\x10\x01R\vcontentType\x12#\n" +
+	"\apayload\x18\x04 \x01(\fB\t\xbaH\x06z\x04\x18\x80\x80@R\apayload\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"6\n" +
 	"\x15CreateMessageResponse\x12\x1d\n" +
 	"\n" +
-	"message_id\x18\x01 \x01(\tR\tmessageId\"?\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\"\xcd\x03\n" +
+	"\x14UpdateMessageRequest\x12*\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\tmessageId\x12\x1d\n" +
+	"\x04text\x18\x02 \x01(\tB\t\xbaH\x06r\x04(\x80\x80@R\x04text\x12\x83\x01\n" +
+	"\x06labels\x18\x03 \x03(\v2/.playground.v1.UpdateMessageRequest.LabelsEntryB:\xbaH7\x9a\x014\x10@\")r'\x10\x01\x18?2!^[a-z0-9]([a-z0-9._-]*[a-z0-9])?$*\x05r\x03\x18\x80\x02R\x06labels\x12G\n" +
+	"\fcontent_type\x18\x04 \x01(\x0e2\x1a.playground.v1.ContentTypeB\b\xbaH\x05\x82\x01\x02\x10\x01R\vcontentType\x12#\n" +
+	"\apayload\x18\x05 \x01(\fB\t\xbaH\x06z\x04\x18\x80\x80@R\apayload\x12;\n" +
+	"\vupdate_mask\x18\x06 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"I\n" +
+	"\x15UpdateMessageResponse\x120\n" +
+	"\amessage\x18\x01 \x01(\v2\x16.playground.v1.MessageR\amessage\"?\n" +
 	"\x11GetMessageRequest\x12*\n" +
 	"\n" +
 	"message_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\tmessageId\"F\n" +
 	"\x12GetMessageResponse\x120\n" +
-	"\amessage\x18\x01 \x01(\v2\x16.playground.v1.MessageR\amessage\"\x15\n" +
-	"\x13ListMessagesRequest\"J\n" +
+	"\amessage\x18\x01 \x01(\v2\x16.playground.v1.MessageR\amessage\"E\n" +
+	"\x13ListMessagesRequest\x12.\n" +
+	"\x06labels\x18\x01 \x03(\tB\x16\xbaH\x13\x92\x01\x10\"\x0er\f2\n" +
+	"^[^=]+=.*$R\x06labels\"J\n" +
 	"\x14ListMessagesResponse\x122\n" +
-	"\bmessages\x18\x01 \x03(\v2\x16.playground.v1.MessageR\bmessages\"B\n" +
+	"\bmessages\x18\x01 \x03(\v2\x16.playground.v1.MessageR\bmessages\"y\n" +
+	"\x15SearchMessagesRequest\x12\x19\n" +
+	"\x01q\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\x18\x80\x02R\x01q\x12&\n" +
+	"\tpage_size\x18\x02 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x00R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"w\n" +
+	"\x16SearchMessagesResponse\x125\n" +
+	"\aresults\x18\x01 \x03(\v2\x1b.playground.v1.SearchResultR\aresults\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"n\n" +
+	"\fSearchResult\x120\n" +
+	"\amessage\x18\x01 \x01(\v2\x16.playground.v1.MessageR\amessage\x12\x18\n" +
+	"\asnippet\x18\x02 \x01(\tR\asnippet\x12\x12\n" +
+	"\x04rank\x18\x03 \x01(\x01R\x04rank\"B\n" +
 	"\x14DeleteMessageRequest\x12*\n" +
 	"\n" +
 	"message_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\tmessageId\"\x17\n" +
-	"\x15DeleteMessageResponse\"\xb4\x01\n" +
+	"\x15DeleteMessageResponse\"\xe5\x01\n" +
+	"\tFaultSpec\x12,\n" +
+	"\rfail_attempts\x18\x01 \x01(\rB\a\xbaH\x04*\x02\x18dR\ffailAttempts\x12A\n" +
+	"\alatency\x18\x02 \x01(\v2\x19.google.protobuf.DurationB\f\xbaH\t\xaa\x01\x06\"\x02\b\x052\x00R\alatency\x12#\n" +
+	"\rnon_retryable\x18\x03 \x01(\bR\fnonRetryable\x12\x14\n" +
+	"\x05panic\x18\x04 \x01(\bR\x05panic\x12,\n" +
+	"\x12crash_after_commit\x18\x05 \x01(\bR\x10crashAfterCommit\"\x8c\x05\n" +
 	"\x10SendMessageState\x12!\n" +
-	"\foperation_id\x18\x01 \x01(\tR\voperationId\x12)\n" +
-	"\x10simulate_failure\x18\x02 \x01(\bR\x0fsimulateFailure\x121\n" +
-	"\x05state\x18\x03 \x01(\x0e2\x1b.playground.v1.MessageStateR\x05state:\x1f\x82\xd28\x1b\n" +
-	"\x19\n" +
+	"\foperation_id\x18\x01 \x01(\tR\voperationId\x121\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x1b.playground.v1.MessageStateR\x05state\x12.\n" +
+	"\x05fault\x18\x04 \x01(\v2\x18.playground.v1.FaultSpecR\x05fault\x12\x1d\n" +
+	"\n" +
+	"receipt_id\x18\x05 \x01(\tR\treceiptId\x12\x1f\n" +
+	"\vtemplate_id\x18\x06 \x01(\tR\n" +
+	"templateId\x12L\n" +
+	"\tvariables\x18\a \x03(\v2..playground.v1.SendMessageState.VariablesEntryR\tvariables\x12!\n" +
+	"\frecipient_id\x18\b \x01(\tR\vrecipientId\x12\x1b\n" +
+	"\ttenant_id\x18\t \x01(\tR\btenantId\x12\x15\n" +
+	"\x06run_id\x18\n" +
+	" \x01(\tR\x05runId\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01:\xb6\x01\x82\xd28\xb1\x01\n" +
+	"\xae\x01\n" +
 	"\x11\b\x05\x10\x01\x19\x00\x00\x00\x00\x00\x00\x00@ \n" +
-	"(<\x12\x04\n" +
-	"\x02do\"k\n" +
+	"(<\x12\x1d\n" +
+	"\bvalidate\x12\x11\b\x03\x10\x01\x19\x00\x00\x00\x00\x00\x00\x00@ \x05(\x1e\x12\t\n" +
+	"\areserve\x12\x1b\n" +
+	"\x06render\x12\x11\b\x03\x10\x01\x19\x00\x00\x00\x00\x00\x00\x00@ \x05(\x1e\x12\t\n" +
+	"\adeliver\x12\x06\n" +
+	"\x04bill\x12\x1d\n" +
+	"\aconfirm\x12\x12\b\n" +
+	"\x10\x02\x19\x00\x00\x00\x00\x00\x00\xf8? \x1e(\xac\x02\x12 \n" +
+	"\n" +
+	"compensate\x12\x12\b\n" +
+	"\x10\x01\x19\x00\x00\x00\x00\x00\x00\x00@ <(\xd8\x04J\x04\b\x02\x10\x03R\x10simulate_failure\"\xf7\x02\n" +
 	"\x12SendMessageRequest\x12*\n" +
 	"\n" +
-	"message_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\tmessageId\x12)\n" +
-	"\x10simulate_failure\x18\x02 \x01(\bR\x0fsimulateFailure\"W\n" +
+	"message_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\tmessageId\x12.\n" +
+	"\x05fault\x18\x03 \x01(\v2\x18.playground.v1.FaultSpecR\x05fault\x12,\n" +
+	"\vtemplate_id\x18\x04 \x01(\tB\v\xbaH\b\xd8\x01\x01r\x03\xb0\x01\x01R\n" +
+	"templateId\x12N\n" +
+	"\tvariables\x18\x05 \x03(\v20.playground.v1.SendMessageRequest.VariablesEntryR\tvariables\x121\n" +
+	"\n" +
+	"recipients\x18\x06 \x03(\tB\x11\xbaH\x0e\x92\x01\v\x10d\x18\x01\"\x05r\x03\xb0\x01\x01R\n" +
+	"recipients\x1a<\n" +
+	"\x0eVariablesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01J\x04\b\x02\x10\x03R\x10simulate_failure\"W\n" +
 	"\x13SendMessageResponse\x12\x1d\n" +
 	"\n" +
 	"message_id\x18\x01 \x01(\tR\tmessageId\x12!\n" +
@@ -767,22 +4956,309 @@ const file_playground_v1_message_proto_rawDesc = "" +
 	"\x14MessageStatusRequest\x12*\n" +
 	"\n" +
 	"message_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\tmessageId\x12)\n" +
-	"\foperation_id\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\voperationId\"-\n" +
+	"\foperation_id\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\voperationId\"^\n" +
+	"\vStepHistory\x12\x12\n" +
+	"\x04step\x18\x01 \x01(\tR\x04step\x12;\n" +
+	"\battempts\x18\x02 \x03(\v2\x1f.playground.v1.OperationAttemptR\battempts\"\xc6\x02\n" +
 	"\x15MessageStatusResponse\x12\x14\n" +
-	"\x05state\x18\x01 \x01(\tR\x05state*6\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\x12!\n" +
+	"\fcurrent_step\x18\x02 \x01(\tR\vcurrentStep\x120\n" +
+	"\x05steps\x18\x03 \x03(\v2\x1a.playground.v1.StepHistoryR\x05steps\x12E\n" +
+	"\rcompensations\x18\x04 \x03(\v2\x1f.playground.v1.OperationAttemptR\rcompensations\x12>\n" +
+	"\n" +
+	"recipients\x18\x05 \x03(\v2\x1e.playground.v1.RecipientStatusR\n" +
+	"recipients\x12;\n" +
+	"\vattachments\x18\x06 \x03(\v2\x19.playground.v1.AttachmentR\vattachments\"\xa5\x01\n" +
+	"\x0fRecipientStatus\x126\n" +
+	"\trecipient\x18\x01 \x01(\v2\x18.playground.v1.RecipientR\trecipient\x12!\n" +
+	"\foperation_id\x18\x02 \x01(\tR\voperationId\x12\x14\n" +
+	"\x05state\x18\x03 \x01(\tR\x05state\x12!\n" +
+	"\fcurrent_step\x18\x04 \x01(\tR\vcurrentStep\"j\n" +
+	"\x11CancelSendRequest\x12*\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\tmessageId\x12)\n" +
+	"\foperation_id\x18\x02 \x01(\tB\x06\xbaH\x03\xc8\x01\x01R\voperationId\"*\n" +
+	"\x12CancelSendResponse\x12\x14\n" +
+	"\x05state\x18\x01 \x01(\tR\x05state\"\xaa\x01\n" +
+	"\x10OperationAttempt\x12\x12\n" +
+	"\x04step\x18\x01 \x01(\tR\x04step\x12\x18\n" +
+	"\aattempt\x18\x02 \x01(\x05R\aattempt\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12;\n" +
+	"\vcreate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12\x15\n" +
+	"\x06run_id\x18\x05 \x01(\tR\x05runId\"\x8a\x03\n" +
+	"\n" +
+	"DeadLetter\x12$\n" +
+	"\x0edead_letter_id\x18\x01 \x01(\tR\fdeadLetterId\x12!\n" +
+	"\foperation_id\x18\x02 \x01(\tR\voperationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x03 \x01(\tR\tmessageId\x12\x12\n" +
+	"\x04step\x18\x04 \x01(\tR\x04step\x125\n" +
+	"\x05input\x18\x05 \x01(\v2\x1f.playground.v1.SendMessageStateR\x05input\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x06 \x01(\tR\tlastError\x12;\n" +
+	"\battempts\x18\a \x03(\v2\x1f.playground.v1.OperationAttemptR\battempts\x120\n" +
+	"\x14redrive_operation_id\x18\b \x01(\tR\x12redriveOperationId\x12;\n" +
+	"\vcreate_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\"D\n" +
+	"\x16ListDeadLettersRequest\x12*\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tB\v\xbaH\b\xd8\x01\x01r\x03\xb0\x01\x01R\tmessageId\"W\n" +
+	"\x17ListDeadLettersResponse\x12<\n" +
+	"\fdead_letters\x18\x01 \x03(\v2\x19.playground.v1.DeadLetterR\vdeadLetters\"I\n" +
+	"\x14GetDeadLetterRequest\x121\n" +
+	"\x0edead_letter_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\fdeadLetterId\"S\n" +
+	"\x15GetDeadLetterResponse\x12:\n" +
+	"\vdead_letter\x18\x01 \x01(\v2\x19.playground.v1.DeadLetterR\n" +
+	"deadLetter\"M\n" +
+	"\x18RedriveDeadLetterRequest\x121\n" +
+	"\x0edead_letter_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\fdeadLetterId\"]\n" +
+	"\x19RedriveDeadLetterResponse\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12!\n" +
+	"\foperation_id\x18\x02 \x01(\tR\voperationId\"P\n" +
+	"\x17PurgeDeadLettersRequest\x125\n" +
+	"\x0fdead_letter_ids\x18\x01 \x03(\tB\r\xbaH\n" +
+	"\x92\x01\a\"\x05r\x03\xb0\x01\x01R\rdeadLetterIds\"2\n" +
+	"\x18PurgeDeadLettersResponse\x12\x16\n" +
+	"\x06purged\x18\x01 \x01(\x03R\x06purged\"q\n" +
+	"\bTemplate\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\tR\n" +
+	"templateId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04body\x18\x03 \x01(\tR\x04body\x12\x1c\n" +
+	"\tvariables\x18\x04 \x03(\tR\tvariables\"\x9e\x01\n" +
+	"\x15CreateTemplateRequest\x12\x1e\n" +
+	"\x04name\x18\x01 \x01(\tB\n" +
+	"\xbaH\a\xc8\x01\x01r\x02\x18@R\x04name\x12\x1f\n" +
+	"\x04body\x18\x02 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\x18\x80 R\x04body\x12D\n" +
+	"\tvariables\x18\x03 \x03(\tB&\xbaH#\x92\x01 \x18\x01\"\x1cr\x1a2\x18^[A-Za-z_][A-Za-z0-9_]*$R\tvariables\"9\n" +
+	"\x16CreateTemplateResponse\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\tR\n" +
+	"templateId\"B\n" +
+	"\x12GetTemplateRequest\x12,\n" +
+	"\vtemplate_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\n" +
+	"templateId\"J\n" +
+	"\x13GetTemplateResponse\x123\n" +
+	"\btemplate\x18\x01 \x01(\v2\x17.playground.v1.TemplateR\btemplate\"\x16\n" +
+	"\x14ListTemplatesRequest\"N\n" +
+	"\x15ListTemplatesResponse\x125\n" +
+	"\ttemplates\x18\x01 \x03(\v2\x17.playground.v1.TemplateR\ttemplates\"\x83\x02\n" +
+	"\x15UpdateTemplateRequest\x12,\n" +
+	"\vtemplate_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\n" +
+	"templateId\x12\x1b\n" +
+	"\x04name\x18\x02 \x01(\tB\a\xbaH\x04r\x02\x18@R\x04name\x12\x1c\n" +
+	"\x04body\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\x80 R\x04body\x12D\n" +
+	"\tvariables\x18\x04 \x03(\tB&\xbaH#\x92\x01 \x18\x01\"\x1cr\x1a2\x18^[A-Za-z_][A-Za-z0-9_]*$R\tvariables\x12;\n" +
+	"\vupdate_mask\x18\x05 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"M\n" +
+	"\x16UpdateTemplateResponse\x123\n" +
+	"\btemplate\x18\x01 \x01(\v2\x17.playground.v1.TemplateR\btemplate\"E\n" +
+	"\x15DeleteTemplateRequest\x12,\n" +
+	"\vtemplate_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\n" +
+	"templateId\"\x18\n" +
+	"\x16DeleteTemplateResponse\"z\n" +
+	"\tRecipient\x12!\n" +
+	"\frecipient_id\x18\x01 \x01(\tR\vrecipientId\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x120\n" +
+	"\achannel\x18\x03 \x01(\x0e2\x16.playground.v1.ChannelR\achannel\"{\n" +
+	"\x16CreateRecipientRequest\x12%\n" +
+	"\aaddress\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\x18\xc0\x02R\aaddress\x12:\n" +
+	"\achannel\x18\x02 \x01(\x0e2\x16.playground.v1.ChannelB\b\xbaH\x05\x82\x01\x02\x10\x01R\achannel\"<\n" +
+	"\x17CreateRecipientResponse\x12!\n" +
+	"\frecipient_id\x18\x01 \x01(\tR\vrecipientId\"E\n" +
+	"\x13GetRecipientRequest\x12.\n" +
+	"\frecipient_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\vrecipientId\"N\n" +
+	"\x14GetRecipientResponse\x126\n" +
+	"\trecipient\x18\x01 \x01(\v2\x18.playground.v1.RecipientR\trecipient\"\x17\n" +
+	"\x15ListRecipientsRequest\"R\n" +
+	"\x16ListRecipientsResponse\x128\n" +
+	"\n" +
+	"recipients\x18\x01 \x03(\v2\x18.playground.v1.RecipientR\n" +
+	"recipients\"H\n" +
+	"\x16DeleteRecipientRequest\x12.\n" +
+	"\frecipient_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\vrecipientId\"\x19\n" +
+	"\x17DeleteRecipientResponse\"\x83\x02\n" +
+	"\n" +
+	"Attachment\x12#\n" +
+	"\rattachment_id\x18\x01 \x01(\tR\fattachmentId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x05 \x01(\x03R\tsizeBytes\x12\x16\n" +
+	"\x06sha256\x18\x06 \x01(\tR\x06sha256\x12;\n" +
+	"\vcreate_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\"\xc7\x01\n" +
+	"\x17UploadAttachmentRequest\x12*\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tB\v\xbaH\b\xd8\x01\x01r\x03\xb0\x01\x01R\tmessageId\x12$\n" +
+	"\bfilename\x18\x02 \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\bfilename\x120\n" +
+	"\x06sha256\x18\x03 \x01(\tB\x18\xbaH\x15\xd8\x01\x01r\x102\x0e^[0-9a-f]{64}$R\x06sha256\x12(\n" +
+	"\x04file\x18\x04 \x01(\v2\x14.google.api.HttpBodyR\x04file\"U\n" +
+	"\x18UploadAttachmentResponse\x129\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x19.playground.v1.AttachmentR\n" +
+	"attachment\"y\n" +
+	"\x19DownloadAttachmentRequest\x12*\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\tmessageId\x120\n" +
+	"\rattachment_id\x18\x02 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\fattachmentId\"F\n" +
+	"\x1aDownloadAttachmentResponse\x12(\n" +
+	"\x04file\x18\x01 \x01(\v2\x14.google.api.HttpBodyR\x04file\"w\n" +
+	"\x17DeleteAttachmentRequest\x12*\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\tmessageId\x120\n" +
+	"\rattachment_id\x18\x02 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\fattachmentId\"\x1a\n" +
+	"\x18DeleteAttachmentResponse\"\xa4\x01\n" +
+	"\x0eOperationEvent\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x01 \x01(\tR\tmessageId\x12!\n" +
+	"\foperation_id\x18\x02 \x01(\tR\voperationId\x121\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x1b.playground.v1.MessageStateR\x05state\x12\x1d\n" +
+	"\n" +
+	"redrive_of\x18\x04 \x01(\tR\tredriveOf\"\xd0\x02\n" +
+	"\x05Event\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x19\n" +
+	"\bevent_id\x18\x02 \x01(\tR\aeventId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x18\n" +
+	"\asubject\x18\x04 \x01(\tR\asubject\x12.\n" +
+	"\x04time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x122\n" +
+	"\amessage\x18\x06 \x01(\v2\x16.playground.v1.MessageH\x00R\amessage\x12=\n" +
+	"\toperation\x18\a \x01(\v2\x1d.playground.v1.OperationEventH\x00R\toperation\x12;\n" +
+	"\n" +
+	"attachment\x18\b \x01(\v2\x19.playground.v1.AttachmentH\x00R\n" +
+	"attachmentB\x06\n" +
+	"\x04data\"\xcf\x02\n" +
+	"\x13StreamEventsRequest\x12*\n" +
+	"\fafter_offset\x18\x01 \x01(\x03B\a\xbaH\x04\"\x02(\x00R\vafterOffset\x12\x8b\x02\n" +
+	"\x05types\x18\x02 \x03(\tB\xf4\x01\xbaH\xf0\x01\x92\x01\xec\x01\x18\x01\"\xe7\x01r\xe4\x01R\x1dplayground.v1.message.createdR\x1dplayground.v1.message.updatedR\x1dplayground.v1.message.deletedR\x1aplayground.v1.message.sentR%playground.v1.operation.state_changedR playground.v1.attachment.createdR playground.v1.attachment.deletedR\x05types\"y\n" +
+	"\x14StreamEventsResponse\x12*\n" +
+	"\x05event\x18\x01 \x01(\v2\x14.playground.v1.EventR\x05event\x125\n" +
+	"\vcloud_event\x18\x02 \x01(\v2\x14.google.api.HttpBodyR\n" +
+	"cloudEvent\"\xcc\x02\n" +
+	"\x13WebhookSubscription\x12'\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tR\x0esubscriptionId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x123\n" +
+	"\x06states\x18\x03 \x03(\x0e2\x1b.playground.v1.MessageStateR\x06states\x12\x16\n" +
+	"\x06secret\x18\x04 \x01(\tR\x06secret\x12;\n" +
+	"\vcreate_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x121\n" +
+	"\x14consecutive_failures\x18\x06 \x01(\x05R\x13consecutiveFailures\x12=\n" +
+	"\fdisable_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vdisableTime\"\xf5\x01\n" +
+	" CreateWebhookSubscriptionRequest\x12 \n" +
+	"\x03url\x18\x01 \x01(\tB\x0e\xbaH\v\xc8\x01\x01r\x06\x18\x80\x10\x88\x01\x01R\x03url\x12F\n" +
+	"\x06states\x18\x02 \x03(\x0e2\x1b.playground.v1.MessageStateB\x11\xbaH\x0e\x92\x01\v\x18\x01\"\a\x82\x01\x04\x10\x01 \x00R\x06states\x12g\n" +
+	"\x06secret\x18\x03 \x01(\tBO\xbaHL\xd8\x01\x01rG2E^whsec_([A-Za-z0-9+/]{4}){8,}([A-Za-z0-9+/]{2}==|[A-Za-z0-9+/]{3}=)?$R\x06secret\"k\n" +
+	"!CreateWebhookSubscriptionResponse\x12F\n" +
+	"\fsubscription\x18\x01 \x01(\v2\".playground.v1.WebhookSubscriptionR\fsubscription\"U\n" +
+	"\x1dGetWebhookSubscriptionRequest\x124\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\x0esubscriptionId\"h\n" +
+	"\x1eGetWebhookSubscriptionResponse\x12F\n" +
+	"\fsubscription\x18\x01 \x01(\v2\".playground.v1.WebhookSubscriptionR\fsubscription\"!\n" +
+	"\x1fListWebhookSubscriptionsRequest\"l\n" +
+	" ListWebhookSubscriptionsResponse\x12H\n" +
+	"\rsubscriptions\x18\x01 \x03(\v2\".playground.v1.WebhookSubscriptionR\rsubscriptions\"\x84\x03\n" +
+	" UpdateWebhookSubscriptionRequest\x124\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\x0esubscriptionId\x12 \n" +
+	"\x03url\x18\x02 \x01(\tB\x0e\xbaH\v\xd8\x01\x01r\x06\x18\x80\x10\x88\x01\x01R\x03url\x12F\n" +
+	"\x06states\x18\x03 \x03(\x0e2\x1b.playground.v1.MessageStateB\x11\xbaH\x0e\x92\x01\v\x18\x01\"\a\x82\x01\x04\x10\x01 \x00R\x06states\x12g\n" +
+	"\x06secret\x18\x05 \x01(\tBO\xbaHL\xd8\x01\x01rG2E^whsec_([A-Za-z0-9+/]{4}){8,}([A-Za-z0-9+/]{2}==|[A-Za-z0-9+/]{3}=)?$R\x06secret\x12\x1a\n" +
+	"\bdisabled\x18\x06 \x01(\bR\bdisabled\x12;\n" +
+	"\vupdate_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"k\n" +
+	"!UpdateWebhookSubscriptionResponse\x12F\n" +
+	"\fsubscription\x18\x01 \x01(\v2\".playground.v1.WebhookSubscriptionR\fsubscription\"X\n" +
+	" DeleteWebhookSubscriptionRequest\x124\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\x0esubscriptionId\"#\n" +
+	"!DeleteWebhookSubscriptionResponse\"\xfb\x03\n" +
+	"\x0fWebhookDelivery\x12\x1f\n" +
+	"\vdelivery_id\x18\x01 \x01(\tR\n" +
+	"deliveryId\x12'\n" +
+	"\x0fsubscription_id\x18\x02 \x01(\tR\x0esubscriptionId\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12!\n" +
+	"\foperation_id\x18\x04 \x01(\tR\voperationId\x129\n" +
+	"\x05state\x18\x05 \x01(\x0e2#.playground.v1.WebhookDeliveryStateR\x05state\x12\x1a\n" +
+	"\battempts\x18\x06 \x01(\x05R\battempts\x12(\n" +
+	"\x10last_status_code\x18\a \x01(\x05R\x0elastStatusCode\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tR\tlastError\x12F\n" +
+	"\x11next_attempt_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x0fnextAttemptTime\x12;\n" +
+	"\vcreate_time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\"T\n" +
+	"\x1cListWebhookDeliveriesRequest\x124\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\x0esubscriptionId\"_\n" +
+	"\x1dListWebhookDeliveriesResponse\x12>\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x1e.playground.v1.WebhookDeliveryR\n" +
+	"deliveries\"J\n" +
+	"\x12TestWebhookRequest\x124\n" +
+	"\x0fsubscription_id\x18\x01 \x01(\tB\v\xbaH\b\xc8\x01\x01r\x03\xb0\x01\x01R\x0esubscriptionId\"\x81\x01\n" +
+	"\x13TestWebhookResponse\x12\x1f\n" +
+	"\vstatus_code\x18\x01 \x01(\x05R\n" +
+	"statusCode\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x123\n" +
+	"\alatency\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\alatency*0\n" +
+	"\vContentType\x12\t\n" +
+	"\x05PLAIN\x10\x00\x12\f\n" +
+	"\bMARKDOWN\x10\x01\x12\b\n" +
+	"\x04JSON\x10\x02*]\n" +
 	"\fMessageState\x12\v\n" +
 	"\aSENDING\x10\x00\x12\n" +
 	"\n" +
 	"\x06FAILED\x10\x01\x12\r\n" +
-	"\tSUCCEEDED\x10\x022\xfa\x05\n" +
-	"\x0eMessageService\x12t\n" +
-	"\n" +
-	"GetMessage\x12 .playground.v1.GetMessageRequest\x1a!.playground.v1.GetMessageResponse\"!\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/messages/{message_id}\x12p\n" +
-	"\rCreateMessage\x12#.playground.v1.CreateMessageRequest\x1a$.playground.v1.CreateMessageResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\"\f/v1/messages\x12}\n" +
-	"\rDeleteMessage\x12#.playground.v1.DeleteMessageRequest\x1a$.playground.v1.DeleteMessageResponse\"!\x82\xd3\xe4\x93\x02\x1b*\x19/v1/messages/{message_id}\x12m\n" +
-	"\fListMessages\x12\".playground.v1.ListMessagesRequest\x1a#.playground.v1.ListMessagesResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/messages\x12|\n" +
-	"\vSendMessage\x12!.playground.v1.SendMessageRequest\x1a\".playground.v1.SendMessageResponse\"&\x82\xd3\xe4\x93\x02 \"\x1e/v1/messages/{message_id}/send\x12\x93\x01\n" +
-	"\rMessageStatus\x12#.playground.v1.MessageStatusRequest\x1a$.playground.v1.MessageStatusResponse\"7\x82\xd3\xe4\x93\x021\x12//v1/messages/{message_id}/status/{operation_id}B\xcb\x01\n" +
+	"\tSUCCEEDED\x10\x02\x12\f\n" +
+	"\bCANCELED\x10\x03\x12\x17\n" +
+	"\x13PARTIALLY_SUCCEEDED\x10\x04*'\n" +
+	"\aChannel\x12\t\n" +
+	"\x05EMAIL\x10\x00\x12\a\n" +
+	"\x03SMS\x10\x01\x12\b\n" +
+	"\x04PUSH\x10\x02*A\n" +
+	"\x14WebhookDeliveryState\x12\v\n" +
+	"\aPENDING\x10\x00\x12\r\n" +
+	"\tDELIVERED\x10\x01\x12\r\n" +
+	"\tABANDONED\x10\x022\xb9$\n" +
+	"\x0eMessageService\x12w\n" +
+	"\n" +
+	"GetMessage\x12 .playground.v1.GetMessageRequest\x1a!.playground.v1.GetMessageResponse\"$\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/messages/{message_id}\x90\x02\x01\x12s\n" +
+	"\rCreateMessage\x12#.playground.v1.CreateMessageRequest\x1a$.playground.v1.CreateMessageResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/messages\x12\x80\x01\n" +
+	"\rUpdateMessage\x12#.playground.v1.UpdateMessageRequest\x1a$.playground.v1.UpdateMessageResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*2\x19/v1/messages/{message_id}\x12}\n" +
+	"\rDeleteMessage\x12#.playground.v1.DeleteMessageRequest\x1a$.playground.v1.DeleteMessageResponse\"!\x82\xd3\xe4\x93\x02\x1b*\x19/v1/messages/{message_id}\x12p\n" +
+	"\fListMessages\x12\".playground.v1.ListMessagesRequest\x1a#.playground.v1.ListMessagesResponse\"\x17\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/messages\x90\x02\x01\x12}\n" +
+	"\x0eSearchMessages\x12$.playground.v1.SearchMessagesRequest\x1a%.playground.v1.SearchMessagesResponse\"\x1e\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/messages:search\x90\x02\x01\x12\x7f\n" +
+	"\vSendMessage\x12!.playground.v1.SendMessageRequest\x1a\".playground.v1.SendMessageResponse\")\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/messages/{message_id}/send\x12\x96\x01\n" +
+	"\rMessageStatus\x12#.playground.v1.MessageStatusRequest\x1a$.playground.v1.MessageStatusResponse\":\x82\xd3\xe4\x93\x021\x12//v1/messages/{message_id}/status/{operation_id}\x90\x02\x01\x12\x94\x01\n" +
+	"\n" +
+	"CancelSend\x12 .playground.v1.CancelSendRequest\x1a!.playground.v1.CancelSendResponse\"A\x82\xd3\xe4\x93\x02;:\x01*\"6/v1/messages/{message_id}/status/{operation_id}:cancel\x12\xa5\x01\n" +
+	"\x10UploadAttachment\x12&.playground.v1.UploadAttachmentRequest\x1a'.playground.v1.UploadAttachmentResponse\">\x82\xd3\xe4\x93\x028:\x04file\"0/v1/messages/{message_id}/attachments/{filename}(\x01\x12\xbc\x01\n" +
+	"\x12DownloadAttachment\x12(.playground.v1.DownloadAttachmentRequest\x1a).playground.v1.DownloadAttachmentResponse\"O\x82\xd3\xe4\x93\x02Fb\x04file\x12>/v1/messages/{message_id}/attachments/{attachment_id}:download\x90\x02\x010\x01\x12\xa2\x01\n" +
+	"\x10DeleteAttachment\x12&.playground.v1.DeleteAttachmentRequest\x1a'.playground.v1.DeleteAttachmentResponse\"=\x82\xd3\xe4\x93\x027*5/v1/messages/{message_id}/attachments/{attachment_id}\x12}\n" +
+	"\fStreamEvents\x12\".playground.v1.StreamEventsRequest\x1a#.playground.v1.StreamEventsResponse\"\"\x82\xd3\xe4\x93\x02\x19b\vcloud_event\x12\n" +
+	"/v1/events\x90\x02\x010\x01\x12w\n" +
+	"\x0eCreateTemplate\x12$.playground.v1.CreateTemplateRequest\x1a%.playground.v1.CreateTemplateResponse\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*\"\r/v1/templates\x12|\n" +
+	"\vGetTemplate\x12!.playground.v1.GetTemplateRequest\x1a\".playground.v1.GetTemplateResponse\"&\x82\xd3\xe4\x93\x02\x1d\x12\x1b/v1/templates/{template_id}\x90\x02\x01\x12t\n" +
+	"\rListTemplates\x12#.playground.v1.ListTemplatesRequest\x1a$.playground.v1.ListTemplatesResponse\"\x18\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/templates\x90\x02\x01\x12\x85\x01\n" +
+	"\x0eUpdateTemplate\x12$.playground.v1.UpdateTemplateRequest\x1a%.playground.v1.UpdateTemplateResponse\"&\x82\xd3\xe4\x93\x02 :\x01*2\x1b/v1/templates/{template_id}\x12\x82\x01\n" +
+	"\x0eDeleteTemplate\x12$.playground.v1.DeleteTemplateRequest\x1a%.playground.v1.DeleteTemplateResponse\"#\x82\xd3\xe4\x93\x02\x1d*\x1b/v1/templates/{template_id}\x12{\n" +
+	"\x0fCreateRecipient\x12%.playground.v1.CreateRecipientRequest\x1a&.playground.v1.CreateRecipientResponse\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*\"\x0e/v1/recipients\x12\x81\x01\n" +
+	"\fGetRecipient\x12\".playground.v1.GetRecipientRequest\x1a#.playground.v1.GetRecipientResponse\"(\x82\xd3\xe4\x93\x02\x1f\x12\x1d/v1/recipients/{recipient_id}\x90\x02\x01\x12x\n" +
+	"\x0eListRecipients\x12$.playground.v1.ListRecipientsRequest\x1a%.playground.v1.ListRecipientsResponse\"\x19\x82\xd3\xe4\x93\x02\x10\x12\x0e/v1/recipients\x90\x02\x01\x12\x87\x01\n" +
+	"\x0fDeleteRecipient\x12%.playground.v1.DeleteRecipientRequest\x1a&.playground.v1.DeleteRecipientResponse\"%\x82\xd3\xe4\x93\x02\x1f*\x1d/v1/recipients/{recipient_id}\x12}\n" +
+	"\x0fListDeadLetters\x12%.playground.v1.ListDeadLettersRequest\x1a&.playground.v1.ListDeadLettersResponse\"\x1b\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/dead-letters\x90\x02\x01\x12\x88\x01\n" +
+	"\rGetDeadLetter\x12#.playground.v1.GetDeadLetterRequest\x1a$.playground.v1.GetDeadLetterResponse\",\x82\xd3\xe4\x93\x02#\x12!/v1/dead-letters/{dead_letter_id}\x90\x02\x01\x12\x9c\x01\n" +
+	"\x11RedriveDeadLetter\x12'.playground.v1.RedriveDeadLetterRequest\x1a(.playground.v1.RedriveDeadLetterResponse\"4\x82\xd3\xe4\x93\x02.:\x01*\")/v1/dead-letters/{dead_letter_id}:redrive\x12\x86\x01\n" +
+	"\x10PurgeDeadLetters\x12&.playground.v1.PurgeDeadLettersRequest\x1a'.playground.v1.PurgeDeadLettersResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/dead-letters:purge\x12\x97\x01\n" +
+	"\x19CreateWebhookSubscription\x12/.playground.v1.CreateWebhookSubscriptionRequest\x1a0.playground.v1.CreateWebhookSubscriptionResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/v1/webhooks\x12\xa0\x01\n" +
+	"\x16GetWebhookSubscription\x12,.playground.v1.GetWebhookSubscriptionRequest\x1a-.playground.v1.GetWebhookSubscriptionResponse\")\x82\xd3\xe4\x93\x02 \x12\x1e/v1/webhooks/{subscription_id}\x90\x02\x01\x12\x94\x01\n" +
+	"\x18ListWebhookSubscriptions\x12..playground.v1.ListWebhookSubscriptionsRequest\x1a/.playground.v1.ListWebhookSubscriptionsResponse\"\x17\x82\xd3\xe4\x93\x02\x0e\x12\f/v1/webhooks\x90\x02\x01\x12\xa9\x01\n" +
+	"\x19UpdateWebhookSubscription\x12/.playground.v1.UpdateWebhookSubscriptionRequest\x1a0.playground.v1.UpdateWebhookSubscriptionResponse\")\x82\xd3\xe4\x93\x02#:\x01*2\x1e/v1/webhooks/{subscription_id}\x12\xa6\x01\n" +
+	"\x19DeleteWebhookSubscription\x12/.playground.v1.DeleteWebhookSubscriptionRequest\x1a0.playground.v1.DeleteWebhookSubscriptionResponse\"&\x82\xd3\xe4\x93\x02 *\x1e/v1/webhooks/{subscription_id}\x12\xa8\x01\n" +
+	"\x15ListWebhookDeliveries\x12+.playground.v1.ListWebhookDeliveriesRequest\x1a,.playground.v1.ListWebhookDeliveriesResponse\"4\x82\xd3\xe4\x93\x02+\x12)/v1/webhooks/{subscription_id}/deliveries\x90\x02\x01\x12\x84\x01\n" +
+	"\vTestWebhook\x12!.playground.v1.TestWebhookRequest\x1a\".playground.v1.TestWebhookResponse\".\x82\xd3\xe4\x93\x02(:\x01*\"#/v1/webhooks/{subscription_id}:testB\xcb\x01\n" +
 	"\x11com.playground.v1B\fMessageProtoP\x01ZSgithub.com/andrewstucki/vanguard-playground/internal/gen/playground/v1;playgroundv1\xa2\x02\x03PXX\xaa\x02\rPlayground.V1\xca\x02\rPlayground\\V1\xe2\x02\x19Playground\\V1\\GPBMetadata\xea\x02\x0ePlayground::V1b\x06proto3"
 
 var (
@@ -797,46 +5273,244 @@ func file_playground_v1_message_proto_rawDescGZIP() []byte {
 	return file_playground_v1_message_proto_rawDescData
 }
 
-var file_playground_v1_message_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_playground_v1_message_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_playground_v1_message_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_playground_v1_message_proto_msgTypes = make([]protoimpl.MessageInfo, 86)
 var file_playground_v1_message_proto_goTypes = []any{
-	(MessageState)(0),             // 0: playground.v1.MessageState
-	(*Message)(nil),               // 1: playground.v1.Message
-	(*CreateMessageRequest)(nil),  // 2: playground.v1.CreateMessageRequest
-	(*CreateMessageResponse)(nil), // 3: playground.v1.CreateMessageResponse
-	(*GetMessageRequest)(nil),     // 4: playground.v1.GetMessageRequest
-	(*GetMessageResponse)(nil),    // 5: playground.v1.GetMessageResponse
-	(*ListMessagesRequest)(nil),   // 6: playground.v1.ListMessagesRequest
-	(*ListMessagesResponse)(nil),  // 7: playground.v1.ListMessagesResponse
-	(*DeleteMessageRequest)(nil),  // 8: playground.v1.DeleteMessageRequest
-	(*DeleteMessageResponse)(nil), // 9: playground.v1.DeleteMessageResponse
-	(*SendMessageState)(nil),      // 10: playground.v1.SendMessageState
-	(*SendMessageRequest)(nil),    // 11: playground.v1.SendMessageRequest
-	(*SendMessageResponse)(nil),   // 12: playground.v1.SendMessageResponse
-	(*MessageStatusRequest)(nil),  // 13: playground.v1.MessageStatusRequest
-	(*MessageStatusResponse)(nil), // 14: playground.v1.MessageStatusResponse
+	(ContentType)(0),                          // 0: playground.v1.ContentType
+	(MessageState)(0),                         // 1: playground.v1.MessageState
+	(Channel)(0),                              // 2: playground.v1.Channel
+	(WebhookDeliveryState)(0),                 // 3: playground.v1.WebhookDeliveryState
+	(*Message)(nil),                           // 4: playground.v1.Message
+	(*CreateMessageRequest)(nil),              // 5: playground.v1.CreateMessageRequest
+	(*CreateMessageResponse)(nil),             // 6: playground.v1.CreateMessageResponse
+	(*UpdateMessageRequest)(nil),              // 7: playground.v1.UpdateMessageRequest
+	(*UpdateMessageResponse)(nil),             // 8: playground.v1.UpdateMessageResponse
+	(*GetMessageRequest)(nil),                 // 9: playground.v1.GetMessageRequest
+	(*GetMessageResponse)(nil),                // 10: playground.v1.GetMessageResponse
+	(*ListMessagesRequest)(nil),               // 11: playground.v1.ListMessagesRequest
+	(*ListMessagesResponse)(nil),              // 12: playground.v1.ListMessagesResponse
+	(*SearchMessagesRequest)(nil),             // 13: playground.v1.SearchMessagesRequest
+	(*SearchMessagesResponse)(nil),            // 14: playground.v1.SearchMessagesResponse
+	(*SearchResult)(nil),                      // 15: playground.v1.SearchResult
+	(*DeleteMessageRequest)(nil),              // 16: playground.v1.DeleteMessageRequest
+	(*DeleteMessageResponse)(nil),             // 17: playground.v1.DeleteMessageResponse
+	(*FaultSpec)(nil),                         // 18: playground.v1.FaultSpec
+	(*SendMessageState)(nil),                  // 19: playground.v1.SendMessageState
+	(*SendMessageRequest)(nil),                // 20: playground.v1.SendMessageRequest
+	(*SendMessageResponse)(nil),               // 21: playground.v1.SendMessageResponse
+	(*MessageStatusRequest)(nil),              // 22: playground.v1.MessageStatusRequest
+	(*StepHistory)(nil),                       // 23: playground.v1.StepHistory
+	(*MessageStatusResponse)(nil),             // 24: playground.v1.MessageStatusResponse
+	(*RecipientStatus)(nil),                   // 25: playground.v1.RecipientStatus
+	(*CancelSendRequest)(nil),                 // 26: playground.v1.CancelSendRequest
+	(*CancelSendResponse)(nil),                // 27: playground.v1.CancelSendResponse
+	(*OperationAttempt)(nil),                  // 28: playground.v1.OperationAttempt
+	(*DeadLetter)(nil),                        // 29: playground.v1.DeadLetter
+	(*ListDeadLettersRequest)(nil),            // 30: playground.v1.ListDeadLettersRequest
+	(*ListDeadLettersResponse)(nil),           // 31: playground.v1.ListDeadLettersResponse
+	(*GetDeadLetterRequest)(nil),              // 32: playground.v1.GetDeadLetterRequest
+	(*GetDeadLetterResponse)(nil),             // 33: playground.v1.GetDeadLetterResponse
+	(*RedriveDeadLetterRequest)(nil),          // 34: playground.v1.RedriveDeadLetterRequest
+	(*RedriveDeadLetterResponse)(nil),         // 35: playground.v1.RedriveDeadLetterResponse
+	(*PurgeDeadLettersRequest)(nil),           // 36: playground.v1.PurgeDeadLettersRequest
+	(*PurgeDeadLettersResponse)(nil),          // 37: playground.v1.PurgeDeadLettersResponse
+	(*Template)(nil),                          // 38: playground.v1.Template
+	(*CreateTemplateRequest)(nil),             // 39: playground.v1.CreateTemplateRequest
+	(*CreateTemplateResponse)(nil),            // 40: playground.v1.CreateTemplateResponse
+	(*GetTemplateRequest)(nil),                // 41: playground.v1.GetTemplateRequest
+	(*GetTemplateResponse)(nil),               // 42: playground.v1.GetTemplateResponse
+	(*ListTemplatesRequest)(nil),              // 43: playground.v1.ListTemplatesRequest
+	(*ListTemplatesResponse)(nil),             // 44: playground.v1.ListTemplatesResponse
+	(*UpdateTemplateRequest)(nil),             // 45: playground.v1.UpdateTemplateRequest
+	(*UpdateTemplateResponse)(nil),            // 46: playground.v1.UpdateTemplateResponse
+	(*DeleteTemplateRequest)(nil),             // 47: playground.v1.DeleteTemplateRequest
+	(*DeleteTemplateResponse)(nil),            // 48: playground.v1.DeleteTemplateResponse
+	(*Recipient)(nil),                         // 49: playground.v1.Recipient
+	(*CreateRecipientRequest)(nil),            // 50: playground.v1.CreateRecipientRequest
+	(*CreateRecipientResponse)(nil),           // 51: playground.v1.CreateRecipientResponse
+	(*GetRecipientRequest)(nil),               // 52: playground.v1.GetRecipientRequest
+	(*GetRecipientResponse)(nil),              // 53: playground.v1.GetRecipientResponse
+	(*ListRecipientsRequest)(nil),             // 54: playground.v1.ListRecipientsRequest
+	(*ListRecipientsResponse)(nil),            // 55: playground.v1.ListRecipientsResponse
+	(*DeleteRecipientRequest)(nil),            // 56: playground.v1.DeleteRecipientRequest
+	(*DeleteRecipientResponse)(nil),           // 57: playground.v1.DeleteRecipientResponse
+	(*Attachment)(nil),                        // 58: playground.v1.Attachment
+	(*UploadAttachmentRequest)(nil),           // 59: playground.v1.UploadAttachmentRequest
+	(*UploadAttachmentResponse)(nil),          // 60: playground.v1.UploadAttachmentResponse
+	(*DownloadAttachmentRequest)(nil),         // 61: playground.v1.DownloadAttachmentRequest
+	(*DownloadAttachmentResponse)(nil),        // 62: playground.v1.DownloadAttachmentResponse
+	(*DeleteAttachmentRequest)(nil),           // 63: playground.v1.DeleteAttachmentRequest
+	(*DeleteAttachmentResponse)(nil),          // 64: playground.v1.DeleteAttachmentResponse
+	(*OperationEvent)(nil),                    // 65: playground.v1.OperationEvent
+	(*Event)(nil),                             // 66: playground.v1.Event
+	(*StreamEventsRequest)(nil),               // 67: playground.v1.StreamEventsRequest
+	(*StreamEventsResponse)(nil),              // 68: playground.v1.StreamEventsResponse
+	(*WebhookSubscription)(nil),               // 69: playground.v1.WebhookSubscription
+	(*CreateWebhookSubscriptionRequest)(nil),  // 70: playground.v1.CreateWebhookSubscriptionRequest
+	(*CreateWebhookSubscriptionResponse)(nil), // 71: playground.v1.CreateWebhookSubscriptionResponse
+	(*GetWebhookSubscriptionRequest)(nil),     // 72: playground.v1.GetWebhookSubscriptionRequest
+	(*GetWebhookSubscriptionResponse)(nil),    // 73: playground.v1.GetWebhookSubscriptionResponse
+	(*ListWebhookSubscriptionsRequest)(nil),   // 74: playground.v1.ListWebhookSubscriptionsRequest
+	(*ListWebhookSubscriptionsResponse)(nil),  // 75: playground.v1.ListWebhookSubscriptionsResponse
+	(*UpdateWebhookSubscriptionRequest)(nil),  // 76: playground.v1.UpdateWebhookSubscriptionRequest
+	(*UpdateWebhookSubscriptionResponse)(nil), // 77: playground.v1.UpdateWebhookSubscriptionResponse
+	(*DeleteWebhookSubscriptionRequest)(nil),  // 78: playground.v1.DeleteWebhookSubscriptionRequest
+	(*DeleteWebhookSubscriptionResponse)(nil), // 79: playground.v1.DeleteWebhookSubscriptionResponse
+	(*WebhookDelivery)(nil),                   // 80: playground.v1.WebhookDelivery
+	(*ListWebhookDeliveriesRequest)(nil),      // 81: playground.v1.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil),     // 82: playground.v1.ListWebhookDeliveriesResponse
+	(*TestWebhookRequest)(nil),                // 83: playground.v1.TestWebhookRequest
+	(*TestWebhookResponse)(nil),               // 84: playground.v1.TestWebhookResponse
+	nil,                                       // 85: playground.v1.Message.LabelsEntry
+	nil,                                       // 86: playground.v1.CreateMessageRequest.LabelsEntry
+	nil,                                       // 87: playground.v1.UpdateMessageRequest.LabelsEntry
+	nil,                                       // 88: playground.v1.SendMessageState.VariablesEntry
+	nil,                                       // 89: playground.v1.SendMessageRequest.VariablesEntry
+	(*timestamppb.Timestamp)(nil),             // 90: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),             // 91: google.protobuf.FieldMask
+	(*durationpb.Duration)(nil),               // 92: google.protobuf.Duration
+	(*httpbody.HttpBody)(nil),                 // 93: google.api.HttpBody
 }
 var file_playground_v1_message_proto_depIdxs = []int32{
-	1,  // 0: playground.v1.GetMessageResponse.message:type_name -> playground.v1.Message
-	1,  // 1: playground.v1.ListMessagesResponse.messages:type_name -> playground.v1.Message
-	0,  // 2: playground.v1.SendMessageState.state:type_name -> playground.v1.MessageState
-	4,  // 3: playground.v1.MessageService.GetMessage:input_type -> playground.v1.GetMessageRequest
-	2,  // 4: playground.v1.MessageService.CreateMessage:input_type -> playground.v1.CreateMessageRequest
-	8,  // 5: playground.v1.MessageService.DeleteMessage:input_type -> playground.v1.DeleteMessageRequest
-	6,  // 6: playground.v1.MessageService.ListMessages:input_type -> playground.v1.ListMessagesRequest
-	11, // 7: playground.v1.MessageService.SendMessage:input_type -> playground.v1.SendMessageRequest
-	13, // 8: playground.v1.MessageService.MessageStatus:input_type -> playground.v1.MessageStatusRequest
-	5,  // 9: playground.v1.MessageService.GetMessage:output_type -> playground.v1.GetMessageResponse
-	3,  // 10: playground.v1.MessageService.CreateMessage:output_type -> playground.v1.CreateMessageResponse
-	9,  // 11: playground.v1.MessageService.DeleteMessage:output_type -> playground.v1.DeleteMessageResponse
-	7,  // 12: playground.v1.MessageService.ListMessages:output_type -> playground.v1.ListMessagesResponse
-	12, // 13: playground.v1.MessageService.SendMessage:output_type -> playground.v1.SendMessageResponse
-	14, // 14: playground.v1.MessageService.MessageStatus:output_type -> playground.v1.MessageStatusResponse
-	9,  // [9:15] is the sub-list for method output_type
-	3,  // [3:9] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	90,  // 0: playground.v1.Message.create_time:type_name -> google.protobuf.Timestamp
+	90,  // 1: playground.v1.Message.update_time:type_name -> google.protobuf.Timestamp
+	85,  // 2: playground.v1.Message.labels:type_name -> playground.v1.Message.LabelsEntry
+	0,   // 3: playground.v1.Message.content_type:type_name -> playground.v1.ContentType
+	58,  // 4: playground.v1.Message.attachments:type_name -> playground.v1.Attachment
+	86,  // 5: playground.v1.CreateMessageRequest.labels:type_name -> playground.v1.CreateMessageRequest.LabelsEntry
+	0,   // 6: playground.v1.CreateMessageRequest.content_type:type_name -> playground.v1.ContentType
+	87,  // 7: playground.v1.UpdateMessageRequest.labels:type_name -> playground.v1.UpdateMessageRequest.LabelsEntry
+	0,   // 8: playground.v1.UpdateMessageRequest.content_type:type_name -> playground.v1.ContentType
+	91,  // 9: playground.v1.UpdateMessageRequest.update_mask:type_name -> google.protobuf.FieldMask
+	4,   // 10: playground.v1.UpdateMessageResponse.message:type_name -> playground.v1.Message
+	4,   // 11: playground.v1.GetMessageResponse.message:type_name -> playground.v1.Message
+	4,   // 12: playground.v1.ListMessagesResponse.messages:type_name -> playground.v1.Message
+	15,  // 13: playground.v1.SearchMessagesResponse.results:type_name -> playground.v1.SearchResult
+	4,   // 14: playground.v1.SearchResult.message:type_name -> playground.v1.Message
+	92,  // 15: playground.v1.FaultSpec.latency:type_name -> google.protobuf.Duration
+	1,   // 16: playground.v1.SendMessageState.state:type_name -> playground.v1.MessageState
+	18,  // 17: playground.v1.SendMessageState.fault:type_name -> playground.v1.FaultSpec
+	88,  // 18: playground.v1.SendMessageState.variables:type_name -> playground.v1.SendMessageState.VariablesEntry
+	18,  // 19: playground.v1.SendMessageRequest.fault:type_name -> playground.v1.FaultSpec
+	89,  // 20: playground.v1.SendMessageRequest.variables:type_name -> playground.v1.SendMessageRequest.VariablesEntry
+	28,  // 21: playground.v1.StepHistory.attempts:type_name -> playground.v1.OperationAttempt
+	23,  // 22: playground.v1.MessageStatusResponse.steps:type_name -> playground.v1.StepHistory
+	28,  // 23: playground.v1.MessageStatusResponse.compensations:type_name -> playground.v1.OperationAttempt
+	25,  // 24: playground.v1.MessageStatusResponse.recipients:type_name -> playground.v1.RecipientStatus
+	58,  // 25: playground.v1.MessageStatusResponse.attachments:type_name -> playground.v1.Attachment
+	49,  // 26: playground.v1.RecipientStatus.recipient:type_name -> playground.v1.Recipient
+	90,  // 27: playground.v1.OperationAttempt.create_time:type_name -> google.protobuf.Timestamp
+	19,  // 28: playground.v1.DeadLetter.input:type_name -> playground.v1.SendMessageState
+	28,  // 29: playground.v1.DeadLetter.attempts:type_name -> playground.v1.OperationAttempt
+	90,  // 30: playground.v1.DeadLetter.create_time:type_name -> google.protobuf.Timestamp
+	29,  // 31: playground.v1.ListDeadLettersResponse.dead_letters:type_name -> playground.v1.DeadLetter
+	29,  // 32: playground.v1.GetDeadLetterResponse.dead_letter:type_name -> playground.v1.DeadLetter
+	38,  // 33: playground.v1.GetTemplateResponse.template:type_name -> playground.v1.Template
+	38,  // 34: playground.v1.ListTemplatesResponse.templates:type_name -> playground.v1.Template
+	91,  // 35: playground.v1.UpdateTemplateRequest.update_mask:type_name -> google.protobuf.FieldMask
+	38,  // 36: playground.v1.UpdateTemplateResponse.template:type_name -> playground.v1.Template
+	2,   // 37: playground.v1.Recipient.channel:type_name -> playground.v1.Channel
+	2,   // 38: playground.v1.CreateRecipientRequest.channel:type_name -> playground.v1.Channel
+	49,  // 39: playground.v1.GetRecipientResponse.recipient:type_name -> playground.v1.Recipient
+	49,  // 40: playground.v1.ListRecipientsResponse.recipients:type_name -> playground.v1.Recipient
+	90,  // 41: playground.v1.Attachment.create_time:type_name -> google.protobuf.Timestamp
+	93,  // 42: playground.v1.UploadAttachmentRequest.file:type_name -> google.api.HttpBody
+	58,  // 43: playground.v1.UploadAttachmentResponse.attachment:type_name -> playground.v1.Attachment
+	93,  // 44: playground.v1.DownloadAttachmentResponse.file:type_name -> google.api.HttpBody
+	1,   // 45: playground.v1.OperationEvent.state:type_name -> playground.v1.MessageState
+	90,  // 46: playground.v1.Event.time:type_name -> google.protobuf.Timestamp
+	4,   // 47: playground.v1.Event.message:type_name -> playground.v1.Message
+	65,  // 48: playground.v1.Event.operation:type_name -> playground.v1.OperationEvent
+	58,  // 49: playground.v1.Event.attachment:type_name -> playground.v1.Attachment
+	66,  // 50: playground.v1.StreamEventsResponse.event:type_name -> playground.v1.Event
+	93,  // 51: playground.v1.StreamEventsResponse.cloud_event:type_name -> google.api.HttpBody
+	1,   // 52: playground.v1.WebhookSubscription.states:type_name -> playground.v1.MessageState
+	90,  // 53: playground.v1.WebhookSubscription.create_time:type_name -> google.protobuf.Timestamp
+	90,  // 54: playground.v1.WebhookSubscription.disable_time:type_name -> google.protobuf.Timestamp
+	1,   // 55: playground.v1.CreateWebhookSubscriptionRequest.states:type_name -> playground.v1.MessageState
+	69,  // 56: playground.v1.CreateWebhookSubscriptionResponse.subscription:type_name -> playground.v1.WebhookSubscription
+	69,  // 57: playground.v1.GetWebhookSubscriptionResponse.subscription:type_name -> playground.v1.WebhookSubscription
+	69,  // 58: playground.v1.ListWebhookSubscriptionsResponse.subscriptions:type_name -> playground.v1.WebhookSubscription
+	1,   // 59: playground.v1.UpdateWebhookSubscriptionRequest.states:type_name -> playground.v1.MessageState
+	91,  // 60: playground.v1.UpdateWebhookSubscriptionRequest.update_mask:type_name -> google.protobuf.FieldMask
+	69,  // 61: playground.v1.UpdateWebhookSubscriptionResponse.subscription:type_name -> playground.v1.WebhookSubscription
+	3,   // 62: playground.v1.WebhookDelivery.state:type_name -> playground.v1.WebhookDeliveryState
+	90,  // 63: playground.v1.WebhookDelivery.next_attempt_time:type_name -> google.protobuf.Timestamp
+	90,  // 64: playground.v1.WebhookDelivery.create_time:type_name -> google.protobuf.Timestamp
+	90,  // 65: playground.v1.WebhookDelivery.update_time:type_name -> google.protobuf.Timestamp
+	80,  // 66: playground.v1.ListWebhookDeliveriesResponse.deliveries:type_name -> playground.v1.WebhookDelivery
+	92,  // 67: playground.v1.TestWebhookResponse.latency:type_name -> google.protobuf.Duration
+	9,   // 68: playground.v1.MessageService.GetMessage:input_type -> playground.v1.GetMessageRequest
+	5,   // 69: playground.v1.MessageService.CreateMessage:input_type -> playground.v1.CreateMessageRequest
+	7,   // 70: playground.v1.MessageService.UpdateMessage:input_type -> playground.v1.UpdateMessageRequest
+	16,  // 71: playground.v1.MessageService.DeleteMessage:input_type -> playground.v1.DeleteMessageRequest
+	11,  // 72: playground.v1.MessageService.ListMessages:input_type -> playground.v1.ListMessagesRequest
+	13,  // 73: playground.v1.MessageService.SearchMessages:input_type -> playground.v1.SearchMessagesRequest
+	20,  // 74: playground.v1.MessageService.SendMessage:input_type -> playground.v1.SendMessageRequest
+	22,  // 75: playground.v1.MessageService.MessageStatus:input_type -> playground.v1.MessageStatusRequest
+	26,  // 76: playground.v1.MessageService.CancelSend:input_type -> playground.v1.CancelSendRequest
+	59,  // 77: playground.v1.MessageService.UploadAttachment:input_type -> playground.v1.UploadAttachmentRequest
+	61,  // 78: playground.v1.MessageService.DownloadAttachment:input_type -> playground.v1.DownloadAttachmentRequest
+	63,  // 79: playground.v1.MessageService.DeleteAttachment:input_type -> playground.v1.DeleteAttachmentRequest
+	67,  // 80: playground.v1.MessageService.StreamEvents:input_type -> playground.v1.StreamEventsRequest
+	39,  // 81: playground.v1.MessageService.CreateTemplate:input_type -> playground.v1.CreateTemplateRequest
+	41,  // 82: playground.v1.MessageService.GetTemplate:input_type -> playground.v1.GetTemplateRequest
+	43,  // 83: playground.v1.MessageService.ListTemplates:input_type -> playground.v1.ListTemplatesRequest
+	45,  // 84: playground.v1.MessageService.UpdateTemplate:input_type -> playground.v1.UpdateTemplateRequest
+	47,  // 85: playground.v1.MessageService.DeleteTemplate:input_type -> playground.v1.DeleteTemplateRequest
+	50,  // 86: playground.v1.MessageService.CreateRecipient:input_type -> playground.v1.CreateRecipientRequest
+	52,  // 87: playground.v1.MessageService.GetRecipient:input_type -> playground.v1.GetRecipientRequest
+	54,  // 88: playground.v1.MessageService.ListRecipients:input_type -> playground.v1.ListRecipientsRequest
+	56,  // 89: playground.v1.MessageService.DeleteRecipient:input_type -> playground.v1.DeleteRecipientRequest
+	30,  // 90: playground.v1.MessageService.ListDeadLetters:input_type -> playground.v1.ListDeadLettersRequest
+	32,  // 91: playground.v1.MessageService.GetDeadLetter:input_type -> playground.v1.GetDeadLetterRequest
+	34,  // 92: playground.v1.MessageService.RedriveDeadLetter:input_type -> playground.v1.RedriveDeadLetterRequest
+	36,  // 93: playground.v1.MessageService.PurgeDeadLetters:input_type -> playground.v1.PurgeDeadLettersRequest
+	70,  // 94: playground.v1.MessageService.CreateWebhookSubscription:input_type -> playground.v1.CreateWebhookSubscriptionRequest
+	72,  // 95: playground.v1.MessageService.GetWebhookSubscription:input_type -> playground.v1.GetWebhookSubscriptionRequest
+	74,  // 96: playground.v1.MessageService.ListWebhookSubscriptions:input_type -> playground.v1.ListWebhookSubscriptionsRequest
+	76,  // 97: playground.v1.MessageService.UpdateWebhookSubscription:input_type -> playground.v1.UpdateWebhookSubscriptionRequest
+	78,  // 98: playground.v1.MessageService.DeleteWebhookSubscription:input_type -> playground.v1.DeleteWebhookSubscriptionRequest
+	81,  // 99: playground.v1.MessageService.ListWebhookDeliveries:input_type -> playground.v1.ListWebhookDeliveriesRequest
+	83,  // 100: playground.v1.MessageService.TestWebhook:input_type -> playground.v1.TestWebhookRequest
+	10,  // 101: playground.v1.MessageService.GetMessage:output_type -> playground.v1.GetMessageResponse
+	6,   // 102: playground.v1.MessageService.CreateMessage:output_type -> playground.v1.CreateMessageResponse
+	8,   // 103: playground.v1.MessageService.UpdateMessage:output_type -> playground.v1.UpdateMessageResponse
+	17,  // 104: playground.v1.MessageService.DeleteMessage:output_type -> playground.v1.DeleteMessageResponse
+	12,  // 105: playground.v1.MessageService.ListMessages:output_type -> playground.v1.ListMessagesResponse
+	14,  // 106: playground.v1.MessageService.SearchMessages:output_type -> playground.v1.SearchMessagesResponse
+	21,  // 107: playground.v1.MessageService.SendMessage:output_type -> playground.v1.SendMessageResponse
+	24,  // 108: playground.v1.MessageService.MessageStatus:output_type -> playground.v1.MessageStatusResponse
+	27,  // 109: playground.v1.MessageService.CancelSend:output_type -> playground.v1.CancelSendResponse
+	60,  // 110: playground.v1.MessageService.UploadAttachment:output_type -> playground.v1.UploadAttachmentResponse
+	62,  // 111: playground.v1.MessageService.DownloadAttachment:output_type -> playground.v1.DownloadAttachmentResponse
+	64,  // 112: playground.v1.MessageService.DeleteAttachment:output_type -> playground.v1.DeleteAttachmentResponse
+	68,  // 113: playground.v1.MessageService.StreamEvents:output_type -> playground.v1.StreamEventsResponse
+	40,  // 114: playground.v1.MessageService.CreateTemplate:output_type -> playground.v1.CreateTemplateResponse
+	42,  // 115: playground.v1.MessageService.GetTemplate:output_type -> playground.v1.GetTemplateResponse
+	44,  // 116: playground.v1.MessageService.ListTemplates:output_type -> playground.v1.ListTemplatesResponse
+	46,  // 117: playground.v1.MessageService.UpdateTemplate:output_type -> playground.v1.UpdateTemplateResponse
+	48,  // 118: playground.v1.MessageService.DeleteTemplate:output_type -> playground.v1.DeleteTemplateResponse
+	51,  // 119: playground.v1.MessageService.CreateRecipient:output_type -> playground.v1.CreateRecipientResponse
+	53,  // 120: playground.v1.MessageService.GetRecipient:output_type -> playground.v1.GetRecipientResponse
+	55,  // 121: playground.v1.MessageService.ListRecipients:output_type -> playground.v1.ListRecipientsResponse
+	57,  // 122: playground.v1.MessageService.DeleteRecipient:output_type -> playground.v1.DeleteRecipientResponse
+	31,  // 123: playground.v1.MessageService.ListDeadLetters:output_type -> playground.v1.ListDeadLettersResponse
+	33,  // 124: playground.v1.MessageService.GetDeadLetter:output_type -> playground.v1.GetDeadLetterResponse
+	35,  // 125: playground.v1.MessageService.RedriveDeadLetter:output_type -> playground.v1.RedriveDeadLetterResponse
+	37,  // 126: playground.v1.MessageService.PurgeDeadLetters:output_type -> playground.v1.PurgeDeadLettersResponse
+	71,  // 127: playground.v1.MessageService.CreateWebhookSubscription:output_type -> playground.v1.CreateWebhookSubscriptionResponse
+	73,  // 128: playground.v1.MessageService.GetWebhookSubscription:output_type -> playground.v1.GetWebhookSubscriptionResponse
+	75,  // 129: playground.v1.MessageService.ListWebhookSubscriptions:output_type -> playground.v1.ListWebhookSubscriptionsResponse
+	77,  // 130: playground.v1.MessageService.UpdateWebhookSubscription:output_type -> playground.v1.UpdateWebhookSubscriptionResponse
+	79,  // 131: playground.v1.MessageService.DeleteWebhookSubscription:output_type -> playground.v1.DeleteWebhookSubscriptionResponse
+	82,  // 132: playground.v1.MessageService.ListWebhookDeliveries:output_type -> playground.v1.ListWebhookDeliveriesResponse
+	84,  // 133: playground.v1.MessageService.TestWebhook:output_type -> playground.v1.TestWebhookResponse
+	101, // [101:134] is the sub-list for method output_type
+	68,  // [68:101] is the sub-list for method input_type
+	68,  // [68:68] is the sub-list for extension type_name
+	68,  // [68:68] is the sub-list for extension extendee
+	0,   // [0:68] is the sub-list for field type_name
 }
 
 func init() { file_playground_v1_message_proto_init() }
@@ -844,13 +5518,18 @@ func file_playground_v1_message_proto_init() {
 	if File_playground_v1_message_proto != nil {
 		return
 	}
+	file_playground_v1_message_proto_msgTypes[62].OneofWrappers = []any{
+		(*Event_Message)(nil),
+		(*Event_Operation)(nil),
+		(*Event_Attachment)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_playground_v1_message_proto_rawDesc), len(file_playground_v1_message_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   14,
+			NumEnums:      4,
+			NumMessages:   86,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
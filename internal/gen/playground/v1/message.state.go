@@ -9,13 +9,75 @@ import (
 const SendMessageStateWorkflow = "SendMessageState"
 
 type SendMessageStateWorkflowHandler interface {
-	Do(io *SendMessageState) error
+	Validate(io *SendMessageState) error
+	Reserve(io *SendMessageState) error
+	Render(io *SendMessageState) error
+	Deliver(io *SendMessageState) error
+	Bill(io *SendMessageState) error
+	Confirm(io *SendMessageState) error
+	Compensate(io *SendMessageState) error
 }
 
-func workflowStepDo(handler SendMessageStateWorkflowHandler) *workflows.WorkflowStep[SendMessageState] {
+func workflowStepValidate(handler SendMessageStateWorkflowHandler) *workflows.WorkflowStep[SendMessageState] {
 	return &workflows.WorkflowStep[SendMessageState]{
-		Name: "do",
-		Fn:   handler.Do,
+		Name: "validate",
+		Fn:   handler.Validate,
+		Retries: &workflows.RetryPolicy{
+			MaxAttempts:          3,
+			InitialRetryInterval: 1 * time.Second,
+			BackoffCoefficient:   2,
+			MaxRetryInterval:     5 * time.Second,
+			RetryTimeout:         30 * time.Second,
+		},
+		Next: workflowStepReserve(handler),
+	}
+}
+func workflowStepReserve(handler SendMessageStateWorkflowHandler) *workflows.WorkflowStep[SendMessageState] {
+	return &workflows.WorkflowStep[SendMessageState]{
+		Name: "reserve",
+		Fn:   handler.Reserve,
+		Retries: &workflows.RetryPolicy{
+			MaxAttempts:          5,
+			InitialRetryInterval: 1 * time.Second,
+			BackoffCoefficient:   2,
+			MaxRetryInterval:     10 * time.Second,
+			RetryTimeout:         60 * time.Second,
+		},
+		Next: workflowStepRender(handler),
+	}
+}
+func workflowStepRender(handler SendMessageStateWorkflowHandler) *workflows.WorkflowStep[SendMessageState] {
+	return &workflows.WorkflowStep[SendMessageState]{
+		Name: "render",
+		Fn:   handler.Render,
+		Retries: &workflows.RetryPolicy{
+			MaxAttempts:          3,
+			InitialRetryInterval: 1 * time.Second,
+			BackoffCoefficient:   2,
+			MaxRetryInterval:     5 * time.Second,
+			RetryTimeout:         30 * time.Second,
+		},
+		Next: workflowStepDeliver(handler),
+	}
+}
+func workflowStepDeliver(handler SendMessageStateWorkflowHandler) *workflows.WorkflowStep[SendMessageState] {
+	return &workflows.WorkflowStep[SendMessageState]{
+		Name: "deliver",
+		Fn:   handler.Deliver,
+		Retries: &workflows.RetryPolicy{
+			MaxAttempts:          5,
+			InitialRetryInterval: 1 * time.Second,
+			BackoffCoefficient:   2,
+			MaxRetryInterval:     10 * time.Second,
+			RetryTimeout:         60 * time.Second,
+		},
+		Next: workflowStepBill(handler),
+	}
+}
+func workflowStepBill(handler SendMessageStateWorkflowHandler) *workflows.WorkflowStep[SendMessageState] {
+	return &workflows.WorkflowStep[SendMessageState]{
+		Name: "bill",
+		Fn:   handler.Bill,
 		Retries: &workflows.RetryPolicy{
 			MaxAttempts:          5,
 			InitialRetryInterval: 1 * time.Second,
@@ -23,12 +85,40 @@ func workflowStepDo(handler SendMessageStateWorkflowHandler) *workflows.Workflow
 			MaxRetryInterval:     10 * time.Second,
 			RetryTimeout:         60 * time.Second,
 		},
+		Next: workflowStepConfirm(handler),
+	}
+}
+func workflowStepConfirm(handler SendMessageStateWorkflowHandler) *workflows.WorkflowStep[SendMessageState] {
+	return &workflows.WorkflowStep[SendMessageState]{
+		Name: "confirm",
+		Fn:   handler.Confirm,
+		Retries: &workflows.RetryPolicy{
+			MaxAttempts:          10,
+			InitialRetryInterval: 2 * time.Second,
+			BackoffCoefficient:   1.5,
+			MaxRetryInterval:     30 * time.Second,
+			RetryTimeout:         300 * time.Second,
+		},
+		Next: workflowStepCompensate(handler),
+	}
+}
+func workflowStepCompensate(handler SendMessageStateWorkflowHandler) *workflows.WorkflowStep[SendMessageState] {
+	return &workflows.WorkflowStep[SendMessageState]{
+		Name: "compensate",
+		Fn:   handler.Compensate,
+		Retries: &workflows.RetryPolicy{
+			MaxAttempts:          10,
+			InitialRetryInterval: 1 * time.Second,
+			BackoffCoefficient:   2,
+			MaxRetryInterval:     60 * time.Second,
+			RetryTimeout:         600 * time.Second,
+		},
 	}
 }
 
 func NewSendMessageStateWorkflowRegistration(handler SendMessageStateWorkflowHandler) workflows.Registration {
 	return workflows.NewRegistration(&workflows.Workflow[SendMessageState]{
 		Name:       SendMessageStateWorkflow,
-		Entrypoint: workflowStepDo(handler),
+		Entrypoint: workflowStepValidate(handler),
 	})
 }
@@ -0,0 +1,243 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: playground/v1/admin.proto
+
+package playgroundv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// AdminServiceName is the fully-qualified name of the AdminService service.
+	AdminServiceName = "playground.v1.AdminService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// AdminServiceListWorkflowInstancesProcedure is the fully-qualified name of the AdminService's
+	// ListWorkflowInstances RPC.
+	AdminServiceListWorkflowInstancesProcedure = "/playground.v1.AdminService/ListWorkflowInstances"
+	// AdminServiceGetWorkflowInstanceProcedure is the fully-qualified name of the AdminService's
+	// GetWorkflowInstance RPC.
+	AdminServiceGetWorkflowInstanceProcedure = "/playground.v1.AdminService/GetWorkflowInstance"
+	// AdminServiceTerminateWorkflowProcedure is the fully-qualified name of the AdminService's
+	// TerminateWorkflow RPC.
+	AdminServiceTerminateWorkflowProcedure = "/playground.v1.AdminService/TerminateWorkflow"
+	// AdminServicePurgeWorkflowProcedure is the fully-qualified name of the AdminService's
+	// PurgeWorkflow RPC.
+	AdminServicePurgeWorkflowProcedure = "/playground.v1.AdminService/PurgeWorkflow"
+	// AdminServiceRetryWorkflowProcedure is the fully-qualified name of the AdminService's
+	// RetryWorkflow RPC.
+	AdminServiceRetryWorkflowProcedure = "/playground.v1.AdminService/RetryWorkflow"
+)
+
+// AdminServiceClient is a client for the playground.v1.AdminService service.
+type AdminServiceClient interface {
+	ListWorkflowInstances(context.Context, *connect.Request[v1.ListWorkflowInstancesRequest]) (*connect.Response[v1.ListWorkflowInstancesResponse], error)
+	GetWorkflowInstance(context.Context, *connect.Request[v1.GetWorkflowInstanceRequest]) (*connect.Response[v1.GetWorkflowInstanceResponse], error)
+	// stops a running instance without compensating it, and fails its
+	// operation
+	TerminateWorkflow(context.Context, *connect.Request[v1.TerminateWorkflowRequest]) (*connect.Response[v1.TerminateWorkflowResponse], error)
+	// deletes the state and history of an instance that has finished
+	PurgeWorkflow(context.Context, *connect.Request[v1.PurgeWorkflowRequest]) (*connect.Response[v1.PurgeWorkflowResponse], error)
+	// runs a failed or terminated instance again from its original input as a
+	// new instance, without any injected faults. Steps skip work their
+	// operation already did, and compensate it when the operation has failed.
+	// The new run counts its attempts at each step from the first.
+	RetryWorkflow(context.Context, *connect.Request[v1.RetryWorkflowRequest]) (*connect.Response[v1.RetryWorkflowResponse], error)
+}
+
+// NewAdminServiceClient constructs a client for the playground.v1.AdminService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAdminServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) AdminServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	adminServiceMethods := v1.File_playground_v1_admin_proto.Services().ByName("AdminService").Methods()
+	return &adminServiceClient{
+		listWorkflowInstances: connect.NewClient[v1.ListWorkflowInstancesRequest, v1.ListWorkflowInstancesResponse](
+			httpClient,
+			baseURL+AdminServiceListWorkflowInstancesProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ListWorkflowInstances")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		getWorkflowInstance: connect.NewClient[v1.GetWorkflowInstanceRequest, v1.GetWorkflowInstanceResponse](
+			httpClient,
+			baseURL+AdminServiceGetWorkflowInstanceProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("GetWorkflowInstance")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		terminateWorkflow: connect.NewClient[v1.TerminateWorkflowRequest, v1.TerminateWorkflowResponse](
+			httpClient,
+			baseURL+AdminServiceTerminateWorkflowProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("TerminateWorkflow")),
+			connect.WithClientOptions(opts...),
+		),
+		purgeWorkflow: connect.NewClient[v1.PurgeWorkflowRequest, v1.PurgeWorkflowResponse](
+			httpClient,
+			baseURL+AdminServicePurgeWorkflowProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("PurgeWorkflow")),
+			connect.WithClientOptions(opts...),
+		),
+		retryWorkflow: connect.NewClient[v1.RetryWorkflowRequest, v1.RetryWorkflowResponse](
+			httpClient,
+			baseURL+AdminServiceRetryWorkflowProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("RetryWorkflow")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// adminServiceClient implements AdminServiceClient.
+type adminServiceClient struct {
+	listWorkflowInstances *connect.Client[v1.ListWorkflowInstancesRequest, v1.ListWorkflowInstancesResponse]
+	getWorkflowInstance   *connect.Client[v1.GetWorkflowInstanceRequest, v1.GetWorkflowInstanceResponse]
+	terminateWorkflow     *connect.Client[v1.TerminateWorkflowRequest, v1.TerminateWorkflowResponse]
+	purgeWorkflow         *connect.Client[v1.PurgeWorkflowRequest, v1.PurgeWorkflowResponse]
+	retryWorkflow         *connect.Client[v1.RetryWorkflowRequest, v1.RetryWorkflowResponse]
+}
+
+// ListWorkflowInstances calls playground.v1.AdminService.ListWorkflowInstances.
+func (c *adminServiceClient) ListWorkflowInstances(ctx context.Context, req *connect.Request[v1.ListWorkflowInstancesRequest]) (*connect.Response[v1.ListWorkflowInstancesResponse], error) {
+	return c.listWorkflowInstances.CallUnary(ctx, req)
+}
+
+// GetWorkflowInstance calls playground.v1.AdminService.GetWorkflowInstance.
+func (c *adminServiceClient) GetWorkflowInstance(ctx context.Context, req *connect.Request[v1.GetWorkflowInstanceRequest]) (*connect.Response[v1.GetWorkflowInstanceResponse], error) {
+	return c.getWorkflowInstance.CallUnary(ctx, req)
+}
+
+// TerminateWorkflow calls playground.v1.AdminService.TerminateWorkflow.
+func (c *adminServiceClient) TerminateWorkflow(ctx context.Context, req *connect.Request[v1.TerminateWorkflowRequest]) (*connect.Response[v1.TerminateWorkflowResponse], error) {
+	return c.terminateWorkflow.CallUnary(ctx, req)
+}
+
+// PurgeWorkflow calls playground.v1.AdminService.PurgeWorkflow.
+func (c *adminServiceClient) PurgeWorkflow(ctx context.Context, req *connect.Request[v1.PurgeWorkflowRequest]) (*connect.Response[v1.PurgeWorkflowResponse], error) {
+	return c.purgeWorkflow.CallUnary(ctx, req)
+}
+
+// RetryWorkflow calls playground.v1.AdminService.RetryWorkflow.
+func (c *adminServiceClient) RetryWorkflow(ctx context.Context, req *connect.Request[v1.RetryWorkflowRequest]) (*connect.Response[v1.RetryWorkflowResponse], error) {
+	return c.retryWorkflow.CallUnary(ctx, req)
+}
+
+// AdminServiceHandler is an implementation of the playground.v1.AdminService service.
+type AdminServiceHandler interface {
+	ListWorkflowInstances(context.Context, *connect.Request[v1.ListWorkflowInstancesRequest]) (*connect.Response[v1.ListWorkflowInstancesResponse], error)
+	GetWorkflowInstance(context.Context, *connect.Request[v1.GetWorkflowInstanceRequest]) (*connect.Response[v1.GetWorkflowInstanceResponse], error)
+	// stops a running instance without compensating it, and fails its
+	// operation
+	TerminateWorkflow(context.Context, *connect.Request[v1.TerminateWorkflowRequest]) (*connect.Response[v1.TerminateWorkflowResponse], error)
+	// deletes the state and history of an instance that has finished
+	PurgeWorkflow(context.Context, *connect.Request[v1.PurgeWorkflowRequest]) (*connect.Response[v1.PurgeWorkflowResponse], error)
+	// runs a failed or terminated instance again from its original input as a
+	// new instance, without any injected faults. Steps skip work their
+	// operation already did, and compensate it when the operation has failed.
+	// The new run counts its attempts at each step from the first.
+	RetryWorkflow(context.Context, *connect.Request[v1.RetryWorkflowRequest]) (*connect.Response[v1.RetryWorkflowResponse], error)
+}
+
+// NewAdminServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAdminServiceHandler(svc AdminServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	adminServiceMethods := v1.File_playground_v1_admin_proto.Services().ByName("AdminService").Methods()
+	adminServiceListWorkflowInstancesHandler := connect.NewUnaryHandler(
+		AdminServiceListWorkflowInstancesProcedure,
+		svc.ListWorkflowInstances,
+		connect.WithSchema(adminServiceMethods.ByName("ListWorkflowInstances")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceGetWorkflowInstanceHandler := connect.NewUnaryHandler(
+		AdminServiceGetWorkflowInstanceProcedure,
+		svc.GetWorkflowInstance,
+		connect.WithSchema(adminServiceMethods.ByName("GetWorkflowInstance")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceTerminateWorkflowHandler := connect.NewUnaryHandler(
+		AdminServiceTerminateWorkflowProcedure,
+		svc.TerminateWorkflow,
+		connect.WithSchema(adminServiceMethods.ByName("TerminateWorkflow")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServicePurgeWorkflowHandler := connect.NewUnaryHandler(
+		AdminServicePurgeWorkflowProcedure,
+		svc.PurgeWorkflow,
+		connect.WithSchema(adminServiceMethods.ByName("PurgeWorkflow")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceRetryWorkflowHandler := connect.NewUnaryHandler(
+		AdminServiceRetryWorkflowProcedure,
+		svc.RetryWorkflow,
+		connect.WithSchema(adminServiceMethods.ByName("RetryWorkflow")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/playground.v1.AdminService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AdminServiceListWorkflowInstancesProcedure:
+			adminServiceListWorkflowInstancesHandler.ServeHTTP(w, r)
+		case AdminServiceGetWorkflowInstanceProcedure:
+			adminServiceGetWorkflowInstanceHandler.ServeHTTP(w, r)
+		case AdminServiceTerminateWorkflowProcedure:
+			adminServiceTerminateWorkflowHandler.ServeHTTP(w, r)
+		case AdminServicePurgeWorkflowProcedure:
+			adminServicePurgeWorkflowHandler.ServeHTTP(w, r)
+		case AdminServiceRetryWorkflowProcedure:
+			adminServiceRetryWorkflowHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAdminServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAdminServiceHandler struct{}
+
+func (UnimplementedAdminServiceHandler) ListWorkflowInstances(context.Context, *connect.Request[v1.ListWorkflowInstancesRequest]) (*connect.Response[v1.ListWorkflowInstancesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.AdminService.ListWorkflowInstances is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) GetWorkflowInstance(context.Context, *connect.Request[v1.GetWorkflowInstanceRequest]) (*connect.Response[v1.GetWorkflowInstanceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.AdminService.GetWorkflowInstance is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) TerminateWorkflow(context.Context, *connect.Request[v1.TerminateWorkflowRequest]) (*connect.Response[v1.TerminateWorkflowResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.AdminService.TerminateWorkflow is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) PurgeWorkflow(context.Context, *connect.Request[v1.PurgeWorkflowRequest]) (*connect.Response[v1.PurgeWorkflowResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.AdminService.PurgeWorkflow is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) RetryWorkflow(context.Context, *connect.Request[v1.RetryWorkflowRequest]) (*connect.Response[v1.RetryWorkflowResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.AdminService.RetryWorkflow is not implemented"))
+}
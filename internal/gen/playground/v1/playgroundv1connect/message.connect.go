@@ -39,28 +39,138 @@ const (
 	// MessageServiceCreateMessageProcedure is the fully-qualified name of the MessageService's
 	// CreateMessage RPC.
 	MessageServiceCreateMessageProcedure = "/playground.v1.MessageService/CreateMessage"
+	// MessageServiceUpdateMessageProcedure is the fully-qualified name of the MessageService's
+	// UpdateMessage RPC.
+	MessageServiceUpdateMessageProcedure = "/playground.v1.MessageService/UpdateMessage"
 	// MessageServiceDeleteMessageProcedure is the fully-qualified name of the MessageService's
 	// DeleteMessage RPC.
 	MessageServiceDeleteMessageProcedure = "/playground.v1.MessageService/DeleteMessage"
 	// MessageServiceListMessagesProcedure is the fully-qualified name of the MessageService's
 	// ListMessages RPC.
 	MessageServiceListMessagesProcedure = "/playground.v1.MessageService/ListMessages"
+	// MessageServiceSearchMessagesProcedure is the fully-qualified name of the MessageService's
+	// SearchMessages RPC.
+	MessageServiceSearchMessagesProcedure = "/playground.v1.MessageService/SearchMessages"
 	// MessageServiceSendMessageProcedure is the fully-qualified name of the MessageService's
 	// SendMessage RPC.
 	MessageServiceSendMessageProcedure = "/playground.v1.MessageService/SendMessage"
 	// MessageServiceMessageStatusProcedure is the fully-qualified name of the MessageService's
 	// MessageStatus RPC.
 	MessageServiceMessageStatusProcedure = "/playground.v1.MessageService/MessageStatus"
+	// MessageServiceCancelSendProcedure is the fully-qualified name of the MessageService's CancelSend
+	// RPC.
+	MessageServiceCancelSendProcedure = "/playground.v1.MessageService/CancelSend"
+	// MessageServiceUploadAttachmentProcedure is the fully-qualified name of the MessageService's
+	// UploadAttachment RPC.
+	MessageServiceUploadAttachmentProcedure = "/playground.v1.MessageService/UploadAttachment"
+	// MessageServiceDownloadAttachmentProcedure is the fully-qualified name of the MessageService's
+	// DownloadAttachment RPC.
+	MessageServiceDownloadAttachmentProcedure = "/playground.v1.MessageService/DownloadAttachment"
+	// MessageServiceDeleteAttachmentProcedure is the fully-qualified name of the MessageService's
+	// DeleteAttachment RPC.
+	MessageServiceDeleteAttachmentProcedure = "/playground.v1.MessageService/DeleteAttachment"
+	// MessageServiceStreamEventsProcedure is the fully-qualified name of the MessageService's
+	// StreamEvents RPC.
+	MessageServiceStreamEventsProcedure = "/playground.v1.MessageService/StreamEvents"
+	// MessageServiceCreateTemplateProcedure is the fully-qualified name of the MessageService's
+	// CreateTemplate RPC.
+	MessageServiceCreateTemplateProcedure = "/playground.v1.MessageService/CreateTemplate"
+	// MessageServiceGetTemplateProcedure is the fully-qualified name of the MessageService's
+	// GetTemplate RPC.
+	MessageServiceGetTemplateProcedure = "/playground.v1.MessageService/GetTemplate"
+	// MessageServiceListTemplatesProcedure is the fully-qualified name of the MessageService's
+	// ListTemplates RPC.
+	MessageServiceListTemplatesProcedure = "/playground.v1.MessageService/ListTemplates"
+	// MessageServiceUpdateTemplateProcedure is the fully-qualified name of the MessageService's
+	// UpdateTemplate RPC.
+	MessageServiceUpdateTemplateProcedure = "/playground.v1.MessageService/UpdateTemplate"
+	// MessageServiceDeleteTemplateProcedure is the fully-qualified name of the MessageService's
+	// DeleteTemplate RPC.
+	MessageServiceDeleteTemplateProcedure = "/playground.v1.MessageService/DeleteTemplate"
+	// MessageServiceCreateRecipientProcedure is the fully-qualified name of the MessageService's
+	// CreateRecipient RPC.
+	MessageServiceCreateRecipientProcedure = "/playground.v1.MessageService/CreateRecipient"
+	// MessageServiceGetRecipientProcedure is the fully-qualified name of the MessageService's
+	// GetRecipient RPC.
+	MessageServiceGetRecipientProcedure = "/playground.v1.MessageService/GetRecipient"
+	// MessageServiceListRecipientsProcedure is the fully-qualified name of the MessageService's
+	// ListRecipients RPC.
+	MessageServiceListRecipientsProcedure = "/playground.v1.MessageService/ListRecipients"
+	// MessageServiceDeleteRecipientProcedure is the fully-qualified name of the MessageService's
+	// DeleteRecipient RPC.
+	MessageServiceDeleteRecipientProcedure = "/playground.v1.MessageService/DeleteRecipient"
+	// MessageServiceListDeadLettersProcedure is the fully-qualified name of the MessageService's
+	// ListDeadLetters RPC.
+	MessageServiceListDeadLettersProcedure = "/playground.v1.MessageService/ListDeadLetters"
+	// MessageServiceGetDeadLetterProcedure is the fully-qualified name of the MessageService's
+	// GetDeadLetter RPC.
+	MessageServiceGetDeadLetterProcedure = "/playground.v1.MessageService/GetDeadLetter"
+	// MessageServiceRedriveDeadLetterProcedure is the fully-qualified name of the MessageService's
+	// RedriveDeadLetter RPC.
+	MessageServiceRedriveDeadLetterProcedure = "/playground.v1.MessageService/RedriveDeadLetter"
+	// MessageServicePurgeDeadLettersProcedure is the fully-qualified name of the MessageService's
+	// PurgeDeadLetters RPC.
+	MessageServicePurgeDeadLettersProcedure = "/playground.v1.MessageService/PurgeDeadLetters"
+	// MessageServiceCreateWebhookSubscriptionProcedure is the fully-qualified name of the
+	// MessageService's CreateWebhookSubscription RPC.
+	MessageServiceCreateWebhookSubscriptionProcedure = "/playground.v1.MessageService/CreateWebhookSubscription"
+	// MessageServiceGetWebhookSubscriptionProcedure is the fully-qualified name of the MessageService's
+	// GetWebhookSubscription RPC.
+	MessageServiceGetWebhookSubscriptionProcedure = "/playground.v1.MessageService/GetWebhookSubscription"
+	// MessageServiceListWebhookSubscriptionsProcedure is the fully-qualified name of the
+	// MessageService's ListWebhookSubscriptions RPC.
+	MessageServiceListWebhookSubscriptionsProcedure = "/playground.v1.MessageService/ListWebhookSubscriptions"
+	// MessageServiceUpdateWebhookSubscriptionProcedure is the fully-qualified name of the
+	// MessageService's UpdateWebhookSubscription RPC.
+	MessageServiceUpdateWebhookSubscriptionProcedure = "/playground.v1.MessageService/UpdateWebhookSubscription"
+	// MessageServiceDeleteWebhookSubscriptionProcedure is the fully-qualified name of the
+	// MessageService's DeleteWebhookSubscription RPC.
+	MessageServiceDeleteWebhookSubscriptionProcedure = "/playground.v1.MessageService/DeleteWebhookSubscription"
+	// MessageServiceListWebhookDeliveriesProcedure is the fully-qualified name of the MessageService's
+	// ListWebhookDeliveries RPC.
+	MessageServiceListWebhookDeliveriesProcedure = "/playground.v1.MessageService/ListWebhookDeliveries"
+	// MessageServiceTestWebhookProcedure is the fully-qualified name of the MessageService's
+	// TestWebhook RPC.
+	MessageServiceTestWebhookProcedure = "/playground.v1.MessageService/TestWebhook"
 )
 
 // MessageServiceClient is a client for the playground.v1.MessageService service.
 type MessageServiceClient interface {
 	GetMessage(context.Context, *connect.Request[v1.GetMessageRequest]) (*connect.Response[v1.GetMessageResponse], error)
 	CreateMessage(context.Context, *connect.Request[v1.CreateMessageRequest]) (*connect.Response[v1.CreateMessageResponse], error)
+	UpdateMessage(context.Context, *connect.Request[v1.UpdateMessageRequest]) (*connect.Response[v1.UpdateMessageResponse], error)
 	DeleteMessage(context.Context, *connect.Request[v1.DeleteMessageRequest]) (*connect.Response[v1.DeleteMessageResponse], error)
 	ListMessages(context.Context, *connect.Request[v1.ListMessagesRequest]) (*connect.Response[v1.ListMessagesResponse], error)
+	SearchMessages(context.Context, *connect.Request[v1.SearchMessagesRequest]) (*connect.Response[v1.SearchMessagesResponse], error)
 	SendMessage(context.Context, *connect.Request[v1.SendMessageRequest]) (*connect.Response[v1.SendMessageResponse], error)
 	MessageStatus(context.Context, *connect.Request[v1.MessageStatusRequest]) (*connect.Response[v1.MessageStatusResponse], error)
+	CancelSend(context.Context, *connect.Request[v1.CancelSendRequest]) (*connect.Response[v1.CancelSendResponse], error)
+	UploadAttachment(context.Context) *connect.ClientStreamForClient[v1.UploadAttachmentRequest, v1.UploadAttachmentResponse]
+	DownloadAttachment(context.Context, *connect.Request[v1.DownloadAttachmentRequest]) (*connect.ServerStreamForClient[v1.DownloadAttachmentResponse], error)
+	// DeleteAttachment deletes an attachment and its content. Attachments
+	// still being sent can't be deleted.
+	DeleteAttachment(context.Context, *connect.Request[v1.DeleteAttachmentRequest]) (*connect.Response[v1.DeleteAttachmentResponse], error)
+	StreamEvents(context.Context, *connect.Request[v1.StreamEventsRequest]) (*connect.ServerStreamForClient[v1.StreamEventsResponse], error)
+	CreateTemplate(context.Context, *connect.Request[v1.CreateTemplateRequest]) (*connect.Response[v1.CreateTemplateResponse], error)
+	GetTemplate(context.Context, *connect.Request[v1.GetTemplateRequest]) (*connect.Response[v1.GetTemplateResponse], error)
+	ListTemplates(context.Context, *connect.Request[v1.ListTemplatesRequest]) (*connect.Response[v1.ListTemplatesResponse], error)
+	UpdateTemplate(context.Context, *connect.Request[v1.UpdateTemplateRequest]) (*connect.Response[v1.UpdateTemplateResponse], error)
+	DeleteTemplate(context.Context, *connect.Request[v1.DeleteTemplateRequest]) (*connect.Response[v1.DeleteTemplateResponse], error)
+	CreateRecipient(context.Context, *connect.Request[v1.CreateRecipientRequest]) (*connect.Response[v1.CreateRecipientResponse], error)
+	GetRecipient(context.Context, *connect.Request[v1.GetRecipientRequest]) (*connect.Response[v1.GetRecipientResponse], error)
+	ListRecipients(context.Context, *connect.Request[v1.ListRecipientsRequest]) (*connect.Response[v1.ListRecipientsResponse], error)
+	DeleteRecipient(context.Context, *connect.Request[v1.DeleteRecipientRequest]) (*connect.Response[v1.DeleteRecipientResponse], error)
+	ListDeadLetters(context.Context, *connect.Request[v1.ListDeadLettersRequest]) (*connect.Response[v1.ListDeadLettersResponse], error)
+	GetDeadLetter(context.Context, *connect.Request[v1.GetDeadLetterRequest]) (*connect.Response[v1.GetDeadLetterResponse], error)
+	RedriveDeadLetter(context.Context, *connect.Request[v1.RedriveDeadLetterRequest]) (*connect.Response[v1.RedriveDeadLetterResponse], error)
+	PurgeDeadLetters(context.Context, *connect.Request[v1.PurgeDeadLettersRequest]) (*connect.Response[v1.PurgeDeadLettersResponse], error)
+	CreateWebhookSubscription(context.Context, *connect.Request[v1.CreateWebhookSubscriptionRequest]) (*connect.Response[v1.CreateWebhookSubscriptionResponse], error)
+	GetWebhookSubscription(context.Context, *connect.Request[v1.GetWebhookSubscriptionRequest]) (*connect.Response[v1.GetWebhookSubscriptionResponse], error)
+	ListWebhookSubscriptions(context.Context, *connect.Request[v1.ListWebhookSubscriptionsRequest]) (*connect.Response[v1.ListWebhookSubscriptionsResponse], error)
+	UpdateWebhookSubscription(context.Context, *connect.Request[v1.UpdateWebhookSubscriptionRequest]) (*connect.Response[v1.UpdateWebhookSubscriptionResponse], error)
+	DeleteWebhookSubscription(context.Context, *connect.Request[v1.DeleteWebhookSubscriptionRequest]) (*connect.Response[v1.DeleteWebhookSubscriptionResponse], error)
+	ListWebhookDeliveries(context.Context, *connect.Request[v1.ListWebhookDeliveriesRequest]) (*connect.Response[v1.ListWebhookDeliveriesResponse], error)
+	TestWebhook(context.Context, *connect.Request[v1.TestWebhookRequest]) (*connect.Response[v1.TestWebhookResponse], error)
 }
 
 // NewMessageServiceClient constructs a client for the playground.v1.MessageService service. By
@@ -78,6 +188,7 @@ func NewMessageServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			httpClient,
 			baseURL+MessageServiceGetMessageProcedure,
 			connect.WithSchema(messageServiceMethods.ByName("GetMessage")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 			connect.WithClientOptions(opts...),
 		),
 		createMessage: connect.NewClient[v1.CreateMessageRequest, v1.CreateMessageResponse](
@@ -86,6 +197,12 @@ func NewMessageServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(messageServiceMethods.ByName("CreateMessage")),
 			connect.WithClientOptions(opts...),
 		),
+		updateMessage: connect.NewClient[v1.UpdateMessageRequest, v1.UpdateMessageResponse](
+			httpClient,
+			baseURL+MessageServiceUpdateMessageProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("UpdateMessage")),
+			connect.WithClientOptions(opts...),
+		),
 		deleteMessage: connect.NewClient[v1.DeleteMessageRequest, v1.DeleteMessageResponse](
 			httpClient,
 			baseURL+MessageServiceDeleteMessageProcedure,
@@ -96,6 +213,14 @@ func NewMessageServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			httpClient,
 			baseURL+MessageServiceListMessagesProcedure,
 			connect.WithSchema(messageServiceMethods.ByName("ListMessages")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		searchMessages: connect.NewClient[v1.SearchMessagesRequest, v1.SearchMessagesResponse](
+			httpClient,
+			baseURL+MessageServiceSearchMessagesProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("SearchMessages")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 			connect.WithClientOptions(opts...),
 		),
 		sendMessage: connect.NewClient[v1.SendMessageRequest, v1.SendMessageResponse](
@@ -108,6 +233,168 @@ func NewMessageServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			httpClient,
 			baseURL+MessageServiceMessageStatusProcedure,
 			connect.WithSchema(messageServiceMethods.ByName("MessageStatus")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		cancelSend: connect.NewClient[v1.CancelSendRequest, v1.CancelSendResponse](
+			httpClient,
+			baseURL+MessageServiceCancelSendProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("CancelSend")),
+			connect.WithClientOptions(opts...),
+		),
+		uploadAttachment: connect.NewClient[v1.UploadAttachmentRequest, v1.UploadAttachmentResponse](
+			httpClient,
+			baseURL+MessageServiceUploadAttachmentProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("UploadAttachment")),
+			connect.WithClientOptions(opts...),
+		),
+		downloadAttachment: connect.NewClient[v1.DownloadAttachmentRequest, v1.DownloadAttachmentResponse](
+			httpClient,
+			baseURL+MessageServiceDownloadAttachmentProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("DownloadAttachment")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		deleteAttachment: connect.NewClient[v1.DeleteAttachmentRequest, v1.DeleteAttachmentResponse](
+			httpClient,
+			baseURL+MessageServiceDeleteAttachmentProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("DeleteAttachment")),
+			connect.WithClientOptions(opts...),
+		),
+		streamEvents: connect.NewClient[v1.StreamEventsRequest, v1.StreamEventsResponse](
+			httpClient,
+			baseURL+MessageServiceStreamEventsProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("StreamEvents")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		createTemplate: connect.NewClient[v1.CreateTemplateRequest, v1.CreateTemplateResponse](
+			httpClient,
+			baseURL+MessageServiceCreateTemplateProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("CreateTemplate")),
+			connect.WithClientOptions(opts...),
+		),
+		getTemplate: connect.NewClient[v1.GetTemplateRequest, v1.GetTemplateResponse](
+			httpClient,
+			baseURL+MessageServiceGetTemplateProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("GetTemplate")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		listTemplates: connect.NewClient[v1.ListTemplatesRequest, v1.ListTemplatesResponse](
+			httpClient,
+			baseURL+MessageServiceListTemplatesProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("ListTemplates")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		updateTemplate: connect.NewClient[v1.UpdateTemplateRequest, v1.UpdateTemplateResponse](
+			httpClient,
+			baseURL+MessageServiceUpdateTemplateProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("UpdateTemplate")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteTemplate: connect.NewClient[v1.DeleteTemplateRequest, v1.DeleteTemplateResponse](
+			httpClient,
+			baseURL+MessageServiceDeleteTemplateProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("DeleteTemplate")),
+			connect.WithClientOptions(opts...),
+		),
+		createRecipient: connect.NewClient[v1.CreateRecipientRequest, v1.CreateRecipientResponse](
+			httpClient,
+			baseURL+MessageServiceCreateRecipientProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("CreateRecipient")),
+			connect.WithClientOptions(opts...),
+		),
+		getRecipient: connect.NewClient[v1.GetRecipientRequest, v1.GetRecipientResponse](
+			httpClient,
+			baseURL+MessageServiceGetRecipientProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("GetRecipient")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		listRecipients: connect.NewClient[v1.ListRecipientsRequest, v1.ListRecipientsResponse](
+			httpClient,
+			baseURL+MessageServiceListRecipientsProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("ListRecipients")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		deleteRecipient: connect.NewClient[v1.DeleteRecipientRequest, v1.DeleteRecipientResponse](
+			httpClient,
+			baseURL+MessageServiceDeleteRecipientProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("DeleteRecipient")),
+			connect.WithClientOptions(opts...),
+		),
+		listDeadLetters: connect.NewClient[v1.ListDeadLettersRequest, v1.ListDeadLettersResponse](
+			httpClient,
+			baseURL+MessageServiceListDeadLettersProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("ListDeadLetters")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		getDeadLetter: connect.NewClient[v1.GetDeadLetterRequest, v1.GetDeadLetterResponse](
+			httpClient,
+			baseURL+MessageServiceGetDeadLetterProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("GetDeadLetter")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		redriveDeadLetter: connect.NewClient[v1.RedriveDeadLetterRequest, v1.RedriveDeadLetterResponse](
+			httpClient,
+			baseURL+MessageServiceRedriveDeadLetterProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("RedriveDeadLetter")),
+			connect.WithClientOptions(opts...),
+		),
+		purgeDeadLetters: connect.NewClient[v1.PurgeDeadLettersRequest, v1.PurgeDeadLettersResponse](
+			httpClient,
+			baseURL+MessageServicePurgeDeadLettersProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("PurgeDeadLetters")),
+			connect.WithClientOptions(opts...),
+		),
+		createWebhookSubscription: connect.NewClient[v1.CreateWebhookSubscriptionRequest, v1.CreateWebhookSubscriptionResponse](
+			httpClient,
+			baseURL+MessageServiceCreateWebhookSubscriptionProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("CreateWebhookSubscription")),
+			connect.WithClientOptions(opts...),
+		),
+		getWebhookSubscription: connect.NewClient[v1.GetWebhookSubscriptionRequest, v1.GetWebhookSubscriptionResponse](
+			httpClient,
+			baseURL+MessageServiceGetWebhookSubscriptionProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("GetWebhookSubscription")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		listWebhookSubscriptions: connect.NewClient[v1.ListWebhookSubscriptionsRequest, v1.ListWebhookSubscriptionsResponse](
+			httpClient,
+			baseURL+MessageServiceListWebhookSubscriptionsProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("ListWebhookSubscriptions")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		updateWebhookSubscription: connect.NewClient[v1.UpdateWebhookSubscriptionRequest, v1.UpdateWebhookSubscriptionResponse](
+			httpClient,
+			baseURL+MessageServiceUpdateWebhookSubscriptionProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("UpdateWebhookSubscription")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteWebhookSubscription: connect.NewClient[v1.DeleteWebhookSubscriptionRequest, v1.DeleteWebhookSubscriptionResponse](
+			httpClient,
+			baseURL+MessageServiceDeleteWebhookSubscriptionProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("DeleteWebhookSubscription")),
+			connect.WithClientOptions(opts...),
+		),
+		listWebhookDeliveries: connect.NewClient[v1.ListWebhookDeliveriesRequest, v1.ListWebhookDeliveriesResponse](
+			httpClient,
+			baseURL+MessageServiceListWebhookDeliveriesProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("ListWebhookDeliveries")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		testWebhook: connect.NewClient[v1.TestWebhookRequest, v1.TestWebhookResponse](
+			httpClient,
+			baseURL+MessageServiceTestWebhookProcedure,
+			connect.WithSchema(messageServiceMethods.ByName("TestWebhook")),
 			connect.WithClientOptions(opts...),
 		),
 	}
@@ -115,12 +402,39 @@ func NewMessageServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 
 // messageServiceClient implements MessageServiceClient.
 type messageServiceClient struct {
-	getMessage    *connect.Client[v1.GetMessageRequest, v1.GetMessageResponse]
-	createMessage *connect.Client[v1.CreateMessageRequest, v1.CreateMessageResponse]
-	deleteMessage *connect.Client[v1.DeleteMessageRequest, v1.DeleteMessageResponse]
-	listMessages  *connect.Client[v1.ListMessagesRequest, v1.ListMessagesResponse]
-	sendMessage   *connect.Client[v1.SendMessageRequest, v1.SendMessageResponse]
-	messageStatus *connect.Client[v1.MessageStatusRequest, v1.MessageStatusResponse]
+	getMessage                *connect.Client[v1.GetMessageRequest, v1.GetMessageResponse]
+	createMessage             *connect.Client[v1.CreateMessageRequest, v1.CreateMessageResponse]
+	updateMessage             *connect.Client[v1.UpdateMessageRequest, v1.UpdateMessageResponse]
+	deleteMessage             *connect.Client[v1.DeleteMessageRequest, v1.DeleteMessageResponse]
+	listMessages              *connect.Client[v1.ListMessagesRequest, v1.ListMessagesResponse]
+	searchMessages            *connect.Client[v1.SearchMessagesRequest, v1.SearchMessagesResponse]
+	sendMessage               *connect.Client[v1.SendMessageRequest, v1.SendMessageResponse]
+	messageStatus             *connect.Client[v1.MessageStatusRequest, v1.MessageStatusResponse]
+	cancelSend                *connect.Client[v1.CancelSendRequest, v1.CancelSendResponse]
+	uploadAttachment          *connect.Client[v1.UploadAttachmentRequest, v1.UploadAttachmentResponse]
+	downloadAttachment        *connect.Client[v1.DownloadAttachmentRequest, v1.DownloadAttachmentResponse]
+	deleteAttachment          *connect.Client[v1.DeleteAttachmentRequest, v1.DeleteAttachmentResponse]
+	streamEvents              *connect.Client[v1.StreamEventsRequest, v1.StreamEventsResponse]
+	createTemplate            *connect.Client[v1.CreateTemplateRequest, v1.CreateTemplateResponse]
+	getTemplate               *connect.Client[v1.GetTemplateRequest, v1.GetTemplateResponse]
+	listTemplates             *connect.Client[v1.ListTemplatesRequest, v1.ListTemplatesResponse]
+	updateTemplate            *connect.Client[v1.UpdateTemplateRequest, v1.UpdateTemplateResponse]
+	deleteTemplate            *connect.Client[v1.DeleteTemplateRequest, v1.DeleteTemplateResponse]
+	createRecipient           *connect.Client[v1.CreateRecipientRequest, v1.CreateRecipientResponse]
+	getRecipient              *connect.Client[v1.GetRecipientRequest, v1.GetRecipientResponse]
+	listRecipients            *connect.Client[v1.ListRecipientsRequest, v1.ListRecipientsResponse]
+	deleteRecipient           *connect.Client[v1.DeleteRecipientRequest, v1.DeleteRecipientResponse]
+	listDeadLetters           *connect.Client[v1.ListDeadLettersRequest, v1.ListDeadLettersResponse]
+	getDeadLetter             *connect.Client[v1.GetDeadLetterRequest, v1.GetDeadLetterResponse]
+	redriveDeadLetter         *connect.Client[v1.RedriveDeadLetterRequest, v1.RedriveDeadLetterResponse]
+	purgeDeadLetters          *connect.Client[v1.PurgeDeadLettersRequest, v1.PurgeDeadLettersResponse]
+	createWebhookSubscription *connect.Client[v1.CreateWebhookSubscriptionRequest, v1.CreateWebhookSubscriptionResponse]
+	getWebhookSubscription    *connect.Client[v1.GetWebhookSubscriptionRequest, v1.GetWebhookSubscriptionResponse]
+	listWebhookSubscriptions  *connect.Client[v1.ListWebhookSubscriptionsRequest, v1.ListWebhookSubscriptionsResponse]
+	updateWebhookSubscription *connect.Client[v1.UpdateWebhookSubscriptionRequest, v1.UpdateWebhookSubscriptionResponse]
+	deleteWebhookSubscription *connect.Client[v1.DeleteWebhookSubscriptionRequest, v1.DeleteWebhookSubscriptionResponse]
+	listWebhookDeliveries     *connect.Client[v1.ListWebhookDeliveriesRequest, v1.ListWebhookDeliveriesResponse]
+	testWebhook               *connect.Client[v1.TestWebhookRequest, v1.TestWebhookResponse]
 }
 
 // GetMessage calls playground.v1.MessageService.GetMessage.
@@ -133,6 +447,11 @@ func (c *messageServiceClient) CreateMessage(ctx context.Context, req *connect.R
 	return c.createMessage.CallUnary(ctx, req)
 }
 
+// UpdateMessage calls playground.v1.MessageService.UpdateMessage.
+func (c *messageServiceClient) UpdateMessage(ctx context.Context, req *connect.Request[v1.UpdateMessageRequest]) (*connect.Response[v1.UpdateMessageResponse], error) {
+	return c.updateMessage.CallUnary(ctx, req)
+}
+
 // DeleteMessage calls playground.v1.MessageService.DeleteMessage.
 func (c *messageServiceClient) DeleteMessage(ctx context.Context, req *connect.Request[v1.DeleteMessageRequest]) (*connect.Response[v1.DeleteMessageResponse], error) {
 	return c.deleteMessage.CallUnary(ctx, req)
@@ -143,6 +462,11 @@ func (c *messageServiceClient) ListMessages(ctx context.Context, req *connect.Re
 	return c.listMessages.CallUnary(ctx, req)
 }
 
+// SearchMessages calls playground.v1.MessageService.SearchMessages.
+func (c *messageServiceClient) SearchMessages(ctx context.Context, req *connect.Request[v1.SearchMessagesRequest]) (*connect.Response[v1.SearchMessagesResponse], error) {
+	return c.searchMessages.CallUnary(ctx, req)
+}
+
 // SendMessage calls playground.v1.MessageService.SendMessage.
 func (c *messageServiceClient) SendMessage(ctx context.Context, req *connect.Request[v1.SendMessageRequest]) (*connect.Response[v1.SendMessageResponse], error) {
 	return c.sendMessage.CallUnary(ctx, req)
@@ -153,14 +477,168 @@ func (c *messageServiceClient) MessageStatus(ctx context.Context, req *connect.R
 	return c.messageStatus.CallUnary(ctx, req)
 }
 
+// CancelSend calls playground.v1.MessageService.CancelSend.
+func (c *messageServiceClient) CancelSend(ctx context.Context, req *connect.Request[v1.CancelSendRequest]) (*connect.Response[v1.CancelSendResponse], error) {
+	return c.cancelSend.CallUnary(ctx, req)
+}
+
+// UploadAttachment calls playground.v1.MessageService.UploadAttachment.
+func (c *messageServiceClient) UploadAttachment(ctx context.Context) *connect.ClientStreamForClient[v1.UploadAttachmentRequest, v1.UploadAttachmentResponse] {
+	return c.uploadAttachment.CallClientStream(ctx)
+}
+
+// DownloadAttachment calls playground.v1.MessageService.DownloadAttachment.
+func (c *messageServiceClient) DownloadAttachment(ctx context.Context, req *connect.Request[v1.DownloadAttachmentRequest]) (*connect.ServerStreamForClient[v1.DownloadAttachmentResponse], error) {
+	return c.downloadAttachment.CallServerStream(ctx, req)
+}
+
+// DeleteAttachment calls playground.v1.MessageService.DeleteAttachment.
+func (c *messageServiceClient) DeleteAttachment(ctx context.Context, req *connect.Request[v1.DeleteAttachmentRequest]) (*connect.Response[v1.DeleteAttachmentResponse], error) {
+	return c.deleteAttachment.CallUnary(ctx, req)
+}
+
+// StreamEvents calls playground.v1.MessageService.StreamEvents.
+func (c *messageServiceClient) StreamEvents(ctx context.Context, req *connect.Request[v1.StreamEventsRequest]) (*connect.ServerStreamForClient[v1.StreamEventsResponse], error) {
+	return c.streamEvents.CallServerStream(ctx, req)
+}
+
+// CreateTemplate calls playground.v1.MessageService.CreateTemplate.
+func (c *messageServiceClient) CreateTemplate(ctx context.Context, req *connect.Request[v1.CreateTemplateRequest]) (*connect.Response[v1.CreateTemplateResponse], error) {
+	return c.createTemplate.CallUnary(ctx, req)
+}
+
+// GetTemplate calls playground.v1.MessageService.GetTemplate.
+func (c *messageServiceClient) GetTemplate(ctx context.Context, req *connect.Request[v1.GetTemplateRequest]) (*connect.Response[v1.GetTemplateResponse], error) {
+	return c.getTemplate.CallUnary(ctx, req)
+}
+
+// ListTemplates calls playground.v1.MessageService.ListTemplates.
+func (c *messageServiceClient) ListTemplates(ctx context.Context, req *connect.Request[v1.ListTemplatesRequest]) (*connect.Response[v1.ListTemplatesResponse], error) {
+	return c.listTemplates.CallUnary(ctx, req)
+}
+
+// UpdateTemplate calls playground.v1.MessageService.UpdateTemplate.
+func (c *messageServiceClient) UpdateTemplate(ctx context.Context, req *connect.Request[v1.UpdateTemplateRequest]) (*connect.Response[v1.UpdateTemplateResponse], error) {
+	return c.updateTemplate.CallUnary(ctx, req)
+}
+
+// DeleteTemplate calls playground.v1.MessageService.DeleteTemplate.
+func (c *messageServiceClient) DeleteTemplate(ctx context.Context, req *connect.Request[v1.DeleteTemplateRequest]) (*connect.Response[v1.DeleteTemplateResponse], error) {
+	return c.deleteTemplate.CallUnary(ctx, req)
+}
+
+// CreateRecipient calls playground.v1.MessageService.CreateRecipient.
+func (c *messageServiceClient) CreateRecipient(ctx context.Context, req *connect.Request[v1.CreateRecipientRequest]) (*connect.Response[v1.CreateRecipientResponse], error) {
+	return c.createRecipient.CallUnary(ctx, req)
+}
+
+// GetRecipient calls playground.v1.MessageService.GetRecipient.
+func (c *messageServiceClient) GetRecipient(ctx context.Context, req *connect.Request[v1.GetRecipientRequest]) (*connect.Response[v1.GetRecipientResponse], error) {
+	return c.getRecipient.CallUnary(ctx, req)
+}
+
+// ListRecipients calls playground.v1.MessageService.ListRecipients.
+func (c *messageServiceClient) ListRecipients(ctx context.Context, req *connect.Request[v1.ListRecipientsRequest]) (*connect.Response[v1.ListRecipientsResponse], error) {
+	return c.listRecipients.CallUnary(ctx, req)
+}
+
+// DeleteRecipient calls playground.v1.MessageService.DeleteRecipient.
+func (c *messageServiceClient) DeleteRecipient(ctx context.Context, req *connect.Request[v1.DeleteRecipientRequest]) (*connect.Response[v1.DeleteRecipientResponse], error) {
+	return c.deleteRecipient.CallUnary(ctx, req)
+}
+
+// ListDeadLetters calls playground.v1.MessageService.ListDeadLetters.
+func (c *messageServiceClient) ListDeadLetters(ctx context.Context, req *connect.Request[v1.ListDeadLettersRequest]) (*connect.Response[v1.ListDeadLettersResponse], error) {
+	return c.listDeadLetters.CallUnary(ctx, req)
+}
+
+// GetDeadLetter calls playground.v1.MessageService.GetDeadLetter.
+func (c *messageServiceClient) GetDeadLetter(ctx context.Context, req *connect.Request[v1.GetDeadLetterRequest]) (*connect.Response[v1.GetDeadLetterResponse], error) {
+	return c.getDeadLetter.CallUnary(ctx, req)
+}
+
+// RedriveDeadLetter calls playground.v1.MessageService.RedriveDeadLetter.
+func (c *messageServiceClient) RedriveDeadLetter(ctx context.Context, req *connect.Request[v1.RedriveDeadLetterRequest]) (*connect.Response[v1.RedriveDeadLetterResponse], error) {
+	return c.redriveDeadLetter.CallUnary(ctx, req)
+}
+
+// PurgeDeadLetters calls playground.v1.MessageService.PurgeDeadLetters.
+func (c *messageServiceClient) PurgeDeadLetters(ctx context.Context, req *connect.Request[v1.PurgeDeadLettersRequest]) (*connect.Response[v1.PurgeDeadLettersResponse], error) {
+	return c.purgeDeadLetters.CallUnary(ctx, req)
+}
+
+// CreateWebhookSubscription calls playground.v1.MessageService.CreateWebhookSubscription.
+func (c *messageServiceClient) CreateWebhookSubscription(ctx context.Context, req *connect.Request[v1.CreateWebhookSubscriptionRequest]) (*connect.Response[v1.CreateWebhookSubscriptionResponse], error) {
+	return c.createWebhookSubscription.CallUnary(ctx, req)
+}
+
+// GetWebhookSubscription calls playground.v1.MessageService.GetWebhookSubscription.
+func (c *messageServiceClient) GetWebhookSubscription(ctx context.Context, req *connect.Request[v1.GetWebhookSubscriptionRequest]) (*connect.Response[v1.GetWebhookSubscriptionResponse], error) {
+	return c.getWebhookSubscription.CallUnary(ctx, req)
+}
+
+// ListWebhookSubscriptions calls playground.v1.MessageService.ListWebhookSubscriptions.
+func (c *messageServiceClient) ListWebhookSubscriptions(ctx context.Context, req *connect.Request[v1.ListWebhookSubscriptionsRequest]) (*connect.Response[v1.ListWebhookSubscriptionsResponse], error) {
+	return c.listWebhookSubscriptions.CallUnary(ctx, req)
+}
+
+// UpdateWebhookSubscription calls playground.v1.MessageService.UpdateWebhookSubscription.
+func (c *messageServiceClient) UpdateWebhookSubscription(ctx context.Context, req *connect.Request[v1.UpdateWebhookSubscriptionRequest]) (*connect.Response[v1.UpdateWebhookSubscriptionResponse], error) {
+	return c.updateWebhookSubscription.CallUnary(ctx, req)
+}
+
+// DeleteWebhookSubscription calls playground.v1.MessageService.DeleteWebhookSubscription.
+func (c *messageServiceClient) DeleteWebhookSubscription(ctx context.Context, req *connect.Request[v1.DeleteWebhookSubscriptionRequest]) (*connect.Response[v1.DeleteWebhookSubscriptionResponse], error) {
+	return c.deleteWebhookSubscription.CallUnary(ctx, req)
+}
+
+// ListWebhookDeliveries calls playground.v1.MessageService.ListWebhookDeliveries.
+func (c *messageServiceClient) ListWebhookDeliveries(ctx context.Context, req *connect.Request[v1.ListWebhookDeliveriesRequest]) (*connect.Response[v1.ListWebhookDeliveriesResponse], error) {
+	return c.listWebhookDeliveries.CallUnary(ctx, req)
+}
+
+// TestWebhook calls playground.v1.MessageService.TestWebhook.
+func (c *messageServiceClient) TestWebhook(ctx context.Context, req *connect.Request[v1.TestWebhookRequest]) (*connect.Response[v1.TestWebhookResponse], error) {
+	return c.testWebhook.CallUnary(ctx, req)
+}
+
 // MessageServiceHandler is an implementation of the playground.v1.MessageService service.
 type MessageServiceHandler interface {
 	GetMessage(context.Context, *connect.Request[v1.GetMessageRequest]) (*connect.Response[v1.GetMessageResponse], error)
 	CreateMessage(context.Context, *connect.Request[v1.CreateMessageRequest]) (*connect.Response[v1.CreateMessageResponse], error)
+	UpdateMessage(context.Context, *connect.Request[v1.UpdateMessageRequest]) (*connect.Response[v1.UpdateMessageResponse], error)
 	DeleteMessage(context.Context, *connect.Request[v1.DeleteMessageRequest]) (*connect.Response[v1.DeleteMessageResponse], error)
 	ListMessages(context.Context, *connect.Request[v1.ListMessagesRequest]) (*connect.Response[v1.ListMessagesResponse], error)
+	SearchMessages(context.Context, *connect.Request[v1.SearchMessagesRequest]) (*connect.Response[v1.SearchMessagesResponse], error)
 	SendMessage(context.Context, *connect.Request[v1.SendMessageRequest]) (*connect.Response[v1.SendMessageResponse], error)
 	MessageStatus(context.Context, *connect.Request[v1.MessageStatusRequest]) (*connect.Response[v1.MessageStatusResponse], error)
+	CancelSend(context.Context, *connect.Request[v1.CancelSendRequest]) (*connect.Response[v1.CancelSendResponse], error)
+	UploadAttachment(context.Context, *connect.ClientStream[v1.UploadAttachmentRequest]) (*connect.Response[v1.UploadAttachmentResponse], error)
+	DownloadAttachment(context.Context, *connect.Request[v1.DownloadAttachmentRequest], *connect.ServerStream[v1.DownloadAttachmentResponse]) error
+	// DeleteAttachment deletes an attachment and its content. Attachments
+	// still being sent can't be deleted.
+	DeleteAttachment(context.Context, *connect.Request[v1.DeleteAttachmentRequest]) (*connect.Response[v1.DeleteAttachmentResponse], error)
+	StreamEvents(context.Context, *connect.Request[v1.StreamEventsRequest], *connect.ServerStream[v1.StreamEventsResponse]) error
+	CreateTemplate(context.Context, *connect.Request[v1.CreateTemplateRequest]) (*connect.Response[v1.CreateTemplateResponse], error)
+	GetTemplate(context.Context, *connect.Request[v1.GetTemplateRequest]) (*connect.Response[v1.GetTemplateResponse], error)
+	ListTemplates(context.Context, *connect.Request[v1.ListTemplatesRequest]) (*connect.Response[v1.ListTemplatesResponse], error)
+	UpdateTemplate(context.Context, *connect.Request[v1.UpdateTemplateRequest]) (*connect.Response[v1.UpdateTemplateResponse], error)
+	DeleteTemplate(context.Context, *connect.Request[v1.DeleteTemplateRequest]) (*connect.Response[v1.DeleteTemplateResponse], error)
+	CreateRecipient(context.Context, *connect.Request[v1.CreateRecipientRequest]) (*connect.Response[v1.CreateRecipientResponse], error)
+	GetRecipient(context.Context, *connect.Request[v1.GetRecipientRequest]) (*connect.Response[v1.GetRecipientResponse], error)
+	ListRecipients(context.Context, *connect.Request[v1.ListRecipientsRequest]) (*connect.Response[v1.ListRecipientsResponse], error)
+	DeleteRecipient(context.Context, *connect.Request[v1.DeleteRecipientRequest]) (*connect.Response[v1.DeleteRecipientResponse], error)
+	ListDeadLetters(context.Context, *connect.Request[v1.ListDeadLettersRequest]) (*connect.Response[v1.ListDeadLettersResponse], error)
+	GetDeadLetter(context.Context, *connect.Request[v1.GetDeadLetterRequest]) (*connect.Response[v1.GetDeadLetterResponse], error)
+	RedriveDeadLetter(context.Context, *connect.Request[v1.RedriveDeadLetterRequest]) (*connect.Response[v1.RedriveDeadLetterResponse], error)
+	PurgeDeadLetters(context.Context, *connect.Request[v1.PurgeDeadLettersRequest]) (*connect.Response[v1.PurgeDeadLettersResponse], error)
+	CreateWebhookSubscription(context.Context, *connect.Request[v1.CreateWebhookSubscriptionRequest]) (*connect.Response[v1.CreateWebhookSubscriptionResponse], error)
+	GetWebhookSubscription(context.Context, *connect.Request[v1.GetWebhookSubscriptionRequest]) (*connect.Response[v1.GetWebhookSubscriptionResponse], error)
+	ListWebhookSubscriptions(context.Context, *connect.Request[v1.ListWebhookSubscriptionsRequest]) (*connect.Response[v1.ListWebhookSubscriptionsResponse], error)
+	UpdateWebhookSubscription(context.Context, *connect.Request[v1.UpdateWebhookSubscriptionRequest]) (*connect.Response[v1.UpdateWebhookSubscriptionResponse], error)
+	DeleteWebhookSubscription(context.Context, *connect.Request[v1.DeleteWebhookSubscriptionRequest]) (*connect.Response[v1.DeleteWebhookSubscriptionResponse], error)
+	ListWebhookDeliveries(context.Context, *connect.Request[v1.ListWebhookDeliveriesRequest]) (*connect.Response[v1.ListWebhookDeliveriesResponse], error)
+	TestWebhook(context.Context, *connect.Request[v1.TestWebhookRequest]) (*connect.Response[v1.TestWebhookResponse], error)
 }
 
 // NewMessageServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -174,6 +652,7 @@ func NewMessageServiceHandler(svc MessageServiceHandler, opts ...connect.Handler
 		MessageServiceGetMessageProcedure,
 		svc.GetMessage,
 		connect.WithSchema(messageServiceMethods.ByName("GetMessage")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 		connect.WithHandlerOptions(opts...),
 	)
 	messageServiceCreateMessageHandler := connect.NewUnaryHandler(
@@ -182,6 +661,12 @@ func NewMessageServiceHandler(svc MessageServiceHandler, opts ...connect.Handler
 		connect.WithSchema(messageServiceMethods.ByName("CreateMessage")),
 		connect.WithHandlerOptions(opts...),
 	)
+	messageServiceUpdateMessageHandler := connect.NewUnaryHandler(
+		MessageServiceUpdateMessageProcedure,
+		svc.UpdateMessage,
+		connect.WithSchema(messageServiceMethods.ByName("UpdateMessage")),
+		connect.WithHandlerOptions(opts...),
+	)
 	messageServiceDeleteMessageHandler := connect.NewUnaryHandler(
 		MessageServiceDeleteMessageProcedure,
 		svc.DeleteMessage,
@@ -192,6 +677,14 @@ func NewMessageServiceHandler(svc MessageServiceHandler, opts ...connect.Handler
 		MessageServiceListMessagesProcedure,
 		svc.ListMessages,
 		connect.WithSchema(messageServiceMethods.ByName("ListMessages")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceSearchMessagesHandler := connect.NewUnaryHandler(
+		MessageServiceSearchMessagesProcedure,
+		svc.SearchMessages,
+		connect.WithSchema(messageServiceMethods.ByName("SearchMessages")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
 		connect.WithHandlerOptions(opts...),
 	)
 	messageServiceSendMessageHandler := connect.NewUnaryHandler(
@@ -204,6 +697,168 @@ func NewMessageServiceHandler(svc MessageServiceHandler, opts ...connect.Handler
 		MessageServiceMessageStatusProcedure,
 		svc.MessageStatus,
 		connect.WithSchema(messageServiceMethods.ByName("MessageStatus")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceCancelSendHandler := connect.NewUnaryHandler(
+		MessageServiceCancelSendProcedure,
+		svc.CancelSend,
+		connect.WithSchema(messageServiceMethods.ByName("CancelSend")),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceUploadAttachmentHandler := connect.NewClientStreamHandler(
+		MessageServiceUploadAttachmentProcedure,
+		svc.UploadAttachment,
+		connect.WithSchema(messageServiceMethods.ByName("UploadAttachment")),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceDownloadAttachmentHandler := connect.NewServerStreamHandler(
+		MessageServiceDownloadAttachmentProcedure,
+		svc.DownloadAttachment,
+		connect.WithSchema(messageServiceMethods.ByName("DownloadAttachment")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceDeleteAttachmentHandler := connect.NewUnaryHandler(
+		MessageServiceDeleteAttachmentProcedure,
+		svc.DeleteAttachment,
+		connect.WithSchema(messageServiceMethods.ByName("DeleteAttachment")),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceStreamEventsHandler := connect.NewServerStreamHandler(
+		MessageServiceStreamEventsProcedure,
+		svc.StreamEvents,
+		connect.WithSchema(messageServiceMethods.ByName("StreamEvents")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceCreateTemplateHandler := connect.NewUnaryHandler(
+		MessageServiceCreateTemplateProcedure,
+		svc.CreateTemplate,
+		connect.WithSchema(messageServiceMethods.ByName("CreateTemplate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceGetTemplateHandler := connect.NewUnaryHandler(
+		MessageServiceGetTemplateProcedure,
+		svc.GetTemplate,
+		connect.WithSchema(messageServiceMethods.ByName("GetTemplate")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceListTemplatesHandler := connect.NewUnaryHandler(
+		MessageServiceListTemplatesProcedure,
+		svc.ListTemplates,
+		connect.WithSchema(messageServiceMethods.ByName("ListTemplates")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceUpdateTemplateHandler := connect.NewUnaryHandler(
+		MessageServiceUpdateTemplateProcedure,
+		svc.UpdateTemplate,
+		connect.WithSchema(messageServiceMethods.ByName("UpdateTemplate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceDeleteTemplateHandler := connect.NewUnaryHandler(
+		MessageServiceDeleteTemplateProcedure,
+		svc.DeleteTemplate,
+		connect.WithSchema(messageServiceMethods.ByName("DeleteTemplate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceCreateRecipientHandler := connect.NewUnaryHandler(
+		MessageServiceCreateRecipientProcedure,
+		svc.CreateRecipient,
+		connect.WithSchema(messageServiceMethods.ByName("CreateRecipient")),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceGetRecipientHandler := connect.NewUnaryHandler(
+		MessageServiceGetRecipientProcedure,
+		svc.GetRecipient,
+		connect.WithSchema(messageServiceMethods.ByName("GetRecipient")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceListRecipientsHandler := connect.NewUnaryHandler(
+		MessageServiceListRecipientsProcedure,
+		svc.ListRecipients,
+		connect.WithSchema(messageServiceMethods.ByName("ListRecipients")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceDeleteRecipientHandler := connect.NewUnaryHandler(
+		MessageServiceDeleteRecipientProcedure,
+		svc.DeleteRecipient,
+		connect.WithSchema(messageServiceMethods.ByName("DeleteRecipient")),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceListDeadLettersHandler := connect.NewUnaryHandler(
+		MessageServiceListDeadLettersProcedure,
+		svc.ListDeadLetters,
+		connect.WithSchema(messageServiceMethods.ByName("ListDeadLetters")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceGetDeadLetterHandler := connect.NewUnaryHandler(
+		MessageServiceGetDeadLetterProcedure,
+		svc.GetDeadLetter,
+		connect.WithSchema(messageServiceMethods.ByName("GetDeadLetter")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceRedriveDeadLetterHandler := connect.NewUnaryHandler(
+		MessageServiceRedriveDeadLetterProcedure,
+		svc.RedriveDeadLetter,
+		connect.WithSchema(messageServiceMethods.ByName("RedriveDeadLetter")),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServicePurgeDeadLettersHandler := connect.NewUnaryHandler(
+		MessageServicePurgeDeadLettersProcedure,
+		svc.PurgeDeadLetters,
+		connect.WithSchema(messageServiceMethods.ByName("PurgeDeadLetters")),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceCreateWebhookSubscriptionHandler := connect.NewUnaryHandler(
+		MessageServiceCreateWebhookSubscriptionProcedure,
+		svc.CreateWebhookSubscription,
+		connect.WithSchema(messageServiceMethods.ByName("CreateWebhookSubscription")),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceGetWebhookSubscriptionHandler := connect.NewUnaryHandler(
+		MessageServiceGetWebhookSubscriptionProcedure,
+		svc.GetWebhookSubscription,
+		connect.WithSchema(messageServiceMethods.ByName("GetWebhookSubscription")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceListWebhookSubscriptionsHandler := connect.NewUnaryHandler(
+		MessageServiceListWebhookSubscriptionsProcedure,
+		svc.ListWebhookSubscriptions,
+		connect.WithSchema(messageServiceMethods.ByName("ListWebhookSubscriptions")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceUpdateWebhookSubscriptionHandler := connect.NewUnaryHandler(
+		MessageServiceUpdateWebhookSubscriptionProcedure,
+		svc.UpdateWebhookSubscription,
+		connect.WithSchema(messageServiceMethods.ByName("UpdateWebhookSubscription")),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceDeleteWebhookSubscriptionHandler := connect.NewUnaryHandler(
+		MessageServiceDeleteWebhookSubscriptionProcedure,
+		svc.DeleteWebhookSubscription,
+		connect.WithSchema(messageServiceMethods.ByName("DeleteWebhookSubscription")),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceListWebhookDeliveriesHandler := connect.NewUnaryHandler(
+		MessageServiceListWebhookDeliveriesProcedure,
+		svc.ListWebhookDeliveries,
+		connect.WithSchema(messageServiceMethods.ByName("ListWebhookDeliveries")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	messageServiceTestWebhookHandler := connect.NewUnaryHandler(
+		MessageServiceTestWebhookProcedure,
+		svc.TestWebhook,
+		connect.WithSchema(messageServiceMethods.ByName("TestWebhook")),
 		connect.WithHandlerOptions(opts...),
 	)
 	return "/playground.v1.MessageService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -212,14 +867,68 @@ func NewMessageServiceHandler(svc MessageServiceHandler, opts ...connect.Handler
 			messageServiceGetMessageHandler.ServeHTTP(w, r)
 		case MessageServiceCreateMessageProcedure:
 			messageServiceCreateMessageHandler.ServeHTTP(w, r)
+		case MessageServiceUpdateMessageProcedure:
+			messageServiceUpdateMessageHandler.ServeHTTP(w, r)
 		case MessageServiceDeleteMessageProcedure:
 			messageServiceDeleteMessageHandler.ServeHTTP(w, r)
 		case MessageServiceListMessagesProcedure:
 			messageServiceListMessagesHandler.ServeHTTP(w, r)
+		case MessageServiceSearchMessagesProcedure:
+			messageServiceSearchMessagesHandler.ServeHTTP(w, r)
 		case MessageServiceSendMessageProcedure:
 			messageServiceSendMessageHandler.ServeHTTP(w, r)
 		case MessageServiceMessageStatusProcedure:
 			messageServiceMessageStatusHandler.ServeHTTP(w, r)
+		case MessageServiceCancelSendProcedure:
+			messageServiceCancelSendHandler.ServeHTTP(w, r)
+		case MessageServiceUploadAttachmentProcedure:
+			messageServiceUploadAttachmentHandler.ServeHTTP(w, r)
+		case MessageServiceDownloadAttachmentProcedure:
+			messageServiceDownloadAttachmentHandler.ServeHTTP(w, r)
+		case MessageServiceDeleteAttachmentProcedure:
+			messageServiceDeleteAttachmentHandler.ServeHTTP(w, r)
+		case MessageServiceStreamEventsProcedure:
+			messageServiceStreamEventsHandler.ServeHTTP(w, r)
+		case MessageServiceCreateTemplateProcedure:
+			messageServiceCreateTemplateHandler.ServeHTTP(w, r)
+		case MessageServiceGetTemplateProcedure:
+			messageServiceGetTemplateHandler.ServeHTTP(w, r)
+		case MessageServiceListTemplatesProcedure:
+			messageServiceListTemplatesHandler.ServeHTTP(w, r)
+		case MessageServiceUpdateTemplateProcedure:
+			messageServiceUpdateTemplateHandler.ServeHTTP(w, r)
+		case MessageServiceDeleteTemplateProcedure:
+			messageServiceDeleteTemplateHandler.ServeHTTP(w, r)
+		case MessageServiceCreateRecipientProcedure:
+			messageServiceCreateRecipientHandler.ServeHTTP(w, r)
+		case MessageServiceGetRecipientProcedure:
+			messageServiceGetRecipientHandler.ServeHTTP(w, r)
+		case MessageServiceListRecipientsProcedure:
+			messageServiceListRecipientsHandler.ServeHTTP(w, r)
+		case MessageServiceDeleteRecipientProcedure:
+			messageServiceDeleteRecipientHandler.ServeHTTP(w, r)
+		case MessageServiceListDeadLettersProcedure:
+			messageServiceListDeadLettersHandler.ServeHTTP(w, r)
+		case MessageServiceGetDeadLetterProcedure:
+			messageServiceGetDeadLetterHandler.ServeHTTP(w, r)
+		case MessageServiceRedriveDeadLetterProcedure:
+			messageServiceRedriveDeadLetterHandler.ServeHTTP(w, r)
+		case MessageServicePurgeDeadLettersProcedure:
+			messageServicePurgeDeadLettersHandler.ServeHTTP(w, r)
+		case MessageServiceCreateWebhookSubscriptionProcedure:
+			messageServiceCreateWebhookSubscriptionHandler.ServeHTTP(w, r)
+		case MessageServiceGetWebhookSubscriptionProcedure:
+			messageServiceGetWebhookSubscriptionHandler.ServeHTTP(w, r)
+		case MessageServiceListWebhookSubscriptionsProcedure:
+			messageServiceListWebhookSubscriptionsHandler.ServeHTTP(w, r)
+		case MessageServiceUpdateWebhookSubscriptionProcedure:
+			messageServiceUpdateWebhookSubscriptionHandler.ServeHTTP(w, r)
+		case MessageServiceDeleteWebhookSubscriptionProcedure:
+			messageServiceDeleteWebhookSubscriptionHandler.ServeHTTP(w, r)
+		case MessageServiceListWebhookDeliveriesProcedure:
+			messageServiceListWebhookDeliveriesHandler.ServeHTTP(w, r)
+		case MessageServiceTestWebhookProcedure:
+			messageServiceTestWebhookHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -237,6 +946,10 @@ func (UnimplementedMessageServiceHandler) CreateMessage(context.Context, *connec
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.CreateMessage is not implemented"))
 }
 
+func (UnimplementedMessageServiceHandler) UpdateMessage(context.Context, *connect.Request[v1.UpdateMessageRequest]) (*connect.Response[v1.UpdateMessageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.UpdateMessage is not implemented"))
+}
+
 func (UnimplementedMessageServiceHandler) DeleteMessage(context.Context, *connect.Request[v1.DeleteMessageRequest]) (*connect.Response[v1.DeleteMessageResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.DeleteMessage is not implemented"))
 }
@@ -245,6 +958,10 @@ func (UnimplementedMessageServiceHandler) ListMessages(context.Context, *connect
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.ListMessages is not implemented"))
 }
 
+func (UnimplementedMessageServiceHandler) SearchMessages(context.Context, *connect.Request[v1.SearchMessagesRequest]) (*connect.Response[v1.SearchMessagesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.SearchMessages is not implemented"))
+}
+
 func (UnimplementedMessageServiceHandler) SendMessage(context.Context, *connect.Request[v1.SendMessageRequest]) (*connect.Response[v1.SendMessageResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.SendMessage is not implemented"))
 }
@@ -252,3 +969,103 @@ func (UnimplementedMessageServiceHandler) SendMessage(context.Context, *connect.
 func (UnimplementedMessageServiceHandler) MessageStatus(context.Context, *connect.Request[v1.MessageStatusRequest]) (*connect.Response[v1.MessageStatusResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.MessageStatus is not implemented"))
 }
+
+func (UnimplementedMessageServiceHandler) CancelSend(context.Context, *connect.Request[v1.CancelSendRequest]) (*connect.Response[v1.CancelSendResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.CancelSend is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) UploadAttachment(context.Context, *connect.ClientStream[v1.UploadAttachmentRequest]) (*connect.Response[v1.UploadAttachmentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.UploadAttachment is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) DownloadAttachment(context.Context, *connect.Request[v1.DownloadAttachmentRequest], *connect.ServerStream[v1.DownloadAttachmentResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.DownloadAttachment is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) DeleteAttachment(context.Context, *connect.Request[v1.DeleteAttachmentRequest]) (*connect.Response[v1.DeleteAttachmentResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.DeleteAttachment is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) StreamEvents(context.Context, *connect.Request[v1.StreamEventsRequest], *connect.ServerStream[v1.StreamEventsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.StreamEvents is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) CreateTemplate(context.Context, *connect.Request[v1.CreateTemplateRequest]) (*connect.Response[v1.CreateTemplateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.CreateTemplate is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) GetTemplate(context.Context, *connect.Request[v1.GetTemplateRequest]) (*connect.Response[v1.GetTemplateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.GetTemplate is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) ListTemplates(context.Context, *connect.Request[v1.ListTemplatesRequest]) (*connect.Response[v1.ListTemplatesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.ListTemplates is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) UpdateTemplate(context.Context, *connect.Request[v1.UpdateTemplateRequest]) (*connect.Response[v1.UpdateTemplateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.UpdateTemplate is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) DeleteTemplate(context.Context, *connect.Request[v1.DeleteTemplateRequest]) (*connect.Response[v1.DeleteTemplateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.DeleteTemplate is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) CreateRecipient(context.Context, *connect.Request[v1.CreateRecipientRequest]) (*connect.Response[v1.CreateRecipientResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.CreateRecipient is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) GetRecipient(context.Context, *connect.Request[v1.GetRecipientRequest]) (*connect.Response[v1.GetRecipientResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.GetRecipient is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) ListRecipients(context.Context, *connect.Request[v1.ListRecipientsRequest]) (*connect.Response[v1.ListRecipientsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.ListRecipients is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) DeleteRecipient(context.Context, *connect.Request[v1.DeleteRecipientRequest]) (*connect.Response[v1.DeleteRecipientResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.DeleteRecipient is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) ListDeadLetters(context.Context, *connect.Request[v1.ListDeadLettersRequest]) (*connect.Response[v1.ListDeadLettersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.ListDeadLetters is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) GetDeadLetter(context.Context, *connect.Request[v1.GetDeadLetterRequest]) (*connect.Response[v1.GetDeadLetterResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.GetDeadLetter is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) RedriveDeadLetter(context.Context, *connect.Request[v1.RedriveDeadLetterRequest]) (*connect.Response[v1.RedriveDeadLetterResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.RedriveDeadLetter is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) PurgeDeadLetters(context.Context, *connect.Request[v1.PurgeDeadLettersRequest]) (*connect.Response[v1.PurgeDeadLettersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.PurgeDeadLetters is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) CreateWebhookSubscription(context.Context, *connect.Request[v1.CreateWebhookSubscriptionRequest]) (*connect.Response[v1.CreateWebhookSubscriptionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.CreateWebhookSubscription is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) GetWebhookSubscription(context.Context, *connect.Request[v1.GetWebhookSubscriptionRequest]) (*connect.Response[v1.GetWebhookSubscriptionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.GetWebhookSubscription is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) ListWebhookSubscriptions(context.Context, *connect.Request[v1.ListWebhookSubscriptionsRequest]) (*connect.Response[v1.ListWebhookSubscriptionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.ListWebhookSubscriptions is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) UpdateWebhookSubscription(context.Context, *connect.Request[v1.UpdateWebhookSubscriptionRequest]) (*connect.Response[v1.UpdateWebhookSubscriptionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.UpdateWebhookSubscription is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) DeleteWebhookSubscription(context.Context, *connect.Request[v1.DeleteWebhookSubscriptionRequest]) (*connect.Response[v1.DeleteWebhookSubscriptionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.DeleteWebhookSubscription is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) ListWebhookDeliveries(context.Context, *connect.Request[v1.ListWebhookDeliveriesRequest]) (*connect.Response[v1.ListWebhookDeliveriesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.ListWebhookDeliveries is not implemented"))
+}
+
+func (UnimplementedMessageServiceHandler) TestWebhook(context.Context, *connect.Request[v1.TestWebhookRequest]) (*connect.Response[v1.TestWebhookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("playground.v1.MessageService.TestWebhook is not implemented"))
+}
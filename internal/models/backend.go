@@ -4,8 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"net/url"
 
 	"github.com/andrewstucki/protoc-states/workflows"
+	"github.com/microsoft/durabletask-go/api"
+	"github.com/microsoft/durabletask-go/backend"
 	"github.com/rs/zerolog"
 
 	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
@@ -14,6 +18,9 @@ import (
 type Backend struct {
 	*Queries
 	*workflows.WorkflowProcessor
+	// TaskHub manages the workflow instances run by the processor
+	TaskHub backend.TaskHubClient
+	taskHub backend.Backend
 	db      *sql.DB
 	cleanup func()
 }
@@ -21,13 +28,26 @@ type Backend struct {
 type BackendConfig struct {
 	Logger     zerolog.Logger
 	Persistent bool
-	Handler    playgroundv1.SendMessageStateWorkflowHandler
+	// DatabaseURL is the libsql database a persistent backend stores its
+	// state in, DefaultDatabaseURL when empty. A file: URL opens a local
+	// database file instead of a sqld server.
+	DatabaseURL string
+	Handler     playgroundv1.SendMessageStateWorkflowHandler
 }
 
+// DefaultDatabaseURL is where a sqld server started with default flags
+// listens.
+const DefaultDatabaseURL = "http://localhost:8080"
+
 func (c BackendConfig) validate() error {
 	if c.Handler == nil {
 		return errors.New("handler must not be nil")
 	}
+	if c.DatabaseURL != "" {
+		if _, _, _, err := databaseLocation(c.DatabaseURL); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -43,32 +63,96 @@ func NewBackend(config BackendConfig) (*Backend, error) {
 	var err error
 	var db *sql.DB
 	var cleanup func()
+	factory := workflows.BackendFactory(workflows.NewMemoryBackend)
 	if config.Persistent {
-		dbBuilder := workflows.NewLibSQLBackendBuilder()
+		databaseURL := config.DatabaseURL
+		if databaseURL == "" {
+			databaseURL = DefaultDatabaseURL
+		}
+		scheme, host, token, err := databaseLocation(databaseURL)
+		if err != nil {
+			return nil, err
+		}
+		dbBuilder := workflows.NewLibSQLBackendBuilder().WithScheme(scheme).WithHost(host).WithToken(token)
 		db, cleanup, err = dbBuilder.DB()
 		if err != nil {
 			return nil, err
 		}
-		builder.WithBackendFactory(dbBuilder.Build)
+		factory = dbBuilder.Build
 	} else {
 		db, cleanup, err = workflows.MemoryDB()
 		if err != nil {
 			return nil, err
 		}
+		// every connection to an in-memory database opens a database of its
+		// own, so the pool must never open a second one
+		db.SetMaxOpenConns(1)
 	}
 
-	if err := EnsureSchema(db); err != nil {
+	// keep hold of the task hub the processor runs on so that its instances
+	// can be inspected and managed
+	var taskHub backend.Backend
+	builder.WithBackendFactory(func(logger backend.Logger) backend.Backend {
+		taskHub = factory(logger)
+		return taskHub
+	})
+	processor := builder.Build()
+
+	if err := Migrate(context.Background(), db); err != nil {
 		return nil, err
 	}
 
 	return &Backend{
 		Queries:           New(db),
-		WorkflowProcessor: builder.Build(),
+		WorkflowProcessor: processor,
+		TaskHub:           backend.NewTaskHubClient(taskHub),
+		taskHub:           taskHub,
 		db:                db,
 		cleanup:           cleanup,
 	}, nil
 }
 
+// databaseLocation splits a database URL into the scheme, the host, or the
+// path of a file: URL, and the authToken query parameter that the libsql
+// backend builder takes.
+func databaseLocation(databaseURL string) (scheme, host, token string, err error) {
+	parsed, err := url.Parse(databaseURL)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid database URL: %w", err)
+	}
+	token = parsed.Query().Get("authToken")
+	switch parsed.Scheme {
+	case "file":
+		path := parsed.Path
+		if path == "" {
+			path = parsed.Opaque
+		}
+		if path == "" {
+			return "", "", "", fmt.Errorf("database URL %q has no path", databaseURL)
+		}
+		return "file", path, token, nil
+	case "http", "https", "libsql", "ws", "wss":
+		if parsed.Host == "" {
+			return "", "", "", fmt.Errorf("database URL %q has no host", databaseURL)
+		}
+		return parsed.Scheme, parsed.Host, token, nil
+	default:
+		return "", "", "", fmt.Errorf("database URL %q must be a file:, http(s)://, libsql:// or ws(s):// URL", databaseURL)
+	}
+}
+
+// WorkflowHistory returns the history of a workflow instance, oldest event
+// first.
+func (b *Backend) WorkflowHistory(ctx context.Context, id string) ([]*backend.HistoryEvent, error) {
+	state, err := b.taskHub.GetOrchestrationRuntimeState(ctx, &backend.OrchestrationWorkItem{
+		InstanceID: api.InstanceID(id),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return state.OldEvents(), nil
+}
+
 func (b *Backend) Tx(ctx context.Context) (*sql.Tx, *Queries, error) {
 	tx, err := b.db.BeginTx(ctx, nil)
 	if err != nil {
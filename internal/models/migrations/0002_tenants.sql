@@ -0,0 +1,246 @@
+-- every table became scoped to a tenant, so the baseline's rows move to the
+-- default tenant, and the tables added since are created
+
+ALTER TABLE messages RENAME TO messages_v1;
+
+CREATE TABLE messages (
+  tenant_id TEXT NOT NULL,
+  id   TEXT NOT NULL,
+  text TEXT    NOT NULL,
+  content_type TEXT NOT NULL DEFAULT 'PLAIN',
+  labels TEXT NOT NULL DEFAULT '{}',
+  payload BLOB,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (tenant_id, id)
+);
+
+-- rowids are kept since the full-text index refers to them
+INSERT INTO messages (rowid, tenant_id, id, text)
+SELECT rowid, 'default', id, text FROM messages_v1;
+
+DROP TABLE messages_v1;
+
+ALTER TABLE sent_messages RENAME TO sent_messages_v1;
+
+CREATE TABLE sent_messages (
+  tenant_id TEXT NOT NULL,
+  id TEXT NOT NULL,
+  message_id TEXT NOT NULL,
+  text TEXT NOT NULL,
+  result TEXT NOT NULL,
+  step TEXT NOT NULL DEFAULT '',
+  receipt_id TEXT,
+  redrive_of TEXT,
+  PRIMARY KEY (tenant_id, id)
+);
+
+INSERT INTO sent_messages (tenant_id, id, message_id, text, result)
+SELECT 'default', id, message_id, text, result FROM sent_messages_v1;
+
+DROP TABLE sent_messages_v1;
+
+CREATE TABLE tenants (
+  id TEXT PRIMARY KEY,
+  display_name TEXT NOT NULL DEFAULT '',
+  token_sha256 TEXT UNIQUE,
+  max_messages INTEGER NOT NULL DEFAULT 0,
+  max_sends INTEGER NOT NULL DEFAULT 0,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE operation_attempts (
+  tenant_id TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  step TEXT NOT NULL,
+  attempt INTEGER NOT NULL,
+  error TEXT,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (tenant_id, operation_id, step, attempt)
+);
+
+CREATE TABLE dead_letters (
+  tenant_id TEXT NOT NULL,
+  id TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  message_id TEXT NOT NULL,
+  step TEXT NOT NULL,
+  input TEXT NOT NULL,
+  last_error TEXT NOT NULL,
+  redrive_operation_id TEXT,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (tenant_id, id),
+  UNIQUE (tenant_id, operation_id)
+);
+
+CREATE TABLE operation_compensations (
+  tenant_id TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  step TEXT NOT NULL,
+  attempt INTEGER NOT NULL,
+  error TEXT,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (tenant_id, operation_id, step, attempt)
+);
+
+CREATE TABLE quota_reservations (
+  tenant_id TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  units INTEGER NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (tenant_id, operation_id)
+);
+
+CREATE TABLE billing_records (
+  tenant_id TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  amount INTEGER NOT NULL,
+  refunded BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (tenant_id, operation_id)
+);
+
+CREATE TABLE templates (
+  tenant_id TEXT NOT NULL,
+  id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  body TEXT NOT NULL,
+  variables TEXT NOT NULL,
+  PRIMARY KEY (tenant_id, id)
+);
+
+CREATE TABLE attachments (
+  tenant_id TEXT NOT NULL,
+  id TEXT NOT NULL,
+  message_id TEXT NOT NULL,
+  filename TEXT NOT NULL,
+  content_type TEXT NOT NULL,
+  size INTEGER NOT NULL,
+  sha256 TEXT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (tenant_id, id)
+);
+
+CREATE TABLE sent_message_attachments (
+  tenant_id TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  attachment_id TEXT NOT NULL,
+  PRIMARY KEY (tenant_id, operation_id, attachment_id)
+);
+
+CREATE TABLE events (
+  seq INTEGER PRIMARY KEY AUTOINCREMENT,
+  tenant_id TEXT NOT NULL,
+  id TEXT NOT NULL,
+  type TEXT NOT NULL,
+  subject TEXT NOT NULL,
+  data TEXT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (tenant_id, id)
+);
+
+CREATE INDEX events_tenant ON events (tenant_id, seq);
+
+CREATE TABLE recipients (
+  tenant_id TEXT NOT NULL,
+  id TEXT NOT NULL,
+  address TEXT NOT NULL,
+  channel TEXT NOT NULL,
+  PRIMARY KEY (tenant_id, id)
+);
+
+CREATE TABLE operation_recipients (
+  tenant_id TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  parent_id TEXT NOT NULL,
+  recipient_id TEXT NOT NULL,
+  address TEXT NOT NULL,
+  channel TEXT NOT NULL,
+  PRIMARY KEY (tenant_id, operation_id)
+);
+
+CREATE TABLE webhook_subscriptions (
+  tenant_id TEXT NOT NULL,
+  id TEXT NOT NULL,
+  url TEXT NOT NULL,
+  states TEXT NOT NULL,
+  secret TEXT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (tenant_id, id)
+);
+
+CREATE TABLE webhook_deliveries (
+  tenant_id TEXT NOT NULL,
+  id TEXT NOT NULL,
+  subscription_id TEXT NOT NULL,
+  event_id TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  state TEXT NOT NULL,
+  attempts INTEGER NOT NULL DEFAULT 0,
+  last_status_code INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT NOT NULL DEFAULT '',
+  next_attempt_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (tenant_id, id)
+);
+
+CREATE INDEX webhook_deliveries_due ON webhook_deliveries (state, next_attempt_at);
+
+CREATE VIRTUAL TABLE messages_fts USING fts5(
+  text,
+  content='messages',
+  content_rowid='rowid'
+);
+
+CREATE TRIGGER messages_fts_insert AFTER INSERT ON messages BEGIN
+  INSERT INTO messages_fts (rowid, text) VALUES (new.rowid, new.text);
+END;
+
+CREATE TRIGGER messages_fts_delete AFTER DELETE ON messages BEGIN
+  INSERT INTO messages_fts (messages_fts, rowid, text) VALUES ('delete', old.rowid, old.text);
+END;
+
+CREATE TRIGGER messages_fts_update AFTER UPDATE ON messages BEGIN
+  INSERT INTO messages_fts (messages_fts, rowid, text) VALUES ('delete', old.rowid, old.text);
+  INSERT INTO messages_fts (rowid, text) VALUES (new.rowid, new.text);
+END;
+
+-- audit_log is append-only: rows are written once, in the same transaction as
+-- the change they describe where there is one, and never updated or deleted
+CREATE TABLE audit_log (
+  seq INTEGER PRIMARY KEY AUTOINCREMENT,
+  id TEXT NOT NULL UNIQUE,
+  tenant_id TEXT NOT NULL,
+  principal TEXT NOT NULL,
+  procedure TEXT NOT NULL,
+  resource_id TEXT NOT NULL,
+  request_digest TEXT NOT NULL,
+  outcome TEXT NOT NULL,
+  peer TEXT NOT NULL,
+  created_at DATETIME NOT NULL
+);
+
+CREATE INDEX audit_log_created ON audit_log (created_at);
+
+CREATE TRIGGER audit_log_no_update BEFORE UPDATE ON audit_log BEGIN
+  SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
+
+CREATE TRIGGER audit_log_no_delete BEFORE DELETE ON audit_log BEGIN
+  SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
+
+-- workflow_instances indexes the durable workflow instances started for send
+-- operations, since the task hub itself can't be listed
+CREATE TABLE workflow_instances (
+  instance_id TEXT PRIMARY KEY,
+  tenant_id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX workflow_instances_operation ON workflow_instances (tenant_id, operation_id);
+
+INSERT INTO messages_fts (messages_fts) VALUES ('rebuild');
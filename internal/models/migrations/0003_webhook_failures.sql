@@ -0,0 +1,5 @@
+-- webhook subscriptions count their failed delivery attempts in a row, so
+-- that one whose receiver keeps failing can be disabled
+
+ALTER TABLE webhook_subscriptions ADD COLUMN consecutive_failures INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE webhook_subscriptions ADD COLUMN disabled_at DATETIME;
@@ -0,0 +1,36 @@
+-- a call whose handler committed its audit record can still fail afterwards,
+-- which is recorded by a second record of the failure that refers to the
+-- first. The log is append-only, so its table is rebuilt with the reference
+-- rather than altered.
+
+ALTER TABLE audit_log RENAME TO audit_log_v1;
+
+CREATE TABLE audit_log (
+  seq INTEGER PRIMARY KEY AUTOINCREMENT,
+  id TEXT NOT NULL UNIQUE,
+  tenant_id TEXT NOT NULL,
+  principal TEXT NOT NULL,
+  procedure TEXT NOT NULL,
+  resource_id TEXT NOT NULL,
+  request_digest TEXT NOT NULL,
+  outcome TEXT NOT NULL,
+  peer TEXT NOT NULL,
+  created_at DATETIME NOT NULL,
+  original_id TEXT NOT NULL DEFAULT ''
+);
+
+-- offsets are kept since readers resume listing the log after them
+INSERT INTO audit_log (seq, id, tenant_id, principal, procedure, resource_id, request_digest, outcome, peer, created_at)
+SELECT seq, id, tenant_id, principal, procedure, resource_id, request_digest, outcome, peer, created_at FROM audit_log_v1;
+
+DROP TABLE audit_log_v1;
+
+CREATE INDEX audit_log_created ON audit_log (created_at);
+
+CREATE TRIGGER audit_log_no_update BEFORE UPDATE ON audit_log BEGIN
+  SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
+
+CREATE TRIGGER audit_log_no_delete BEFORE DELETE ON audit_log BEGIN
+  SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
@@ -0,0 +1,22 @@
+-- attempts are counted per workflow run, so that retrying a workflow starts
+-- its steps over at the first attempt. The attempts made so far belong to an
+-- unnamed run.
+
+ALTER TABLE operation_attempts RENAME TO operation_attempts_v1;
+
+CREATE TABLE operation_attempts (
+  tenant_id TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  run_id TEXT NOT NULL DEFAULT '',
+  step TEXT NOT NULL,
+  attempt INTEGER NOT NULL,
+  error TEXT,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (tenant_id, operation_id, run_id, step, attempt)
+);
+
+-- rowids are kept since attempts are listed in rowid order
+INSERT INTO operation_attempts (rowid, tenant_id, operation_id, step, attempt, error, created_at)
+SELECT rowid, tenant_id, operation_id, step, attempt, error, created_at FROM operation_attempts_v1;
+
+DROP TABLE operation_attempts_v1;
@@ -0,0 +1,11 @@
+-- workflows are recorded as pending in the transaction that needs them run,
+-- and forgotten once they are scheduled, so that the give-up sweep retries
+-- those that fail to be scheduled
+
+CREATE TABLE pending_workflows (
+  tenant_id TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  input TEXT NOT NULL,
+  created_at DATETIME NOT NULL,
+  PRIMARY KEY (tenant_id, operation_id)
+);
@@ -4,14 +4,198 @@
 
 package models
 
+import (
+	"database/sql"
+	"time"
+)
+
+type Attachment struct {
+	TenantID    string
+	ID          string
+	MessageID   string
+	Filename    string
+	ContentType string
+	Size        int64
+	Sha256      string
+	CreatedAt   time.Time
+}
+
+type AuditLog struct {
+	Seq           int64
+	ID            string
+	TenantID      string
+	Principal     string
+	Procedure     string
+	ResourceID    string
+	RequestDigest string
+	Outcome       string
+	Peer          string
+	CreatedAt     time.Time
+	OriginalID    string
+}
+
+type BillingRecord struct {
+	TenantID    string
+	OperationID string
+	Amount      int64
+	Refunded    bool
+	CreatedAt   time.Time
+}
+
+type DeadLetter struct {
+	TenantID           string
+	ID                 string
+	OperationID        string
+	MessageID          string
+	Step               string
+	Input              string
+	LastError          string
+	RedriveOperationID sql.NullString
+	CreatedAt          time.Time
+}
+
+type Event struct {
+	Seq       int64
+	TenantID  string
+	ID        string
+	Type      string
+	Subject   string
+	Data      string
+	CreatedAt time.Time
+}
+
 type Message struct {
-	ID   string
+	TenantID    string
+	ID          string
+	Text        string
+	ContentType string
+	Labels      string
+	Payload     []byte
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type MessagesFt struct {
 	Text string
 }
 
+type OperationAttempt struct {
+	TenantID    string
+	OperationID string
+	RunID       string
+	Step        string
+	Attempt     int64
+	Error       sql.NullString
+	CreatedAt   time.Time
+}
+
+type OperationCompensation struct {
+	TenantID    string
+	OperationID string
+	Step        string
+	Attempt     int64
+	Error       sql.NullString
+	CreatedAt   time.Time
+}
+
+type OperationRecipient struct {
+	TenantID    string
+	OperationID string
+	ParentID    string
+	RecipientID string
+	Address     string
+	Channel     string
+}
+
+type PendingWorkflow struct {
+	TenantID    string
+	OperationID string
+	Input       string
+	CreatedAt   time.Time
+}
+
+type QuotaReservation struct {
+	TenantID    string
+	OperationID string
+	Units       int64
+	CreatedAt   time.Time
+}
+
+type Recipient struct {
+	TenantID string
+	ID       string
+	Address  string
+	Channel  string
+}
+
+type SchemaVersion struct {
+	Version int64
+}
+
 type SentMessage struct {
+	TenantID  string
 	ID        string
 	MessageID string
 	Text      string
 	Result    string
+	Step      string
+	ReceiptID sql.NullString
+	RedriveOf sql.NullString
+}
+
+type SentMessageAttachment struct {
+	TenantID     string
+	OperationID  string
+	AttachmentID string
+}
+
+type Template struct {
+	TenantID  string
+	ID        string
+	Name      string
+	Body      string
+	Variables string
+}
+
+type Tenant struct {
+	ID          string
+	DisplayName string
+	TokenSha256 sql.NullString
+	MaxMessages int64
+	MaxSends    int64
+	CreatedAt   time.Time
+}
+
+type WebhookDelivery struct {
+	TenantID       string
+	ID             string
+	SubscriptionID string
+	EventID        string
+	OperationID    string
+	State          string
+	Attempts       int64
+	LastStatusCode int64
+	LastError      string
+	NextAttemptAt  time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type WebhookSubscription struct {
+	TenantID            string
+	ID                  string
+	Url                 string
+	States              string
+	Secret              string
+	CreatedAt           time.Time
+	ConsecutiveFailures int64
+	DisabledAt          sql.NullTime
+}
+
+type WorkflowInstance struct {
+	InstanceID  string
+	TenantID    string
+	Name        string
+	OperationID string
+	CreatedAt   time.Time
 }
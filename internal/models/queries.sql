@@ -1,40 +1,525 @@
+-- name: GetTenant :one
+SELECT * FROM tenants
+WHERE id = ? LIMIT 1;
+
+-- name: GetTenantByToken :one
+SELECT * FROM tenants
+WHERE token_sha256 = ? LIMIT 1;
+
+-- name: ListTenants :many
+SELECT * FROM tenants
+ORDER BY id;
+
+-- name: CreateTenant :one
+INSERT INTO tenants (
+  id, display_name, token_sha256, max_messages, max_sends
+) VALUES (
+  ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: UpdateTenant :one
+UPDATE tenants
+set display_name = ?, max_messages = ?, max_sends = ?
+WHERE id = ?
+RETURNING *;
+
+-- name: CountMessages :one
+SELECT COUNT(*) FROM messages
+WHERE tenant_id = ?;
+
+-- name: SumQuotaReservations :one
+SELECT CAST(COALESCE(SUM(units), 0) AS INTEGER) FROM quota_reservations
+WHERE tenant_id = ?;
+
 -- name: GetMessage :one
 SELECT * FROM messages
-WHERE id = ? LIMIT 1;
+WHERE tenant_id = ? AND id = ? LIMIT 1;
 
 -- name: ListMessages :many
-SELECT * FROM messages;
+SELECT * FROM messages
+WHERE tenant_id = ?;
 
 -- name: CreateMessage :one
 INSERT INTO messages (
-  id, text
+  tenant_id, id, text, content_type, labels, payload
 ) VALUES (
-  ?, ?
+  ?, ?, ?, ?, ?, ?
 )
 RETURNING *;
 
+-- name: UpdateMessage :one
+UPDATE messages
+set text = ?, content_type = ?, labels = ?, payload = ?, updated_at = CURRENT_TIMESTAMP
+WHERE tenant_id = ? AND id = ?
+RETURNING *;
+
 -- name: DeleteMessage :exec
 DELETE FROM messages
-WHERE id = ?;
+WHERE tenant_id = ? AND id = ?;
 
 -- name: GetSentMessage :one
 SELECT * FROM sent_messages
-WHERE id = ? AND message_id = ? LIMIT 1;
+WHERE tenant_id = ? AND id = ? AND message_id = ? LIMIT 1;
 
 -- name: GetSentMessageByID :one
 SELECT * FROM sent_messages
-WHERE id = ? LIMIT 1;
+WHERE tenant_id = ? AND id = ? LIMIT 1;
 
 -- name: CreateSentMessage :one
 INSERT INTO sent_messages (
-  id, message_id, text, result
+  tenant_id, id, message_id, text, result, redrive_of
 ) VALUES (
-  ?, ?, ?, ?
+  ?, ?, ?, ?, ?, ?
 )
 RETURNING *;
 
 -- name: UpdateSentMessage :one
 UPDATE sent_messages
 set result = ?
-WHERE id = ?
-RETURNING *;
\ No newline at end of file
+WHERE tenant_id = ? AND id = ?
+RETURNING *;
+
+-- name: UpdateSentMessageStep :exec
+UPDATE sent_messages
+set step = ?
+WHERE tenant_id = ? AND id = ?;
+
+-- name: UpdateSentMessageText :exec
+UPDATE sent_messages
+set text = ?
+WHERE tenant_id = ? AND id = ?;
+
+-- name: UpdateSentMessageReceipt :exec
+UPDATE sent_messages
+set receipt_id = ?
+WHERE tenant_id = ? AND id = ?;
+
+-- name: CountOperationAttempts :one
+SELECT COUNT(*) FROM operation_attempts
+WHERE tenant_id = ? AND operation_id = ? AND run_id = ? AND step = ?;
+
+-- name: CreateOperationAttempt :one
+INSERT INTO operation_attempts (
+  tenant_id, operation_id, run_id, step, attempt, error
+) VALUES (
+  ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: ListOperationAttempts :many
+-- created_at only has second precision, so the rowid keeps attempts in the
+-- order they were made
+SELECT * FROM operation_attempts
+WHERE tenant_id = ? AND operation_id = ?
+ORDER BY created_at, rowid;
+
+-- name: GetDeadLetter :one
+SELECT * FROM dead_letters
+WHERE tenant_id = ? AND id = ? LIMIT 1;
+
+-- name: ListDeadLetters :many
+SELECT * FROM dead_letters
+WHERE tenant_id = ?
+ORDER BY created_at;
+
+-- name: ListDeadLettersByMessage :many
+SELECT * FROM dead_letters
+WHERE tenant_id = ? AND message_id = ?
+ORDER BY created_at;
+
+-- name: CreateDeadLetter :one
+INSERT INTO dead_letters (
+  tenant_id, id, operation_id, message_id, step, input, last_error
+) VALUES (
+  ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: UpdateDeadLetterRedrive :one
+-- a dead letter is only redriven once
+UPDATE dead_letters
+set redrive_operation_id = ?
+WHERE tenant_id = ? AND id = ? AND redrive_operation_id IS NULL
+RETURNING *;
+
+-- name: DeleteDeadLetter :execrows
+DELETE FROM dead_letters
+WHERE tenant_id = ? AND id = ?;
+
+-- name: DeleteDeadLetters :execrows
+DELETE FROM dead_letters
+WHERE tenant_id = ?;
+
+-- name: CreatePendingWorkflow :exec
+INSERT INTO pending_workflows (
+  tenant_id, operation_id, input, created_at
+) VALUES (
+  ?, ?, ?, ?
+)
+ON CONFLICT (tenant_id, operation_id) DO NOTHING;
+
+-- name: ListPendingWorkflows :many
+-- the workflows recorded before the given time that are left to schedule,
+-- across every tenant
+SELECT * FROM pending_workflows
+WHERE created_at < sqlc.arg(before)
+ORDER BY created_at;
+
+-- name: DeletePendingWorkflow :exec
+DELETE FROM pending_workflows
+WHERE tenant_id = ? AND operation_id = ?;
+
+-- name: CountOperationCompensations :one
+SELECT COUNT(*) FROM operation_compensations
+WHERE tenant_id = ? AND operation_id = ? AND step = ?;
+
+-- name: CreateOperationCompensation :one
+INSERT INTO operation_compensations (
+  tenant_id, operation_id, step, attempt, error
+) VALUES (
+  ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: ListOperationCompensations :many
+SELECT * FROM operation_compensations
+WHERE tenant_id = ? AND operation_id = ?
+ORDER BY created_at, rowid;
+
+-- name: CreateQuotaReservation :exec
+INSERT INTO quota_reservations (
+  tenant_id, operation_id, units
+) VALUES (
+  ?, ?, ?
+)
+ON CONFLICT (tenant_id, operation_id) DO NOTHING;
+
+-- name: GetQuotaReservation :one
+SELECT * FROM quota_reservations
+WHERE tenant_id = ? AND operation_id = ? LIMIT 1;
+
+-- name: DeleteQuotaReservation :exec
+DELETE FROM quota_reservations
+WHERE tenant_id = ? AND operation_id = ?;
+
+-- name: CreateBillingRecord :exec
+INSERT INTO billing_records (
+  tenant_id, operation_id, amount
+) VALUES (
+  ?, ?, ?
+)
+ON CONFLICT (tenant_id, operation_id) DO NOTHING;
+
+-- name: RefundBillingRecord :exec
+UPDATE billing_records
+set refunded = TRUE
+WHERE tenant_id = ? AND operation_id = ?;
+
+-- name: GetTemplate :one
+SELECT * FROM templates
+WHERE tenant_id = ? AND id = ? LIMIT 1;
+
+-- name: ListTemplates :many
+SELECT * FROM templates
+WHERE tenant_id = ?;
+
+-- name: CreateTemplate :one
+INSERT INTO templates (
+  tenant_id, id, name, body, variables
+) VALUES (
+  ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: UpdateTemplate :one
+UPDATE templates
+set name = ?, body = ?, variables = ?
+WHERE tenant_id = ? AND id = ?
+RETURNING *;
+
+-- name: DeleteTemplate :execrows
+DELETE FROM templates
+WHERE tenant_id = ? AND id = ?;
+
+-- name: GetRecipient :one
+SELECT * FROM recipients
+WHERE tenant_id = ? AND id = ? LIMIT 1;
+
+-- name: ListRecipients :many
+SELECT * FROM recipients
+WHERE tenant_id = ?;
+
+-- name: CreateRecipient :one
+INSERT INTO recipients (
+  tenant_id, id, address, channel
+) VALUES (
+  ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: DeleteRecipient :execrows
+DELETE FROM recipients
+WHERE tenant_id = ? AND id = ?;
+
+-- name: CreateOperationRecipient :one
+INSERT INTO operation_recipients (
+  tenant_id, operation_id, parent_id, recipient_id, address, channel
+) VALUES (
+  ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: GetOperationRecipient :one
+SELECT * FROM operation_recipients
+WHERE tenant_id = ? AND operation_id = ? LIMIT 1;
+
+-- name: ListChildOperations :many
+-- operations that were redriven are replaced by their redrive
+SELECT sqlc.embed(operation_recipients), sqlc.embed(sent_messages) FROM operation_recipients
+JOIN sent_messages ON sent_messages.tenant_id = operation_recipients.tenant_id
+  AND sent_messages.id = operation_recipients.operation_id
+WHERE operation_recipients.tenant_id = ? AND operation_recipients.parent_id = ?
+AND NOT EXISTS (
+  SELECT 1 FROM sent_messages AS redrives
+  WHERE redrives.tenant_id = sent_messages.tenant_id AND redrives.redrive_of = sent_messages.id
+)
+ORDER BY sent_messages.rowid;
+
+-- name: GetAttachment :one
+SELECT * FROM attachments
+WHERE tenant_id = ? AND id = ? AND message_id = ? LIMIT 1;
+
+-- name: ListAttachments :many
+SELECT * FROM attachments
+WHERE tenant_id = ? AND message_id = ?
+ORDER BY created_at, id;
+
+-- name: CreateAttachment :one
+INSERT INTO attachments (
+  tenant_id, id, message_id, filename, content_type, size, sha256
+) VALUES (
+  ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: CountSendingAttachmentOperations :one
+-- counts the operations in the given state that include the message's
+-- attachments, or just the one named by attachment_id when it is set
+SELECT COUNT(DISTINCT sent_message_attachments.operation_id) FROM sent_message_attachments
+JOIN attachments ON attachments.tenant_id = sent_message_attachments.tenant_id
+  AND attachments.id = sent_message_attachments.attachment_id
+JOIN sent_messages ON sent_messages.tenant_id = sent_message_attachments.tenant_id
+  AND sent_messages.id = sent_message_attachments.operation_id
+WHERE attachments.tenant_id = sqlc.arg(tenant_id) AND attachments.message_id = sqlc.arg(message_id)
+  AND (CAST(sqlc.arg(attachment_id) AS TEXT) = '' OR attachments.id = sqlc.arg(attachment_id))
+  AND sent_messages.result = sqlc.arg(state);
+
+-- name: DeleteSentMessageAttachments :exec
+-- unlinks the message's attachments from the operations that sent them, or
+-- just the one named by attachment_id when it is set
+DELETE FROM sent_message_attachments
+WHERE sent_message_attachments.tenant_id = sqlc.arg(tenant_id) AND sent_message_attachments.attachment_id IN (
+  SELECT attachments.id FROM attachments
+  WHERE attachments.tenant_id = sqlc.arg(tenant_id) AND attachments.message_id = sqlc.arg(message_id)
+    AND (CAST(sqlc.arg(attachment_id) AS TEXT) = '' OR attachments.id = sqlc.arg(attachment_id))
+);
+
+-- name: DeleteAttachments :execrows
+-- deletes the message's attachments, or just the one named by attachment_id
+-- when it is set
+DELETE FROM attachments
+WHERE tenant_id = sqlc.arg(tenant_id) AND message_id = sqlc.arg(message_id)
+  AND (CAST(sqlc.arg(attachment_id) AS TEXT) = '' OR id = sqlc.arg(attachment_id));
+
+-- name: AttachMessageAttachments :exec
+-- includes every attachment of the message in the operation
+INSERT INTO sent_message_attachments (
+  tenant_id, operation_id, attachment_id
+)
+SELECT attachments.tenant_id, sqlc.arg(operation_id), attachments.id FROM attachments
+WHERE attachments.tenant_id = sqlc.arg(tenant_id) AND attachments.message_id = sqlc.arg(message_id);
+
+-- name: CopySentMessageAttachments :exec
+INSERT INTO sent_message_attachments (
+  tenant_id, operation_id, attachment_id
+)
+SELECT sent_message_attachments.tenant_id, sqlc.arg(operation_id), sent_message_attachments.attachment_id FROM sent_message_attachments
+WHERE sent_message_attachments.tenant_id = sqlc.arg(tenant_id)
+  AND sent_message_attachments.operation_id = sqlc.arg(source_operation_id);
+
+-- name: ListSentMessageAttachments :many
+SELECT attachments.* FROM sent_message_attachments
+JOIN attachments ON attachments.tenant_id = sent_message_attachments.tenant_id
+  AND attachments.id = sent_message_attachments.attachment_id
+WHERE sent_message_attachments.tenant_id = ? AND sent_message_attachments.operation_id = ?
+ORDER BY attachments.created_at, attachments.id;
+
+-- name: SearchMessages :many
+SELECT sqlc.embed(messages),
+  CAST(snippet(messages_fts, 0, sqlc.arg(highlight_start), sqlc.arg(highlight_end), '...', 16) AS TEXT) AS snippet,
+  CAST(bm25(messages_fts) AS REAL) AS score
+FROM messages_fts
+JOIN messages ON messages.rowid = messages_fts.rowid
+WHERE messages_fts.text MATCH sqlc.arg(query) AND messages.tenant_id = sqlc.arg(tenant_id)
+ORDER BY score, messages.id
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: CreateEvent :one
+INSERT INTO events (
+  tenant_id, id, type, subject, data
+) VALUES (
+  ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: ListEvents :many
+SELECT * FROM events
+WHERE tenant_id = ? AND seq > ?
+ORDER BY seq
+LIMIT ?;
+
+-- name: ListEventsByType :many
+SELECT * FROM events
+WHERE tenant_id = sqlc.arg(tenant_id) AND seq > sqlc.arg(after_seq) AND type IN (sqlc.slice(types))
+ORDER BY seq
+LIMIT sqlc.arg(limit);
+
+-- name: GetEvent :one
+SELECT * FROM events
+WHERE tenant_id = ? AND id = ? LIMIT 1;
+
+-- name: GetWebhookSubscription :one
+SELECT * FROM webhook_subscriptions
+WHERE tenant_id = ? AND id = ? LIMIT 1;
+
+-- name: ListWebhookSubscriptions :many
+SELECT * FROM webhook_subscriptions
+WHERE tenant_id = ?
+ORDER BY created_at, id;
+
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (
+  tenant_id, id, url, states, secret
+) VALUES (
+  ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscriptions
+set url = ?, states = ?, secret = ?, consecutive_failures = ?, disabled_at = ?
+WHERE tenant_id = ? AND id = ?
+RETURNING *;
+
+-- name: RecordWebhookSuccess :exec
+UPDATE webhook_subscriptions
+set consecutive_failures = 0
+WHERE tenant_id = ? AND id = ?;
+
+-- name: RecordWebhookFailure :one
+-- counts a failed attempt, disabling the subscription once disable_after
+-- attempts in a row have failed
+UPDATE webhook_subscriptions
+set consecutive_failures = consecutive_failures + 1,
+  disabled_at = CASE
+    WHEN disabled_at IS NULL AND consecutive_failures + 1 >= sqlc.arg(disable_after) THEN sqlc.arg(now)
+    ELSE disabled_at
+  END
+WHERE tenant_id = sqlc.arg(tenant_id) AND id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscriptions
+WHERE tenant_id = ? AND id = ?;
+
+-- name: DeleteWebhookDeliveries :exec
+DELETE FROM webhook_deliveries
+WHERE tenant_id = ? AND subscription_id = ?;
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (
+  tenant_id, id, subscription_id, event_id, operation_id, state, next_attempt_at
+) VALUES (
+  ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING *;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM webhook_deliveries
+WHERE tenant_id = ? AND subscription_id = ?
+ORDER BY created_at DESC, rowid DESC;
+
+-- name: ListDueWebhookDeliveries :many
+-- the delivery worker serves every tenant, taking the earliest due delivery
+-- of each enabled subscription so that deliveries to one receiver are made in
+-- order and never hold up those to another
+SELECT webhook_deliveries.* FROM webhook_deliveries
+JOIN webhook_subscriptions ON webhook_subscriptions.tenant_id = webhook_deliveries.tenant_id
+  AND webhook_subscriptions.id = webhook_deliveries.subscription_id
+WHERE webhook_deliveries.state = sqlc.arg(state) AND webhook_deliveries.next_attempt_at <= sqlc.arg(now)
+  AND webhook_subscriptions.disabled_at IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM webhook_deliveries earlier
+    WHERE earlier.tenant_id = webhook_deliveries.tenant_id
+      AND earlier.subscription_id = webhook_deliveries.subscription_id
+      AND earlier.state = webhook_deliveries.state
+      AND (earlier.next_attempt_at < webhook_deliveries.next_attempt_at
+        OR (earlier.next_attempt_at = webhook_deliveries.next_attempt_at AND earlier.rowid < webhook_deliveries.rowid))
+  )
+ORDER BY webhook_deliveries.next_attempt_at, webhook_deliveries.rowid
+LIMIT sqlc.arg(limit);
+
+-- name: UpdateWebhookDelivery :one
+UPDATE webhook_deliveries
+set state = ?, attempts = ?, last_status_code = ?, last_error = ?, next_attempt_at = ?, updated_at = CURRENT_TIMESTAMP
+WHERE tenant_id = ? AND id = ?
+RETURNING *;
+
+-- name: CreateAuditEvent :execrows
+INSERT INTO audit_log (
+  id, tenant_id, principal, procedure, resource_id, request_digest, outcome, peer, created_at, original_id
+) VALUES (
+  ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT (id) DO NOTHING;
+
+-- name: ListAuditEvents :many
+SELECT * FROM audit_log
+WHERE seq > sqlc.arg(after_seq)
+  AND created_at >= sqlc.arg(start_time)
+  AND created_at < sqlc.arg(end_time)
+  AND (CAST(sqlc.arg(tenant_id) AS TEXT) = '' OR tenant_id = sqlc.arg(tenant_id))
+ORDER BY seq
+LIMIT sqlc.arg(limit);
+
+-- name: CreateWorkflowInstance :exec
+INSERT INTO workflow_instances (
+  instance_id, tenant_id, name, operation_id
+) VALUES (
+  ?, ?, ?, ?
+);
+
+-- name: GetWorkflowInstance :one
+SELECT * FROM workflow_instances
+WHERE instance_id = ? LIMIT 1;
+
+-- name: ListWorkflowInstances :many
+SELECT * FROM workflow_instances
+WHERE CAST(sqlc.arg(name) AS TEXT) = '' OR name = sqlc.arg(name)
+ORDER BY created_at DESC, rowid DESC;
+
+-- name: ListLatestWorkflowInstances :many
+-- the latest instance of every operation still in the given state, which
+-- the give-up sweep checks across every tenant
+SELECT w.* FROM workflow_instances w
+JOIN sent_messages s ON s.tenant_id = w.tenant_id AND s.id = w.operation_id
+WHERE s.result = sqlc.arg(state) AND NOT EXISTS (
+  SELECT 1 FROM workflow_instances l
+  WHERE l.tenant_id = w.tenant_id AND l.operation_id = w.operation_id AND l.rowid > w.rowid
+)
+ORDER BY w.rowid;
+
+-- name: DeleteWorkflowInstance :exec
+DELETE FROM workflow_instances
+WHERE instance_id = ?;
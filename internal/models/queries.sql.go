@@ -7,137 +7,2318 @@ package models
 
 import (
 	"context"
+	"database/sql"
+	"strings"
+	"time"
 )
 
+const attachMessageAttachments = `-- name: AttachMessageAttachments :exec
+INSERT INTO sent_message_attachments (
+  tenant_id, operation_id, attachment_id
+)
+SELECT attachments.tenant_id, ?1, attachments.id FROM attachments
+WHERE attachments.tenant_id = ?2 AND attachments.message_id = ?3
+`
+
+type AttachMessageAttachmentsParams struct {
+	OperationID string
+	TenantID    string
+	MessageID   string
+}
+
+// includes every attachment of the message in the operation
+func (q *Queries) AttachMessageAttachments(ctx context.Context, arg AttachMessageAttachmentsParams) error {
+	_, err := q.db.ExecContext(ctx, attachMessageAttachments, arg.OperationID, arg.TenantID, arg.MessageID)
+	return err
+}
+
+const copySentMessageAttachments = `-- name: CopySentMessageAttachments :exec
+INSERT INTO sent_message_attachments (
+  tenant_id, operation_id, attachment_id
+)
+SELECT sent_message_attachments.tenant_id, ?1, sent_message_attachments.attachment_id FROM sent_message_attachments
+WHERE sent_message_attachments.tenant_id = ?2
+  AND sent_message_attachments.operation_id = ?3
+`
+
+type CopySentMessageAttachmentsParams struct {
+	OperationID       string
+	TenantID          string
+	SourceOperationID string
+}
+
+func (q *Queries) CopySentMessageAttachments(ctx context.Context, arg CopySentMessageAttachmentsParams) error {
+	_, err := q.db.ExecContext(ctx, copySentMessageAttachments, arg.OperationID, arg.TenantID, arg.SourceOperationID)
+	return err
+}
+
+const countMessages = `-- name: CountMessages :one
+SELECT COUNT(*) FROM messages
+WHERE tenant_id = ?
+`
+
+func (q *Queries) CountMessages(ctx context.Context, tenantID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countMessages, tenantID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countOperationAttempts = `-- name: CountOperationAttempts :one
+SELECT COUNT(*) FROM operation_attempts
+WHERE tenant_id = ? AND operation_id = ? AND run_id = ? AND step = ?
+`
+
+type CountOperationAttemptsParams struct {
+	TenantID    string
+	OperationID string
+	RunID       string
+	Step        string
+}
+
+func (q *Queries) CountOperationAttempts(ctx context.Context, arg CountOperationAttemptsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countOperationAttempts,
+		arg.TenantID,
+		arg.OperationID,
+		arg.RunID,
+		arg.Step,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countOperationCompensations = `-- name: CountOperationCompensations :one
+SELECT COUNT(*) FROM operation_compensations
+WHERE tenant_id = ? AND operation_id = ? AND step = ?
+`
+
+type CountOperationCompensationsParams struct {
+	TenantID    string
+	OperationID string
+	Step        string
+}
+
+func (q *Queries) CountOperationCompensations(ctx context.Context, arg CountOperationCompensationsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countOperationCompensations, arg.TenantID, arg.OperationID, arg.Step)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countSendingAttachmentOperations = `-- name: CountSendingAttachmentOperations :one
+SELECT COUNT(DISTINCT sent_message_attachments.operation_id) FROM sent_message_attachments
+JOIN attachments ON attachments.tenant_id = sent_message_attachments.tenant_id
+  AND attachments.id = sent_message_attachments.attachment_id
+JOIN sent_messages ON sent_messages.tenant_id = sent_message_attachments.tenant_id
+  AND sent_messages.id = sent_message_attachments.operation_id
+WHERE attachments.tenant_id = ?1 AND attachments.message_id = ?2
+  AND (CAST(?3 AS TEXT) = '' OR attachments.id = ?3)
+  AND sent_messages.result = ?4
+`
+
+type CountSendingAttachmentOperationsParams struct {
+	TenantID     string
+	MessageID    string
+	AttachmentID string
+	State        string
+}
+
+// counts the operations in the given state that include the message's
+// attachments, or just the one named by attachment_id when it is set
+func (q *Queries) CountSendingAttachmentOperations(ctx context.Context, arg CountSendingAttachmentOperationsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSendingAttachmentOperations,
+		arg.TenantID,
+		arg.MessageID,
+		arg.AttachmentID,
+		arg.State,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAttachment = `-- name: CreateAttachment :one
+INSERT INTO attachments (
+  tenant_id, id, message_id, filename, content_type, size, sha256
+) VALUES (
+  ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING tenant_id, id, message_id, filename, content_type, size, sha256, created_at
+`
+
+type CreateAttachmentParams struct {
+	TenantID    string
+	ID          string
+	MessageID   string
+	Filename    string
+	ContentType string
+	Size        int64
+	Sha256      string
+}
+
+func (q *Queries) CreateAttachment(ctx context.Context, arg CreateAttachmentParams) (Attachment, error) {
+	row := q.db.QueryRowContext(ctx, createAttachment,
+		arg.TenantID,
+		arg.ID,
+		arg.MessageID,
+		arg.Filename,
+		arg.ContentType,
+		arg.Size,
+		arg.Sha256,
+	)
+	var i Attachment
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.MessageID,
+		&i.Filename,
+		&i.ContentType,
+		&i.Size,
+		&i.Sha256,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createAuditEvent = `-- name: CreateAuditEvent :execrows
+INSERT INTO audit_log (
+  id, tenant_id, principal, procedure, resource_id, request_digest, outcome, peer, created_at, original_id
+) VALUES (
+  ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+)
+ON CONFLICT (id) DO NOTHING
+`
+
+type CreateAuditEventParams struct {
+	ID            string
+	TenantID      string
+	Principal     string
+	Procedure     string
+	ResourceID    string
+	RequestDigest string
+	Outcome       string
+	Peer          string
+	CreatedAt     time.Time
+	OriginalID    string
+}
+
+func (q *Queries) CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createAuditEvent,
+		arg.ID,
+		arg.TenantID,
+		arg.Principal,
+		arg.Procedure,
+		arg.ResourceID,
+		arg.RequestDigest,
+		arg.Outcome,
+		arg.Peer,
+		arg.CreatedAt,
+		arg.OriginalID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const createBillingRecord = `-- name: CreateBillingRecord :exec
+INSERT INTO billing_records (
+  tenant_id, operation_id, amount
+) VALUES (
+  ?, ?, ?
+)
+ON CONFLICT (tenant_id, operation_id) DO NOTHING
+`
+
+type CreateBillingRecordParams struct {
+	TenantID    string
+	OperationID string
+	Amount      int64
+}
+
+func (q *Queries) CreateBillingRecord(ctx context.Context, arg CreateBillingRecordParams) error {
+	_, err := q.db.ExecContext(ctx, createBillingRecord, arg.TenantID, arg.OperationID, arg.Amount)
+	return err
+}
+
+const createDeadLetter = `-- name: CreateDeadLetter :one
+INSERT INTO dead_letters (
+  tenant_id, id, operation_id, message_id, step, input, last_error
+) VALUES (
+  ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING tenant_id, id, operation_id, message_id, step, input, last_error, redrive_operation_id, created_at
+`
+
+type CreateDeadLetterParams struct {
+	TenantID    string
+	ID          string
+	OperationID string
+	MessageID   string
+	Step        string
+	Input       string
+	LastError   string
+}
+
+func (q *Queries) CreateDeadLetter(ctx context.Context, arg CreateDeadLetterParams) (DeadLetter, error) {
+	row := q.db.QueryRowContext(ctx, createDeadLetter,
+		arg.TenantID,
+		arg.ID,
+		arg.OperationID,
+		arg.MessageID,
+		arg.Step,
+		arg.Input,
+		arg.LastError,
+	)
+	var i DeadLetter
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.OperationID,
+		&i.MessageID,
+		&i.Step,
+		&i.Input,
+		&i.LastError,
+		&i.RedriveOperationID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createEvent = `-- name: CreateEvent :one
+INSERT INTO events (
+  tenant_id, id, type, subject, data
+) VALUES (
+  ?, ?, ?, ?, ?
+)
+RETURNING seq, tenant_id, id, type, subject, data, created_at
+`
+
+type CreateEventParams struct {
+	TenantID string
+	ID       string
+	Type     string
+	Subject  string
+	Data     string
+}
+
+func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) (Event, error) {
+	row := q.db.QueryRowContext(ctx, createEvent,
+		arg.TenantID,
+		arg.ID,
+		arg.Type,
+		arg.Subject,
+		arg.Data,
+	)
+	var i Event
+	err := row.Scan(
+		&i.Seq,
+		&i.TenantID,
+		&i.ID,
+		&i.Type,
+		&i.Subject,
+		&i.Data,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const createMessage = `-- name: CreateMessage :one
 INSERT INTO messages (
-  id, text
+  tenant_id, id, text, content_type, labels, payload
 ) VALUES (
-  ?, ?
+  ?, ?, ?, ?, ?, ?
 )
-RETURNING id, text
+RETURNING tenant_id, id, text, content_type, labels, payload, created_at, updated_at
 `
 
 type CreateMessageParams struct {
-	ID   string
-	Text string
+	TenantID    string
+	ID          string
+	Text        string
+	ContentType string
+	Labels      string
+	Payload     []byte
 }
 
 func (q *Queries) CreateMessage(ctx context.Context, arg CreateMessageParams) (Message, error) {
-	row := q.db.QueryRowContext(ctx, createMessage, arg.ID, arg.Text)
+	row := q.db.QueryRowContext(ctx, createMessage,
+		arg.TenantID,
+		arg.ID,
+		arg.Text,
+		arg.ContentType,
+		arg.Labels,
+		arg.Payload,
+	)
 	var i Message
-	err := row.Scan(&i.ID, &i.Text)
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.Text,
+		&i.ContentType,
+		&i.Labels,
+		&i.Payload,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createOperationAttempt = `-- name: CreateOperationAttempt :one
+INSERT INTO operation_attempts (
+  tenant_id, operation_id, run_id, step, attempt, error
+) VALUES (
+  ?, ?, ?, ?, ?, ?
+)
+RETURNING tenant_id, operation_id, run_id, step, attempt, error, created_at
+`
+
+type CreateOperationAttemptParams struct {
+	TenantID    string
+	OperationID string
+	RunID       string
+	Step        string
+	Attempt     int64
+	Error       sql.NullString
+}
+
+func (q *Queries) CreateOperationAttempt(ctx context.Context, arg CreateOperationAttemptParams) (OperationAttempt, error) {
+	row := q.db.QueryRowContext(ctx, createOperationAttempt,
+		arg.TenantID,
+		arg.OperationID,
+		arg.RunID,
+		arg.Step,
+		arg.Attempt,
+		arg.Error,
+	)
+	var i OperationAttempt
+	err := row.Scan(
+		&i.TenantID,
+		&i.OperationID,
+		&i.RunID,
+		&i.Step,
+		&i.Attempt,
+		&i.Error,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createOperationCompensation = `-- name: CreateOperationCompensation :one
+INSERT INTO operation_compensations (
+  tenant_id, operation_id, step, attempt, error
+) VALUES (
+  ?, ?, ?, ?, ?
+)
+RETURNING tenant_id, operation_id, step, attempt, error, created_at
+`
+
+type CreateOperationCompensationParams struct {
+	TenantID    string
+	OperationID string
+	Step        string
+	Attempt     int64
+	Error       sql.NullString
+}
+
+func (q *Queries) CreateOperationCompensation(ctx context.Context, arg CreateOperationCompensationParams) (OperationCompensation, error) {
+	row := q.db.QueryRowContext(ctx, createOperationCompensation,
+		arg.TenantID,
+		arg.OperationID,
+		arg.Step,
+		arg.Attempt,
+		arg.Error,
+	)
+	var i OperationCompensation
+	err := row.Scan(
+		&i.TenantID,
+		&i.OperationID,
+		&i.Step,
+		&i.Attempt,
+		&i.Error,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createOperationRecipient = `-- name: CreateOperationRecipient :one
+INSERT INTO operation_recipients (
+  tenant_id, operation_id, parent_id, recipient_id, address, channel
+) VALUES (
+  ?, ?, ?, ?, ?, ?
+)
+RETURNING tenant_id, operation_id, parent_id, recipient_id, address, channel
+`
+
+type CreateOperationRecipientParams struct {
+	TenantID    string
+	OperationID string
+	ParentID    string
+	RecipientID string
+	Address     string
+	Channel     string
+}
+
+func (q *Queries) CreateOperationRecipient(ctx context.Context, arg CreateOperationRecipientParams) (OperationRecipient, error) {
+	row := q.db.QueryRowContext(ctx, createOperationRecipient,
+		arg.TenantID,
+		arg.OperationID,
+		arg.ParentID,
+		arg.RecipientID,
+		arg.Address,
+		arg.Channel,
+	)
+	var i OperationRecipient
+	err := row.Scan(
+		&i.TenantID,
+		&i.OperationID,
+		&i.ParentID,
+		&i.RecipientID,
+		&i.Address,
+		&i.Channel,
+	)
+	return i, err
+}
+
+const createPendingWorkflow = `-- name: CreatePendingWorkflow :exec
+INSERT INTO pending_workflows (
+  tenant_id, operation_id, input, created_at
+) VALUES (
+  ?, ?, ?, ?
+)
+ON CONFLICT (tenant_id, operation_id) DO NOTHING
+`
+
+type CreatePendingWorkflowParams struct {
+	TenantID    string
+	OperationID string
+	Input       string
+	CreatedAt   time.Time
+}
+
+func (q *Queries) CreatePendingWorkflow(ctx context.Context, arg CreatePendingWorkflowParams) error {
+	_, err := q.db.ExecContext(ctx, createPendingWorkflow,
+		arg.TenantID,
+		arg.OperationID,
+		arg.Input,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createQuotaReservation = `-- name: CreateQuotaReservation :exec
+INSERT INTO quota_reservations (
+  tenant_id, operation_id, units
+) VALUES (
+  ?, ?, ?
+)
+ON CONFLICT (tenant_id, operation_id) DO NOTHING
+`
+
+type CreateQuotaReservationParams struct {
+	TenantID    string
+	OperationID string
+	Units       int64
+}
+
+func (q *Queries) CreateQuotaReservation(ctx context.Context, arg CreateQuotaReservationParams) error {
+	_, err := q.db.ExecContext(ctx, createQuotaReservation, arg.TenantID, arg.OperationID, arg.Units)
+	return err
+}
+
+const createRecipient = `-- name: CreateRecipient :one
+INSERT INTO recipients (
+  tenant_id, id, address, channel
+) VALUES (
+  ?, ?, ?, ?
+)
+RETURNING tenant_id, id, address, channel
+`
+
+type CreateRecipientParams struct {
+	TenantID string
+	ID       string
+	Address  string
+	Channel  string
+}
+
+func (q *Queries) CreateRecipient(ctx context.Context, arg CreateRecipientParams) (Recipient, error) {
+	row := q.db.QueryRowContext(ctx, createRecipient,
+		arg.TenantID,
+		arg.ID,
+		arg.Address,
+		arg.Channel,
+	)
+	var i Recipient
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.Address,
+		&i.Channel,
+	)
 	return i, err
 }
 
 const createSentMessage = `-- name: CreateSentMessage :one
 INSERT INTO sent_messages (
-  id, message_id, text, result
+  tenant_id, id, message_id, text, result, redrive_of
 ) VALUES (
-  ?, ?, ?, ?
+  ?, ?, ?, ?, ?, ?
 )
-RETURNING id, message_id, text, result
+RETURNING tenant_id, id, message_id, text, result, step, receipt_id, redrive_of
 `
 
 type CreateSentMessageParams struct {
+	TenantID  string
 	ID        string
 	MessageID string
 	Text      string
 	Result    string
+	RedriveOf sql.NullString
 }
 
 func (q *Queries) CreateSentMessage(ctx context.Context, arg CreateSentMessageParams) (SentMessage, error) {
 	row := q.db.QueryRowContext(ctx, createSentMessage,
+		arg.TenantID,
 		arg.ID,
 		arg.MessageID,
 		arg.Text,
 		arg.Result,
+		arg.RedriveOf,
 	)
 	var i SentMessage
 	err := row.Scan(
+		&i.TenantID,
 		&i.ID,
 		&i.MessageID,
 		&i.Text,
 		&i.Result,
+		&i.Step,
+		&i.ReceiptID,
+		&i.RedriveOf,
 	)
 	return i, err
 }
 
-const deleteMessage = `-- name: DeleteMessage :exec
-DELETE FROM messages
-WHERE id = ?
+const createTemplate = `-- name: CreateTemplate :one
+INSERT INTO templates (
+  tenant_id, id, name, body, variables
+) VALUES (
+  ?, ?, ?, ?, ?
+)
+RETURNING tenant_id, id, name, body, variables
 `
 
-func (q *Queries) DeleteMessage(ctx context.Context, id string) error {
-	_, err := q.db.ExecContext(ctx, deleteMessage, id)
-	return err
+type CreateTemplateParams struct {
+	TenantID  string
+	ID        string
+	Name      string
+	Body      string
+	Variables string
 }
 
-const getMessage = `-- name: GetMessage :one
-SELECT id, text FROM messages
-WHERE id = ? LIMIT 1
+func (q *Queries) CreateTemplate(ctx context.Context, arg CreateTemplateParams) (Template, error) {
+	row := q.db.QueryRowContext(ctx, createTemplate,
+		arg.TenantID,
+		arg.ID,
+		arg.Name,
+		arg.Body,
+		arg.Variables,
+	)
+	var i Template
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.Name,
+		&i.Body,
+		&i.Variables,
+	)
+	return i, err
+}
+
+const createTenant = `-- name: CreateTenant :one
+INSERT INTO tenants (
+  id, display_name, token_sha256, max_messages, max_sends
+) VALUES (
+  ?, ?, ?, ?, ?
+)
+RETURNING id, display_name, token_sha256, max_messages, max_sends, created_at
 `
 
-func (q *Queries) GetMessage(ctx context.Context, id string) (Message, error) {
-	row := q.db.QueryRowContext(ctx, getMessage, id)
-	var i Message
-	err := row.Scan(&i.ID, &i.Text)
+type CreateTenantParams struct {
+	ID          string
+	DisplayName string
+	TokenSha256 sql.NullString
+	MaxMessages int64
+	MaxSends    int64
+}
+
+func (q *Queries) CreateTenant(ctx context.Context, arg CreateTenantParams) (Tenant, error) {
+	row := q.db.QueryRowContext(ctx, createTenant,
+		arg.ID,
+		arg.DisplayName,
+		arg.TokenSha256,
+		arg.MaxMessages,
+		arg.MaxSends,
+	)
+	var i Tenant
+	err := row.Scan(
+		&i.ID,
+		&i.DisplayName,
+		&i.TokenSha256,
+		&i.MaxMessages,
+		&i.MaxSends,
+		&i.CreatedAt,
+	)
 	return i, err
 }
 
-const getSentMessage = `-- name: GetSentMessage :one
-SELECT id, message_id, text, result FROM sent_messages
-WHERE id = ? AND message_id = ? LIMIT 1
+const createWebhookDelivery = `-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (
+  tenant_id, id, subscription_id, event_id, operation_id, state, next_attempt_at
+) VALUES (
+  ?, ?, ?, ?, ?, ?, ?
+)
+RETURNING tenant_id, id, subscription_id, event_id, operation_id, state, attempts, last_status_code, last_error, next_attempt_at, created_at, updated_at
 `
 
-type GetSentMessageParams struct {
-	ID        string
-	MessageID string
+type CreateWebhookDeliveryParams struct {
+	TenantID       string
+	ID             string
+	SubscriptionID string
+	EventID        string
+	OperationID    string
+	State          string
+	NextAttemptAt  time.Time
 }
 
-func (q *Queries) GetSentMessage(ctx context.Context, arg GetSentMessageParams) (SentMessage, error) {
-	row := q.db.QueryRowContext(ctx, getSentMessage, arg.ID, arg.MessageID)
-	var i SentMessage
+func (q *Queries) CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error) {
+	row := q.db.QueryRowContext(ctx, createWebhookDelivery,
+		arg.TenantID,
+		arg.ID,
+		arg.SubscriptionID,
+		arg.EventID,
+		arg.OperationID,
+		arg.State,
+		arg.NextAttemptAt,
+	)
+	var i WebhookDelivery
 	err := row.Scan(
+		&i.TenantID,
 		&i.ID,
-		&i.MessageID,
-		&i.Text,
-		&i.Result,
+		&i.SubscriptionID,
+		&i.EventID,
+		&i.OperationID,
+		&i.State,
+		&i.Attempts,
+		&i.LastStatusCode,
+		&i.LastError,
+		&i.NextAttemptAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const getSentMessageByID = `-- name: GetSentMessageByID :one
-SELECT id, message_id, text, result FROM sent_messages
-WHERE id = ? LIMIT 1
+const createWebhookSubscription = `-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (
+  tenant_id, id, url, states, secret
+) VALUES (
+  ?, ?, ?, ?, ?
+)
+RETURNING tenant_id, id, url, states, secret, created_at, consecutive_failures, disabled_at
 `
 
-func (q *Queries) GetSentMessageByID(ctx context.Context, id string) (SentMessage, error) {
-	row := q.db.QueryRowContext(ctx, getSentMessageByID, id)
-	var i SentMessage
+type CreateWebhookSubscriptionParams struct {
+	TenantID string
+	ID       string
+	Url      string
+	States   string
+	Secret   string
+}
+
+func (q *Queries) CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error) {
+	row := q.db.QueryRowContext(ctx, createWebhookSubscription,
+		arg.TenantID,
+		arg.ID,
+		arg.Url,
+		arg.States,
+		arg.Secret,
+	)
+	var i WebhookSubscription
 	err := row.Scan(
+		&i.TenantID,
 		&i.ID,
-		&i.MessageID,
-		&i.Text,
-		&i.Result,
+		&i.Url,
+		&i.States,
+		&i.Secret,
+		&i.CreatedAt,
+		&i.ConsecutiveFailures,
+		&i.DisabledAt,
 	)
 	return i, err
 }
 
-const listMessages = `-- name: ListMessages :many
-SELECT id, text FROM messages
+const createWorkflowInstance = `-- name: CreateWorkflowInstance :exec
+INSERT INTO workflow_instances (
+  instance_id, tenant_id, name, operation_id
+) VALUES (
+  ?, ?, ?, ?
+)
+`
+
+type CreateWorkflowInstanceParams struct {
+	InstanceID  string
+	TenantID    string
+	Name        string
+	OperationID string
+}
+
+func (q *Queries) CreateWorkflowInstance(ctx context.Context, arg CreateWorkflowInstanceParams) error {
+	_, err := q.db.ExecContext(ctx, createWorkflowInstance,
+		arg.InstanceID,
+		arg.TenantID,
+		arg.Name,
+		arg.OperationID,
+	)
+	return err
+}
+
+const deleteAttachments = `-- name: DeleteAttachments :execrows
+DELETE FROM attachments
+WHERE tenant_id = ?1 AND message_id = ?2
+  AND (CAST(?3 AS TEXT) = '' OR id = ?3)
 `
 
-func (q *Queries) ListMessages(ctx context.Context) ([]Message, error) {
-	rows, err := q.db.QueryContext(ctx, listMessages)
+type DeleteAttachmentsParams struct {
+	TenantID     string
+	MessageID    string
+	AttachmentID string
+}
+
+// deletes the message's attachments, or just the one named by attachment_id
+// when it is set
+func (q *Queries) DeleteAttachments(ctx context.Context, arg DeleteAttachmentsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAttachments, arg.TenantID, arg.MessageID, arg.AttachmentID)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer rows.Close()
-	var items []Message
+	return result.RowsAffected()
+}
+
+const deleteDeadLetter = `-- name: DeleteDeadLetter :execrows
+DELETE FROM dead_letters
+WHERE tenant_id = ? AND id = ?
+`
+
+type DeleteDeadLetterParams struct {
+	TenantID string
+	ID       string
+}
+
+func (q *Queries) DeleteDeadLetter(ctx context.Context, arg DeleteDeadLetterParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteDeadLetter, arg.TenantID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteDeadLetters = `-- name: DeleteDeadLetters :execrows
+DELETE FROM dead_letters
+WHERE tenant_id = ?
+`
+
+func (q *Queries) DeleteDeadLetters(ctx context.Context, tenantID string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteDeadLetters, tenantID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteMessage = `-- name: DeleteMessage :exec
+DELETE FROM messages
+WHERE tenant_id = ? AND id = ?
+`
+
+type DeleteMessageParams struct {
+	TenantID string
+	ID       string
+}
+
+func (q *Queries) DeleteMessage(ctx context.Context, arg DeleteMessageParams) error {
+	_, err := q.db.ExecContext(ctx, deleteMessage, arg.TenantID, arg.ID)
+	return err
+}
+
+const deletePendingWorkflow = `-- name: DeletePendingWorkflow :exec
+DELETE FROM pending_workflows
+WHERE tenant_id = ? AND operation_id = ?
+`
+
+type DeletePendingWorkflowParams struct {
+	TenantID    string
+	OperationID string
+}
+
+func (q *Queries) DeletePendingWorkflow(ctx context.Context, arg DeletePendingWorkflowParams) error {
+	_, err := q.db.ExecContext(ctx, deletePendingWorkflow, arg.TenantID, arg.OperationID)
+	return err
+}
+
+const deleteQuotaReservation = `-- name: DeleteQuotaReservation :exec
+DELETE FROM quota_reservations
+WHERE tenant_id = ? AND operation_id = ?
+`
+
+type DeleteQuotaReservationParams struct {
+	TenantID    string
+	OperationID string
+}
+
+func (q *Queries) DeleteQuotaReservation(ctx context.Context, arg DeleteQuotaReservationParams) error {
+	_, err := q.db.ExecContext(ctx, deleteQuotaReservation, arg.TenantID, arg.OperationID)
+	return err
+}
+
+const deleteRecipient = `-- name: DeleteRecipient :execrows
+DELETE FROM recipients
+WHERE tenant_id = ? AND id = ?
+`
+
+type DeleteRecipientParams struct {
+	TenantID string
+	ID       string
+}
+
+func (q *Queries) DeleteRecipient(ctx context.Context, arg DeleteRecipientParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteRecipient, arg.TenantID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteSentMessageAttachments = `-- name: DeleteSentMessageAttachments :exec
+DELETE FROM sent_message_attachments
+WHERE sent_message_attachments.tenant_id = ?1 AND sent_message_attachments.attachment_id IN (
+  SELECT attachments.id FROM attachments
+  WHERE attachments.tenant_id = ?1 AND attachments.message_id = ?2
+    AND (CAST(?3 AS TEXT) = '' OR attachments.id = ?3)
+)
+`
+
+type DeleteSentMessageAttachmentsParams struct {
+	TenantID     string
+	MessageID    string
+	AttachmentID string
+}
+
+// unlinks the message's attachments from the operations that sent them, or
+// just the one named by attachment_id when it is set
+func (q *Queries) DeleteSentMessageAttachments(ctx context.Context, arg DeleteSentMessageAttachmentsParams) error {
+	_, err := q.db.ExecContext(ctx, deleteSentMessageAttachments, arg.TenantID, arg.MessageID, arg.AttachmentID)
+	return err
+}
+
+const deleteTemplate = `-- name: DeleteTemplate :execrows
+DELETE FROM templates
+WHERE tenant_id = ? AND id = ?
+`
+
+type DeleteTemplateParams struct {
+	TenantID string
+	ID       string
+}
+
+func (q *Queries) DeleteTemplate(ctx context.Context, arg DeleteTemplateParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteTemplate, arg.TenantID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteWebhookDeliveries = `-- name: DeleteWebhookDeliveries :exec
+DELETE FROM webhook_deliveries
+WHERE tenant_id = ? AND subscription_id = ?
+`
+
+type DeleteWebhookDeliveriesParams struct {
+	TenantID       string
+	SubscriptionID string
+}
+
+func (q *Queries) DeleteWebhookDeliveries(ctx context.Context, arg DeleteWebhookDeliveriesParams) error {
+	_, err := q.db.ExecContext(ctx, deleteWebhookDeliveries, arg.TenantID, arg.SubscriptionID)
+	return err
+}
+
+const deleteWebhookSubscription = `-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscriptions
+WHERE tenant_id = ? AND id = ?
+`
+
+type DeleteWebhookSubscriptionParams struct {
+	TenantID string
+	ID       string
+}
+
+func (q *Queries) DeleteWebhookSubscription(ctx context.Context, arg DeleteWebhookSubscriptionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteWebhookSubscription, arg.TenantID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteWorkflowInstance = `-- name: DeleteWorkflowInstance :exec
+DELETE FROM workflow_instances
+WHERE instance_id = ?
+`
+
+func (q *Queries) DeleteWorkflowInstance(ctx context.Context, instanceID string) error {
+	_, err := q.db.ExecContext(ctx, deleteWorkflowInstance, instanceID)
+	return err
+}
+
+const getAttachment = `-- name: GetAttachment :one
+SELECT tenant_id, id, message_id, filename, content_type, size, sha256, created_at FROM attachments
+WHERE tenant_id = ? AND id = ? AND message_id = ? LIMIT 1
+`
+
+type GetAttachmentParams struct {
+	TenantID  string
+	ID        string
+	MessageID string
+}
+
+func (q *Queries) GetAttachment(ctx context.Context, arg GetAttachmentParams) (Attachment, error) {
+	row := q.db.QueryRowContext(ctx, getAttachment, arg.TenantID, arg.ID, arg.MessageID)
+	var i Attachment
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.MessageID,
+		&i.Filename,
+		&i.ContentType,
+		&i.Size,
+		&i.Sha256,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getDeadLetter = `-- name: GetDeadLetter :one
+SELECT tenant_id, id, operation_id, message_id, step, input, last_error, redrive_operation_id, created_at FROM dead_letters
+WHERE tenant_id = ? AND id = ? LIMIT 1
+`
+
+type GetDeadLetterParams struct {
+	TenantID string
+	ID       string
+}
+
+func (q *Queries) GetDeadLetter(ctx context.Context, arg GetDeadLetterParams) (DeadLetter, error) {
+	row := q.db.QueryRowContext(ctx, getDeadLetter, arg.TenantID, arg.ID)
+	var i DeadLetter
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.OperationID,
+		&i.MessageID,
+		&i.Step,
+		&i.Input,
+		&i.LastError,
+		&i.RedriveOperationID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getEvent = `-- name: GetEvent :one
+SELECT seq, tenant_id, id, type, subject, data, created_at FROM events
+WHERE tenant_id = ? AND id = ? LIMIT 1
+`
+
+type GetEventParams struct {
+	TenantID string
+	ID       string
+}
+
+func (q *Queries) GetEvent(ctx context.Context, arg GetEventParams) (Event, error) {
+	row := q.db.QueryRowContext(ctx, getEvent, arg.TenantID, arg.ID)
+	var i Event
+	err := row.Scan(
+		&i.Seq,
+		&i.TenantID,
+		&i.ID,
+		&i.Type,
+		&i.Subject,
+		&i.Data,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getMessage = `-- name: GetMessage :one
+SELECT tenant_id, id, text, content_type, labels, payload, created_at, updated_at FROM messages
+WHERE tenant_id = ? AND id = ? LIMIT 1
+`
+
+type GetMessageParams struct {
+	TenantID string
+	ID       string
+}
+
+func (q *Queries) GetMessage(ctx context.Context, arg GetMessageParams) (Message, error) {
+	row := q.db.QueryRowContext(ctx, getMessage, arg.TenantID, arg.ID)
+	var i Message
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.Text,
+		&i.ContentType,
+		&i.Labels,
+		&i.Payload,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getOperationRecipient = `-- name: GetOperationRecipient :one
+SELECT tenant_id, operation_id, parent_id, recipient_id, address, channel FROM operation_recipients
+WHERE tenant_id = ? AND operation_id = ? LIMIT 1
+`
+
+type GetOperationRecipientParams struct {
+	TenantID    string
+	OperationID string
+}
+
+func (q *Queries) GetOperationRecipient(ctx context.Context, arg GetOperationRecipientParams) (OperationRecipient, error) {
+	row := q.db.QueryRowContext(ctx, getOperationRecipient, arg.TenantID, arg.OperationID)
+	var i OperationRecipient
+	err := row.Scan(
+		&i.TenantID,
+		&i.OperationID,
+		&i.ParentID,
+		&i.RecipientID,
+		&i.Address,
+		&i.Channel,
+	)
+	return i, err
+}
+
+const getQuotaReservation = `-- name: GetQuotaReservation :one
+SELECT tenant_id, operation_id, units, created_at FROM quota_reservations
+WHERE tenant_id = ? AND operation_id = ? LIMIT 1
+`
+
+type GetQuotaReservationParams struct {
+	TenantID    string
+	OperationID string
+}
+
+func (q *Queries) GetQuotaReservation(ctx context.Context, arg GetQuotaReservationParams) (QuotaReservation, error) {
+	row := q.db.QueryRowContext(ctx, getQuotaReservation, arg.TenantID, arg.OperationID)
+	var i QuotaReservation
+	err := row.Scan(
+		&i.TenantID,
+		&i.OperationID,
+		&i.Units,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getRecipient = `-- name: GetRecipient :one
+SELECT tenant_id, id, address, channel FROM recipients
+WHERE tenant_id = ? AND id = ? LIMIT 1
+`
+
+type GetRecipientParams struct {
+	TenantID string
+	ID       string
+}
+
+func (q *Queries) GetRecipient(ctx context.Context, arg GetRecipientParams) (Recipient, error) {
+	row := q.db.QueryRowContext(ctx, getRecipient, arg.TenantID, arg.ID)
+	var i Recipient
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.Address,
+		&i.Channel,
+	)
+	return i, err
+}
+
+const getSentMessage = `-- name: GetSentMessage :one
+SELECT tenant_id, id, message_id, text, result, step, receipt_id, redrive_of FROM sent_messages
+WHERE tenant_id = ? AND id = ? AND message_id = ? LIMIT 1
+`
+
+type GetSentMessageParams struct {
+	TenantID  string
+	ID        string
+	MessageID string
+}
+
+func (q *Queries) GetSentMessage(ctx context.Context, arg GetSentMessageParams) (SentMessage, error) {
+	row := q.db.QueryRowContext(ctx, getSentMessage, arg.TenantID, arg.ID, arg.MessageID)
+	var i SentMessage
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.MessageID,
+		&i.Text,
+		&i.Result,
+		&i.Step,
+		&i.ReceiptID,
+		&i.RedriveOf,
+	)
+	return i, err
+}
+
+const getSentMessageByID = `-- name: GetSentMessageByID :one
+SELECT tenant_id, id, message_id, text, result, step, receipt_id, redrive_of FROM sent_messages
+WHERE tenant_id = ? AND id = ? LIMIT 1
+`
+
+type GetSentMessageByIDParams struct {
+	TenantID string
+	ID       string
+}
+
+func (q *Queries) GetSentMessageByID(ctx context.Context, arg GetSentMessageByIDParams) (SentMessage, error) {
+	row := q.db.QueryRowContext(ctx, getSentMessageByID, arg.TenantID, arg.ID)
+	var i SentMessage
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.MessageID,
+		&i.Text,
+		&i.Result,
+		&i.Step,
+		&i.ReceiptID,
+		&i.RedriveOf,
+	)
+	return i, err
+}
+
+const getTemplate = `-- name: GetTemplate :one
+SELECT tenant_id, id, name, body, variables FROM templates
+WHERE tenant_id = ? AND id = ? LIMIT 1
+`
+
+type GetTemplateParams struct {
+	TenantID string
+	ID       string
+}
+
+func (q *Queries) GetTemplate(ctx context.Context, arg GetTemplateParams) (Template, error) {
+	row := q.db.QueryRowContext(ctx, getTemplate, arg.TenantID, arg.ID)
+	var i Template
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.Name,
+		&i.Body,
+		&i.Variables,
+	)
+	return i, err
+}
+
+const getTenant = `-- name: GetTenant :one
+SELECT id, display_name, token_sha256, max_messages, max_sends, created_at FROM tenants
+WHERE id = ? LIMIT 1
+`
+
+func (q *Queries) GetTenant(ctx context.Context, id string) (Tenant, error) {
+	row := q.db.QueryRowContext(ctx, getTenant, id)
+	var i Tenant
+	err := row.Scan(
+		&i.ID,
+		&i.DisplayName,
+		&i.TokenSha256,
+		&i.MaxMessages,
+		&i.MaxSends,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTenantByToken = `-- name: GetTenantByToken :one
+SELECT id, display_name, token_sha256, max_messages, max_sends, created_at FROM tenants
+WHERE token_sha256 = ? LIMIT 1
+`
+
+func (q *Queries) GetTenantByToken(ctx context.Context, tokenSha256 sql.NullString) (Tenant, error) {
+	row := q.db.QueryRowContext(ctx, getTenantByToken, tokenSha256)
+	var i Tenant
+	err := row.Scan(
+		&i.ID,
+		&i.DisplayName,
+		&i.TokenSha256,
+		&i.MaxMessages,
+		&i.MaxSends,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getWebhookSubscription = `-- name: GetWebhookSubscription :one
+SELECT tenant_id, id, url, states, secret, created_at, consecutive_failures, disabled_at FROM webhook_subscriptions
+WHERE tenant_id = ? AND id = ? LIMIT 1
+`
+
+type GetWebhookSubscriptionParams struct {
+	TenantID string
+	ID       string
+}
+
+func (q *Queries) GetWebhookSubscription(ctx context.Context, arg GetWebhookSubscriptionParams) (WebhookSubscription, error) {
+	row := q.db.QueryRowContext(ctx, getWebhookSubscription, arg.TenantID, arg.ID)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.Url,
+		&i.States,
+		&i.Secret,
+		&i.CreatedAt,
+		&i.ConsecutiveFailures,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const getWorkflowInstance = `-- name: GetWorkflowInstance :one
+SELECT instance_id, tenant_id, name, operation_id, created_at FROM workflow_instances
+WHERE instance_id = ? LIMIT 1
+`
+
+func (q *Queries) GetWorkflowInstance(ctx context.Context, instanceID string) (WorkflowInstance, error) {
+	row := q.db.QueryRowContext(ctx, getWorkflowInstance, instanceID)
+	var i WorkflowInstance
+	err := row.Scan(
+		&i.InstanceID,
+		&i.TenantID,
+		&i.Name,
+		&i.OperationID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAttachments = `-- name: ListAttachments :many
+SELECT tenant_id, id, message_id, filename, content_type, size, sha256, created_at FROM attachments
+WHERE tenant_id = ? AND message_id = ?
+ORDER BY created_at, id
+`
+
+type ListAttachmentsParams struct {
+	TenantID  string
+	MessageID string
+}
+
+func (q *Queries) ListAttachments(ctx context.Context, arg ListAttachmentsParams) ([]Attachment, error) {
+	rows, err := q.db.QueryContext(ctx, listAttachments, arg.TenantID, arg.MessageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attachment
+	for rows.Next() {
+		var i Attachment
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.ID,
+			&i.MessageID,
+			&i.Filename,
+			&i.ContentType,
+			&i.Size,
+			&i.Sha256,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAuditEvents = `-- name: ListAuditEvents :many
+SELECT seq, id, tenant_id, principal, procedure, resource_id, request_digest, outcome, peer, created_at, original_id FROM audit_log
+WHERE seq > ?1
+  AND created_at >= ?2
+  AND created_at < ?3
+  AND (CAST(?4 AS TEXT) = '' OR tenant_id = ?4)
+ORDER BY seq
+LIMIT ?5
+`
+
+type ListAuditEventsParams struct {
+	AfterSeq  int64
+	StartTime time.Time
+	EndTime   time.Time
+	TenantID  string
+	Limit     int64
+}
+
+func (q *Queries) ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditLog, error) {
+	rows, err := q.db.QueryContext(ctx, listAuditEvents,
+		arg.AfterSeq,
+		arg.StartTime,
+		arg.EndTime,
+		arg.TenantID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AuditLog
+	for rows.Next() {
+		var i AuditLog
+		if err := rows.Scan(
+			&i.Seq,
+			&i.ID,
+			&i.TenantID,
+			&i.Principal,
+			&i.Procedure,
+			&i.ResourceID,
+			&i.RequestDigest,
+			&i.Outcome,
+			&i.Peer,
+			&i.CreatedAt,
+			&i.OriginalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listChildOperations = `-- name: ListChildOperations :many
+SELECT operation_recipients.tenant_id, operation_recipients.operation_id, operation_recipients.parent_id, operation_recipients.recipient_id, operation_recipients.address, operation_recipients.channel, sent_messages.tenant_id, sent_messages.id, sent_messages.message_id, sent_messages.text, sent_messages.result, sent_messages.step, sent_messages.receipt_id, sent_messages.redrive_of FROM operation_recipients
+JOIN sent_messages ON sent_messages.tenant_id = operation_recipients.tenant_id
+  AND sent_messages.id = operation_recipients.operation_id
+WHERE operation_recipients.tenant_id = ? AND operation_recipients.parent_id = ?
+AND NOT EXISTS (
+  SELECT 1 FROM sent_messages AS redrives
+  WHERE redrives.tenant_id = sent_messages.tenant_id AND redrives.redrive_of = sent_messages.id
+)
+ORDER BY sent_messages.rowid
+`
+
+type ListChildOperationsParams struct {
+	TenantID string
+	ParentID string
+}
+
+type ListChildOperationsRow struct {
+	OperationRecipient OperationRecipient
+	SentMessage        SentMessage
+}
+
+// operations that were redriven are replaced by their redrive
+func (q *Queries) ListChildOperations(ctx context.Context, arg ListChildOperationsParams) ([]ListChildOperationsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listChildOperations, arg.TenantID, arg.ParentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListChildOperationsRow
+	for rows.Next() {
+		var i ListChildOperationsRow
+		if err := rows.Scan(
+			&i.OperationRecipient.TenantID,
+			&i.OperationRecipient.OperationID,
+			&i.OperationRecipient.ParentID,
+			&i.OperationRecipient.RecipientID,
+			&i.OperationRecipient.Address,
+			&i.OperationRecipient.Channel,
+			&i.SentMessage.TenantID,
+			&i.SentMessage.ID,
+			&i.SentMessage.MessageID,
+			&i.SentMessage.Text,
+			&i.SentMessage.Result,
+			&i.SentMessage.Step,
+			&i.SentMessage.ReceiptID,
+			&i.SentMessage.RedriveOf,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDeadLetters = `-- name: ListDeadLetters :many
+SELECT tenant_id, id, operation_id, message_id, step, input, last_error, redrive_operation_id, created_at FROM dead_letters
+WHERE tenant_id = ?
+ORDER BY created_at
+`
+
+func (q *Queries) ListDeadLetters(ctx context.Context, tenantID string) ([]DeadLetter, error) {
+	rows, err := q.db.QueryContext(ctx, listDeadLetters, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DeadLetter
+	for rows.Next() {
+		var i DeadLetter
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.ID,
+			&i.OperationID,
+			&i.MessageID,
+			&i.Step,
+			&i.Input,
+			&i.LastError,
+			&i.RedriveOperationID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDeadLettersByMessage = `-- name: ListDeadLettersByMessage :many
+SELECT tenant_id, id, operation_id, message_id, step, input, last_error, redrive_operation_id, created_at FROM dead_letters
+WHERE tenant_id = ? AND message_id = ?
+ORDER BY created_at
+`
+
+type ListDeadLettersByMessageParams struct {
+	TenantID  string
+	MessageID string
+}
+
+func (q *Queries) ListDeadLettersByMessage(ctx context.Context, arg ListDeadLettersByMessageParams) ([]DeadLetter, error) {
+	rows, err := q.db.QueryContext(ctx, listDeadLettersByMessage, arg.TenantID, arg.MessageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []DeadLetter
+	for rows.Next() {
+		var i DeadLetter
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.ID,
+			&i.OperationID,
+			&i.MessageID,
+			&i.Step,
+			&i.Input,
+			&i.LastError,
+			&i.RedriveOperationID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDueWebhookDeliveries = `-- name: ListDueWebhookDeliveries :many
+SELECT webhook_deliveries.tenant_id, webhook_deliveries.id, webhook_deliveries.subscription_id, webhook_deliveries.event_id, webhook_deliveries.operation_id, webhook_deliveries.state, webhook_deliveries.attempts, webhook_deliveries.last_status_code, webhook_deliveries.last_error, webhook_deliveries.next_attempt_at, webhook_deliveries.created_at, webhook_deliveries.updated_at FROM webhook_deliveries
+JOIN webhook_subscriptions ON webhook_subscriptions.tenant_id = webhook_deliveries.tenant_id
+  AND webhook_subscriptions.id = webhook_deliveries.subscription_id
+WHERE webhook_deliveries.state = ?1 AND webhook_deliveries.next_attempt_at <= ?2
+  AND webhook_subscriptions.disabled_at IS NULL
+  AND NOT EXISTS (
+    SELECT 1 FROM webhook_deliveries earlier
+    WHERE earlier.tenant_id = webhook_deliveries.tenant_id
+      AND earlier.subscription_id = webhook_deliveries.subscription_id
+      AND earlier.state = webhook_deliveries.state
+      AND (earlier.next_attempt_at < webhook_deliveries.next_attempt_at
+        OR (earlier.next_attempt_at = webhook_deliveries.next_attempt_at AND earlier.rowid < webhook_deliveries.rowid))
+  )
+ORDER BY webhook_deliveries.next_attempt_at, webhook_deliveries.rowid
+LIMIT ?3
+`
+
+type ListDueWebhookDeliveriesParams struct {
+	State string
+	Now   time.Time
+	Limit int64
+}
+
+// the delivery worker serves every tenant, taking the earliest due delivery
+// of each enabled subscription so that deliveries to one receiver are made in
+// order and never hold up those to another
+func (q *Queries) ListDueWebhookDeliveries(ctx context.Context, arg ListDueWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, listDueWebhookDeliveries, arg.State, arg.Now, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.ID,
+			&i.SubscriptionID,
+			&i.EventID,
+			&i.OperationID,
+			&i.State,
+			&i.Attempts,
+			&i.LastStatusCode,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEvents = `-- name: ListEvents :many
+SELECT seq, tenant_id, id, type, subject, data, created_at FROM events
+WHERE tenant_id = ? AND seq > ?
+ORDER BY seq
+LIMIT ?
+`
+
+type ListEventsParams struct {
+	TenantID string
+	Seq      int64
+	Limit    int64
+}
+
+func (q *Queries) ListEvents(ctx context.Context, arg ListEventsParams) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, listEvents, arg.TenantID, arg.Seq, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.Seq,
+			&i.TenantID,
+			&i.ID,
+			&i.Type,
+			&i.Subject,
+			&i.Data,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEventsByType = `-- name: ListEventsByType :many
+SELECT seq, tenant_id, id, type, subject, data, created_at FROM events
+WHERE tenant_id = ?1 AND seq > ?2 AND type IN (/*SLICE:types*/?)
+ORDER BY seq
+LIMIT ?4
+`
+
+type ListEventsByTypeParams struct {
+	TenantID string
+	AfterSeq int64
+	Types    []string
+	Limit    int64
+}
+
+func (q *Queries) ListEventsByType(ctx context.Context, arg ListEventsByTypeParams) ([]Event, error) {
+	query := listEventsByType
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.TenantID)
+	queryParams = append(queryParams, arg.AfterSeq)
+	if len(arg.Types) > 0 {
+		for _, v := range arg.Types {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:types*/?", strings.Repeat(",?", len(arg.Types))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:types*/?", "NULL", 1)
+	}
+	queryParams = append(queryParams, arg.Limit)
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.Seq,
+			&i.TenantID,
+			&i.ID,
+			&i.Type,
+			&i.Subject,
+			&i.Data,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLatestWorkflowInstances = `-- name: ListLatestWorkflowInstances :many
+SELECT w.instance_id, w.tenant_id, w.name, w.operation_id, w.created_at FROM workflow_instances w
+JOIN sent_messages s ON s.tenant_id = w.tenant_id AND s.id = w.operation_id
+WHERE s.result = ?1 AND NOT EXISTS (
+  SELECT 1 FROM workflow_instances l
+  WHERE l.tenant_id = w.tenant_id AND l.operation_id = w.operation_id AND l.rowid > w.rowid
+)
+ORDER BY w.rowid
+`
+
+// the latest instance of every operation still in the given state, which
+// the give-up sweep checks across every tenant
+func (q *Queries) ListLatestWorkflowInstances(ctx context.Context, state string) ([]WorkflowInstance, error) {
+	rows, err := q.db.QueryContext(ctx, listLatestWorkflowInstances, state)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorkflowInstance
+	for rows.Next() {
+		var i WorkflowInstance
+		if err := rows.Scan(
+			&i.InstanceID,
+			&i.TenantID,
+			&i.Name,
+			&i.OperationID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMessages = `-- name: ListMessages :many
+SELECT tenant_id, id, text, content_type, labels, payload, created_at, updated_at FROM messages
+WHERE tenant_id = ?
+`
+
+func (q *Queries) ListMessages(ctx context.Context, tenantID string) ([]Message, error) {
+	rows, err := q.db.QueryContext(ctx, listMessages, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Message
+	for rows.Next() {
+		var i Message
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.ID,
+			&i.Text,
+			&i.ContentType,
+			&i.Labels,
+			&i.Payload,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOperationAttempts = `-- name: ListOperationAttempts :many
+SELECT tenant_id, operation_id, run_id, step, attempt, error, created_at FROM operation_attempts
+WHERE tenant_id = ? AND operation_id = ?
+ORDER BY created_at, rowid
+`
+
+type ListOperationAttemptsParams struct {
+	TenantID    string
+	OperationID string
+}
+
+// created_at only has second precision, so the rowid keeps attempts in the
+// order they were made
+func (q *Queries) ListOperationAttempts(ctx context.Context, arg ListOperationAttemptsParams) ([]OperationAttempt, error) {
+	rows, err := q.db.QueryContext(ctx, listOperationAttempts, arg.TenantID, arg.OperationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OperationAttempt
+	for rows.Next() {
+		var i OperationAttempt
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.OperationID,
+			&i.RunID,
+			&i.Step,
+			&i.Attempt,
+			&i.Error,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOperationCompensations = `-- name: ListOperationCompensations :many
+SELECT tenant_id, operation_id, step, attempt, error, created_at FROM operation_compensations
+WHERE tenant_id = ? AND operation_id = ?
+ORDER BY created_at, rowid
+`
+
+type ListOperationCompensationsParams struct {
+	TenantID    string
+	OperationID string
+}
+
+func (q *Queries) ListOperationCompensations(ctx context.Context, arg ListOperationCompensationsParams) ([]OperationCompensation, error) {
+	rows, err := q.db.QueryContext(ctx, listOperationCompensations, arg.TenantID, arg.OperationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OperationCompensation
+	for rows.Next() {
+		var i OperationCompensation
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.OperationID,
+			&i.Step,
+			&i.Attempt,
+			&i.Error,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPendingWorkflows = `-- name: ListPendingWorkflows :many
+SELECT tenant_id, operation_id, input, created_at FROM pending_workflows
+WHERE created_at < ?1
+ORDER BY created_at
+`
+
+// the workflows recorded before the given time that are left to schedule,
+// across every tenant
+func (q *Queries) ListPendingWorkflows(ctx context.Context, before time.Time) ([]PendingWorkflow, error) {
+	rows, err := q.db.QueryContext(ctx, listPendingWorkflows, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PendingWorkflow
+	for rows.Next() {
+		var i PendingWorkflow
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.OperationID,
+			&i.Input,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecipients = `-- name: ListRecipients :many
+SELECT tenant_id, id, address, channel FROM recipients
+WHERE tenant_id = ?
+`
+
+func (q *Queries) ListRecipients(ctx context.Context, tenantID string) ([]Recipient, error) {
+	rows, err := q.db.QueryContext(ctx, listRecipients, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Recipient
+	for rows.Next() {
+		var i Recipient
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.ID,
+			&i.Address,
+			&i.Channel,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSentMessageAttachments = `-- name: ListSentMessageAttachments :many
+SELECT attachments.tenant_id, attachments.id, attachments.message_id, attachments.filename, attachments.content_type, attachments.size, attachments.sha256, attachments.created_at FROM sent_message_attachments
+JOIN attachments ON attachments.tenant_id = sent_message_attachments.tenant_id
+  AND attachments.id = sent_message_attachments.attachment_id
+WHERE sent_message_attachments.tenant_id = ? AND sent_message_attachments.operation_id = ?
+ORDER BY attachments.created_at, attachments.id
+`
+
+type ListSentMessageAttachmentsParams struct {
+	TenantID    string
+	OperationID string
+}
+
+func (q *Queries) ListSentMessageAttachments(ctx context.Context, arg ListSentMessageAttachmentsParams) ([]Attachment, error) {
+	rows, err := q.db.QueryContext(ctx, listSentMessageAttachments, arg.TenantID, arg.OperationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attachment
+	for rows.Next() {
+		var i Attachment
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.ID,
+			&i.MessageID,
+			&i.Filename,
+			&i.ContentType,
+			&i.Size,
+			&i.Sha256,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTemplates = `-- name: ListTemplates :many
+SELECT tenant_id, id, name, body, variables FROM templates
+WHERE tenant_id = ?
+`
+
+func (q *Queries) ListTemplates(ctx context.Context, tenantID string) ([]Template, error) {
+	rows, err := q.db.QueryContext(ctx, listTemplates, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Template
 	for rows.Next() {
-		var i Message
-		if err := rows.Scan(&i.ID, &i.Text); err != nil {
+		var i Template
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.ID,
+			&i.Name,
+			&i.Body,
+			&i.Variables,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTenants = `-- name: ListTenants :many
+SELECT id, display_name, token_sha256, max_messages, max_sends, created_at FROM tenants
+ORDER BY id
+`
+
+func (q *Queries) ListTenants(ctx context.Context) ([]Tenant, error) {
+	rows, err := q.db.QueryContext(ctx, listTenants)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Tenant
+	for rows.Next() {
+		var i Tenant
+		if err := rows.Scan(
+			&i.ID,
+			&i.DisplayName,
+			&i.TokenSha256,
+			&i.MaxMessages,
+			&i.MaxSends,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookDeliveries = `-- name: ListWebhookDeliveries :many
+SELECT tenant_id, id, subscription_id, event_id, operation_id, state, attempts, last_status_code, last_error, next_attempt_at, created_at, updated_at FROM webhook_deliveries
+WHERE tenant_id = ? AND subscription_id = ?
+ORDER BY created_at DESC, rowid DESC
+`
+
+type ListWebhookDeliveriesParams struct {
+	TenantID       string
+	SubscriptionID string
+}
+
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookDeliveries, arg.TenantID, arg.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookDelivery
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.ID,
+			&i.SubscriptionID,
+			&i.EventID,
+			&i.OperationID,
+			&i.State,
+			&i.Attempts,
+			&i.LastStatusCode,
+			&i.LastError,
+			&i.NextAttemptAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookSubscriptions = `-- name: ListWebhookSubscriptions :many
+SELECT tenant_id, id, url, states, secret, created_at, consecutive_failures, disabled_at FROM webhook_subscriptions
+WHERE tenant_id = ?
+ORDER BY created_at, id
+`
+
+func (q *Queries) ListWebhookSubscriptions(ctx context.Context, tenantID string) ([]WebhookSubscription, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookSubscriptions, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WebhookSubscription
+	for rows.Next() {
+		var i WebhookSubscription
+		if err := rows.Scan(
+			&i.TenantID,
+			&i.ID,
+			&i.Url,
+			&i.States,
+			&i.Secret,
+			&i.CreatedAt,
+			&i.ConsecutiveFailures,
+			&i.DisabledAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWorkflowInstances = `-- name: ListWorkflowInstances :many
+SELECT instance_id, tenant_id, name, operation_id, created_at FROM workflow_instances
+WHERE CAST(?1 AS TEXT) = '' OR name = ?1
+ORDER BY created_at DESC, rowid DESC
+`
+
+func (q *Queries) ListWorkflowInstances(ctx context.Context, name string) ([]WorkflowInstance, error) {
+	rows, err := q.db.QueryContext(ctx, listWorkflowInstances, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorkflowInstance
+	for rows.Next() {
+		var i WorkflowInstance
+		if err := rows.Scan(
+			&i.InstanceID,
+			&i.TenantID,
+			&i.Name,
+			&i.OperationID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordWebhookFailure = `-- name: RecordWebhookFailure :one
+UPDATE webhook_subscriptions
+set consecutive_failures = consecutive_failures + 1,
+  disabled_at = CASE
+    WHEN disabled_at IS NULL AND consecutive_failures + 1 >= ?1 THEN ?2
+    ELSE disabled_at
+  END
+WHERE tenant_id = ?3 AND id = ?4
+RETURNING tenant_id, id, url, states, secret, created_at, consecutive_failures, disabled_at
+`
+
+type RecordWebhookFailureParams struct {
+	DisableAfter int64
+	Now          sql.NullTime
+	TenantID     string
+	ID           string
+}
+
+// counts a failed attempt, disabling the subscription once disable_after
+// attempts in a row have failed
+func (q *Queries) RecordWebhookFailure(ctx context.Context, arg RecordWebhookFailureParams) (WebhookSubscription, error) {
+	row := q.db.QueryRowContext(ctx, recordWebhookFailure,
+		arg.DisableAfter,
+		arg.Now,
+		arg.TenantID,
+		arg.ID,
+	)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.Url,
+		&i.States,
+		&i.Secret,
+		&i.CreatedAt,
+		&i.ConsecutiveFailures,
+		&i.DisabledAt,
+	)
+	return i, err
+}
+
+const recordWebhookSuccess = `-- name: RecordWebhookSuccess :exec
+UPDATE webhook_subscriptions
+set consecutive_failures = 0
+WHERE tenant_id = ? AND id = ?
+`
+
+type RecordWebhookSuccessParams struct {
+	TenantID string
+	ID       string
+}
+
+func (q *Queries) RecordWebhookSuccess(ctx context.Context, arg RecordWebhookSuccessParams) error {
+	_, err := q.db.ExecContext(ctx, recordWebhookSuccess, arg.TenantID, arg.ID)
+	return err
+}
+
+const refundBillingRecord = `-- name: RefundBillingRecord :exec
+UPDATE billing_records
+set refunded = TRUE
+WHERE tenant_id = ? AND operation_id = ?
+`
+
+type RefundBillingRecordParams struct {
+	TenantID    string
+	OperationID string
+}
+
+func (q *Queries) RefundBillingRecord(ctx context.Context, arg RefundBillingRecordParams) error {
+	_, err := q.db.ExecContext(ctx, refundBillingRecord, arg.TenantID, arg.OperationID)
+	return err
+}
+
+const searchMessages = `-- name: SearchMessages :many
+SELECT messages.tenant_id, messages.id, messages.text, messages.content_type, messages.labels, messages.payload, messages.created_at, messages.updated_at,
+  CAST(snippet(messages_fts, 0, ?1, ?2, '...', 16) AS TEXT) AS snippet,
+  CAST(bm25(messages_fts) AS REAL) AS score
+FROM messages_fts
+JOIN messages ON messages.rowid = messages_fts.rowid
+WHERE messages_fts.text MATCH ?3 AND messages.tenant_id = ?4
+ORDER BY score, messages.id
+LIMIT ?6 OFFSET ?5
+`
+
+type SearchMessagesParams struct {
+	HighlightStart string
+	HighlightEnd   string
+	Query          string
+	TenantID       string
+	Offset         int64
+	Limit          int64
+}
+
+type SearchMessagesRow struct {
+	Message Message
+	Snippet string
+	Score   float64
+}
+
+func (q *Queries) SearchMessages(ctx context.Context, arg SearchMessagesParams) ([]SearchMessagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchMessages,
+		arg.HighlightStart,
+		arg.HighlightEnd,
+		arg.Query,
+		arg.TenantID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchMessagesRow
+	for rows.Next() {
+		var i SearchMessagesRow
+		if err := rows.Scan(
+			&i.Message.TenantID,
+			&i.Message.ID,
+			&i.Message.Text,
+			&i.Message.ContentType,
+			&i.Message.Labels,
+			&i.Message.Payload,
+			&i.Message.CreatedAt,
+			&i.Message.UpdatedAt,
+			&i.Snippet,
+			&i.Score,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -151,26 +2332,317 @@ func (q *Queries) ListMessages(ctx context.Context) ([]Message, error) {
 	return items, nil
 }
 
+const sumQuotaReservations = `-- name: SumQuotaReservations :one
+SELECT CAST(COALESCE(SUM(units), 0) AS INTEGER) FROM quota_reservations
+WHERE tenant_id = ?
+`
+
+func (q *Queries) SumQuotaReservations(ctx context.Context, tenantID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, sumQuotaReservations, tenantID)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const updateDeadLetterRedrive = `-- name: UpdateDeadLetterRedrive :one
+UPDATE dead_letters
+set redrive_operation_id = ?
+WHERE tenant_id = ? AND id = ? AND redrive_operation_id IS NULL
+RETURNING tenant_id, id, operation_id, message_id, step, input, last_error, redrive_operation_id, created_at
+`
+
+type UpdateDeadLetterRedriveParams struct {
+	RedriveOperationID sql.NullString
+	TenantID           string
+	ID                 string
+}
+
+// a dead letter is only redriven once
+func (q *Queries) UpdateDeadLetterRedrive(ctx context.Context, arg UpdateDeadLetterRedriveParams) (DeadLetter, error) {
+	row := q.db.QueryRowContext(ctx, updateDeadLetterRedrive, arg.RedriveOperationID, arg.TenantID, arg.ID)
+	var i DeadLetter
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.OperationID,
+		&i.MessageID,
+		&i.Step,
+		&i.Input,
+		&i.LastError,
+		&i.RedriveOperationID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateMessage = `-- name: UpdateMessage :one
+UPDATE messages
+set text = ?, content_type = ?, labels = ?, payload = ?, updated_at = CURRENT_TIMESTAMP
+WHERE tenant_id = ? AND id = ?
+RETURNING tenant_id, id, text, content_type, labels, payload, created_at, updated_at
+`
+
+type UpdateMessageParams struct {
+	Text        string
+	ContentType string
+	Labels      string
+	Payload     []byte
+	TenantID    string
+	ID          string
+}
+
+func (q *Queries) UpdateMessage(ctx context.Context, arg UpdateMessageParams) (Message, error) {
+	row := q.db.QueryRowContext(ctx, updateMessage,
+		arg.Text,
+		arg.ContentType,
+		arg.Labels,
+		arg.Payload,
+		arg.TenantID,
+		arg.ID,
+	)
+	var i Message
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.Text,
+		&i.ContentType,
+		&i.Labels,
+		&i.Payload,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateSentMessage = `-- name: UpdateSentMessage :one
 UPDATE sent_messages
 set result = ?
-WHERE id = ?
-RETURNING id, message_id, text, result
+WHERE tenant_id = ? AND id = ?
+RETURNING tenant_id, id, message_id, text, result, step, receipt_id, redrive_of
 `
 
 type UpdateSentMessageParams struct {
-	Result string
-	ID     string
+	Result   string
+	TenantID string
+	ID       string
 }
 
 func (q *Queries) UpdateSentMessage(ctx context.Context, arg UpdateSentMessageParams) (SentMessage, error) {
-	row := q.db.QueryRowContext(ctx, updateSentMessage, arg.Result, arg.ID)
+	row := q.db.QueryRowContext(ctx, updateSentMessage, arg.Result, arg.TenantID, arg.ID)
 	var i SentMessage
 	err := row.Scan(
+		&i.TenantID,
 		&i.ID,
 		&i.MessageID,
 		&i.Text,
 		&i.Result,
+		&i.Step,
+		&i.ReceiptID,
+		&i.RedriveOf,
+	)
+	return i, err
+}
+
+const updateSentMessageReceipt = `-- name: UpdateSentMessageReceipt :exec
+UPDATE sent_messages
+set receipt_id = ?
+WHERE tenant_id = ? AND id = ?
+`
+
+type UpdateSentMessageReceiptParams struct {
+	ReceiptID sql.NullString
+	TenantID  string
+	ID        string
+}
+
+func (q *Queries) UpdateSentMessageReceipt(ctx context.Context, arg UpdateSentMessageReceiptParams) error {
+	_, err := q.db.ExecContext(ctx, updateSentMessageReceipt, arg.ReceiptID, arg.TenantID, arg.ID)
+	return err
+}
+
+const updateSentMessageStep = `-- name: UpdateSentMessageStep :exec
+UPDATE sent_messages
+set step = ?
+WHERE tenant_id = ? AND id = ?
+`
+
+type UpdateSentMessageStepParams struct {
+	Step     string
+	TenantID string
+	ID       string
+}
+
+func (q *Queries) UpdateSentMessageStep(ctx context.Context, arg UpdateSentMessageStepParams) error {
+	_, err := q.db.ExecContext(ctx, updateSentMessageStep, arg.Step, arg.TenantID, arg.ID)
+	return err
+}
+
+const updateSentMessageText = `-- name: UpdateSentMessageText :exec
+UPDATE sent_messages
+set text = ?
+WHERE tenant_id = ? AND id = ?
+`
+
+type UpdateSentMessageTextParams struct {
+	Text     string
+	TenantID string
+	ID       string
+}
+
+func (q *Queries) UpdateSentMessageText(ctx context.Context, arg UpdateSentMessageTextParams) error {
+	_, err := q.db.ExecContext(ctx, updateSentMessageText, arg.Text, arg.TenantID, arg.ID)
+	return err
+}
+
+const updateTemplate = `-- name: UpdateTemplate :one
+UPDATE templates
+set name = ?, body = ?, variables = ?
+WHERE tenant_id = ? AND id = ?
+RETURNING tenant_id, id, name, body, variables
+`
+
+type UpdateTemplateParams struct {
+	Name      string
+	Body      string
+	Variables string
+	TenantID  string
+	ID        string
+}
+
+func (q *Queries) UpdateTemplate(ctx context.Context, arg UpdateTemplateParams) (Template, error) {
+	row := q.db.QueryRowContext(ctx, updateTemplate,
+		arg.Name,
+		arg.Body,
+		arg.Variables,
+		arg.TenantID,
+		arg.ID,
+	)
+	var i Template
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.Name,
+		&i.Body,
+		&i.Variables,
+	)
+	return i, err
+}
+
+const updateTenant = `-- name: UpdateTenant :one
+UPDATE tenants
+set display_name = ?, max_messages = ?, max_sends = ?
+WHERE id = ?
+RETURNING id, display_name, token_sha256, max_messages, max_sends, created_at
+`
+
+type UpdateTenantParams struct {
+	DisplayName string
+	MaxMessages int64
+	MaxSends    int64
+	ID          string
+}
+
+func (q *Queries) UpdateTenant(ctx context.Context, arg UpdateTenantParams) (Tenant, error) {
+	row := q.db.QueryRowContext(ctx, updateTenant,
+		arg.DisplayName,
+		arg.MaxMessages,
+		arg.MaxSends,
+		arg.ID,
+	)
+	var i Tenant
+	err := row.Scan(
+		&i.ID,
+		&i.DisplayName,
+		&i.TokenSha256,
+		&i.MaxMessages,
+		&i.MaxSends,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateWebhookDelivery = `-- name: UpdateWebhookDelivery :one
+UPDATE webhook_deliveries
+set state = ?, attempts = ?, last_status_code = ?, last_error = ?, next_attempt_at = ?, updated_at = CURRENT_TIMESTAMP
+WHERE tenant_id = ? AND id = ?
+RETURNING tenant_id, id, subscription_id, event_id, operation_id, state, attempts, last_status_code, last_error, next_attempt_at, created_at, updated_at
+`
+
+type UpdateWebhookDeliveryParams struct {
+	State          string
+	Attempts       int64
+	LastStatusCode int64
+	LastError      string
+	NextAttemptAt  time.Time
+	TenantID       string
+	ID             string
+}
+
+func (q *Queries) UpdateWebhookDelivery(ctx context.Context, arg UpdateWebhookDeliveryParams) (WebhookDelivery, error) {
+	row := q.db.QueryRowContext(ctx, updateWebhookDelivery,
+		arg.State,
+		arg.Attempts,
+		arg.LastStatusCode,
+		arg.LastError,
+		arg.NextAttemptAt,
+		arg.TenantID,
+		arg.ID,
+	)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.SubscriptionID,
+		&i.EventID,
+		&i.OperationID,
+		&i.State,
+		&i.Attempts,
+		&i.LastStatusCode,
+		&i.LastError,
+		&i.NextAttemptAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateWebhookSubscription = `-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscriptions
+set url = ?, states = ?, secret = ?, consecutive_failures = ?, disabled_at = ?
+WHERE tenant_id = ? AND id = ?
+RETURNING tenant_id, id, url, states, secret, created_at, consecutive_failures, disabled_at
+`
+
+type UpdateWebhookSubscriptionParams struct {
+	Url                 string
+	States              string
+	Secret              string
+	ConsecutiveFailures int64
+	DisabledAt          sql.NullTime
+	TenantID            string
+	ID                  string
+}
+
+func (q *Queries) UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) (WebhookSubscription, error) {
+	row := q.db.QueryRowContext(ctx, updateWebhookSubscription,
+		arg.Url,
+		arg.States,
+		arg.Secret,
+		arg.ConsecutiveFailures,
+		arg.DisabledAt,
+		arg.TenantID,
+		arg.ID,
+	)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.Url,
+		&i.States,
+		&i.Secret,
+		&i.CreatedAt,
+		&i.ConsecutiveFailures,
+		&i.DisabledAt,
 	)
 	return i, err
 }
@@ -2,26 +2,199 @@ package models
 
 import (
 	"context"
-	_ "embed"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strconv"
 	"strings"
 )
 
-//go:embed schema.sql
-var schema string
+// migrationFiles hold the numbered steps that bring a database from one
+// schema version to the next. schema.sql is the schema they add up to, which
+// is what sqlc generates from, so a change to the schema updates both.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
 
-func EnsureSchema(db DBTX) error {
-	return execStatements(db, schema)
+const createSchemaVersion = `CREATE TABLE IF NOT EXISTS schema_version (
+  version INTEGER NOT NULL
+)`
+
+type migration struct {
+	version    int
+	name       string
+	statements string
+}
+
+// migrations returns the migrations in the order they run, checking that
+// their versions run from 1 without gaps.
+func migrations() ([]migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	var loaded []migration
+	for _, entry := range entries {
+		prefix, _, ok := strings.Cut(entry.Name(), "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil {
+			return nil, fmt.Errorf("migration %s isn't named <version>_<name>.sql", entry.Name())
+		}
+		statements, err := fs.ReadFile(migrationFiles, "migrations/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		loaded = append(loaded, migration{
+			version:    version,
+			name:       entry.Name(),
+			statements: string(statements),
+		})
+	}
+	slices.SortFunc(loaded, func(a, b migration) int {
+		return a.version - b.version
+	})
+	for i, m := range loaded {
+		if m.version != i+1 {
+			return nil, fmt.Errorf("migration %s is out of sequence, expected version %d", m.name, i+1)
+		}
+	}
+	return loaded, nil
 }
 
-func execStatements(db DBTX, statements string) error {
+// latestVersion is the version the migrations bring a database to.
+func latestVersion() (int, error) {
+	loaded, err := migrations()
+	if err != nil {
+		return 0, err
+	}
+	return len(loaded), nil
+}
+
+// Migrate brings the schema of a database up to date, running each migration
+// it hasn't had yet in a transaction of its own along with the bump of its
+// version.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	loaded, err := migrations()
+	if err != nil {
+		return err
+	}
+	if _, err := db.ExecContext(ctx, createSchemaVersion); err != nil {
+		return err
+	}
+
+	for _, m := range loaded {
+		if err := migrate(ctx, db, m); err != nil {
+			return fmt.Errorf("running migration %s: %w", m.name, err)
+		}
+	}
+	return nil
+}
+
+func migrate(ctx context.Context, db *sql.DB, m migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// read within the transaction so that a server and worker starting
+	// together don't both run the migration
+	version, err := currentVersion(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if version >= m.version {
+		return nil
+	}
+
+	if err := execStatements(ctx, tx, m.statements); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_version"); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO schema_version (version) VALUES (?)", m.version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// currentVersion returns the schema version of a database. Databases from
+// before versioning have none recorded, so it is worked out from their
+// messages table: a baseline one has no tenants, and one created since
+// tenants were added has the schema of version 2.
+func currentVersion(ctx context.Context, db DBTX) (int, error) {
+	var version int
+	err := db.QueryRowContext(ctx, "SELECT version FROM schema_version").Scan(&version)
+	if err == nil {
+		return version, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+
+	var tables, tenantColumns int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = 'messages'").Scan(&tables); err != nil {
+		return 0, err
+	}
+	if tables == 0 {
+		return 0, nil
+	}
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*) FROM pragma_table_info('messages') WHERE name = 'tenant_id'").Scan(&tenantColumns); err != nil {
+		return 0, err
+	}
+	if tenantColumns == 0 {
+		return 1, nil
+	}
+	return 2, nil
+}
+
+func execStatements(ctx context.Context, db DBTX, statements string) error {
+	for _, statement := range splitStatements(statements) {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitStatements splits statements on semicolons, keeping the statements in
+// a trigger's BEGIN ... END body together with the CREATE TRIGGER.
+func splitStatements(statements string) []string {
+	var split []string
+	var trigger []string
 	for _, statement := range strings.Split(statements, ";") {
 		statement = strings.TrimSpace(statement)
 		if statement == "" {
 			continue
 		}
-		if _, err := db.ExecContext(context.Background(), statement); err != nil {
-			return err
+
+		upper := strings.ToUpper(withoutComments(statement))
+		switch {
+		case trigger != nil:
+			trigger = append(trigger, statement)
+			if upper == "END" {
+				split = append(split, strings.Join(trigger, ";\n"))
+				trigger = nil
+			}
+		case strings.HasPrefix(upper, "CREATE TRIGGER"):
+			trigger = []string{statement}
+		default:
+			split = append(split, statement)
 		}
 	}
-	return nil
+	return split
+}
+
+// withoutComments drops the comment lines leading a statement.
+func withoutComments(statement string) string {
+	for strings.HasPrefix(statement, "--") {
+		_, statement, _ = strings.Cut(statement, "\n")
+		statement = strings.TrimSpace(statement)
+	}
+	return statement
 }
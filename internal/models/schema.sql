@@ -1,11 +1,248 @@
+-- schema_version holds the version of the migrations the schema is at
+CREATE TABLE IF NOT EXISTS schema_version (
+  version INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS tenants (
+  id TEXT PRIMARY KEY,
+  display_name TEXT NOT NULL DEFAULT '',
+  token_sha256 TEXT UNIQUE,
+  max_messages INTEGER NOT NULL DEFAULT 0,
+  max_sends INTEGER NOT NULL DEFAULT 0,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
 CREATE TABLE IF NOT EXISTS messages (
-  id   TEXT PRIMARY KEY,
-  text TEXT    NOT NULL
+  tenant_id TEXT NOT NULL,
+  id   TEXT NOT NULL,
+  text TEXT    NOT NULL,
+  content_type TEXT NOT NULL DEFAULT 'PLAIN',
+  labels TEXT NOT NULL DEFAULT '{}',
+  payload BLOB,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (tenant_id, id)
 );
 
 CREATE TABLE IF NOT EXISTS sent_messages (
-  id TEXT PRIMARY KEY,
+  tenant_id TEXT NOT NULL,
+  id TEXT NOT NULL,
   message_id TEXT NOT NULL,
   text TEXT NOT NULL,
-  result TEXT NOT NULL
-);
\ No newline at end of file
+  result TEXT NOT NULL,
+  step TEXT NOT NULL DEFAULT '',
+  receipt_id TEXT,
+  redrive_of TEXT,
+  PRIMARY KEY (tenant_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS operation_attempts (
+  tenant_id TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  run_id TEXT NOT NULL DEFAULT '',
+  step TEXT NOT NULL,
+  attempt INTEGER NOT NULL,
+  error TEXT,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (tenant_id, operation_id, run_id, step, attempt)
+);
+
+CREATE TABLE IF NOT EXISTS dead_letters (
+  tenant_id TEXT NOT NULL,
+  id TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  message_id TEXT NOT NULL,
+  step TEXT NOT NULL,
+  input TEXT NOT NULL,
+  last_error TEXT NOT NULL,
+  redrive_operation_id TEXT,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (tenant_id, id),
+  UNIQUE (tenant_id, operation_id)
+);
+
+-- pending_workflows holds the input of the workflows that are yet to be
+-- scheduled for the transactions that committed their operations, which the
+-- give-up sweep schedules when their call didn't get to
+CREATE TABLE IF NOT EXISTS pending_workflows (
+  tenant_id TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  input TEXT NOT NULL,
+  created_at DATETIME NOT NULL,
+  PRIMARY KEY (tenant_id, operation_id)
+);
+
+CREATE TABLE IF NOT EXISTS operation_compensations (
+  tenant_id TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  step TEXT NOT NULL,
+  attempt INTEGER NOT NULL,
+  error TEXT,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (tenant_id, operation_id, step, attempt)
+);
+
+CREATE TABLE IF NOT EXISTS quota_reservations (
+  tenant_id TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  units INTEGER NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (tenant_id, operation_id)
+);
+
+CREATE TABLE IF NOT EXISTS billing_records (
+  tenant_id TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  amount INTEGER NOT NULL,
+  refunded BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (tenant_id, operation_id)
+);
+
+CREATE TABLE IF NOT EXISTS templates (
+  tenant_id TEXT NOT NULL,
+  id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  body TEXT NOT NULL,
+  variables TEXT NOT NULL,
+  PRIMARY KEY (tenant_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS attachments (
+  tenant_id TEXT NOT NULL,
+  id TEXT NOT NULL,
+  message_id TEXT NOT NULL,
+  filename TEXT NOT NULL,
+  content_type TEXT NOT NULL,
+  size INTEGER NOT NULL,
+  sha256 TEXT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (tenant_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS sent_message_attachments (
+  tenant_id TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  attachment_id TEXT NOT NULL,
+  PRIMARY KEY (tenant_id, operation_id, attachment_id)
+);
+
+CREATE TABLE IF NOT EXISTS events (
+  seq INTEGER PRIMARY KEY AUTOINCREMENT,
+  tenant_id TEXT NOT NULL,
+  id TEXT NOT NULL,
+  type TEXT NOT NULL,
+  subject TEXT NOT NULL,
+  data TEXT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE (tenant_id, id)
+);
+
+CREATE INDEX IF NOT EXISTS events_tenant ON events (tenant_id, seq);
+
+CREATE TABLE IF NOT EXISTS recipients (
+  tenant_id TEXT NOT NULL,
+  id TEXT NOT NULL,
+  address TEXT NOT NULL,
+  channel TEXT NOT NULL,
+  PRIMARY KEY (tenant_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS operation_recipients (
+  tenant_id TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  parent_id TEXT NOT NULL,
+  recipient_id TEXT NOT NULL,
+  address TEXT NOT NULL,
+  channel TEXT NOT NULL,
+  PRIMARY KEY (tenant_id, operation_id)
+);
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+  tenant_id TEXT NOT NULL,
+  id TEXT NOT NULL,
+  url TEXT NOT NULL,
+  states TEXT NOT NULL,
+  secret TEXT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  consecutive_failures INTEGER NOT NULL DEFAULT 0,
+  disabled_at DATETIME,
+  PRIMARY KEY (tenant_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+  tenant_id TEXT NOT NULL,
+  id TEXT NOT NULL,
+  subscription_id TEXT NOT NULL,
+  event_id TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  state TEXT NOT NULL,
+  attempts INTEGER NOT NULL DEFAULT 0,
+  last_status_code INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT NOT NULL DEFAULT '',
+  next_attempt_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (tenant_id, id)
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due ON webhook_deliveries (state, next_attempt_at);
+
+CREATE VIRTUAL TABLE IF NOT EXISTS messages_fts USING fts5(
+  text,
+  content='messages',
+  content_rowid='rowid'
+);
+
+CREATE TRIGGER IF NOT EXISTS messages_fts_insert AFTER INSERT ON messages BEGIN
+  INSERT INTO messages_fts (rowid, text) VALUES (new.rowid, new.text);
+END;
+
+CREATE TRIGGER IF NOT EXISTS messages_fts_delete AFTER DELETE ON messages BEGIN
+  INSERT INTO messages_fts (messages_fts, rowid, text) VALUES ('delete', old.rowid, old.text);
+END;
+
+CREATE TRIGGER IF NOT EXISTS messages_fts_update AFTER UPDATE ON messages BEGIN
+  INSERT INTO messages_fts (messages_fts, rowid, text) VALUES ('delete', old.rowid, old.text);
+  INSERT INTO messages_fts (rowid, text) VALUES (new.rowid, new.text);
+END;
+
+-- audit_log is append-only: rows are written once, in the same transaction as
+-- the change they describe where there is one, and never updated or deleted.
+-- A call that fails after its record committed gets a second record of the
+-- failure, whose original_id is the ID of the first.
+CREATE TABLE IF NOT EXISTS audit_log (
+  seq INTEGER PRIMARY KEY AUTOINCREMENT,
+  id TEXT NOT NULL UNIQUE,
+  tenant_id TEXT NOT NULL,
+  principal TEXT NOT NULL,
+  procedure TEXT NOT NULL,
+  resource_id TEXT NOT NULL,
+  request_digest TEXT NOT NULL,
+  outcome TEXT NOT NULL,
+  peer TEXT NOT NULL,
+  created_at DATETIME NOT NULL,
+  original_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_created ON audit_log (created_at);
+
+CREATE TRIGGER IF NOT EXISTS audit_log_no_update BEFORE UPDATE ON audit_log BEGIN
+  SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
+
+CREATE TRIGGER IF NOT EXISTS audit_log_no_delete BEFORE DELETE ON audit_log BEGIN
+  SELECT RAISE(ABORT, 'audit_log is append-only');
+END;
+
+-- workflow_instances indexes the durable workflow instances started for send
+-- operations, since the task hub itself can't be listed
+CREATE TABLE IF NOT EXISTS workflow_instances (
+  instance_id TEXT PRIMARY KEY,
+  tenant_id TEXT NOT NULL,
+  name TEXT NOT NULL,
+  operation_id TEXT NOT NULL,
+  created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS workflow_instances_operation ON workflow_instances (tenant_id, operation_id);
@@ -0,0 +1,241 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/tursodatabase/go-libsql"
+	_ "modernc.org/sqlite"
+)
+
+//go:embed schema.sql
+var schema string
+
+// drivers are the database/sql drivers the migrations are tested on: the
+// sqlite of the memory backend, and the libsql of the persistent one.
+var drivers = []string{"sqlite", "libsql"}
+
+// forEachDriver runs a test on a fresh database file of each driver.
+func forEachDriver(t *testing.T, test func(t *testing.T, db *sql.DB)) {
+	for _, driver := range drivers {
+		t.Run(driver, func(t *testing.T) {
+			test(t, openTestDB(t, driver))
+		})
+	}
+}
+
+func openTestDB(t *testing.T, driver string) *sql.DB {
+	t.Helper()
+	name := filepath.Join(t.TempDir(), "test.db")
+	if driver == "libsql" {
+		name = "file:" + name
+	}
+	db, err := sql.Open(driver, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+var whitespace = regexp.MustCompile(`\s+`)
+
+// schemaOf describes everything a database's schema defines, keyed by name.
+func schemaOf(t *testing.T, db *sql.DB) map[string]string {
+	t.Helper()
+	rows, err := db.Query("SELECT type, name, COALESCE(sql, '') FROM sqlite_master WHERE name NOT LIKE 'sqlite_%'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	described := map[string]string{}
+	for rows.Next() {
+		var kind, name, definition string
+		if err := rows.Scan(&kind, &name, &definition); err != nil {
+			t.Fatal(err)
+		}
+		definition = strings.ReplaceAll(definition, " IF NOT EXISTS", "")
+		described[name] = kind + ": " + whitespace.ReplaceAllString(definition, " ")
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return described
+}
+
+func assertVersion(t *testing.T, db *sql.DB) {
+	t.Helper()
+	latest, err := latestVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	version, err := currentVersion(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != latest {
+		t.Fatalf("expected schema version %d, got %d", latest, version)
+	}
+}
+
+func assertSchema(t *testing.T, db *sql.DB) {
+	t.Helper()
+	expected := openTestDB(t, "sqlite")
+	if err := execStatements(context.Background(), expected, schema); err != nil {
+		t.Fatal(err)
+	}
+	want, got := schemaOf(t, expected), schemaOf(t, db)
+	for name, definition := range want {
+		if got[name] != definition {
+			t.Errorf("%s differs from schema.sql:\n got: %s\nwant: %s", name, got[name], definition)
+		}
+	}
+	for name := range got {
+		if _, ok := want[name]; !ok {
+			t.Errorf("%s isn't in schema.sql", name)
+		}
+	}
+}
+
+func TestMigrateFresh(t *testing.T) {
+	forEachDriver(t, testMigrateFresh)
+}
+
+func testMigrateFresh(t *testing.T, db *sql.DB) {
+	if err := Migrate(context.Background(), db); err != nil {
+		t.Fatal(err)
+	}
+	assertVersion(t, db)
+	assertSchema(t, db)
+
+	// migrating again changes nothing
+	if err := Migrate(context.Background(), db); err != nil {
+		t.Fatal(err)
+	}
+	assertVersion(t, db)
+}
+
+func TestMigrateBaseline(t *testing.T) {
+	forEachDriver(t, testMigrateBaseline)
+}
+
+func testMigrateBaseline(t *testing.T, db *sql.DB) {
+	ctx := context.Background()
+
+	baseline, err := migrationFiles.ReadFile("migrations/0001_baseline.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := execStatements(ctx, db, string(baseline)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT INTO messages (id, text) VALUES ('m1', 'hello world'), ('m2', 'goodbye')`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT INTO sent_messages (id, message_id, text, result) VALUES ('o1', 'm1', 'hello world', 'SUCCEEDED')`); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Migrate(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	assertVersion(t, db)
+	assertSchema(t, db)
+
+	queries := New(db)
+	message, err := queries.GetMessage(ctx, GetMessageParams{TenantID: "default", ID: "m1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if message.Text != "hello world" || message.ContentType != "PLAIN" || message.Labels != "{}" {
+		t.Errorf("unexpected migrated message %+v", message)
+	}
+	operation, err := queries.GetSentMessageByID(ctx, GetSentMessageByIDParams{TenantID: "default", ID: "o1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if operation.MessageID != "m1" || operation.Result != "SUCCEEDED" {
+		t.Errorf("unexpected migrated operation %+v", operation)
+	}
+
+	// the full-text index covers the migrated messages
+	var matched string
+	if err := db.QueryRow(`SELECT m.id FROM messages_fts JOIN messages m ON m.rowid = messages_fts.rowid WHERE messages_fts MATCH 'hello'`).Scan(&matched); err != nil {
+		t.Fatal(err)
+	}
+	if matched != "m1" {
+		t.Errorf("expected the search to match m1, got %s", matched)
+	}
+}
+
+func TestMigrateUnversioned(t *testing.T) {
+	forEachDriver(t, testMigrateUnversioned)
+}
+
+func testMigrateUnversioned(t *testing.T, db *sql.DB) {
+	ctx := context.Background()
+
+	// created with the tenant scoped schema before versions were recorded
+	tenants, err := migrationFiles.ReadFile("migrations/0002_tenants.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseline, err := migrationFiles.ReadFile("migrations/0001_baseline.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := execStatements(ctx, db, string(baseline)+";"+string(tenants)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT INTO messages (tenant_id, id, text) VALUES ('acme', 'm1', 'hello')`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT INTO operation_attempts (tenant_id, operation_id, step, attempt) VALUES ('acme', 'o1', 'validate', 1)`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT INTO audit_log (seq, id, tenant_id, principal, procedure, resource_id, request_digest, outcome, peer, created_at) VALUES (7, 'a1', 'acme', 'anonymous', '/playground.v1.MessageService/CreateMessage', 'm1', '', 'ok', '', '2025-01-01 00:00:00')`); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Migrate(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	assertVersion(t, db)
+	assertSchema(t, db)
+
+	if _, err := New(db).GetMessage(ctx, GetMessageParams{TenantID: "acme", ID: "m1"}); err != nil {
+		t.Fatalf("expected the message to survive migrating: %v", err)
+	}
+	// attempts made before runs were recorded belong to the unnamed run
+	count, err := New(db).CountOperationAttempts(ctx, CountOperationAttemptsParams{TenantID: "acme", OperationID: "o1", Step: "validate"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("expected the attempt to survive migrating, counted %d", count)
+	}
+
+	// audit records keep their offsets, and the log stays append-only
+	records, err := New(db).ListAuditEvents(ctx, ListAuditEventsParams{
+		StartTime: time.Unix(0, 0).UTC(),
+		EndTime:   time.Now().UTC(),
+		TenantID:  "acme",
+		Limit:     10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].Seq != 7 || records[0].ID != "a1" {
+		t.Errorf("expected the audit record to survive migrating, got %+v", records)
+	}
+	if _, err := db.Exec(`UPDATE audit_log SET outcome = 'internal' WHERE id = 'a1'`); err == nil {
+		t.Error("expected updating an audit record to be refused")
+	}
+}
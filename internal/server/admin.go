@@ -0,0 +1,328 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/microsoft/durabletask-go/api"
+	"github.com/microsoft/durabletask-go/backend"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1/playgroundv1connect"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+const defaultWorkflowPageSize = 100
+
+// runningStatuses are the statuses of instances that haven't finished.
+var runningStatuses = []playgroundv1.WorkflowStatus{
+	playgroundv1.WorkflowStatus_WORKFLOW_STATUS_RUNNING,
+	playgroundv1.WorkflowStatus_WORKFLOW_STATUS_PENDING,
+	playgroundv1.WorkflowStatus_WORKFLOW_STATUS_SUSPENDED,
+}
+
+// retryableStatuses are the statuses of instances that stopped without
+// completing.
+var retryableStatuses = []playgroundv1.WorkflowStatus{
+	playgroundv1.WorkflowStatus_WORKFLOW_STATUS_FAILED,
+	playgroundv1.WorkflowStatus_WORKFLOW_STATUS_CANCELED,
+	playgroundv1.WorkflowStatus_WORKFLOW_STATUS_TERMINATED,
+}
+
+// workflowAdmin serves the AdminService.
+type workflowAdmin struct {
+	handler *handler
+}
+
+var _ playgroundv1connect.AdminServiceHandler = (*workflowAdmin)(nil)
+
+func statusName(status playgroundv1.WorkflowStatus) string {
+	return strings.ToLower(strings.TrimPrefix(status.String(), "WORKFLOW_STATUS_"))
+}
+
+// workflowStatus converts the runtime status of an orchestration, whose
+// values WorkflowStatus mirrors.
+func workflowStatus(status api.OrchestrationStatus) playgroundv1.WorkflowStatus {
+	return playgroundv1.WorkflowStatus(status)
+}
+
+func instanceFromModel(model models.WorkflowInstance, metadata *api.OrchestrationMetadata) *playgroundv1.WorkflowInstance {
+	instance := &playgroundv1.WorkflowInstance{
+		InstanceId:  model.InstanceID,
+		Name:        model.Name,
+		TenantId:    model.TenantID,
+		OperationId: model.OperationID,
+		Status:      workflowStatus(metadata.RuntimeStatus),
+		CreateTime:  timestamppb.New(metadata.CreatedAt),
+		UpdateTime:  timestamppb.New(metadata.LastUpdatedAt),
+	}
+	if metadata.FailureDetails != nil {
+		instance.Failure = fmt.Sprintf("%s: %s", metadata.FailureDetails.GetErrorType(), metadata.FailureDetails.GetErrorMessage())
+	}
+	return instance
+}
+
+// historyFromModel converts the history of an instance, and works out the
+// step it last scheduled.
+func historyFromModel(events []*backend.HistoryEvent) ([]*playgroundv1.WorkflowHistoryEvent, string) {
+	scheduled := map[int32]string{}
+	var currentStep string
+
+	history := make([]*playgroundv1.WorkflowHistoryEvent, 0, len(events))
+	for _, e := range events {
+		event := &playgroundv1.WorkflowHistoryEvent{
+			EventId: e.GetEventId(),
+			Time:    e.GetTimestamp(),
+		}
+		reflected := e.ProtoReflect()
+		if field := reflected.WhichOneof(reflected.Descriptor().Oneofs().ByName("eventType")); field != nil {
+			event.Type = field.JSONName()
+		}
+
+		switch {
+		case e.GetExecutionStarted() != nil:
+			event.Name = e.GetExecutionStarted().GetName()
+		case e.GetExecutionCompleted() != nil:
+			if details := e.GetExecutionCompleted().GetFailureDetails(); details != nil {
+				event.Failure = fmt.Sprintf("%s: %s", details.GetErrorType(), details.GetErrorMessage())
+			}
+		case e.GetTaskScheduled() != nil:
+			event.Name = e.GetTaskScheduled().GetName()
+			scheduled[e.GetEventId()] = event.Name
+			currentStep = event.Name
+		case e.GetTaskCompleted() != nil:
+			event.Name = scheduled[e.GetTaskCompleted().GetTaskScheduledId()]
+		case e.GetTaskFailed() != nil:
+			event.Name = scheduled[e.GetTaskFailed().GetTaskScheduledId()]
+			if details := e.GetTaskFailed().GetFailureDetails(); details != nil {
+				event.Failure = fmt.Sprintf("%s: %s", details.GetErrorType(), details.GetErrorMessage())
+			}
+		case e.GetEventRaised() != nil:
+			event.Name = e.GetEventRaised().GetName()
+		}
+
+		history = append(history, event)
+	}
+
+	return history, currentStep
+}
+
+// jsonValue parses the serialized input or output of an instance.
+func jsonValue(serialized string) (*structpb.Value, error) {
+	if serialized == "" {
+		return nil, nil
+	}
+	var decoded any
+	if err := json.Unmarshal([]byte(serialized), &decoded); err != nil {
+		return nil, err
+	}
+	return structpb.NewValue(decoded)
+}
+
+// instance looks up an indexed workflow instance along with its current
+// metadata.
+func (a *workflowAdmin) instance(ctx context.Context, id string) (models.WorkflowInstance, *api.OrchestrationMetadata, error) {
+	model, err := a.handler.backend.GetWorkflowInstance(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return model, nil, notFoundError(resourceWorkflowInstance, id, fmt.Errorf("workflow instance %q not found", id))
+		}
+		return model, nil, connect.NewError(connect.CodeInternal, err)
+	}
+	setAuditTenant(ctx, model.TenantID)
+
+	metadata, err := a.handler.backend.TaskHub.FetchOrchestrationMetadata(ctx, api.InstanceID(id))
+	if err != nil {
+		if errors.Is(err, api.ErrInstanceNotFound) {
+			return model, nil, notFoundError(resourceWorkflowInstance, id, fmt.Errorf("workflow instance %q not found", id))
+		}
+		return model, nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return model, metadata, nil
+}
+
+func (a *workflowAdmin) ListWorkflowInstances(ctx context.Context, req *connect.Request[playgroundv1.ListWorkflowInstancesRequest]) (*connect.Response[playgroundv1.ListWorkflowInstancesResponse], error) {
+	pageSize := int(req.Msg.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultWorkflowPageSize
+	}
+
+	queried, err := a.handler.backend.ListWorkflowInstances(ctx, req.Msg.Name)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	var instances []*playgroundv1.WorkflowInstance
+	for _, model := range queried {
+		if len(instances) == pageSize {
+			break
+		}
+		metadata, err := a.handler.backend.TaskHub.FetchOrchestrationMetadata(ctx, api.InstanceID(model.InstanceID))
+		if err != nil {
+			if errors.Is(err, api.ErrInstanceNotFound) {
+				// purged from the task hub by something other than PurgeWorkflow
+				continue
+			}
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		instance := instanceFromModel(model, metadata)
+		if len(req.Msg.Statuses) > 0 && !slices.Contains(req.Msg.Statuses, instance.Status) {
+			continue
+		}
+		instances = append(instances, instance)
+	}
+
+	return connect.NewResponse(&playgroundv1.ListWorkflowInstancesResponse{
+		Instances: instances,
+	}), nil
+}
+
+func (a *workflowAdmin) GetWorkflowInstance(ctx context.Context, req *connect.Request[playgroundv1.GetWorkflowInstanceRequest]) (*connect.Response[playgroundv1.GetWorkflowInstanceResponse], error) {
+	model, metadata, err := a.instance(ctx, req.Msg.InstanceId)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := jsonValue(metadata.SerializedInput)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("decoding input: %w", err))
+	}
+	output, err := jsonValue(metadata.SerializedOutput)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("decoding output: %w", err))
+	}
+
+	events, err := a.handler.backend.WorkflowHistory(ctx, req.Msg.InstanceId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	history, currentStep := historyFromModel(events)
+
+	return connect.NewResponse(&playgroundv1.GetWorkflowInstanceResponse{
+		Instance:    instanceFromModel(model, metadata),
+		Input:       input,
+		Output:      output,
+		CurrentStep: currentStep,
+		History:     history,
+	}), nil
+}
+
+func (a *workflowAdmin) TerminateWorkflow(ctx context.Context, req *connect.Request[playgroundv1.TerminateWorkflowRequest]) (*connect.Response[playgroundv1.TerminateWorkflowResponse], error) {
+	model, metadata, err := a.instance(ctx, req.Msg.InstanceId)
+	if err != nil {
+		return nil, err
+	}
+	if status := workflowStatus(metadata.RuntimeStatus); !slices.Contains(runningStatuses, status) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("workflow instance %q is %s", model.InstanceID, statusName(status)))
+	}
+
+	var opts []api.TerminateOptions
+	if req.Msg.Reason != "" {
+		opts = append(opts, api.WithOutput(req.Msg.Reason))
+	}
+	if err := a.handler.backend.TaskHub.TerminateOrchestration(ctx, api.InstanceID(model.InstanceID), opts...); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	// the terminated instance never reaches its compensate step, so the
+	// operation is failed here and RetryWorkflow compensates it
+	if err := a.failOperation(ctx, model); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	var input playgroundv1.SendMessageState
+	if err := json.Unmarshal([]byte(metadata.SerializedInput), &input); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("decoding input: %w", err))
+	}
+	if err := a.handler.updateParentOf(ctx, &input); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&playgroundv1.TerminateWorkflowResponse{}), nil
+}
+
+// failOperation moves the operation of an instance to FAILED if it's still
+// SENDING.
+func (a *workflowAdmin) failOperation(ctx context.Context, model models.WorkflowInstance) error {
+	tx, queries, err := a.handler.backend.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	operation, err := queries.GetSentMessageByID(ctx, models.GetSentMessageByIDParams{
+		TenantID: model.TenantID,
+		ID:       model.OperationID,
+	})
+	if err != nil {
+		return err
+	}
+	if operation.Result == playgroundv1.MessageState_SENDING.String() {
+		if _, err := setOperationState(ctx, queries, model.TenantID, model.OperationID, playgroundv1.MessageState_FAILED); err != nil {
+			return err
+		}
+	}
+	if err := recordAudit(ctx, queries, ""); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (a *workflowAdmin) PurgeWorkflow(ctx context.Context, req *connect.Request[playgroundv1.PurgeWorkflowRequest]) (*connect.Response[playgroundv1.PurgeWorkflowResponse], error) {
+	model, metadata, err := a.instance(ctx, req.Msg.InstanceId)
+	if err != nil {
+		return nil, err
+	}
+	if status := workflowStatus(metadata.RuntimeStatus); slices.Contains(runningStatuses, status) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("workflow instance %q is still %s", model.InstanceID, statusName(status)))
+	}
+
+	if err := a.handler.backend.TaskHub.PurgeOrchestrationState(ctx, api.InstanceID(model.InstanceID)); err != nil {
+		if errors.Is(err, api.ErrNotCompleted) {
+			return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("workflow instance %q has not completed", model.InstanceID))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if err := a.handler.backend.DeleteWorkflowInstance(ctx, model.InstanceID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&playgroundv1.PurgeWorkflowResponse{}), nil
+}
+
+func (a *workflowAdmin) RetryWorkflow(ctx context.Context, req *connect.Request[playgroundv1.RetryWorkflowRequest]) (*connect.Response[playgroundv1.RetryWorkflowResponse], error) {
+	model, metadata, err := a.instance(ctx, req.Msg.InstanceId)
+	if err != nil {
+		return nil, err
+	}
+	if status := workflowStatus(metadata.RuntimeStatus); !slices.Contains(retryableStatuses, status) {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("workflow instance %q is %s, only instances that stopped without completing can be retried", model.InstanceID, statusName(status)))
+	}
+	if model.Name != playgroundv1.SendMessageStateWorkflow {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("retrying %s workflows is not supported", model.Name))
+	}
+
+	var input playgroundv1.SendMessageState
+	if err := json.Unmarshal([]byte(metadata.SerializedInput), &input); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("decoding input: %w", err))
+	}
+	// injected faults describe the failure being retried, not the retry
+	input.Fault = nil
+
+	id, err := a.handler.startSendWorkflow(ctx, &input)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&playgroundv1.RetryWorkflowResponse{
+		InstanceId: id,
+	}), nil
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/andrewstucki/vanguard-playground/internal/client"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1/playgroundv1connect"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+func (s *testServer) adminClient(t *testing.T) playgroundv1connect.AdminServiceClient {
+	t.Helper()
+	c, err := client.NewAdminClient(client.WithBaseURL(s.AdminURL), client.WithToken(testAdminToken))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func workflowInstance(t *testing.T, admin playgroundv1connect.AdminServiceClient, operationID string) *playgroundv1.WorkflowInstance {
+	t.Helper()
+	response, err := admin.ListWorkflowInstances(context.Background(), connect.NewRequest(&playgroundv1.ListWorkflowInstancesRequest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, instance := range response.Msg.Instances {
+		if instance.OperationId == operationID {
+			return instance
+		}
+	}
+	t.Fatalf("no workflow instance for operation %q", operationID)
+	return nil
+}
+
+func TestRetryWorkflowStartsAFreshRun(t *testing.T) {
+	s := newTestServer(t, Config{AllowFaultInjection: true, AdminToken: testAdminToken})
+	c := s.client(t)
+	admin := s.adminClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// the first attempt at validate fails once its latency is over, by which
+	// time the workflow is terminated
+	sent, err := c.SendMessage(ctx, connect.NewRequest(&playgroundv1.SendMessageRequest{
+		MessageId: createMessage(t, c, "hello"),
+		Fault: &playgroundv1.FaultSpec{
+			FailAttempts: 1,
+			Latency:      durationpb.New(time.Second),
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	operationID := sent.Msg.OperationId
+
+	waitFor(t, "the validate step to start", func() bool {
+		operation, err := s.handler.backend.GetSentMessageByID(ctx, models.GetSentMessageByIDParams{
+			TenantID: DefaultTenant,
+			ID:       operationID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return operation.Step == "validate"
+	})
+	instance := workflowInstance(t, admin, operationID)
+	if _, err := admin.TerminateWorkflow(ctx, connect.NewRequest(&playgroundv1.TerminateWorkflowRequest{
+		InstanceId: instance.InstanceId,
+	})); err != nil {
+		t.Fatal(err)
+	}
+	failed := fmt.Sprintf("validate/1/injected failure in step %q on attempt 1", "validate")
+	waitFor(t, "the terminated workflow's attempt", func() bool {
+		return len(attemptRows(t, s, operationID)) == 1
+	})
+	waitFor(t, "the workflow to be terminated", func() bool {
+		return workflowInstance(t, admin, operationID).Status == playgroundv1.WorkflowStatus_WORKFLOW_STATUS_TERMINATED
+	})
+
+	// terminating fails the operation, which the retry would only compensate,
+	// so it's put back to how an instance the engine gave up on leaves it
+	if _, err := s.handler.backend.UpdateSentMessage(ctx, models.UpdateSentMessageParams{
+		TenantID: DefaultTenant,
+		ID:       operationID,
+		Result:   playgroundv1.MessageState_SENDING.String(),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := admin.RetryWorkflow(ctx, connect.NewRequest(&playgroundv1.RetryWorkflowRequest{
+		InstanceId: instance.InstanceId,
+	})); err != nil {
+		t.Fatal(err)
+	}
+	status, err := c.WaitForOperation(ctx, sent.Msg.MessageId, operationID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.State != playgroundv1.MessageState_SUCCEEDED.String() {
+		t.Fatalf("expected the retried send to succeed, got %s", status.State)
+	}
+
+	// the retry runs without the fault, starting every step at its first
+	// attempt
+	assertRows(t, attemptRows(t, s, operationID), []string{
+		failed, "validate/1/", "reserve/1/", "render/1/", "deliver/1/", "bill/1/", "confirm/1/",
+	})
+	runs := map[string]bool{}
+	for _, step := range status.Steps {
+		for _, attempt := range step.Attempts {
+			runs[attempt.RunId] = true
+		}
+	}
+	if len(runs) != 2 {
+		t.Errorf("expected the attempts to belong to two runs, got %v", runs)
+	}
+}
@@ -0,0 +1,377 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/andrewstucki/vanguard-playground/internal/blob"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+const (
+	// DefaultMaxAttachmentBytes is the default limit on the size of a single
+	// attachment.
+	DefaultMaxAttachmentBytes = 10 * 1024 * 1024
+	// downloadChunkBytes is the size of each chunk streamed by
+	// DownloadAttachment.
+	downloadChunkBytes = 64 * 1024
+)
+
+func attachmentFromModel(model models.Attachment) *playgroundv1.Attachment {
+	return &playgroundv1.Attachment{
+		AttachmentId: model.ID,
+		MessageId:    model.MessageID,
+		Filename:     model.Filename,
+		ContentType:  model.ContentType,
+		SizeBytes:    model.Size,
+		Sha256:       model.Sha256,
+		CreateTime:   timestamppb.New(model.CreatedAt),
+	}
+}
+
+func attachmentsFromModels(queried []models.Attachment) []*playgroundv1.Attachment {
+	var attachments []*playgroundv1.Attachment
+	for _, model := range queried {
+		attachments = append(attachments, attachmentFromModel(model))
+	}
+	return attachments
+}
+
+// verifyAttachment checks that the stored content of an attachment still
+// matches its recorded size and checksum. Missing or corrupted content is
+// reported as non-retryable.
+func (h *handler) verifyAttachment(ctx context.Context, model models.Attachment) error {
+	reader, err := h.blobs.Open(ctx, model.ID)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return &nonRetryableError{err: fmt.Errorf("content of attachment %q is missing", model.ID)}
+		}
+		return err
+	}
+	defer reader.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, reader)
+	if err != nil {
+		return err
+	}
+
+	if size != model.Size || hex.EncodeToString(hash.Sum(nil)) != model.Sha256 {
+		return &nonRetryableError{err: fmt.Errorf("content of attachment %q does not match its checksum", model.ID)}
+	}
+	return nil
+}
+
+// contentDigest returns the hex encoded sha-256 digest from a Content-Digest
+// header (RFC 9530), if there is one.
+func contentDigest(header http.Header) string {
+	for _, value := range header.Values("Content-Digest") {
+		for _, digest := range strings.Split(value, ",") {
+			algorithm, encoded, ok := strings.Cut(strings.TrimSpace(digest), "=")
+			if !ok || algorithm != "sha-256" {
+				continue
+			}
+			sum, err := base64.StdEncoding.DecodeString(strings.Trim(encoded, ":"))
+			if err != nil {
+				continue
+			}
+			return hex.EncodeToString(sum)
+		}
+	}
+	return ""
+}
+
+func (h *handler) UploadAttachment(ctx context.Context, stream *connect.ClientStream[playgroundv1.UploadAttachmentRequest]) (*connect.Response[playgroundv1.UploadAttachmentResponse], error) {
+	if !stream.Receive() {
+		if err := stream.Err(); err != nil {
+			return nil, err
+		}
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("no attachment was uploaded"))
+	}
+
+	first := stream.Msg()
+	if first.MessageId == "" {
+		return nil, violationsError(fieldViolation(first, "message_id", "", "required", "value is required"))
+	}
+	tenant := tenantFromContext(ctx)
+	if _, err := h.backend.GetMessage(ctx, models.GetMessageParams{
+		TenantID: tenant,
+		ID:       first.MessageId,
+	}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFoundError(resourceMessage, first.MessageId, fmt.Errorf("message with ID %q not found", first.MessageId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	id := uuid.New().String()
+
+	writer, err := h.blobs.Create(ctx, id)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer writer.Abort()
+
+	hash := sha256.New()
+	content := io.MultiWriter(writer, hash)
+
+	var size int64
+	contentType := first.File.GetContentType()
+	for msg := first; msg != nil; {
+		if contentType == "" {
+			contentType = msg.File.GetContentType()
+		}
+
+		data := msg.File.GetData()
+		size += int64(len(data))
+		if size > h.maxAttachmentBytes {
+			return nil, withReason(connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("attachment is larger than the maximum of %d bytes", h.maxAttachmentBytes)), reasonAttachmentTooLarge, nil)
+		}
+		if _, err := content.Write(data); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		msg = nil
+		if stream.Receive() {
+			msg = stream.Msg()
+		}
+	}
+	if err := stream.Err(); err != nil {
+		return nil, err
+	}
+
+	expected := first.Sha256
+	if expected == "" {
+		expected = contentDigest(stream.RequestHeader())
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	if expected != "" && expected != checksum {
+		return nil, violationsError(fieldViolation(first, "sha256", "", "attachment.sha256.mismatch", fmt.Sprintf("uploaded content has SHA-256 %s", checksum)))
+	}
+
+	filename := first.Filename
+	if filename == "" {
+		filename = id
+	}
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(filename))
+	}
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	if err := writer.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	attachment, err := h.createAttachment(ctx, models.CreateAttachmentParams{
+		TenantID:    tenant,
+		ID:          id,
+		MessageID:   first.MessageId,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        size,
+		Sha256:      checksum,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, errors.Join(err, h.blobs.Delete(ctx, id)))
+	}
+
+	return connect.NewResponse(&playgroundv1.UploadAttachmentResponse{
+		Attachment: attachment,
+	}), nil
+}
+
+func (h *handler) createAttachment(ctx context.Context, params models.CreateAttachmentParams) (*playgroundv1.Attachment, error) {
+	tx, queries, err := h.backend.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	model, err := queries.CreateAttachment(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := attachmentFromModel(model)
+	if _, err := recordEvent(ctx, queries, params.TenantID, &playgroundv1.Event{
+		Type:    eventAttachmentCreated,
+		Subject: attachment.AttachmentId,
+		Data:    &playgroundv1.Event_Attachment{Attachment: attachment},
+	}); err != nil {
+		return nil, err
+	}
+	if err := recordAudit(ctx, queries, ""); err != nil {
+		return nil, err
+	}
+
+	return attachment, tx.Commit()
+}
+
+func (h *handler) DownloadAttachment(ctx context.Context, req *connect.Request[playgroundv1.DownloadAttachmentRequest], stream *connect.ServerStream[playgroundv1.DownloadAttachmentResponse]) error {
+	model, err := h.backend.GetAttachment(ctx, models.GetAttachmentParams{
+		TenantID:  tenantFromContext(ctx),
+		ID:        req.Msg.AttachmentId,
+		MessageID: req.Msg.MessageId,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return notFoundError(resourceAttachment, req.Msg.AttachmentId, fmt.Errorf("message with ID %q has no attachment with ID %q", req.Msg.MessageId, req.Msg.AttachmentId))
+		}
+		return connect.NewError(connect.CodeInternal, err)
+	}
+
+	reader, err := h.blobs.Open(ctx, model.ID)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return connect.NewError(connect.CodeDataLoss, fmt.Errorf("content of attachment %q is missing", model.ID))
+		}
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	defer reader.Close()
+
+	hash := sha256.New()
+	buffer := make([]byte, downloadChunkBytes)
+	var size int64
+	for sent := false; ; sent = true {
+		n, readErr := io.ReadFull(reader, buffer)
+		if readErr != nil && !errors.Is(readErr, io.EOF) && !errors.Is(readErr, io.ErrUnexpectedEOF) {
+			return connect.NewError(connect.CodeInternal, readErr)
+		}
+		hash.Write(buffer[:n])
+		size += int64(n)
+
+		// always send at least one chunk so empty attachments still carry
+		// their content type
+		if n > 0 || !sent {
+			if err := stream.Send(&playgroundv1.DownloadAttachmentResponse{
+				File: &httpbody.HttpBody{
+					ContentType: model.ContentType,
+					Data:        buffer[:n],
+				},
+			}); err != nil {
+				return err
+			}
+		}
+
+		if readErr != nil {
+			break
+		}
+	}
+
+	// the content has already been streamed by now, but failing the stream
+	// still tells the client not to trust it
+	if size != model.Size || hex.EncodeToString(hash.Sum(nil)) != model.Sha256 {
+		return connect.NewError(connect.CodeDataLoss, fmt.Errorf("content of attachment %q does not match its checksum", model.ID))
+	}
+
+	return nil
+}
+
+func (h *handler) DeleteAttachment(ctx context.Context, req *connect.Request[playgroundv1.DeleteAttachmentRequest]) (*connect.Response[playgroundv1.DeleteAttachmentResponse], error) {
+	tenant := tenantFromContext(ctx)
+
+	tx, queries, err := h.backend.Tx(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer tx.Rollback()
+
+	model, err := queries.GetAttachment(ctx, models.GetAttachmentParams{
+		TenantID:  tenant,
+		ID:        req.Msg.AttachmentId,
+		MessageID: req.Msg.MessageId,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFoundError(resourceAttachment, req.Msg.AttachmentId, fmt.Errorf("message with ID %q has no attachment with ID %q", req.Msg.MessageId, req.Msg.AttachmentId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := deleteAttachments(ctx, queries, tenant, model.MessageID, model.ID); err != nil {
+		return nil, err
+	}
+
+	attachment := attachmentFromModel(model)
+	if _, err := recordEvent(ctx, queries, tenant, &playgroundv1.Event{
+		Type:    eventAttachmentDeleted,
+		Subject: attachment.AttachmentId,
+		Data:    &playgroundv1.Event_Attachment{Attachment: attachment},
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if err := recordAudit(ctx, queries, ""); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	h.deleteContent(ctx, []models.Attachment{model})
+
+	return connect.NewResponse(&playgroundv1.DeleteAttachmentResponse{}), nil
+}
+
+// deleteAttachments deletes the rows of a message's attachments, or just the
+// one with attachmentID when it is set. Attachments that an operation is
+// still sending are left alone, as its deliver step reads their content.
+func deleteAttachments(ctx context.Context, queries *models.Queries, tenant, messageID, attachmentID string) error {
+	sending, err := queries.CountSendingAttachmentOperations(ctx, models.CountSendingAttachmentOperationsParams{
+		TenantID:     tenant,
+		MessageID:    messageID,
+		AttachmentID: attachmentID,
+		State:        playgroundv1.MessageState_SENDING.String(),
+	})
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if sending > 0 {
+		return connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("attachments of message %q are being sent by %d operation(s)", messageID, sending))
+	}
+
+	if err := queries.DeleteSentMessageAttachments(ctx, models.DeleteSentMessageAttachmentsParams{
+		TenantID:     tenant,
+		MessageID:    messageID,
+		AttachmentID: attachmentID,
+	}); err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if _, err := queries.DeleteAttachments(ctx, models.DeleteAttachmentsParams{
+		TenantID:     tenant,
+		MessageID:    messageID,
+		AttachmentID: attachmentID,
+	}); err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	return nil
+}
+
+// deleteContent deletes the stored content of attachments once their rows
+// are gone. Failing to doesn't fail the request, as nothing refers to the
+// content anymore.
+func (h *handler) deleteContent(ctx context.Context, attachments []models.Attachment) {
+	for _, attachment := range attachments {
+		if err := h.blobs.Delete(ctx, attachment.ID); err != nil {
+			h.logger.Err(err).Str("attachment", attachment.ID).Msg("Error deleting attachment content")
+		}
+	}
+}
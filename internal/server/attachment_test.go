@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+
+	"github.com/andrewstucki/vanguard-playground/internal/blob"
+	"github.com/andrewstucki/vanguard-playground/internal/client"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+func uploadAttachment(t *testing.T, c *client.Client, messageID, content string) *playgroundv1.Attachment {
+	t.Helper()
+	stream := c.UploadAttachment(context.Background())
+	if err := stream.Send(&playgroundv1.UploadAttachmentRequest{
+		MessageId: messageID,
+		Filename:  "note.txt",
+		File:      &httpbody.HttpBody{Data: []byte(content)},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	response, err := stream.CloseAndReceive()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return response.Msg.Attachment
+}
+
+// assertContentDeleted checks that an attachment's content is gone from the
+// blob store.
+func assertContentDeleted(t *testing.T, s *testServer, attachmentID string) {
+	t.Helper()
+	reader, err := s.handler.blobs.Open(context.Background(), attachmentID)
+	if err == nil {
+		reader.Close()
+		t.Fatalf("expected the content of attachment %q to be deleted", attachmentID)
+	}
+	if !errors.Is(err, blob.ErrNotFound) {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteMessageDeletesAttachments(t *testing.T) {
+	s := newTestServer(t, Config{})
+	c := s.client(t)
+	ctx := context.Background()
+
+	messageID := createMessage(t, c, "hello")
+	first := uploadAttachment(t, c, messageID, "first")
+	second := uploadAttachment(t, c, messageID, "second")
+
+	if _, err := c.DeleteMessage(ctx, connect.NewRequest(&playgroundv1.DeleteMessageRequest{
+		MessageId: messageID,
+	})); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, attachment := range []*playgroundv1.Attachment{first, second} {
+		assertContentDeleted(t, s, attachment.AttachmentId)
+	}
+	remaining, err := s.handler.backend.ListAttachments(ctx, models.ListAttachmentsParams{
+		TenantID:  DefaultTenant,
+		MessageID: messageID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 0 {
+		t.Errorf("expected the attachments to be deleted, %d remain", len(remaining))
+	}
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	s := newTestServer(t, Config{})
+	c := s.client(t)
+	ctx := context.Background()
+
+	messageID := createMessage(t, c, "hello")
+	deleted := uploadAttachment(t, c, messageID, "deleted")
+	kept := uploadAttachment(t, c, messageID, "kept")
+
+	if _, err := c.DeleteAttachment(ctx, connect.NewRequest(&playgroundv1.DeleteAttachmentRequest{
+		MessageId:    messageID,
+		AttachmentId: deleted.AttachmentId,
+	})); err != nil {
+		t.Fatal(err)
+	}
+	assertContentDeleted(t, s, deleted.AttachmentId)
+
+	message, err := c.GetMessage(ctx, connect.NewRequest(&playgroundv1.GetMessageRequest{
+		MessageId: messageID,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	attachments := message.Msg.Message.Attachments
+	if len(attachments) != 1 || attachments[0].AttachmentId != kept.AttachmentId {
+		t.Fatalf("expected only attachment %q to remain, got %v", kept.AttachmentId, attachments)
+	}
+
+	_, err = c.DeleteAttachment(ctx, connect.NewRequest(&playgroundv1.DeleteAttachmentRequest{
+		MessageId:    messageID,
+		AttachmentId: deleted.AttachmentId,
+	}))
+	if connect.CodeOf(err) != connect.CodeNotFound {
+		t.Fatalf("expected deleting it again to be NotFound, got %v", err)
+	}
+}
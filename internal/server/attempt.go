@@ -0,0 +1,202 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	statev1 "github.com/andrewstucki/protoc-states/gen/state/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+// machine returns the state machine declared on SendMessageState.
+func machine() *statev1.States {
+	options := (&playgroundv1.SendMessageState{}).ProtoReflect().Descriptor().Options()
+	machine, ok := proto.GetExtension(options, statev1.E_Machine).(*statev1.Machine)
+	if !ok {
+		return nil
+	}
+	return machine.GetStates()
+}
+
+// steps returns the names of the SendMessageState workflow steps in the order
+// they run.
+func steps() []string {
+	var names []string
+	for _, transition := range machine().GetTransitions() {
+		names = append(names, transition.GetName())
+	}
+	return names
+}
+
+// retryPolicy returns the retry policy declared for the given step on the
+// SendMessageState machine, falling back to the machine's default policy.
+func retryPolicy(step string) *statev1.RetryPolicy {
+	states := machine()
+	for _, transition := range states.GetTransitions() {
+		if transition.GetName() == step && transition.GetRetryPolicy() != nil {
+			return transition.GetRetryPolicy()
+		}
+	}
+	return states.GetDefaultRetryPolicy()
+}
+
+// attempt runs a single workflow step, records the attempt against the
+// operation, and dead-letters the operation once the step's retries are
+// exhausted. If the engine gives up first, the give-up sweep dead-letters it
+// instead, so that it always reaches a terminal state. Steps of operations
+// that are already terminal are skipped, which lets the workflow run through
+// to its compensate step.
+func (h *handler) attempt(io *playgroundv1.SendMessageState, step string, fn func(context.Context, *playgroundv1.SendMessageState) error) (ret error) {
+	ctx := context.Background()
+
+	operation, err := h.backend.GetSentMessageByID(ctx, models.GetSentMessageByIDParams{
+		TenantID: io.TenantId,
+		ID:       io.OperationId,
+	})
+	if err != nil {
+		return err
+	}
+
+	if operation.Result != playgroundv1.MessageState_SENDING.String() {
+		// no-op since this is already processed
+		io.State = playgroundv1.MessageState(playgroundv1.MessageState_value[operation.Result])
+		return nil
+	}
+
+	if operation.Step != step {
+		if err := h.backend.UpdateSentMessageStep(ctx, models.UpdateSentMessageStepParams{
+			TenantID: io.TenantId,
+			ID:       io.OperationId,
+			Step:     step,
+		}); err != nil {
+			return err
+		}
+	}
+
+	count, err := h.backend.CountOperationAttempts(ctx, models.CountOperationAttemptsParams{
+		TenantID:    io.TenantId,
+		OperationID: io.OperationId,
+		RunID:       io.RunId,
+		Step:        step,
+	})
+	if err != nil {
+		return err
+	}
+	attempt := count + 1
+
+	defer func() {
+		if r := recover(); r != nil {
+			if h.recordAttempt(ctx, io, step, attempt, fmt.Errorf("panic: %v", r)) == nil {
+				// the operation was moved to a terminal state
+				ret = nil
+				return
+			}
+			// otherwise let the workflow engine retry the panicking step
+			panic(r)
+		}
+	}()
+
+	stepErr := injectFault(io.Fault, step, attempt)
+	if stepErr == nil {
+		stepErr = fn(ctx, io)
+	}
+
+	if err := h.recordAttempt(ctx, io, step, attempt, stepErr); err != nil {
+		return err
+	}
+
+	if stepErr == nil && attempt == 1 {
+		// only crash on the first attempt so the replayed step can complete
+		h.crashAfterCommit(io, step)
+	}
+
+	return nil
+}
+
+// recordAttempt persists the outcome of a step attempt and returns the error
+// that should be reported back to the workflow engine. Failures that leave
+// the operation terminal are dead-lettered and reported as successes so the
+// workflow continues on to compensate.
+func (h *handler) recordAttempt(ctx context.Context, io *playgroundv1.SendMessageState, step string, attempt int64, stepErr error) error {
+	var lastError sql.NullString
+	if stepErr != nil {
+		lastError = sql.NullString{String: stepErr.Error(), Valid: true}
+	}
+	if _, err := h.backend.CreateOperationAttempt(ctx, models.CreateOperationAttemptParams{
+		TenantID:    io.TenantId,
+		OperationID: io.OperationId,
+		RunID:       io.RunId,
+		Step:        step,
+		Attempt:     attempt,
+		Error:       lastError,
+	}); err != nil {
+		return errors.Join(stepErr, err)
+	}
+
+	if stepErr == nil {
+		return nil
+	}
+
+	if policy := retryPolicy(step); !isNonRetryable(stepErr) && policy != nil && attempt < int64(policy.GetMaxAttempts()) {
+		return stepErr
+	}
+
+	if err := h.deadLetter(ctx, io, step, stepErr, false); err != nil {
+		h.logger.Err(err).Str("operation", io.OperationId).Msg("Error dead-lettering operation")
+		return errors.Join(stepErr, err)
+	}
+
+	io.State = playgroundv1.MessageState_FAILED
+	return nil
+}
+
+func operationAttemptFromModel(model models.OperationAttempt) *playgroundv1.OperationAttempt {
+	return &playgroundv1.OperationAttempt{
+		Step:       model.Step,
+		Attempt:    int32(model.Attempt),
+		Error:      model.Error.String,
+		CreateTime: timestamppb.New(model.CreatedAt),
+		RunId:      model.RunID,
+	}
+}
+
+func operationCompensationFromModel(model models.OperationCompensation) *playgroundv1.OperationAttempt {
+	return &playgroundv1.OperationAttempt{
+		Step:       model.Step,
+		Attempt:    int32(model.Attempt),
+		Error:      model.Error.String,
+		CreateTime: timestamppb.New(model.CreatedAt),
+	}
+}
+
+// stepHistory groups an operation's attempts by step, listing every step of
+// the workflow in the order it runs.
+func stepHistory(attempts []models.OperationAttempt) []*playgroundv1.StepHistory {
+	var history []*playgroundv1.StepHistory
+	byStep := map[string]*playgroundv1.StepHistory{}
+	for _, step := range steps() {
+		if step == compensateStep {
+			// compensations are reported separately
+			continue
+		}
+		byStep[step] = &playgroundv1.StepHistory{Step: step}
+		history = append(history, byStep[step])
+	}
+	for _, attempt := range attempts {
+		step, ok := byStep[attempt.Step]
+		if !ok {
+			// attempts recorded against steps that no longer exist
+			step = &playgroundv1.StepHistory{Step: attempt.Step}
+			byStep[attempt.Step] = step
+			history = append(history, step)
+		}
+		step.Attempts = append(step.Attempts, operationAttemptFromModel(attempt))
+	}
+	return history
+}
@@ -0,0 +1,335 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"net/http"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1/playgroundv1connect"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+const (
+	auditOutcomeOK       = "ok"
+	defaultAuditPageSize = 100
+)
+
+// mutating reports whether calls of the procedure get audited, which is all
+// but those whose method is marked as having no side effects.
+func mutating(spec connect.Spec) bool {
+	return spec.IdempotencyLevel != connect.IdempotencyNoSideEffects
+}
+
+// auditEntry is the audit record of a call in progress.
+type auditEntry struct {
+	id         string
+	tenant     string
+	principal  string
+	procedure  string
+	resourceID string
+	peer       string
+	digest     hash.Hash
+	time       time.Time
+	// originalID is the ID of the committed record a record of a failure
+	// refers to
+	originalID string
+	// recorded is set once the handler wrote the record in its transaction
+	recorded bool
+}
+
+type auditKey struct{}
+
+// write inserts the record, reporting whether it did as one with its ID may
+// already exist.
+func (e *auditEntry) write(ctx context.Context, queries *models.Queries, outcome string) (bool, error) {
+	written, err := queries.CreateAuditEvent(ctx, models.CreateAuditEventParams{
+		ID:            e.id,
+		TenantID:      e.tenant,
+		Principal:     e.principal,
+		Procedure:     e.procedure,
+		ResourceID:    e.resourceID,
+		RequestDigest: hex.EncodeToString(e.digest.Sum(nil)),
+		Outcome:       outcome,
+		Peer:          e.peer,
+		CreatedAt:     e.time,
+		OriginalID:    e.originalID,
+	})
+	return written > 0, err
+}
+
+// recordAudit writes the audit record of the current call with the queries of
+// the handler's transaction, so that it commits or rolls back together with
+// the changes it describes. A non-empty resourceID replaces the one taken from
+// the request, which creates need as they only learn the ID here.
+//
+// Calls whose handler never records the audit, or whose transaction rolls
+// back, are recorded by the audit interceptor once the handler returns.
+func recordAudit(ctx context.Context, queries *models.Queries, resourceID string) error {
+	entry, ok := ctx.Value(auditKey{}).(*auditEntry)
+	if !ok {
+		return nil
+	}
+	if resourceID != "" {
+		entry.resourceID = resourceID
+	}
+	if _, err := entry.write(ctx, queries, auditOutcomeOK); err != nil {
+		return err
+	}
+	entry.recorded = true
+	return nil
+}
+
+// setAuditTenant records which tenant the current call acted on, for the
+// services that aren't tenant scoped themselves.
+func setAuditTenant(ctx context.Context, tenant string) {
+	if entry, ok := ctx.Value(auditKey{}).(*auditEntry); ok {
+		entry.tenant = tenant
+	}
+}
+
+// resourceID returns the first ID set on the message, looking one level into
+// its message fields when it has none itself.
+func resourceID(msg any) string {
+	message, ok := msg.(proto.Message)
+	if !ok {
+		return ""
+	}
+	reflected := message.ProtoReflect()
+	if id := idField(reflected); id != "" {
+		return id
+	}
+	fields := reflected.Descriptor().Fields()
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		if field.Kind() == protoreflect.MessageKind && field.Cardinality() != protoreflect.Repeated && reflected.Has(field) {
+			if id := idField(reflected.Get(field).Message()); id != "" {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+func idField(message protoreflect.Message) string {
+	fields := message.Descriptor().Fields()
+	for i := range fields.Len() {
+		field := fields.Get(i)
+		if field.Kind() == protoreflect.StringKind && field.Cardinality() != protoreflect.Repeated && strings.HasSuffix(string(field.Name()), "_id") {
+			if id := message.Get(field).String(); id != "" {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+// digestMessage adds the deterministic encoding of a request message to the
+// digest of the call.
+func digestMessage(digest hash.Hash, msg any) {
+	message, ok := msg.(proto.Message)
+	if !ok {
+		return
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		return
+	}
+	digest.Write(data)
+}
+
+// auditInterceptor writes an audit_log row for every call of a mutating
+// procedure, whether it succeeds or not. Calls rejected before they reach it,
+// such as those with an unknown tenant token, aren't audited.
+type auditInterceptor struct {
+	logger  zerolog.Logger
+	backend *models.Backend
+	// admin is set on the services that require the admin token rather than
+	// acting as a tenant
+	admin bool
+}
+
+func (i *auditInterceptor) entry(ctx context.Context, spec connect.Spec, peer connect.Peer, header http.Header) *auditEntry {
+	entry := &auditEntry{
+		id:        uuid.New().String(),
+		procedure: spec.Procedure,
+		peer:      peer.Addr,
+		digest:    sha256.New(),
+		time:      time.Now().UTC(),
+		principal: "anonymous",
+	}
+	authenticated := bearerToken(header) != ""
+	if i.admin {
+		if authenticated {
+			entry.principal = "admin"
+		}
+		return entry
+	}
+	entry.tenant = tenantFromContext(ctx)
+	if authenticated {
+		entry.principal = "tenant:" + entry.tenant
+	}
+	return entry
+}
+
+// finish records the call unless its handler already committed the record.
+// Failed calls are recorded regardless since their transaction may have
+// rolled back, and a record that did commit is followed by one of the failure.
+func (i *auditInterceptor) finish(ctx context.Context, entry *auditEntry, err error) {
+	if err == nil && entry.recorded {
+		return
+	}
+	outcome := auditOutcomeOK
+	if err != nil {
+		outcome = connect.CodeOf(err).String()
+	}
+	// record the call even when the caller has gone away
+	if err := i.record(context.WithoutCancel(ctx), entry, outcome); err != nil {
+		i.logger.Err(err).Str("procedure", entry.procedure).Msg("Error writing audit record")
+	}
+}
+
+func (i *auditInterceptor) record(ctx context.Context, entry *auditEntry, outcome string) error {
+	written, err := entry.write(ctx, i.backend.Queries, outcome)
+	if err != nil || written || !entry.recorded {
+		return err
+	}
+	// the record the handler committed is never changed, so the failure gets
+	// a record of its own
+	failure := *entry
+	failure.id = uuid.New().String()
+	failure.originalID = entry.id
+	failure.time = time.Now().UTC()
+	_, err = failure.write(ctx, i.backend.Queries, outcome)
+	return err
+}
+
+func (i *auditInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if !mutating(req.Spec()) {
+			return next(ctx, req)
+		}
+
+		entry := i.entry(ctx, req.Spec(), req.Peer(), req.Header())
+		entry.resourceID = resourceID(req.Any())
+		digestMessage(entry.digest, req.Any())
+
+		res, err := next(context.WithValue(ctx, auditKey{}, entry), req)
+		if err == nil && entry.resourceID == "" {
+			entry.resourceID = resourceID(res.Any())
+		}
+		i.finish(ctx, entry, err)
+		return res, err
+	}
+}
+
+func (i *auditInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *auditInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if !mutating(conn.Spec()) {
+			return next(ctx, conn)
+		}
+
+		entry := i.entry(ctx, conn.Spec(), conn.Peer(), conn.RequestHeader())
+		err := next(context.WithValue(ctx, auditKey{}, entry), &auditConn{StreamingHandlerConn: conn, entry: entry})
+		i.finish(ctx, entry, err)
+		return err
+	}
+}
+
+// auditConn digests the messages of a request stream as the handler receives
+// them.
+type auditConn struct {
+	connect.StreamingHandlerConn
+	entry *auditEntry
+}
+
+func (c *auditConn) Receive(msg any) error {
+	if err := c.StreamingHandlerConn.Receive(msg); err != nil {
+		return err
+	}
+	digestMessage(c.entry.digest, msg)
+	if c.entry.resourceID == "" {
+		c.entry.resourceID = resourceID(msg)
+	}
+	return nil
+}
+
+func (c *auditConn) Send(msg any) error {
+	if c.entry.resourceID == "" {
+		c.entry.resourceID = resourceID(msg)
+	}
+	return c.StreamingHandlerConn.Send(msg)
+}
+
+func auditEventFromModel(model models.AuditLog) *playgroundv1.AuditEvent {
+	return &playgroundv1.AuditEvent{
+		Offset:               model.Seq,
+		AuditEventId:         model.ID,
+		TenantId:             model.TenantID,
+		Principal:            model.Principal,
+		Procedure:            model.Procedure,
+		ResourceId:           model.ResourceID,
+		RequestDigest:        model.RequestDigest,
+		Outcome:              model.Outcome,
+		Peer:                 model.Peer,
+		CreateTime:           timestamppb.New(model.CreatedAt),
+		OriginalAuditEventId: model.OriginalID,
+	}
+}
+
+// auditLog serves the AuditService.
+type auditLog struct {
+	backend *models.Backend
+}
+
+var _ playgroundv1connect.AuditServiceHandler = (*auditLog)(nil)
+
+func (a *auditLog) ListAuditEvents(ctx context.Context, req *connect.Request[playgroundv1.ListAuditEventsRequest]) (*connect.Response[playgroundv1.ListAuditEventsResponse], error) {
+	start := time.Unix(0, 0).UTC()
+	if req.Msg.StartTime != nil {
+		start = req.Msg.StartTime.AsTime()
+	}
+	end := time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+	if req.Msg.EndTime != nil {
+		end = req.Msg.EndTime.AsTime()
+	}
+	pageSize := int64(req.Msg.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultAuditPageSize
+	}
+
+	queried, err := a.backend.ListAuditEvents(ctx, models.ListAuditEventsParams{
+		AfterSeq:  req.Msg.AfterOffset,
+		StartTime: start,
+		EndTime:   end,
+		TenantID:  req.Msg.TenantId,
+		Limit:     pageSize,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	events := make([]*playgroundv1.AuditEvent, 0, len(queried))
+	for _, model := range queried {
+		events = append(events, auditEventFromModel(model))
+	}
+
+	return connect.NewResponse(&playgroundv1.ListAuditEventsResponse{
+		Events: events,
+	}), nil
+}
@@ -0,0 +1,211 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/andrewstucki/vanguard-playground/internal/client"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+// auditRecords returns every audit record in the order they were written.
+func auditRecords(t *testing.T, s *testServer) []models.AuditLog {
+	t.Helper()
+	records, err := s.handler.backend.ListAuditEvents(context.Background(), models.ListAuditEventsParams{
+		StartTime: time.Unix(0, 0).UTC(),
+		EndTime:   time.Now().Add(time.Hour).UTC(),
+		Limit:     100,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return records
+}
+
+func TestAuditRecordsFailureAfterCommit(t *testing.T) {
+	s := newTestServer(t, Config{})
+	interceptor := &auditInterceptor{logger: zerolog.Nop(), backend: s.handler.backend}
+	failure := connect.NewError(connect.CodeUnavailable, errors.New("failed after commit"))
+
+	// audit runs the handler's part of a call, committing or rolling back the
+	// transaction its record was written in
+	audit := func(commit bool) string {
+		t.Helper()
+		ctx := context.Background()
+		entry := interceptor.entry(ctx, connect.Spec{Procedure: "/playground.v1.MessageService/CreateMessage"}, connect.Peer{Addr: "test"}, http.Header{})
+		ctx = context.WithValue(ctx, auditKey{}, entry)
+
+		func() {
+			tx, queries, err := s.handler.backend.Tx(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer tx.Rollback()
+			if err := recordAudit(ctx, queries, "message"); err != nil {
+				t.Fatal(err)
+			}
+			if commit {
+				if err := tx.Commit(); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}()
+		interceptor.finish(ctx, entry, failure)
+		return entry.id
+	}
+
+	committed := audit(true)
+	rolledBack := audit(false)
+
+	// the committed record keeps its outcome and is followed by one of the
+	// failure, while the rolled back one is only recorded as the failure
+	var got []string
+	for _, record := range auditRecords(t, s) {
+		id := record.ID
+		switch {
+		case id == committed:
+			id = "committed"
+		case id == rolledBack:
+			id = "rolled back"
+		case record.OriginalID == committed:
+			id = "failure of committed"
+		}
+		if record.ResourceID != "message" {
+			t.Errorf("expected record %s to be of the message, got %q", id, record.ResourceID)
+		}
+		got = append(got, id+"/"+record.Outcome)
+	}
+	assertRows(t, got, []string{
+		"committed/" + auditOutcomeOK,
+		"failure of committed/" + connect.CodeUnavailable.String(),
+		"rolled back/" + connect.CodeUnavailable.String(),
+	})
+
+	// and no record can be changed afterwards
+	tx, _, err := s.handler.backend.Tx(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	if _, err := tx.Exec("UPDATE audit_log SET outcome = ? WHERE id = ?", connect.CodeInternal.String(), committed); err == nil {
+		t.Error("expected updating an audit record to be refused")
+	}
+}
+
+func TestAuditLogRequiresAdminToken(t *testing.T) {
+	s := newTestServer(t, Config{AdminToken: testAdminToken})
+	ctx := context.Background()
+	createMessage(t, s.client(t), "hello")
+
+	if _, err := s.client(t).ListAuditEvents(ctx, connect.NewRequest(&playgroundv1.ListAuditEventsRequest{})); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Fatalf("expected reading the audit log without a token to be Unauthenticated, got %v", err)
+	}
+
+	response, err := s.client(t, client.WithToken(testAdminToken)).ListAuditEvents(ctx, connect.NewRequest(&playgroundv1.ListAuditEventsRequest{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := response.Msg.Events
+	if len(events) != 1 || events[0].Procedure != "/playground.v1.MessageService/CreateMessage" || events[0].Outcome != auditOutcomeOK {
+		t.Fatalf("expected the create to be audited, got %v", events)
+	}
+}
+
+func TestReadsAreNotAudited(t *testing.T) {
+	s := newTestServer(t, Config{})
+	c := s.client(t)
+	ctx := context.Background()
+	messageID := createMessage(t, c, "hello")
+	attachment := uploadAttachment(t, c, messageID, "content")
+
+	if _, err := c.GetMessage(ctx, connect.NewRequest(&playgroundv1.GetMessageRequest{MessageId: messageID})); err != nil {
+		t.Fatal(err)
+	}
+	download, err := c.DownloadAttachment(ctx, connect.NewRequest(&playgroundv1.DownloadAttachmentRequest{MessageId: messageID, AttachmentId: attachment.AttachmentId}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for download.Receive() {
+	}
+	if err := download.Close(); err != nil {
+		t.Fatal(err)
+	}
+	streamedEvents(t, c, &playgroundv1.StreamEventsRequest{}, 1)
+
+	var procedures []string
+	for _, record := range auditRecords(t, s) {
+		procedures = append(procedures, record.Procedure)
+	}
+	want := []string{
+		"/playground.v1.MessageService/CreateMessage",
+		"/playground.v1.MessageService/UploadAttachment",
+	}
+	if !slices.Equal(procedures, want) {
+		t.Errorf("expected only the writes %q to be audited, got %q", want, procedures)
+	}
+}
+
+func TestReadsHaveNoSideEffects(t *testing.T) {
+	// calls are audited unless their method is marked as having no side
+	// effects, so every method bound to GET has to be
+	for _, file := range []protoreflect.FileDescriptor{
+		playgroundv1.File_playground_v1_admin_proto,
+		playgroundv1.File_playground_v1_audit_proto,
+		playgroundv1.File_playground_v1_message_proto,
+		playgroundv1.File_playground_v1_tenant_proto,
+	} {
+		services := file.Services()
+		for i := range services.Len() {
+			methods := services.Get(i).Methods()
+			for j := range methods.Len() {
+				method := methods.Get(j)
+				options := method.Options().(*descriptorpb.MethodOptions)
+				rule, _ := proto.GetExtension(options, annotations.E_Http).(*annotations.HttpRule)
+				if rule.GetGet() != "" && options.GetIdempotencyLevel() != descriptorpb.MethodOptions_NO_SIDE_EFFECTS {
+					t.Errorf("expected %s to be marked as having no side effects", method.FullName())
+				}
+			}
+		}
+	}
+}
+
+func TestMessageWritesAreAuditedWithTheirMessage(t *testing.T) {
+	s := newTestServer(t, Config{})
+	c := s.client(t)
+	ctx := context.Background()
+
+	messageID := createMessage(t, c, "hello")
+	if _, err := c.UpdateMessage(ctx, connect.NewRequest(&playgroundv1.UpdateMessageRequest{
+		MessageId:  messageID,
+		Text:       "goodbye",
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"text"}},
+	})); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.DeleteMessage(ctx, connect.NewRequest(&playgroundv1.DeleteMessageRequest{MessageId: messageID})); err != nil {
+		t.Fatal(err)
+	}
+
+	records := auditRecords(t, s)
+	if len(records) != 3 {
+		t.Fatalf("expected the create, update and delete to be audited, got %v", records)
+	}
+	for _, record := range records {
+		if record.ResourceID != messageID {
+			t.Errorf("expected %s to be recorded against message %q, got %q", record.Procedure, messageID, record.ResourceID)
+		}
+	}
+}
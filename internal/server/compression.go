@@ -0,0 +1,195 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/connect"
+	"connectrpc.com/vanguard"
+	"github.com/andrewstucki/vanguard-playground/internal/compression"
+	"github.com/klauspost/compress/zstd"
+)
+
+// compressionHandlerOption registers zstd next to Connect's built-in gzip and
+// leaves small messages uncompressed, on all three protocols.
+func compressionHandlerOption(minBytes int) connect.HandlerOption {
+	return connect.WithHandlerOptions(
+		connect.WithCompression(compression.Zstd, compression.NewZstdDecompressor, compression.NewZstdCompressor),
+		connect.WithCompressMinBytes(minBytes),
+	)
+}
+
+// compressionTranscoderOptions let the transcoder decode and forward zstd as
+// well as gzip, as it has to when it translates between protocols.
+func compressionTranscoderOptions() []vanguard.TranscoderOption {
+	return []vanguard.TranscoderOption{
+		vanguard.WithCompression(compression.Zstd, compression.NewZstdCompressor, compression.NewZstdDecompressor),
+		vanguard.WithDefaultServiceOptions(vanguard.WithTargetCompression(compression.Names...)),
+	}
+}
+
+// negotiateEncoding picks the content coding of a response from the
+// request's Accept-Encoding, preferring zstd, or returns "" to leave it
+// uncompressed.
+func negotiateEncoding(header http.Header) string {
+	accepted := map[string]bool{}
+	for _, value := range header.Values("Accept-Encoding") {
+		for coding := range strings.SplitSeq(value, ",") {
+			name, params, _ := strings.Cut(coding, ";")
+			name = strings.ToLower(strings.TrimSpace(name))
+			quality := 1.0
+			if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+				if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+					quality = parsed
+				}
+			}
+			accepted[name] = quality > 0
+		}
+	}
+	for _, name := range compression.Names {
+		if enabled, ok := accepted[name]; ok && enabled || !ok && accepted["*"] {
+			return name
+		}
+	}
+	return ""
+}
+
+// flushWriteCloser is what gzip and zstd writers have in common.
+type flushWriteCloser interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// compressingResponseWriter holds back the start of a response until it
+// knows whether the response reaches the size worth compressing.
+type compressingResponseWriter struct {
+	http.ResponseWriter
+	encoding string
+	minBytes int
+
+	status     int
+	buffer     bytes.Buffer
+	started    bool
+	compressor flushWriteCloser
+}
+
+func (w *compressingResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *compressingResponseWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if !w.started {
+		w.buffer.Write(data)
+		if w.buffer.Len() >= w.minBytes {
+			if err := w.start(true); err != nil {
+				return 0, err
+			}
+		}
+		return len(data), nil
+	}
+	if w.compressor != nil {
+		return w.compressor.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// FlushError sends what has been written so far. Streams that flush before
+// reaching the size worth compressing are sent uncompressed.
+func (w *compressingResponseWriter) FlushError() error {
+	if !w.started {
+		if err := w.start(false); err != nil {
+			return err
+		}
+	}
+	if w.compressor != nil {
+		if err := w.compressor.Flush(); err != nil {
+			return err
+		}
+	}
+	return http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *compressingResponseWriter) Flush() {
+	_ = w.FlushError()
+}
+
+func (w *compressingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// start writes the header and whatever was held back, compressing the rest
+// of the response if asked to and the handler didn't encode it already.
+func (w *compressingResponseWriter) start(compress bool) error {
+	w.started = true
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	header := w.Header()
+	if compress && header.Get("Content-Encoding") == "" && w.status != http.StatusPartialContent {
+		var err error
+		switch w.encoding {
+		case compression.Gzip:
+			w.compressor = gzip.NewWriter(w.ResponseWriter)
+		case compression.Zstd:
+			w.compressor, err = zstd.NewWriter(w.ResponseWriter, zstd.WithEncoderConcurrency(1))
+		}
+		if err != nil {
+			return err
+		}
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+	var err error
+	if w.compressor != nil {
+		_, err = w.compressor.Write(w.buffer.Bytes())
+	} else {
+		_, err = w.ResponseWriter.Write(w.buffer.Bytes())
+	}
+	w.buffer.Reset()
+	return err
+}
+
+func (w *compressingResponseWriter) close() error {
+	if !w.started {
+		if err := w.start(false); err != nil {
+			return err
+		}
+	}
+	if w.compressor != nil {
+		return w.compressor.Close()
+	}
+	return nil
+}
+
+// compressResponses compresses the responses of plain HTTP routes, which
+// Connect doesn't handle, with the coding the client prefers once they reach
+// minBytes.
+func compressResponses(minBytes int, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		encoding := negotiateEncoding(r.Header)
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		compressing := &compressingResponseWriter{ResponseWriter: w, encoding: encoding, minBytes: minBytes}
+		next.ServeHTTP(compressing, r)
+		_ = compressing.close()
+	})
+}
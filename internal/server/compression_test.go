@@ -0,0 +1,180 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/andrewstucki/vanguard-playground/internal/client"
+	"github.com/andrewstucki/vanguard-playground/internal/compression"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+)
+
+// decompress decodes a response body by its Content-Encoding.
+func decompress(t *testing.T, encoding string, body string) []byte {
+	t.Helper()
+	var reader io.Reader
+	switch encoding {
+	case "":
+		return []byte(body)
+	case compression.Gzip:
+		decoder, err := gzip.NewReader(strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = decoder
+	case compression.Zstd:
+		decoder, err := zstd.NewReader(strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer decoder.Close()
+		reader = decoder
+	default:
+		t.Fatalf("unexpected encoding %q", encoding)
+	}
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return decoded
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	for _, test := range []struct {
+		accept string
+		want   string
+	}{
+		{accept: "", want: ""},
+		{accept: "gzip", want: compression.Gzip},
+		{accept: "gzip, deflate, br, zstd", want: compression.Zstd},
+		{accept: "zstd;q=0, gzip", want: compression.Gzip},
+		{accept: "ZSTD", want: compression.Zstd},
+		{accept: "*", want: compression.Zstd},
+		{accept: "*, zstd;q=0", want: compression.Gzip},
+		{accept: "identity, br", want: ""},
+	} {
+		header := http.Header{}
+		if test.accept != "" {
+			header.Set("Accept-Encoding", test.accept)
+		}
+		if got := negotiateEncoding(header); got != test.want {
+			t.Errorf("expected Accept-Encoding %q to pick %q, got %q", test.accept, test.want, got)
+		}
+	}
+}
+
+func TestRESTCompression(t *testing.T) {
+	s := newTestServer(t, Config{CompressMinBytes: 256})
+	c := s.client(t)
+	large := s.URL + "/v1/messages/" + createMessage(t, c, strings.Repeat("compress me ", 100))
+	small := s.URL + "/v1/messages/" + createMessage(t, c, "hello")
+
+	for _, test := range []struct {
+		name           string
+		url            string
+		acceptEncoding string
+		accept         string
+		encoding       string
+	}{
+		{name: "gzip", url: large, acceptEncoding: "gzip", encoding: compression.Gzip},
+		{name: "zstd", url: large, acceptEncoding: "zstd", encoding: compression.Zstd},
+		{name: "refused zstd", url: large, acceptEncoding: "zstd;q=0, gzip", encoding: compression.Gzip},
+		{name: "without Accept-Encoding", url: large},
+		{name: "below the minimum size", url: small, acceptEncoding: "gzip, zstd"},
+		{name: "protobuf", url: large, accept: protobufContentType},
+		{name: "compressed protobuf", url: large, accept: protobufContentType, acceptEncoding: "zstd", encoding: compression.Zstd},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			headers := map[string]string{}
+			if test.acceptEncoding != "" {
+				// setting it keeps the transport from decompressing for us
+				headers["Accept-Encoding"] = test.acceptEncoding
+			}
+			if test.accept != "" {
+				headers["Accept"] = test.accept
+			}
+			status, header, body := restCall(t, http.MethodGet, test.url, "", headers)
+			if status != http.StatusOK {
+				t.Fatalf("expected the message, got %d", status)
+			}
+			if got := header.Get("Content-Encoding"); got != test.encoding {
+				t.Fatalf("expected the encoding %q, got %q", test.encoding, got)
+			}
+			if vary := strings.Join(header.Values("Vary"), ","); !strings.Contains(vary, "Accept-Encoding") {
+				t.Errorf("expected responses to vary by Accept-Encoding, got %q", vary)
+			}
+			content := decompress(t, test.encoding, body)
+
+			if test.accept != protobufContentType {
+				if !bytes.Contains(content, []byte(`"text"`)) {
+					t.Errorf("expected the message as JSON, got %s", content)
+				}
+				return
+			}
+			if got := header.Get("Content-Type"); got != protobufContentType {
+				t.Errorf("expected the content type %s, got %s", protobufContentType, got)
+			}
+			var response playgroundv1.GetMessageResponse
+			if err := proto.Unmarshal(content, &response); err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(response.Message.GetText(), "compress me") {
+				t.Errorf("expected the message in protobuf, got %v", &response)
+			}
+		})
+	}
+}
+
+// encodingRecorder notes the response encodings of the calls it carries.
+type encodingRecorder struct {
+	encodings []string
+}
+
+func (r *encodingRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	response, err := http.DefaultTransport.RoundTrip(req)
+	if err == nil {
+		r.encodings = append(r.encodings, response.Header.Get("Content-Encoding")+response.Header.Get("Grpc-Encoding"))
+	}
+	return response, err
+}
+
+func TestRPCCompression(t *testing.T) {
+	s := newTestServer(t, Config{CompressMinBytes: 256})
+	text := strings.Repeat("compress me ", 100)
+
+	for _, protocol := range []client.Protocol{client.ProtocolConnect, client.ProtocolGRPCWeb} {
+		for _, name := range compression.Names {
+			t.Run(string(protocol)+"/"+name, func(t *testing.T) {
+				recorder := &encodingRecorder{}
+				c := s.client(t, client.WithProtocol(protocol), client.WithCompression(name), client.WithTransport(recorder))
+
+				messageID := createMessage(t, c, text)
+				got, err := c.GetMessage(context.Background(), connect.NewRequest(&playgroundv1.GetMessageRequest{MessageId: messageID}))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got.Msg.Message.Text != text {
+					t.Errorf("expected the message to survive compression, got %q", got.Msg.Message.Text)
+				}
+				// Connect answers in the coding the server prefers of those the
+				// client accepts, gRPC-Web in the coding of the request
+				want := compression.Zstd
+				if protocol == client.ProtocolGRPCWeb {
+					want = name
+				}
+				if len(recorder.encodings) != 2 || recorder.encodings[1] != want {
+					t.Errorf("expected the large response to be %s, got %q", want, recorder.encodings)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package server
+
+import (
+	"bufio"
+	"errors"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// DefaultCORSMethods are the methods browsers may use when none are
+// configured, covering the REST routes as well as Connect and gRPC-Web.
+var DefaultCORSMethods = []string{http.MethodGet, http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete}
+
+// DefaultCORSHeaders are the request headers browsers may send when none are
+// configured: those of the Connect and gRPC-Web protocols, and the ones the
+// server authenticates with.
+var DefaultCORSHeaders = []string{
+	"Content-Type",
+	"Accept",
+	"Accept-Encoding",
+	"Content-Encoding",
+	"Connect-Protocol-Version",
+	"Connect-Timeout-Ms",
+	"Connect-Content-Encoding",
+	"Connect-Accept-Encoding",
+	"Grpc-Timeout",
+	"Grpc-Accept-Encoding",
+	"X-Grpc-Web",
+	"X-User-Agent",
+	"Authorization",
+	"If-Match",
+	"If-None-Match",
+	TenantHeader,
+}
+
+// corsExposedHeaders are the response headers scripts may read. gRPC-Web
+// returns its status in headers for errors without a body, Connect clients
+// need the compression headers to decode responses, and conditional requests
+// need the entity tag.
+var corsExposedHeaders = []string{
+	"Grpc-Status",
+	"Grpc-Message",
+	"Grpc-Status-Details-Bin",
+	"Grpc-Encoding",
+	"Connect-Content-Encoding",
+	"Connect-Accept-Encoding",
+	"Content-Encoding",
+	"ETag",
+	CorrelationHeader,
+}
+
+const defaultCORSMaxAge = 2 * time.Hour
+
+// CORSConfig lets browser apps on other origins call the server. CORS is off
+// while no origins are allowed.
+type CORSConfig struct {
+	// AllowedOrigins are the origins browsers may call from, such as
+	// https://app.example.com. An origin of * allows any origin, and one like
+	// https://*.example.com allows its subdomains.
+	AllowedOrigins []string
+	// OriginsFile lists more allowed origins, one per line, with blank lines
+	// and lines starting with # ignored. It is read again whenever the server
+	// is told to reload.
+	OriginsFile string
+	// AllowedMethods default to DefaultCORSMethods
+	AllowedMethods []string
+	// AllowedHeaders default to DefaultCORSHeaders, and a header of * allows
+	// any header
+	AllowedHeaders []string
+	// AllowCredentials lets browsers send cookies and authorization headers,
+	// in which case the origin is always echoed rather than answered with *.
+	// It can't be combined with the * origin, which would share credentialed
+	// responses with every site.
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache a preflight, defaulting to two
+	// hours
+	MaxAge time.Duration
+}
+
+// readOriginsFile returns the origins listed in a file.
+func readOriginsFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var origins []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		origins = append(origins, line)
+	}
+	return origins, scanner.Err()
+}
+
+// cors answers preflight requests and adds the CORS headers to responses for
+// allowed origins. The allowed origins can be swapped while it serves.
+type cors struct {
+	logger  zerolog.Logger
+	config  CORSConfig
+	origins atomic.Pointer[[]string]
+
+	methods string
+	headers string
+	exposed string
+	maxAge  string
+}
+
+func newCORS(logger zerolog.Logger, config CORSConfig) (*cors, error) {
+	if len(config.AllowedMethods) == 0 {
+		config.AllowedMethods = DefaultCORSMethods
+	}
+	if len(config.AllowedHeaders) == 0 {
+		config.AllowedHeaders = DefaultCORSHeaders
+	}
+	if config.MaxAge == 0 {
+		config.MaxAge = defaultCORSMaxAge
+	}
+
+	c := &cors{
+		logger:  logger,
+		config:  config,
+		methods: strings.Join(config.AllowedMethods, ", "),
+		headers: strings.Join(config.AllowedHeaders, ", "),
+		exposed: strings.Join(corsExposedHeaders, ", "),
+		maxAge:  strconv.Itoa(int(config.MaxAge.Seconds())),
+	}
+	if err := c.reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// reload reads the origins file again. The origins in use are kept when it
+// can't be read or lists origins that aren't allowed.
+func (c *cors) reload() error {
+	origins := slices.Clone(c.config.AllowedOrigins)
+	if c.config.OriginsFile != "" {
+		listed, err := readOriginsFile(c.config.OriginsFile)
+		if err != nil {
+			return err
+		}
+		origins = append(origins, listed...)
+	}
+	if c.config.AllowCredentials && slices.Contains(origins, "*") {
+		return errors.New("the * origin can't be allowed along with credentials, list the origins instead")
+	}
+	c.origins.Store(&origins)
+	c.logger.Info().Strs("origins", origins).Msg("Loaded CORS origins")
+	return nil
+}
+
+func (c *cors) enabled() bool {
+	return len(*c.origins.Load()) > 0
+}
+
+func (c *cors) allowedOrigin(origin string) bool {
+	for _, allowed := range *c.origins.Load() {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+		if prefix, suffix, ok := strings.Cut(allowed, "*"); ok &&
+			len(origin) > len(prefix)+len(suffix) &&
+			strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowOrigin sets the origin a response is shared with, echoing the
+// request's origin unless any origin may read it.
+func (c *cors) allowOrigin(header http.Header, origin string) {
+	if !c.config.AllowCredentials && slices.Contains(*c.origins.Load(), "*") {
+		header.Set("Access-Control-Allow-Origin", "*")
+	} else {
+		header.Set("Access-Control-Allow-Origin", origin)
+	}
+	if c.config.AllowCredentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func (c *cors) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" || !c.enabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		header := w.Header()
+		header.Add("Vary", "Origin")
+
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			header.Add("Vary", "Access-Control-Request-Method")
+			header.Add("Vary", "Access-Control-Request-Headers")
+			// a disallowed preflight gets no CORS headers, which is how
+			// browsers learn the request isn't allowed
+			if c.allowedOrigin(origin) && c.allowedMethod(r.Header.Get("Access-Control-Request-Method")) {
+				c.allowOrigin(header, origin)
+				header.Set("Access-Control-Allow-Methods", c.methods)
+				if slices.Contains(c.config.AllowedHeaders, "*") {
+					header.Set("Access-Control-Allow-Headers", r.Header.Get("Access-Control-Request-Headers"))
+				} else {
+					header.Set("Access-Control-Allow-Headers", c.headers)
+				}
+				header.Set("Access-Control-Max-Age", c.maxAge)
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		if c.allowedOrigin(origin) {
+			c.allowOrigin(header, origin)
+			header.Set("Access-Control-Expose-Headers", c.exposed)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (c *cors) allowedMethod(method string) bool {
+	return slices.ContainsFunc(c.config.AllowedMethods, func(allowed string) bool {
+		return strings.EqualFold(allowed, method)
+	})
+}
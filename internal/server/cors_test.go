@@ -0,0 +1,214 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+// preflight sends a CORS preflight for a call from origin to path.
+func preflight(t *testing.T, url, origin, method, headers string) *http.Response {
+	t.Helper()
+	request, err := http.NewRequest(http.MethodOptions, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.Header.Set("Origin", origin)
+	request.Header.Set("Access-Control-Request-Method", method)
+	if headers != "" {
+		request.Header.Set("Access-Control-Request-Headers", headers)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	return response
+}
+
+func TestCORSPreflight(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		config  CORSConfig
+		origin  string
+		method  string
+		headers string
+		path    string
+
+		// allowOrigin is the Access-Control-Allow-Origin answered, empty when
+		// the preflight is refused
+		allowOrigin      string
+		allowHeaders     string
+		allowCredentials bool
+	}{{
+		name:         "listed origin",
+		config:       CORSConfig{AllowedOrigins: []string{"https://app.example.com"}},
+		origin:       "https://app.example.com",
+		method:       http.MethodPost,
+		headers:      "content-type",
+		allowOrigin:  "https://app.example.com",
+		allowHeaders: strings.Join(DefaultCORSHeaders, ", "),
+	}, {
+		name:   "unlisted origin",
+		config: CORSConfig{AllowedOrigins: []string{"https://app.example.com"}},
+		origin: "https://evil.example.net",
+		method: http.MethodPost,
+	}, {
+		name:         "any origin",
+		config:       CORSConfig{AllowedOrigins: []string{"*"}},
+		origin:       "https://anywhere.example.org",
+		method:       http.MethodGet,
+		allowOrigin:  "*",
+		allowHeaders: strings.Join(DefaultCORSHeaders, ", "),
+	}, {
+		name:         "subdomain of a wildcard origin",
+		config:       CORSConfig{AllowedOrigins: []string{"https://*.example.com"}},
+		origin:       "https://app.example.com",
+		method:       http.MethodGet,
+		allowOrigin:  "https://app.example.com",
+		allowHeaders: strings.Join(DefaultCORSHeaders, ", "),
+	}, {
+		name:   "lookalike of a wildcard origin",
+		config: CORSConfig{AllowedOrigins: []string{"https://*.example.com"}},
+		origin: "https://evilexample.com",
+		method: http.MethodGet,
+	}, {
+		name:   "other scheme of a wildcard origin",
+		config: CORSConfig{AllowedOrigins: []string{"https://*.example.com"}},
+		origin: "http://app.example.com",
+		method: http.MethodGet,
+	}, {
+		name:   "method that isn't allowed",
+		config: CORSConfig{AllowedOrigins: []string{"https://app.example.com"}, AllowedMethods: []string{http.MethodGet}},
+		origin: "https://app.example.com",
+		method: http.MethodDelete,
+	}, {
+		name:             "credentials echo the origin",
+		config:           CORSConfig{AllowedOrigins: []string{"https://app.example.com"}, AllowCredentials: true},
+		origin:           "https://app.example.com",
+		method:           http.MethodPost,
+		allowOrigin:      "https://app.example.com",
+		allowHeaders:     strings.Join(DefaultCORSHeaders, ", "),
+		allowCredentials: true,
+	}, {
+		name:         "any header",
+		config:       CORSConfig{AllowedOrigins: []string{"https://app.example.com"}, AllowedHeaders: []string{"*"}},
+		origin:       "https://app.example.com",
+		method:       http.MethodPost,
+		headers:      "x-custom, content-type",
+		allowOrigin:  "https://app.example.com",
+		allowHeaders: "x-custom, content-type",
+	}, {
+		name:         "Connect route",
+		config:       CORSConfig{AllowedOrigins: []string{"https://app.example.com"}},
+		origin:       "https://app.example.com",
+		method:       http.MethodPost,
+		path:         "/playground.v1.MessageService/CreateMessage",
+		allowOrigin:  "https://app.example.com",
+		allowHeaders: strings.Join(DefaultCORSHeaders, ", "),
+	}} {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestServer(t, Config{CORS: test.config})
+			path := test.path
+			if path == "" {
+				path = "/v1/messages"
+			}
+			response := preflight(t, s.URL+path, test.origin, test.method, test.headers)
+
+			if response.StatusCode != http.StatusNoContent {
+				t.Errorf("expected the preflight to be answered with 204, got %d", response.StatusCode)
+			}
+			header := response.Header
+			if got := header.Get("Access-Control-Allow-Origin"); got != test.allowOrigin {
+				t.Fatalf("expected the origin %q to be allowed, got %q", test.allowOrigin, got)
+			}
+			if vary := strings.Join(header.Values("Vary"), ", "); !strings.Contains(vary, "Origin") || !strings.Contains(vary, "Access-Control-Request-Method") {
+				t.Errorf("expected the preflight to vary by origin and method, got %q", vary)
+			}
+			if test.allowOrigin == "" {
+				if methods := header.Get("Access-Control-Allow-Methods"); methods != "" {
+					t.Errorf("expected a refused preflight to allow no methods, got %q", methods)
+				}
+				return
+			}
+			if methods := header.Get("Access-Control-Allow-Methods"); !strings.Contains(methods, test.method) {
+				t.Errorf("expected %s to be allowed, got %q", test.method, methods)
+			}
+			if got := header.Get("Access-Control-Allow-Headers"); got != test.allowHeaders {
+				t.Errorf("expected the headers %q to be allowed, got %q", test.allowHeaders, got)
+			}
+			if got := header.Get("Access-Control-Allow-Credentials") == "true"; got != test.allowCredentials {
+				t.Errorf("expected credentials to be allowed: %t, got %t", test.allowCredentials, got)
+			}
+			if maxAge := header.Get("Access-Control-Max-Age"); maxAge != "7200" {
+				t.Errorf("expected the default max age, got %q", maxAge)
+			}
+		})
+	}
+}
+
+func TestCORSResponses(t *testing.T) {
+	s := newTestServer(t, Config{CORS: CORSConfig{AllowedOrigins: []string{"https://app.example.com"}}})
+
+	for origin, allowed := range map[string]bool{
+		"https://app.example.com":  true,
+		"https://evil.example.net": false,
+	} {
+		request, err := http.NewRequest(http.MethodGet, s.URL+"/v1/messages", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		request.Header.Set("Origin", origin)
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		response.Body.Close()
+
+		// the call is served either way, it's the browser that withholds
+		// the response from scripts of origins that aren't allowed
+		if response.StatusCode != http.StatusOK {
+			t.Errorf("expected the call from %s to be served, got %d", origin, response.StatusCode)
+		}
+		if got := response.Header.Get("Access-Control-Allow-Origin") == origin; got != allowed {
+			t.Errorf("expected %s to be allowed: %t, got %q", origin, allowed, response.Header.Get("Access-Control-Allow-Origin"))
+		}
+		if exposed := response.Header.Get("Access-Control-Expose-Headers"); allowed && !strings.Contains(exposed, "ETag") {
+			t.Errorf("expected the entity tag to be exposed, got %q", exposed)
+		}
+	}
+}
+
+func TestCORSRefusesAnyOriginWithCredentials(t *testing.T) {
+	_, err := newServer(context.Background(), testLogger(t), Config{
+		CORS: CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true},
+	})
+	if err == nil {
+		t.Fatal("expected the * origin to be refused along with credentials")
+	}
+
+	// an origins file that adds * on reload is refused too, keeping the
+	// origins in use
+	file := filepath.Join(t.TempDir(), "origins")
+	if err := os.WriteFile(file, []byte("https://app.example.com\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c, err := newCORS(zerolog.Nop(), CORSConfig{OriginsFile: file, AllowCredentials: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte("*\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.reload(); err == nil {
+		t.Fatal("expected reloading the * origin to be refused along with credentials")
+	}
+	if c.allowedOrigin("https://evil.example.net") || !c.allowedOrigin("https://app.example.com") {
+		t.Errorf("expected the origins in use to be kept, got %v", *c.origins.Load())
+	}
+}
@@ -0,0 +1,469 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/microsoft/durabletask-go/api"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+// giveUpPollInterval is how often the give-up sweep looks for workflows the
+// engine stopped retrying.
+const giveUpPollInterval = 5 * time.Second
+
+// pendingWorkflowGrace is how long a pending workflow is left to the call that
+// recorded it before the give-up sweep schedules it instead.
+const pendingWorkflowGrace = 30 * time.Second
+
+// deadLetter fails an operation that is still SENDING, keeping what is needed
+// to redrive it. Operations that are already terminal are left alone. With
+// compensate set, a run that compensates the operation is kept pending, for
+// operations whose own workflow is no longer running to compensate them.
+func (h *handler) deadLetter(ctx context.Context, io *playgroundv1.SendMessageState, step string, stepErr error, compensate bool) error {
+	input, err := protojson.Marshal(io)
+	if err != nil {
+		return err
+	}
+
+	tx, queries, err := h.backend.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	operation, err := queries.GetSentMessageByID(ctx, models.GetSentMessageByIDParams{
+		TenantID: io.TenantId,
+		ID:       io.OperationId,
+	})
+	if err != nil {
+		return err
+	}
+	if operation.Result != playgroundv1.MessageState_SENDING.String() {
+		return nil
+	}
+
+	if _, err := queries.CreateDeadLetter(ctx, models.CreateDeadLetterParams{
+		TenantID:    io.TenantId,
+		ID:          uuid.New().String(),
+		OperationID: operation.ID,
+		MessageID:   operation.MessageID,
+		Step:        step,
+		Input:       string(input),
+		LastError:   stepErr.Error(),
+	}); err != nil {
+		return err
+	}
+
+	if _, err := setOperationState(ctx, queries, io.TenantId, operation.ID, playgroundv1.MessageState_FAILED); err != nil {
+		return err
+	}
+
+	if compensate {
+		compensation := proto.CloneOf(io)
+		compensation.Fault = nil
+		if err := keepPending(ctx, queries, compensation); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// sweepGivenUp dead-letters the operations whose workflow the engine gave up
+// on, which happens when a step's retry timeout runs out before its attempts
+// do, or when recording the last attempt fails. The workflow is then started
+// again, which skips straight to its compensate step since the operation is
+// terminal. That run is kept pending along with the dead letter, so that it's
+// retried by the next sweep if it fails to be scheduled, as the operation is
+// no longer SENDING by then. The sweep then schedules whatever else was left
+// pending.
+func (h *handler) sweepGivenUp(ctx context.Context) error {
+	instances, err := h.backend.ListLatestWorkflowInstances(ctx, playgroundv1.MessageState_SENDING.String())
+	if err != nil {
+		return err
+	}
+
+	for _, instance := range instances {
+		metadata, err := h.backend.TaskHub.FetchOrchestrationMetadata(ctx, api.InstanceID(instance.InstanceID))
+		if err != nil {
+			if errors.Is(err, api.ErrInstanceNotFound) {
+				continue
+			}
+			return err
+		}
+		if metadata.RuntimeStatus != api.RUNTIME_STATUS_FAILED {
+			continue
+		}
+
+		var input playgroundv1.SendMessageState
+		if err := json.Unmarshal([]byte(metadata.SerializedInput), &input); err != nil {
+			return fmt.Errorf("decoding input of workflow instance %q: %w", instance.InstanceID, err)
+		}
+		operation, err := h.backend.GetSentMessageByID(ctx, models.GetSentMessageByIDParams{
+			TenantID: instance.TenantID,
+			ID:       instance.OperationID,
+		})
+		if err != nil {
+			return err
+		}
+
+		giveUpErr := errors.New("workflow failed")
+		if details := metadata.FailureDetails; details != nil {
+			giveUpErr = fmt.Errorf("workflow failed: %s: %s", details.GetErrorType(), details.GetErrorMessage())
+		}
+		if err := h.deadLetter(ctx, &input, operation.Step, giveUpErr, true); err != nil {
+			return err
+		}
+		h.logger.Warn().Str("operation", instance.OperationID).Str("instance", instance.InstanceID).Msg("Dead-lettered operation whose workflow gave up")
+
+		input.Fault = nil
+		if err := h.schedulePending(ctx, &input); err != nil {
+			h.logger.Err(err).Str("operation", instance.OperationID).Msg("Error scheduling compensation, leaving it to the next sweep")
+		}
+	}
+
+	return h.schedulePendingWorkflows(ctx, time.Now().UTC().Add(-pendingWorkflowGrace))
+}
+
+// keepPending records a workflow to schedule once the transaction of queries
+// commits, so that it is scheduled by the give-up sweep if the caller doesn't
+// get to it.
+func keepPending(ctx context.Context, queries *models.Queries, io *playgroundv1.SendMessageState) error {
+	input, err := protojson.Marshal(io)
+	if err != nil {
+		return err
+	}
+	return queries.CreatePendingWorkflow(ctx, models.CreatePendingWorkflowParams{
+		TenantID:    io.TenantId,
+		OperationID: io.OperationId,
+		Input:       string(input),
+		CreatedAt:   time.Now().UTC(),
+	})
+}
+
+// schedulePending schedules a workflow kept pending and forgets it.
+func (h *handler) schedulePending(ctx context.Context, io *playgroundv1.SendMessageState) error {
+	if _, err := h.startSendWorkflow(ctx, io); err != nil {
+		return err
+	}
+	return h.backend.DeletePendingWorkflow(ctx, models.DeletePendingWorkflowParams{
+		TenantID:    io.TenantId,
+		OperationID: io.OperationId,
+	})
+}
+
+// schedulePendingWorkflows schedules the workflows kept pending since before
+// the given time, which their callers failed to schedule.
+func (h *handler) schedulePendingWorkflows(ctx context.Context, before time.Time) error {
+	pending, err := h.backend.ListPendingWorkflows(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, workflow := range pending {
+		var input playgroundv1.SendMessageState
+		if err := protojson.Unmarshal([]byte(workflow.Input), &input); err != nil {
+			errs = append(errs, fmt.Errorf("decoding pending workflow of operation %q: %w", workflow.OperationID, err))
+			continue
+		}
+		if err := h.schedulePending(ctx, &input); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		h.logger.Warn().Str("operation", workflow.OperationID).Msg("Scheduled workflow left pending")
+	}
+	return errors.Join(errs...)
+}
+
+// runGiveUpSweeps sweeps for given up workflows until the context is done.
+// The sweep works from what is stored, so workflows that gave up while the
+// server was down are picked up once it's back.
+func (h *handler) runGiveUpSweeps(ctx context.Context) {
+	ticker := time.NewTicker(giveUpPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := h.sweepGivenUp(ctx); err != nil && ctx.Err() == nil {
+			h.logger.Err(err).Msg("Error sweeping given up workflows")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *handler) ListDeadLetters(ctx context.Context, req *connect.Request[playgroundv1.ListDeadLettersRequest]) (*connect.Response[playgroundv1.ListDeadLettersResponse], error) {
+	tenant := tenantFromContext(ctx)
+
+	var queried []models.DeadLetter
+	var err error
+	if req.Msg.MessageId != "" {
+		queried, err = h.backend.ListDeadLettersByMessage(ctx, models.ListDeadLettersByMessageParams{
+			TenantID:  tenant,
+			MessageID: req.Msg.MessageId,
+		})
+	} else {
+		queried, err = h.backend.ListDeadLetters(ctx, tenant)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	var deadLetters []*playgroundv1.DeadLetter
+	for _, model := range queried {
+		deadLetter, err := h.deadLetterFromModel(ctx, model)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		deadLetters = append(deadLetters, deadLetter)
+	}
+
+	return connect.NewResponse(&playgroundv1.ListDeadLettersResponse{
+		DeadLetters: deadLetters,
+	}), nil
+}
+
+func (h *handler) GetDeadLetter(ctx context.Context, req *connect.Request[playgroundv1.GetDeadLetterRequest]) (*connect.Response[playgroundv1.GetDeadLetterResponse], error) {
+	model, err := h.backend.GetDeadLetter(ctx, models.GetDeadLetterParams{
+		TenantID: tenantFromContext(ctx),
+		ID:       req.Msg.DeadLetterId,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFoundError(resourceDeadLetter, req.Msg.DeadLetterId, fmt.Errorf("dead letter with ID %q not found", req.Msg.DeadLetterId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	deadLetter, err := h.deadLetterFromModel(ctx, model)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&playgroundv1.GetDeadLetterResponse{
+		DeadLetter: deadLetter,
+	}), nil
+}
+
+func (h *handler) RedriveDeadLetter(ctx context.Context, req *connect.Request[playgroundv1.RedriveDeadLetterRequest]) (*connect.Response[playgroundv1.RedriveDeadLetterResponse], error) {
+	tenant := tenantFromContext(ctx)
+
+	tx, queries, err := h.backend.Tx(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer tx.Rollback()
+
+	deadLetter, err := queries.GetDeadLetter(ctx, models.GetDeadLetterParams{
+		TenantID: tenant,
+		ID:       req.Msg.DeadLetterId,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFoundError(resourceDeadLetter, req.Msg.DeadLetterId, fmt.Errorf("dead letter with ID %q not found", req.Msg.DeadLetterId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if deadLetter.RedriveOperationID.Valid {
+		return nil, alreadyRedrivenError(deadLetter)
+	}
+
+	var input playgroundv1.SendMessageState
+	if err := protojson.Unmarshal([]byte(deadLetter.Input), &input); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("error decoding dead letter input: %w", err))
+	}
+
+	original, err := queries.GetSentMessageByID(ctx, models.GetSentMessageByIDParams{
+		TenantID: tenant,
+		ID:       deadLetter.OperationID,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	operationID := uuid.New().String()
+
+	operation, err := queries.CreateSentMessage(ctx, models.CreateSentMessageParams{
+		TenantID:  tenant,
+		ID:        operationID,
+		MessageID: original.MessageID,
+		Text:      original.Text,
+		Result:    playgroundv1.MessageState_SENDING.String(),
+		RedriveOf: sql.NullString{String: original.ID, Valid: true},
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if _, err := recordEvent(ctx, queries, tenant, operationEvent(eventMessageSent, operation)); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := queries.CopySentMessageAttachments(ctx, models.CopySentMessageAttachmentsParams{
+		TenantID:          tenant,
+		OperationID:       operationID,
+		SourceOperationID: original.ID,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if input.RecipientId != "" {
+		// the redrive replaces the original as its parent's child
+		link, err := queries.GetOperationRecipient(ctx, models.GetOperationRecipientParams{
+			TenantID:    tenant,
+			OperationID: original.ID,
+		})
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		if _, err := queries.CreateOperationRecipient(ctx, models.CreateOperationRecipientParams{
+			TenantID:    tenant,
+			OperationID: operationID,
+			ParentID:    link.ParentID,
+			RecipientID: link.RecipientID,
+			Address:     link.Address,
+			Channel:     link.Channel,
+		}); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		if err := updateParent(ctx, queries, tenant, link.ParentID); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	_, err = queries.UpdateDeadLetterRedrive(ctx, models.UpdateDeadLetterRedriveParams{
+		TenantID:           tenant,
+		ID:                 deadLetter.ID,
+		RedriveOperationID: sql.NullString{String: operationID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// redriven by a concurrent call since it was read
+			return nil, alreadyRedrivenError(deadLetter)
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := recordAudit(ctx, queries, ""); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	input.TenantId = tenant
+	input.OperationId = operationID
+	// injected faults describe the failure being redriven, not the retry
+	input.Fault = nil
+	if err := h.runSendWorkflow(&input); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&playgroundv1.RedriveDeadLetterResponse{
+		MessageId:   original.MessageID,
+		OperationId: operationID,
+	}), nil
+}
+
+// alreadyRedrivenError rejects redriving a dead letter a second time, which
+// would send the message again.
+func alreadyRedrivenError(deadLetter models.DeadLetter) *connect.Error {
+	err := connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("dead letter %q was already redriven", deadLetter.ID))
+	metadata := map[string]string{}
+	if deadLetter.RedriveOperationID.Valid {
+		metadata["redrive_operation_id"] = deadLetter.RedriveOperationID.String
+	}
+	return withReason(err, reasonAlreadyRedriven, metadata)
+}
+
+func (h *handler) PurgeDeadLetters(ctx context.Context, req *connect.Request[playgroundv1.PurgeDeadLettersRequest]) (*connect.Response[playgroundv1.PurgeDeadLettersResponse], error) {
+	tenant := tenantFromContext(ctx)
+
+	if len(req.Msg.DeadLetterIds) == 0 {
+		purged, err := h.backend.DeleteDeadLetters(ctx, tenant)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		return connect.NewResponse(&playgroundv1.PurgeDeadLettersResponse{
+			Purged: purged,
+		}), nil
+	}
+
+	tx, queries, err := h.backend.Tx(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer tx.Rollback()
+
+	var purged int64
+	for _, id := range req.Msg.DeadLetterIds {
+		rows, err := queries.DeleteDeadLetter(ctx, models.DeleteDeadLetterParams{
+			TenantID: tenant,
+			ID:       id,
+		})
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		purged += rows
+	}
+
+	if err := recordAudit(ctx, queries, ""); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&playgroundv1.PurgeDeadLettersResponse{
+		Purged: purged,
+	}), nil
+}
+
+func (h *handler) deadLetterFromModel(ctx context.Context, model models.DeadLetter) (*playgroundv1.DeadLetter, error) {
+	var input playgroundv1.SendMessageState
+	if err := protojson.Unmarshal([]byte(model.Input), &input); err != nil {
+		return nil, fmt.Errorf("error decoding dead letter input: %w", err)
+	}
+
+	attempts, err := h.backend.ListOperationAttempts(ctx, models.ListOperationAttemptsParams{
+		TenantID:    model.TenantID,
+		OperationID: model.OperationID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deadLetter := &playgroundv1.DeadLetter{
+		DeadLetterId:       model.ID,
+		OperationId:        model.OperationID,
+		MessageId:          model.MessageID,
+		Step:               model.Step,
+		Input:              &input,
+		LastError:          model.LastError,
+		RedriveOperationId: model.RedriveOperationID.String,
+		CreateTime:         timestamppb.New(model.CreatedAt),
+	}
+	for _, attempt := range attempts {
+		deadLetter.Attempts = append(deadLetter.Attempts, operationAttemptFromModel(attempt))
+	}
+
+	return deadLetter, nil
+}
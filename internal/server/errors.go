@@ -0,0 +1,299 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"buf.build/go/protovalidate"
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// errorDomain is the ErrorInfo domain of every error the server returns.
+const errorDomain = "playground.v1"
+
+// CorrelationHeader carries the correlation ID of an internal error, which
+// is also logged with the error itself.
+const CorrelationHeader = "X-Correlation-ID"
+
+// The reasons of the ErrorInfo detail on errors. They are part of the API, so
+// they never change once added. Errors without a more specific reason get the
+// name of their code.
+const (
+	reasonInternal               = "INTERNAL"
+	reasonUnavailable            = "UNAVAILABLE"
+	reasonValidation             = "VALIDATION_FAILED"
+	reasonNotFound               = "RESOURCE_NOT_FOUND"
+	reasonAlreadyExists          = "RESOURCE_ALREADY_EXISTS"
+	reasonQuotaExceeded          = "QUOTA_EXCEEDED"
+	reasonAttachmentTooLarge     = "ATTACHMENT_TOO_LARGE"
+	reasonFaultInjectionDisabled = "FAULT_INJECTION_DISABLED"
+	reasonTokenRequired          = "TOKEN_REQUIRED"
+	reasonInvalidToken           = "INVALID_TOKEN"
+	reasonTenantMismatch         = "TENANT_MISMATCH"
+	reasonETagMismatch           = "ETAG_MISMATCH"
+	reasonAlreadyRedriven        = "ALREADY_REDRIVEN"
+	reasonAdminDisabled          = "ADMIN_DISABLED"
+)
+
+// busyRetryDelay is how long clients are told to wait before retrying a call
+// that found the database busy.
+const busyRetryDelay = time.Second
+
+// Resource types reported in ResourceInfo details.
+const (
+	resourceMessage          = "playground.v1.Message"
+	resourceOperation        = "playground.v1.Operation"
+	resourceAttachment       = "playground.v1.Attachment"
+	resourceTemplate         = "playground.v1.Template"
+	resourceRecipient        = "playground.v1.Recipient"
+	resourceDeadLetter       = "playground.v1.DeadLetter"
+	resourceWebhook          = "playground.v1.WebhookSubscription"
+	resourceTenant           = "playground.v1.Tenant"
+	resourceWorkflowInstance = "playground.v1.WorkflowInstance"
+)
+
+// addDetail attaches a detail to an error, which can only fail for messages
+// that don't marshal.
+func addDetail(err *connect.Error, detail proto.Message) {
+	if d, marshalErr := connect.NewErrorDetail(detail); marshalErr == nil {
+		err.AddDetail(d)
+	}
+}
+
+// withReason attaches an ErrorInfo detail with a stable reason to an error.
+func withReason(err *connect.Error, reason string, metadata map[string]string) *connect.Error {
+	addDetail(err, &errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   errorDomain,
+		Metadata: metadata,
+	})
+	return err
+}
+
+// notFoundError reports a missing resource, naming it in a ResourceInfo
+// detail.
+func notFoundError(resourceType, name string, err error) *connect.Error {
+	connectErr := connect.NewError(connect.CodeNotFound, err)
+	addDetail(connectErr, &errdetails.ResourceInfo{
+		ResourceType: resourceType,
+		ResourceName: name,
+		Description:  err.Error(),
+	})
+	return withReason(connectErr, reasonNotFound, map[string]string{"resource_type": resourceType})
+}
+
+// errorInterceptor maps the errors of every call to what clients see. Details
+// are added for the errors handlers and other interceptors return, and
+// internal errors, which may carry anything from SQL to file paths, are
+// logged and replaced with a correlation ID that finds them in the log.
+//
+// It goes first on every handler so that it sees the errors of the other
+// interceptors too.
+type errorInterceptor struct {
+	logger zerolog.Logger
+}
+
+func (i *errorInterceptor) mapError(ctx context.Context, procedure string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) {
+		connectErr = connect.NewError(connect.CodeUnknown, err)
+	}
+	if ctx.Err() != nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		// the caller went away, so there's nobody to tell
+		return err
+	}
+
+	switch connectErr.Code() {
+	case connect.CodeInternal, connect.CodeUnknown:
+		return i.sanitize(procedure, connectErr)
+	case connect.CodeInvalidArgument:
+		addBadRequest(connectErr)
+	}
+
+	if !hasDetail[*errdetails.ErrorInfo](connectErr) {
+		withReason(connectErr, codeReason(connectErr.Code()), nil)
+	}
+	return connectErr
+}
+
+// sanitize replaces an internal error with one that only carries a
+// correlation ID, except that a busy database is reported as unavailable
+// since retrying is all it takes.
+func (i *errorInterceptor) sanitize(procedure string, err *connect.Error) error {
+	if busy(err) {
+		unavailable := connect.NewError(connect.CodeUnavailable, errors.New("the server is busy, try again shortly"))
+		addDetail(unavailable, &errdetails.RetryInfo{RetryDelay: durationpb.New(busyRetryDelay)})
+		return withReason(unavailable, reasonUnavailable, nil)
+	}
+
+	id := uuid.New().String()
+	i.logger.Err(err).Str("procedure", procedure).Str("correlation_id", id).Msg("Internal error")
+
+	sanitized := connect.NewError(connect.CodeInternal, errors.New("internal error, correlation ID "+id))
+	sanitized.Meta().Set(CorrelationHeader, id)
+	addDetail(sanitized, &errdetails.RequestInfo{RequestId: id})
+	return withReason(sanitized, reasonInternal, map[string]string{"correlation_id": id})
+}
+
+// busy reports whether an error comes from SQLite giving up on a lock.
+func busy(err error) bool {
+	message := err.Error()
+	return strings.Contains(message, "database is locked") || strings.Contains(message, "SQLITE_BUSY")
+}
+
+// uniqueViolation reports whether an error comes from SQLite refusing a row
+// that breaks a primary key or unique constraint.
+func uniqueViolation(err error) bool {
+	message := err.Error()
+	return strings.Contains(message, "UNIQUE constraint failed") || strings.Contains(message, "SQLITE_CONSTRAINT_PRIMARYKEY") || strings.Contains(message, "SQLITE_CONSTRAINT_UNIQUE")
+}
+
+// addBadRequest mirrors the protovalidate violations of an error as a
+// BadRequest detail, for clients that only know the standard details.
+func addBadRequest(err *connect.Error) {
+	if hasDetail[*errdetails.BadRequest](err) {
+		return
+	}
+
+	var violations []*validate.Violation
+	var validationErr *protovalidate.ValidationError
+	if errors.As(err, &validationErr) {
+		violations = validationErr.ToProto().GetViolations()
+	} else {
+		for _, detail := range err.Details() {
+			if value, valueErr := detail.Value(); valueErr == nil {
+				if decoded, ok := value.(*validate.Violations); ok {
+					violations = append(violations, decoded.GetViolations()...)
+				}
+			}
+		}
+	}
+	if len(violations) == 0 {
+		return
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       protovalidate.FieldPathString(violation.GetField()),
+			Description: violation.GetMessage(),
+			Reason:      violation.GetRuleId(),
+		})
+	}
+	addDetail(err, badRequest)
+	withReason(err, reasonValidation, nil)
+}
+
+func hasDetail[T proto.Message](err *connect.Error) bool {
+	for _, detail := range err.Details() {
+		if value, valueErr := detail.Value(); valueErr == nil {
+			if _, ok := value.(T); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// codeReason is the reason of errors that don't give a more specific one.
+func codeReason(code connect.Code) string {
+	switch code {
+	case connect.CodeNotFound:
+		return reasonNotFound
+	case connect.CodeAlreadyExists:
+		return reasonAlreadyExists
+	}
+	return strings.ToUpper(code.String())
+}
+
+func (i *errorInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		res, err := next(ctx, req)
+		return res, i.mapError(ctx, req.Spec().Procedure, err)
+	}
+}
+
+func (i *errorInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *errorInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		return i.mapError(ctx, conn.Spec().Procedure, next(ctx, conn))
+	}
+}
+
+// httpStatus is the HTTP status of REST responses with an error code, as
+// google.rpc.Code documents it.
+func httpStatus(code connect.Code) int {
+	switch code {
+	case connect.CodeCanceled:
+		return 499
+	case connect.CodeInvalidArgument, connect.CodeFailedPrecondition, connect.CodeOutOfRange:
+		return http.StatusBadRequest
+	case connect.CodeDeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case connect.CodeNotFound:
+		return http.StatusNotFound
+	case connect.CodeAlreadyExists, connect.CodeAborted:
+		return http.StatusConflict
+	case connect.CodePermissionDenied:
+		return http.StatusForbidden
+	case connect.CodeUnauthenticated:
+		return http.StatusUnauthorized
+	case connect.CodeResourceExhausted:
+		return http.StatusTooManyRequests
+	case connect.CodeUnimplemented:
+		return http.StatusNotImplemented
+	case connect.CodeUnavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// writeRESTError writes an error for the REST routes served outside the
+// transcoder, as the same google.rpc.Status JSON the transcoder writes.
+func writeRESTError(w http.ResponseWriter, err error) {
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) {
+		connectErr = connect.NewError(connect.CodeUnknown, err)
+	}
+
+	result := &status.Status{
+		Code:    int32(connectErr.Code()),
+		Message: connectErr.Message(),
+	}
+	for _, detail := range connectErr.Details() {
+		result.Details = append(result.Details, &anypb.Any{
+			TypeUrl: "type.googleapis.com/" + detail.Type(),
+			Value:   detail.Bytes(),
+		})
+	}
+	body, marshalErr := protojson.Marshal(result)
+	if marshalErr != nil {
+		body = []byte(`{"code":13,"message":"internal error"}`)
+	}
+
+	for key, values := range connectErr.Meta() {
+		w.Header()[key] = values
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus(connectErr.Code()))
+	_, _ = w.Write(body)
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1/playgroundv1connect"
+)
+
+// failingMessageService fails GetMessage with an internal error.
+type failingMessageService struct {
+	playgroundv1connect.UnimplementedMessageServiceHandler
+}
+
+func (failingMessageService) GetMessage(context.Context, *connect.Request[playgroundv1.GetMessageRequest]) (*connect.Response[playgroundv1.GetMessageResponse], error) {
+	return nil, connect.NewError(connect.CodeInternal, errors.New("no such table: messages"))
+}
+
+// errorDetail returns the first detail of type T of an error.
+func errorDetail[T any](t *testing.T, err *connect.Error) T {
+	t.Helper()
+	for _, detail := range err.Details() {
+		value, valueErr := detail.Value()
+		if valueErr != nil {
+			t.Fatal(valueErr)
+		}
+		if detail, ok := value.(T); ok {
+			return detail
+		}
+	}
+	var zero T
+	t.Fatalf("expected a %T detail on %v", zero, err)
+	return zero
+}
+
+func TestInternalErrorsAreSanitized(t *testing.T) {
+	var log bytes.Buffer
+	interceptor := &errorInterceptor{logger: zerolog.New(&log)}
+	procedure := playgroundv1connect.MessageServiceGetMessageProcedure
+
+	for _, test := range []struct {
+		name string
+		err  error
+	}{
+		{name: "internal", err: connect.NewError(connect.CodeInternal, errors.New(`no such column: "secret" in /var/lib/playground.db`))},
+		{name: "unknown", err: errors.New(`no such column: "secret" in /var/lib/playground.db`)},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			log.Reset()
+			var mapped *connect.Error
+			if !errors.As(interceptor.mapError(context.Background(), procedure, test.err), &mapped) {
+				t.Fatal("expected a connect error")
+			}
+			if mapped.Code() != connect.CodeInternal {
+				t.Errorf("expected Internal, got %s", mapped.Code())
+			}
+			if strings.Contains(mapped.Message(), "secret") {
+				t.Errorf("expected the cause to be hidden, got %q", mapped.Message())
+			}
+
+			id := mapped.Meta().Get(CorrelationHeader)
+			if id == "" || !strings.Contains(mapped.Message(), id) {
+				t.Fatalf("expected the message %q to carry the correlation ID %q", mapped.Message(), id)
+			}
+			if got := errorDetail[*errdetails.RequestInfo](t, mapped).RequestId; got != id {
+				t.Errorf("expected the request ID %q, got %q", id, got)
+			}
+			info := errorDetail[*errdetails.ErrorInfo](t, mapped)
+			if info.Reason != reasonInternal || info.Metadata["correlation_id"] != id {
+				t.Errorf("expected the reason %s with the correlation ID, got %v", reasonInternal, info)
+			}
+
+			// the log has what the response doesn't, under the same ID
+			logged := log.String()
+			if !strings.Contains(logged, id) || !strings.Contains(logged, "secret") || !strings.Contains(logged, procedure) {
+				t.Errorf("expected the cause to be logged with the correlation ID, got %s", logged)
+			}
+		})
+	}
+}
+
+func TestBusyDatabaseIsUnavailable(t *testing.T) {
+	var log bytes.Buffer
+	interceptor := &errorInterceptor{logger: zerolog.New(&log)}
+
+	for _, cause := range []string{
+		"database is locked",
+		"SQLITE_BUSY: cannot commit transaction",
+	} {
+		t.Run(cause, func(t *testing.T) {
+			err := connect.NewError(connect.CodeInternal, fmt.Errorf("error creating message: %w", errors.New(cause)))
+			var mapped *connect.Error
+			if !errors.As(interceptor.mapError(context.Background(), playgroundv1connect.MessageServiceCreateMessageProcedure, err), &mapped) {
+				t.Fatal("expected a connect error")
+			}
+			if mapped.Code() != connect.CodeUnavailable {
+				t.Fatalf("expected Unavailable, got %s", mapped.Code())
+			}
+			if strings.Contains(mapped.Message(), cause) {
+				t.Errorf("expected the cause to be hidden, got %q", mapped.Message())
+			}
+			if delay := errorDetail[*errdetails.RetryInfo](t, mapped).RetryDelay.AsDuration(); delay != busyRetryDelay {
+				t.Errorf("expected a retry delay of %s, got %s", busyRetryDelay, delay)
+			}
+			if reason := errorDetail[*errdetails.ErrorInfo](t, mapped).Reason; reason != reasonUnavailable {
+				t.Errorf("expected the reason %s, got %s", reasonUnavailable, reason)
+			}
+		})
+	}
+	if log.Len() != 0 {
+		t.Errorf("expected a busy database not to be logged as an internal error, got %s", log.String())
+	}
+}
+
+func TestCorrelationIDReachesClients(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle(playgroundv1connect.NewMessageServiceHandler(failingMessageService{},
+		connect.WithInterceptors(&errorInterceptor{logger: testLogger(t)})))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	for _, protocol := range []connect.ClientOption{connect.WithProtoJSON(), connect.WithGRPC()} {
+		c := playgroundv1connect.NewMessageServiceClient(server.Client(), server.URL, protocol)
+		_, err := c.GetMessage(context.Background(), connect.NewRequest(&playgroundv1.GetMessageRequest{MessageId: "m"}))
+		var connectErr *connect.Error
+		if !errors.As(err, &connectErr) || connectErr.Code() != connect.CodeInternal {
+			t.Fatalf("expected an internal error, got %v", err)
+		}
+		id := connectErr.Meta().Get(CorrelationHeader)
+		if id == "" || !strings.Contains(connectErr.Message(), id) || strings.Contains(connectErr.Message(), "messages") {
+			t.Errorf("expected only the correlation ID %q to reach the client, got %q", id, connectErr.Message())
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+)
+
+// etag returns the strong entity tag of a resource, a hash of its
+// deterministic encoding, so that it changes whenever anything a client sees
+// of the resource does.
+func etag(msg proto.Message) (string, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// setCacheHeaders marks a response as revalidated with its entity tag before
+// every use. Responses differ between tenants, so they vary on the headers
+// that pick the tenant.
+func setCacheHeaders(header http.Header, tag string) {
+	header.Set("ETag", tag)
+	header.Set("Cache-Control", "no-cache")
+	header.Add("Vary", "Authorization")
+	header.Add("Vary", TenantHeader)
+}
+
+// representationTag derives the entity tag of one representation of a
+// resource from the resource's tag, suffixing it with the content type and
+// coding of the representation when they aren't plain JSON. Each
+// representation then has its own strong tag, while resourceTag can still
+// recover the resource's.
+func representationTag(tag string, header http.Header) string {
+	var suffixes []string
+	if mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type")); err == nil && mediaType != "application/json" {
+		_, subtype, _ := strings.Cut(mediaType, "/")
+		suffixes = append(suffixes, subtype)
+	}
+	if encoding := header.Get("Content-Encoding"); encoding != "" && encoding != "identity" {
+		suffixes = append(suffixes, encoding)
+	}
+	if len(suffixes) == 0 {
+		return tag
+	}
+	return strings.TrimSuffix(tag, `"`) + "-" + strings.Join(suffixes, "-") + `"`
+}
+
+// resourceTag returns the tag of the resource a representation's tag was
+// derived from, as the hash etag makes never holds a dash.
+func resourceTag(tag string) string {
+	if hash, _, ok := strings.Cut(tag, "-"); ok {
+		return hash + `"`
+	}
+	return tag
+}
+
+// etagsMatch reports whether the list of entity tags in If-Match names a
+// representation of the resource with the given tag, using the strong
+// comparison If-Match calls for.
+func etagsMatch(list, tag string) bool {
+	for candidate := range strings.SplitSeq(list, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || resourceTag(candidate) == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// checkIfMatch enforces the If-Match header of a request that changes a
+// resource, which fails when the resource changed since the client read the
+// given entity tag.
+func checkIfMatch(header http.Header, current proto.Message) error {
+	list := header.Get("If-Match")
+	if list == "" {
+		return nil
+	}
+	tag, err := etag(current)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if !etagsMatch(list, tag) {
+		return withReason(
+			connect.NewError(connect.CodeAborted, fmt.Errorf("resource has changed, its entity tag is now %s", tag)),
+			reasonETagMismatch,
+			map[string]string{"etag": tag},
+		)
+	}
+	return nil
+}
+
+// bufferedResponseWriter holds back a response so that it can be replaced or
+// rewritten once it is complete.
+type bufferedResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) Header() http.Header { return w.header }
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.body.Write(data)
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+// Flush does nothing, as the response is only written once it's complete, but
+// the transcoder insists on a writer that can flush.
+func (w *bufferedResponseWriter) Flush() {}
+
+// copyHeader copies a held back response's header, keeping the Vary values
+// outer handlers already set.
+func copyHeader(dst, src http.Header) {
+	for key, values := range src {
+		if key == "Vary" {
+			dst[key] = append(dst[key], values...)
+			continue
+		}
+		dst[key] = values
+	}
+}
+
+// representationETags replaces the entity tag handlers set from a resource
+// with the tag of the representation the response ends up being, so it has to
+// wrap whatever re-encodes or compresses responses.
+func representationETags(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(&etagResponseWriter{ResponseWriter: w}, r)
+	})
+}
+
+// etagResponseWriter rewrites the entity tag of a response as its header is
+// written, once its content type and coding are settled.
+type etagResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *etagResponseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		header := w.Header()
+		if tag := header.Get("ETag"); tag != "" {
+			header.Set("ETag", representationTag(tag, header))
+		}
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *etagResponseWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *etagResponseWriter) FlushError() error {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+func (w *etagResponseWriter) Flush() {
+	_ = w.FlushError()
+}
+
+func (w *etagResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// conditionalGET answers REST GET requests whose If-None-Match names the
+// entity tag of the response with 304 Not Modified. It goes around the
+// handlers that pick the representation, so that it compares the tag of the
+// representation the client would get. The transcoder buffers REST responses
+// anyway, so holding them back here costs nothing extra.
+func conditionalGET(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ifNoneMatch := r.Header.Get("If-None-Match")
+		if ifNoneMatch == "" || (r.Method != http.MethodGet && r.Method != http.MethodHead) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		recorded := &bufferedResponseWriter{header: http.Header{}}
+		next.ServeHTTP(recorded, r)
+		if recorded.status == 0 {
+			recorded.status = http.StatusOK
+		}
+
+		copyHeader(w.Header(), recorded.header)
+		if tag := recorded.header.Get("ETag"); recorded.status == http.StatusOK && tag != "" && weakMatch(ifNoneMatch, tag) {
+			w.Header().Del("Content-Length")
+			w.Header().Del("Content-Type")
+			w.Header().Del("Content-Encoding")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.WriteHeader(recorded.status)
+		_, _ = w.Write(recorded.body.Bytes())
+	})
+}
+
+// weakMatch reports whether the list of entity tags in If-None-Match names
+// the given tag, using the weak comparison it calls for.
+func weakMatch(list, tag string) bool {
+	tag = strings.TrimPrefix(tag, "W/")
+	for candidate := range strings.SplitSeq(list, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,201 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+)
+
+// restCall makes a REST call with the given headers, returning its status,
+// headers and body.
+func restCall(t *testing.T, method, url, body string, header map[string]string) (int, http.Header, string) {
+	t.Helper()
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	request, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != "" {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range header {
+		request.Header.Set(key, value)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return response.StatusCode, response.Header, string(content)
+}
+
+func TestConditionalGET(t *testing.T) {
+	s := newTestServer(t, Config{})
+	url := s.URL + "/v1/messages/" + createMessage(t, s.client(t), "hello")
+
+	status, header, _ := restCall(t, http.MethodGet, url, "", nil)
+	tag := header.Get("ETag")
+	if status != http.StatusOK || tag == "" {
+		t.Fatalf("expected the message with an entity tag, got %d %q", status, tag)
+	}
+	if status, _, _ := restCall(t, http.MethodGet, url, "", map[string]string{"If-None-Match": tag}); status != http.StatusNotModified {
+		t.Errorf("expected an unchanged message to be 304, got %d", status)
+	}
+	if status, _, _ := restCall(t, http.MethodGet, url, "", map[string]string{"If-None-Match": `"stale"`}); status != http.StatusOK {
+		t.Errorf("expected a changed message to be 200, got %d", status)
+	}
+}
+
+func TestIfMatchUpdate(t *testing.T) {
+	s := newTestServer(t, Config{})
+	url := s.URL + "/v1/messages/" + createMessage(t, s.client(t), "hello")
+
+	_, header, _ := restCall(t, http.MethodGet, url, "", nil)
+	tag := header.Get("ETag")
+
+	status, _, body := restCall(t, http.MethodPatch, url, `{"text":"lost update"}`, map[string]string{"If-Match": `"stale"`})
+	if status != http.StatusConflict || !strings.Contains(body, reasonETagMismatch) {
+		t.Fatalf("expected a stale If-Match to be refused, got %d: %s", status, body)
+	}
+
+	status, header, body = restCall(t, http.MethodPatch, url, `{"text":"first"}`, map[string]string{"If-Match": tag})
+	if status != http.StatusOK || !strings.Contains(body, "first") {
+		t.Fatalf("expected a current If-Match to update the message, got %d: %s", status, body)
+	}
+	updated := header.Get("ETag")
+	if _, header, _ := restCall(t, http.MethodGet, url, "", nil); updated == "" || header.Get("ETag") != updated {
+		t.Errorf("expected the update to return the new entity tag %q, got %q", header.Get("ETag"), updated)
+	}
+
+	// a second writer still holding the first tag loses
+	status, _, body = restCall(t, http.MethodPatch, url, `{"text":"second"}`, map[string]string{"If-Match": tag})
+	if status != http.StatusConflict {
+		t.Fatalf("expected an update from a stale read to be refused, got %d: %s", status, body)
+	}
+	if _, _, body := restCall(t, http.MethodGet, url, "", nil); !strings.Contains(body, "first") {
+		t.Errorf("expected the refused update to change nothing, got %s", body)
+	}
+
+	if status, _, body := restCall(t, http.MethodPatch, url, `{"text":"any"}`, map[string]string{"If-Match": "*"}); status != http.StatusOK {
+		t.Errorf("expected If-Match * to update the message, got %d: %s", status, body)
+	}
+
+	// Connect calls send the header the same way
+	request := connect.NewRequest(&playgroundv1.UpdateMessageRequest{
+		MessageId:  strings.TrimPrefix(url, s.URL+"/v1/messages/"),
+		Text:       "connect",
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"text"}},
+	})
+	request.Header().Set("If-Match", tag)
+	if _, err := s.client(t).UpdateMessage(context.Background(), request); connect.CodeOf(err) != connect.CodeAborted {
+		t.Errorf("expected a stale If-Match to be Aborted, got %v", err)
+	}
+}
+
+func TestIfMatchDelete(t *testing.T) {
+	s := newTestServer(t, Config{})
+	url := s.URL + "/v1/messages/" + createMessage(t, s.client(t), "hello")
+
+	_, header, _ := restCall(t, http.MethodGet, url, "", nil)
+	tag := header.Get("ETag")
+	if status, _, body := restCall(t, http.MethodPatch, url, `{"text":"changed"}`, nil); status != http.StatusOK {
+		t.Fatalf("expected the update to succeed, got %d: %s", status, body)
+	}
+
+	if status, _, body := restCall(t, http.MethodDelete, url, "", map[string]string{"If-Match": tag}); status != http.StatusConflict {
+		t.Fatalf("expected deleting a changed message to be refused, got %d: %s", status, body)
+	}
+	_, header, _ = restCall(t, http.MethodGet, url, "", nil)
+	if status, _, body := restCall(t, http.MethodDelete, url, "", map[string]string{"If-Match": header.Get("ETag")}); status != http.StatusOK {
+		t.Fatalf("expected deleting the current message to succeed, got %d: %s", status, body)
+	}
+	if status, _, _ := restCall(t, http.MethodGet, url, "", nil); status != http.StatusNotFound {
+		t.Errorf("expected the message to be deleted, got %d", status)
+	}
+}
+
+func TestRepresentationETags(t *testing.T) {
+	s := newTestServer(t, Config{CompressMinBytes: 64})
+	url := s.URL + "/v1/messages/" + createMessage(t, s.client(t), strings.Repeat("hello ", 50))
+
+	// identity keeps the transport from asking for gzip itself
+	representations := map[string]map[string]string{
+		"json":          {"Accept-Encoding": "identity"},
+		"gzip":          {"Accept-Encoding": "gzip"},
+		"zstd":          {"Accept-Encoding": "zstd"},
+		"protobuf":      {"Accept": protobufContentType, "Accept-Encoding": "identity"},
+		"protobuf gzip": {"Accept": protobufContentType, "Accept-Encoding": "gzip"},
+		"protobuf zstd": {"Accept": protobufContentType, "Accept-Encoding": "zstd"},
+	}
+	tags := map[string]string{}
+	for name, headers := range representations {
+		status, header, _ := restCall(t, http.MethodGet, url, "", headers)
+		if status != http.StatusOK || header.Get("ETag") == "" {
+			t.Fatalf("expected %s with an entity tag, got %d", name, status)
+		}
+		vary := strings.Join(header.Values("Vary"), ",")
+		if !strings.Contains(vary, "Accept") || !strings.Contains(vary, "Accept-Encoding") {
+			t.Errorf("expected %s to vary by Accept and Accept-Encoding, got %q", name, vary)
+		}
+		tags[name] = header.Get("ETag")
+	}
+	seen := map[string]string{}
+	for name, tag := range tags {
+		if strings.HasPrefix(tag, "W/") {
+			t.Errorf("expected %s to have a strong tag, got %s", name, tag)
+		}
+		if other, ok := seen[tag]; ok {
+			t.Errorf("expected %s and %s to have their own tags, both got %s", name, other, tag)
+		}
+		seen[tag] = name
+	}
+
+	for name, headers := range representations {
+		// each representation revalidates against its own tag only
+		conditional := map[string]string{"If-None-Match": tags[name]}
+		for key, value := range headers {
+			conditional[key] = value
+		}
+		status, header, _ := restCall(t, http.MethodGet, url, "", conditional)
+		if status != http.StatusNotModified || header.Get("ETag") != tags[name] {
+			t.Errorf("expected %s to be 304 with its tag %s, got %d with %s", name, tags[name], status, header.Get("ETag"))
+		}
+		if name != "json" {
+			conditional["If-None-Match"] = tags["json"]
+			if status, _, _ := restCall(t, http.MethodGet, url, "", conditional); status != http.StatusOK {
+				t.Errorf("expected the JSON tag not to revalidate %s, got %d", name, status)
+			}
+		}
+	}
+
+	// any representation's tag is good for If-Match, as they all name the
+	// same message
+	status, header, body := restCall(t, http.MethodPatch, url, `{"text":"first"}`, map[string]string{"If-Match": tags["protobuf zstd"]})
+	if status != http.StatusOK {
+		t.Fatalf("expected the protobuf zstd tag to match, got %d: %s", status, body)
+	}
+	updated := header.Get("ETag")
+	if status, _, _ := restCall(t, http.MethodPatch, url, `{"text":"weak"}`, map[string]string{"If-Match": "W/" + updated}); status != http.StatusConflict {
+		t.Errorf("expected a weak tag to fail the strong comparison of If-Match, got %d", status)
+	}
+	if status, _, body := restCall(t, http.MethodPatch, url, `{"text":"second"}`, map[string]string{"If-Match": updated}); status != http.StatusOK {
+		t.Errorf("expected the tag of the update's response to match, got %d: %s", status, body)
+	}
+	if status, _, _ := restCall(t, http.MethodPatch, url, `{"text":"stale"}`, map[string]string{"If-Match": tags["gzip"]}); status != http.StatusConflict {
+		t.Errorf("expected the tag of a stale representation to be refused, got %d", status)
+	}
+}
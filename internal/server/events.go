@@ -0,0 +1,293 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"buf.build/go/protovalidate"
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1/playgroundv1connect"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+const (
+	eventMessageCreated        = "playground.v1.message.created"
+	eventMessageUpdated        = "playground.v1.message.updated"
+	eventMessageDeleted        = "playground.v1.message.deleted"
+	eventMessageSent           = "playground.v1.message.sent"
+	eventOperationStateChanged = "playground.v1.operation.state_changed"
+	eventAttachmentCreated     = "playground.v1.attachment.created"
+	eventAttachmentDeleted     = "playground.v1.attachment.deleted"
+
+	// eventSource is the CloudEvents source of every event.
+	eventSource = "/" + playgroundv1connect.MessageServiceName
+
+	// eventPollInterval is how often StreamEvents checks for new events once
+	// it has caught up.
+	eventPollInterval = 500 * time.Millisecond
+	// eventBatchSize is the most events StreamEvents reads at a time.
+	eventBatchSize = 100
+)
+
+// recordEvent appends an event to the events table. queries should be the
+// transaction making the change the event describes.
+func recordEvent(ctx context.Context, queries *models.Queries, tenant string, event *playgroundv1.Event) (models.Event, error) {
+	data, err := protojson.Marshal(event)
+	if err != nil {
+		return models.Event{}, err
+	}
+
+	return queries.CreateEvent(ctx, models.CreateEventParams{
+		TenantID: tenant,
+		ID:       uuid.New().String(),
+		Type:     event.Type,
+		Subject:  event.Subject,
+		Data:     string(data),
+	})
+}
+
+func messageEvent(eventType string, message *playgroundv1.Message) *playgroundv1.Event {
+	return &playgroundv1.Event{
+		Type:    eventType,
+		Subject: message.MessageId,
+		Data:    &playgroundv1.Event_Message{Message: message},
+	}
+}
+
+func operationEvent(eventType string, operation models.SentMessage) *playgroundv1.Event {
+	return &playgroundv1.Event{
+		Type:    eventType,
+		Subject: operation.ID,
+		Data: &playgroundv1.Event_Operation{Operation: &playgroundv1.OperationEvent{
+			MessageId:   operation.MessageID,
+			OperationId: operation.ID,
+			State:       playgroundv1.MessageState(playgroundv1.MessageState_value[operation.Result]),
+			RedriveOf:   operation.RedriveOf.String,
+		}},
+	}
+}
+
+// setOperationState moves an operation to a new state and records the change
+// as an event, queueing webhook deliveries when the new state is terminal.
+func setOperationState(ctx context.Context, queries *models.Queries, tenant, id string, state playgroundv1.MessageState) (models.SentMessage, error) {
+	operation, err := queries.UpdateSentMessage(ctx, models.UpdateSentMessageParams{
+		TenantID: tenant,
+		ID:       id,
+		Result:   state.String(),
+	})
+	if err != nil {
+		return operation, err
+	}
+
+	event, err := recordEvent(ctx, queries, tenant, operationEvent(eventOperationStateChanged, operation))
+	if err != nil {
+		return operation, err
+	}
+
+	if isTerminal(state) {
+		return operation, enqueueWebhookDeliveries(ctx, queries, tenant, event, operation)
+	}
+	return operation, nil
+}
+
+func eventFromModel(model models.Event) (*playgroundv1.Event, error) {
+	var event playgroundv1.Event
+	if err := protojson.Unmarshal([]byte(model.Data), &event); err != nil {
+		return nil, err
+	}
+
+	event.Offset = model.Seq
+	event.EventId = model.ID
+	event.Time = timestamppb.New(model.CreatedAt)
+	return &event, nil
+}
+
+// cloudEvent renders an event as a structured mode CloudEvents JSON document,
+// followed by a newline so that a stream of them is newline delimited.
+func cloudEvent(event *playgroundv1.Event) (*httpbody.HttpBody, error) {
+	var data proto.Message
+	switch payload := event.Data.(type) {
+	case *playgroundv1.Event_Message:
+		data = payload.Message
+	case *playgroundv1.Event_Operation:
+		data = payload.Operation
+	case *playgroundv1.Event_Attachment:
+		data = payload.Attachment
+	}
+
+	var encoded json.RawMessage
+	if data != nil {
+		var err error
+		if encoded, err = protojson.Marshal(data); err != nil {
+			return nil, err
+		}
+	}
+
+	document, err := json.Marshal(struct {
+		SpecVersion     string          `json:"specversion"`
+		ID              string          `json:"id"`
+		Source          string          `json:"source"`
+		Type            string          `json:"type"`
+		Subject         string          `json:"subject"`
+		Time            string          `json:"time"`
+		Sequence        string          `json:"sequence"`
+		DataContentType string          `json:"datacontenttype,omitempty"`
+		Data            json.RawMessage `json:"data,omitempty"`
+	}{
+		SpecVersion:     "1.0",
+		ID:              event.EventId,
+		Source:          eventSource,
+		Type:            event.Type,
+		Subject:         event.Subject,
+		Time:            event.Time.AsTime().Format(time.RFC3339Nano),
+		Sequence:        strconv.FormatInt(event.Offset, 10),
+		DataContentType: "application/json",
+		Data:            encoded,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &httpbody.HttpBody{
+		ContentType: "application/x-ndjson",
+		Data:        append(document, '\n'),
+	}, nil
+}
+
+func (h *handler) listEvents(ctx context.Context, tenant string, after int64, types []string) ([]models.Event, error) {
+	if len(types) == 0 {
+		return h.backend.ListEvents(ctx, models.ListEventsParams{
+			TenantID: tenant,
+			Seq:      after,
+			Limit:    eventBatchSize,
+		})
+	}
+	return h.backend.ListEventsByType(ctx, models.ListEventsByTypeParams{
+		TenantID: tenant,
+		AfterSeq: after,
+		Types:    types,
+		Limit:    eventBatchSize,
+	})
+}
+
+func (h *handler) StreamEvents(ctx context.Context, req *connect.Request[playgroundv1.StreamEventsRequest], stream *connect.ServerStream[playgroundv1.StreamEventsResponse]) error {
+	return h.streamEvents(ctx, tenantFromContext(ctx), req.Msg, func(event *playgroundv1.Event, body *httpbody.HttpBody) error {
+		return stream.Send(&playgroundv1.StreamEventsResponse{
+			Event:      event,
+			CloudEvent: body,
+		})
+	})
+}
+
+// streamEvents sends the tenant's events after the requested offset as they
+// are recorded until the context is done.
+func (h *handler) streamEvents(ctx context.Context, tenant string, req *playgroundv1.StreamEventsRequest, send func(*playgroundv1.Event, *httpbody.HttpBody) error) error {
+	ticker := time.NewTicker(eventPollInterval)
+	defer ticker.Stop()
+
+	offset := req.AfterOffset
+	for {
+		queried, err := h.listEvents(ctx, tenant, offset, req.Types)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return connect.NewError(connect.CodeInternal, err)
+		}
+
+		for _, model := range queried {
+			event, err := eventFromModel(model)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, err)
+			}
+			body, err := cloudEvent(event)
+			if err != nil {
+				return connect.NewError(connect.CodeInternal, err)
+			}
+			if err := send(event, body); err != nil {
+				return err
+			}
+			offset = model.Seq
+		}
+
+		if len(queried) == eventBatchSize {
+			// there may be more events ready to send
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// eventsHTTPHandler serves the REST binding of StreamEvents. The transcoder
+// buffers REST responses until they complete, which would hold back a stream
+// that never ends, so the route is served directly and flushes each event.
+func (h *handler) eventsHTTPHandler(errorMapper *errorInterceptor) http.Handler {
+	procedure := playgroundv1connect.MessageServiceStreamEventsProcedure
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeError := func(err error) {
+			writeRESTError(w, errorMapper.mapError(r.Context(), procedure, err))
+		}
+
+		tenant, err := h.resolveTenant(r.Context(), r.Header)
+		if err != nil {
+			writeError(err)
+			return
+		}
+
+		query := r.URL.Query()
+
+		req := &playgroundv1.StreamEventsRequest{
+			Types: query["types"],
+		}
+		// like the transcoder, take the field by its JSON name as well
+		if after := cmp.Or(query.Get("after_offset"), query.Get("afterOffset")); after != "" {
+			offset, err := strconv.ParseInt(after, 10, 64)
+			if err != nil {
+				writeError(violationsError(fieldViolation(req, "after_offset", "", "int64.parse", "value must be an integer")))
+				return
+			}
+			req.AfterOffset = offset
+		}
+		if err := protovalidate.Validate(req); err != nil {
+			var invalid *protovalidate.ValidationError
+			if errors.As(err, &invalid) {
+				writeError(violationsError(invalid.Violations...))
+				return
+			}
+			writeError(connect.NewError(connect.CodeInternal, err))
+			return
+		}
+
+		controller := http.NewResponseController(w)
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		_ = controller.Flush()
+
+		err = h.streamEvents(r.Context(), tenant, req, func(_ *playgroundv1.Event, body *httpbody.HttpBody) error {
+			if _, err := w.Write(body.Data); err != nil {
+				return err
+			}
+			return controller.Flush()
+		})
+		if err != nil && r.Context().Err() == nil {
+			h.logger.Err(err).Msg("Error streaming events")
+		}
+	})
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	"github.com/andrewstucki/vanguard-playground/internal/client"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+)
+
+// streamedEvents reads the first n events of a stream as "offset/type"
+// strings.
+func streamedEvents(t *testing.T, c *client.Client, req *playgroundv1.StreamEventsRequest, n int) []string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	stream, err := c.StreamEvents(ctx, connect.NewRequest(req))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	var events []string
+	for len(events) < n && stream.Receive() {
+		event := stream.Msg().Event
+		events = append(events, fmt.Sprintf("%d/%s", event.Offset, event.Type))
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return events
+}
+
+func TestStreamEvents(t *testing.T) {
+	s := newTestServer(t, Config{})
+	c := s.client(t)
+
+	first := createMessage(t, c, "first")
+	createMessage(t, c, "second")
+	if _, err := c.DeleteMessage(context.Background(), connect.NewRequest(&playgroundv1.DeleteMessageRequest{MessageId: first})); err != nil {
+		t.Fatal(err)
+	}
+
+	all := []string{
+		"1/" + eventMessageCreated,
+		"2/" + eventMessageCreated,
+		"3/" + eventMessageDeleted,
+	}
+	if got := streamedEvents(t, c, &playgroundv1.StreamEventsRequest{}, 3); !slices.Equal(got, all) {
+		t.Fatalf("expected events %q, got %q", all, got)
+	}
+	if got := streamedEvents(t, c, &playgroundv1.StreamEventsRequest{AfterOffset: 1}, 2); !slices.Equal(got, all[1:]) {
+		t.Errorf("expected to resume with events %q, got %q", all[1:], got)
+	}
+	if got := streamedEvents(t, c, &playgroundv1.StreamEventsRequest{Types: []string{eventMessageDeleted}}, 1); !slices.Equal(got, all[2:]) {
+		t.Errorf("expected only deletions %q, got %q", all[2:], got)
+	}
+
+	// events recorded while streaming are sent as well, the stream opening
+	// once the first of them is
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	created := make(chan error, 1)
+	go func() {
+		time.Sleep(2 * eventPollInterval)
+		_, err := c.CreateMessage(ctx, connect.NewRequest(&playgroundv1.CreateMessageRequest{Text: "third"}))
+		created <- err
+	}()
+	stream, err := c.StreamEvents(ctx, connect.NewRequest(&playgroundv1.StreamEventsRequest{AfterOffset: 3}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+	if err := <-created; err != nil {
+		t.Fatal(err)
+	}
+	if !stream.Receive() {
+		t.Fatalf("expected a new event, got %v", stream.Err())
+	}
+	if event := stream.Msg().Event; event.Offset != 4 || event.Type != eventMessageCreated {
+		t.Errorf("expected the new message's event, got %v", event)
+	}
+}
+
+func TestStreamEventsREST(t *testing.T) {
+	s := newTestServer(t, Config{})
+	c := s.client(t)
+	createMessage(t, c, "first")
+	createMessage(t, c, "second")
+
+	for _, query := range []string{"after_offset=1", "afterOffset=1"} {
+		t.Run(query, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			request, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL+"/v1/events?"+query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			response, err := http.DefaultClient.Do(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+
+			lines := bufio.NewScanner(response.Body)
+			if !lines.Scan() {
+				t.Fatalf("expected an event, got %v", lines.Err())
+			}
+			var event struct {
+				Type     string `json:"type"`
+				Sequence string `json:"sequence"`
+			}
+			if err := json.Unmarshal(lines.Bytes(), &event); err != nil {
+				t.Fatal(err)
+			}
+			if event.Sequence != "2" || event.Type != eventMessageCreated {
+				t.Errorf("expected to resume after the first event, got %s", lines.Text())
+			}
+		})
+	}
+
+	if status, _, body := restCall(t, http.MethodGet, s.URL+"/v1/events?afterOffset=first", "", nil); status != http.StatusBadRequest {
+		t.Errorf("expected an offset that isn't a number to be refused, got %d: %s", status, body)
+	}
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+)
+
+// nonRetryableError marks a step failure that should immediately move the
+// operation to a terminal state rather than being retried.
+type nonRetryableError struct {
+	err error
+}
+
+func (e *nonRetryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *nonRetryableError) Unwrap() error {
+	return e.err
+}
+
+func isNonRetryable(err error) bool {
+	var nonRetryable *nonRetryableError
+	return errors.As(err, &nonRetryable)
+}
+
+// injectFault applies the faults requested for an operation to a single
+// attempt of one of its steps. Injection is gated at request time, so any
+// fault present on a workflow input is honored here.
+func injectFault(fault *playgroundv1.FaultSpec, step string, attempt int64) error {
+	if fault == nil {
+		return nil
+	}
+
+	if latency := fault.GetLatency(); latency != nil {
+		time.Sleep(latency.AsDuration())
+	}
+
+	if fault.Panic {
+		panic(fmt.Sprintf("injected panic in step %q on attempt %d", step, attempt))
+	}
+
+	if fault.NonRetryable {
+		return &nonRetryableError{err: fmt.Errorf("injected non-retryable failure in step %q", step)}
+	}
+
+	if attempt <= int64(fault.FailAttempts) {
+		return fmt.Errorf("injected failure in step %q on attempt %d", step, attempt)
+	}
+
+	return nil
+}
+
+// crashAfterCommit terminates the process once a step has committed its
+// changes but before the workflow engine records the step as complete, so the
+// step is replayed against already-committed state.
+func (h *handler) crashAfterCommit(io *playgroundv1.SendMessageState, step string) {
+	if io.GetFault().GetCrashAfterCommit() {
+		h.logger.Fatal().Str("operation", io.OperationId).Str("step", step).Msg("Injected crash after commit")
+	}
+}
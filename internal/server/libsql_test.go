@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+// libsqlURLs are the libsql databases to test the persistent backend
+// against: a local database file, which runs the libsql engine in process,
+// and the sqld server in VANGUARD_TEST_LIBSQL_URL when it is set.
+func libsqlURLs(t *testing.T) map[string]string {
+	urls := map[string]string{
+		"file": "file:" + filepath.Join(t.TempDir(), "playground.db"),
+	}
+	if url := os.Getenv("VANGUARD_TEST_LIBSQL_URL"); url != "" {
+		urls["sqld"] = url
+	}
+	return urls
+}
+
+func searchMessages(t *testing.T, s *testServer, query string) []string {
+	t.Helper()
+	response, err := s.client(t).SearchMessages(context.Background(), connect.NewRequest(&playgroundv1.SearchMessagesRequest{
+		Q: query,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, result := range response.Msg.Results {
+		ids = append(ids, result.Message.MessageId)
+	}
+	return ids
+}
+
+func TestLibSQLBackend(t *testing.T) {
+	for name, url := range libsqlURLs(t) {
+		t.Run(name, func(t *testing.T) {
+			s := newTestServer(t, Config{
+				Persistent:          true,
+				DatabaseURL:         url,
+				BlobDir:             t.TempDir(),
+				AllowFaultInjection: true,
+			})
+			c := s.client(t)
+			ctx := context.Background()
+
+			// a word of its own, as a sqld database outlives the test
+			word := "w" + strings.ReplaceAll(uuid.NewString(), "-", "")
+			kept := createMessage(t, c, "kept "+word)
+			deleted := createMessage(t, c, "deleted "+word)
+			assertRows(t, searchMessages(t, s, "deleted "+word), []string{deleted})
+
+			// the triggers keep the full-text index in step with messages
+			if _, err := c.DeleteMessage(ctx, connect.NewRequest(&playgroundv1.DeleteMessageRequest{
+				MessageId: deleted,
+			})); err != nil {
+				t.Fatal(err)
+			}
+			assertRows(t, searchMessages(t, s, word), []string{kept})
+
+			operationID, status := sendAndWait(t, s, nil)
+			if status.State != playgroundv1.MessageState_SUCCEEDED.String() {
+				t.Fatalf("expected the send to succeed, got %s", status.State)
+			}
+			assertRows(t, attemptRows(t, s, operationID), []string{
+				"validate/1/", "reserve/1/", "render/1/", "deliver/1/", "bill/1/", "confirm/1/",
+			})
+
+			operationID, status = sendAndWait(t, s, &playgroundv1.FaultSpec{NonRetryable: true})
+			if status.State != playgroundv1.MessageState_FAILED.String() {
+				t.Fatalf("expected the send to fail, got %s", status.State)
+			}
+			deadLetters, err := s.handler.backend.ListDeadLetters(ctx, DefaultTenant)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var deadLettered bool
+			for _, deadLetter := range deadLetters {
+				deadLettered = deadLettered || deadLetter.OperationID == operationID
+			}
+			if !deadLettered {
+				t.Errorf("expected operation %q to be dead-lettered", operationID)
+			}
+		})
+	}
+}
+
+func TestWorkerSharesTheServersBlobs(t *testing.T) {
+	url := "file:" + filepath.Join(t.TempDir(), "playground.db")
+	if _, err := newServer(context.Background(), testLogger(t), Config{Persistent: true, DatabaseURL: url}); err == nil {
+		t.Fatal("expected a persistent server without a blob directory to be refused")
+	}
+
+	dir := t.TempDir()
+	s := newTestServer(t, Config{Persistent: true, DatabaseURL: url, BlobDir: dir})
+	c := s.client(t)
+	messageID := createMessage(t, c, "hello")
+	attachment := uploadAttachment(t, c, messageID, "content")
+
+	// a worker given the server's config verifies the content the server
+	// stored, which its validate step does before sending
+	worker, closers, err := newHandler(testLogger(t), Config{Persistent: true, DatabaseURL: url, BlobDir: dir})
+	t.Cleanup(func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			if err := closers[i](); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := worker.backend.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	model, err := worker.backend.GetAttachment(context.Background(), models.GetAttachmentParams{
+		TenantID:  DefaultTenant,
+		ID:        attachment.AttachmentId,
+		MessageID: messageID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := worker.verifyAttachment(context.Background(), model); err != nil {
+		t.Fatalf("expected the worker to verify the attachment, got %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+func TestUpdateMessage(t *testing.T) {
+	s := newTestServer(t, Config{})
+	c := s.client(t)
+	ctx := context.Background()
+
+	created, err := c.CreateMessage(ctx, connect.NewRequest(&playgroundv1.CreateMessageRequest{
+		Text:   "hello there",
+		Labels: map[string]string{"team": "a"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	messageID := created.Msg.MessageId
+
+	updated, err := c.UpdateMessage(ctx, connect.NewRequest(&playgroundv1.UpdateMessageRequest{
+		MessageId:  messageID,
+		Text:       "goodbye world",
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"text"}},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := updated.Msg.Message
+	if message.Text != "goodbye world" || message.Labels["team"] != "a" {
+		t.Fatalf("expected only the text to change, got %+v", message)
+	}
+
+	// the full-text index follows the new text
+	assertRows(t, searchMessages(t, s, "goodbye"), []string{messageID})
+	assertRows(t, searchMessages(t, s, "hello"), nil)
+
+	events, err := s.handler.backend.ListEventsByType(ctx, models.ListEventsByTypeParams{
+		TenantID: DefaultTenant,
+		Types:    []string{eventMessageUpdated},
+		Limit:    10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Subject != messageID {
+		t.Fatalf("expected an updated event for %q, got %+v", messageID, events)
+	}
+
+	_, err = c.UpdateMessage(ctx, connect.NewRequest(&playgroundv1.UpdateMessageRequest{
+		MessageId:  messageID,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"text"}},
+	}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("expected clearing the text to be InvalidArgument, got %v", err)
+	}
+	_, err = c.UpdateMessage(ctx, connect.NewRequest(&playgroundv1.UpdateMessageRequest{
+		MessageId:  messageID,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"message_id"}},
+	}))
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("expected updating message_id to be InvalidArgument, got %v", err)
+	}
+
+	// PATCH on the REST route updates the fields named by the mask
+	request, err := http.NewRequest(http.MethodPatch, s.URL+"/v1/messages/"+messageID+"?updateMask=labels", strings.NewReader(`{"labels":{"team":"b"}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	body, _ := io.ReadAll(response.Body)
+	if response.StatusCode != http.StatusOK || !strings.Contains(string(body), `"team":"b"`) || !strings.Contains(string(body), "goodbye world") {
+		t.Fatalf("expected the labels to be replaced, got %d: %s", response.StatusCode, body)
+	}
+}
+
+func TestMemoryBackendConcurrentQueries(t *testing.T) {
+	s := newTestServer(t, Config{})
+	createMessage(t, s.client(t), "hello")
+
+	// queries at once all reach the one in-memory database rather than
+	// empty ones of their own connections
+	const concurrent = 8
+	errs := make(chan error, concurrent)
+	for range concurrent {
+		go func() {
+			tx, queries, err := s.handler.backend.Tx(context.Background())
+			if err != nil {
+				errs <- err
+				return
+			}
+			defer tx.Rollback()
+			time.Sleep(10 * time.Millisecond)
+			_, err = queries.CountMessages(context.Background(), DefaultTenant)
+			errs <- err
+		}()
+	}
+	for range concurrent {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/andrewstucki/vanguard-playground/api"
+)
+
+const adminPathPrefix = "/v1/admin/"
+
+// adminTags name the services that take the admin token rather than a tenant's.
+var adminTags = []string{"AdminService", "AuditService", "TenantService"}
+
+// openAPISpec is the generated OpenAPI document completed with what only the
+// running server knows: where it listens and how callers authenticate.
+type openAPISpec struct {
+	yaml []byte
+	json []byte
+}
+
+func newOpenAPISpec(config Config) (*openAPISpec, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(api.OpenAPI, &document); err != nil {
+		return nil, fmt.Errorf("parsing OpenAPI spec: %w", err)
+	}
+	if document.Kind != yaml.DocumentNode || len(document.Content) != 1 {
+		return nil, errors.New("parsing OpenAPI spec: not a YAML document")
+	}
+	root := document.Content[0]
+
+	if err := configureOpenAPI(root, config); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(4)
+	if err := encoder.Encode(&document); err != nil {
+		return nil, fmt.Errorf("encoding OpenAPI spec: %w", err)
+	}
+
+	var decoded any
+	if err := root.Decode(&decoded); err != nil {
+		return nil, fmt.Errorf("decoding OpenAPI spec: %w", err)
+	}
+	encoded, err := json.MarshalIndent(decoded, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding OpenAPI spec: %w", err)
+	}
+
+	return &openAPISpec{yaml: out.Bytes(), json: encoded}, nil
+}
+
+// configureOpenAPI adds the servers and security schemes to the spec. The
+// AdminService paths are served from the admin listener, so they get its
+// address, or are dropped when it is disabled.
+func configureOpenAPI(root *yaml.Node, config Config) error {
+	if title := mappingValue(root, "info", "title"); title != nil && title.Value == "" {
+		title.Value = "Vanguard Playground"
+		title.Style = 0
+	}
+
+	servers, err := encodeNode([]map[string]string{{
+		"url":         fmt.Sprintf("http://localhost:%d", config.Port),
+		"description": "API listener",
+	}})
+	if err != nil {
+		return err
+	}
+	setMappingValue(root, "servers", servers)
+
+	schemes := map[string]map[string]string{
+		"tenantToken": {
+			"type":        "http",
+			"scheme":      "bearer",
+			"description": "The token of a tenant, which scopes the call to that tenant.",
+		},
+		"adminToken": {
+			"type":        "http",
+			"scheme":      "bearer",
+			"description": "The admin token the server was started with.",
+		},
+	}
+	tenantRequirement := []map[string][]string{{"tenantToken": {}}}
+	switch config.Tenancy {
+	case TenancySingle:
+		// the empty requirement keeps the token optional, as calls
+		// without one act as the default tenant
+		tenantRequirement = append(tenantRequirement, map[string][]string{})
+	case TenancyOpen:
+		schemes["tenantHeader"] = map[string]string{
+			"type":        "apiKey",
+			"in":          "header",
+			"name":        TenantHeader,
+			"description": "The tenant to act as, for tenants without a token. Calls without either act as the default tenant.",
+		}
+		tenantRequirement = append(tenantRequirement, map[string][]string{"tenantHeader": {}}, map[string][]string{})
+	}
+	securitySchemes, err := encodeNode(schemes)
+	if err != nil {
+		return err
+	}
+	components := mappingValue(root, "components")
+	if components == nil {
+		components = &yaml.Node{Kind: yaml.MappingNode}
+		setMappingValue(root, "components", components)
+	}
+	setMappingValue(components, "securitySchemes", securitySchemes)
+
+	tenantSecurity, err := encodeNode(tenantRequirement)
+	if err != nil {
+		return err
+	}
+	setMappingValue(root, "security", tenantSecurity)
+
+	adminSecurity, err := encodeNode([]map[string][]string{{"adminToken": {}}})
+	if err != nil {
+		return err
+	}
+	adminServers, err := encodeNode([]map[string]string{{
+		"url":         fmt.Sprintf("http://localhost:%d", config.AdminPort),
+		"description": "Admin listener",
+	}})
+	if err != nil {
+		return err
+	}
+
+	paths := mappingValue(root, "paths")
+	if paths == nil {
+		return nil
+	}
+	kept := paths.Content[:0]
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		path, item := paths.Content[i], paths.Content[i+1]
+		if strings.HasPrefix(path.Value, adminPathPrefix) {
+			if config.AdminPort == 0 {
+				continue
+			}
+			setMappingValue(item, "servers", adminServers)
+		}
+		for j := 1; j < len(item.Content); j += 2 {
+			operation := item.Content[j]
+			if operation.Kind == yaml.MappingNode && hasTag(operation, adminTags) {
+				setMappingValue(operation, "security", adminSecurity)
+			}
+		}
+		kept = append(kept, path, item)
+	}
+	paths.Content = kept
+
+	return nil
+}
+
+func hasTag(operation *yaml.Node, tags []string) bool {
+	node := mappingValue(operation, "tags")
+	if node == nil {
+		return false
+	}
+	for _, tag := range node.Content {
+		if slices.Contains(tags, tag.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// mappingValue follows the keys down from a mapping node, returning nil when
+// any of them is missing.
+func mappingValue(node *yaml.Node, keys ...string) *yaml.Node {
+	for _, key := range keys {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		var found *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				found = node.Content[i+1]
+				break
+			}
+		}
+		if found == nil {
+			return nil
+		}
+		node = found
+	}
+	return node
+}
+
+// setMappingValue replaces the value of a key in a mapping node, adding the key
+// at the end when the mapping doesn't have it.
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+func encodeNode(value any) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return nil, fmt.Errorf("encoding OpenAPI spec: %w", err)
+	}
+	return &node, nil
+}
+
+// ServeHTTP serves the spec as YAML or JSON depending on the extension of the
+// path it is routed under.
+func (s *openAPISpec) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.URL.Path, ".json") {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(s.json)
+		return
+	}
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(s.yaml)
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"gopkg.in/yaml.v3"
+
+	_ "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+)
+
+// httpRoute is a REST route declared with a google.api.http rule.
+type httpRoute struct {
+	method      string
+	path        string
+	operationID string
+	streaming   bool
+}
+
+var pathVariable = regexp.MustCompile(`\{([a-z0-9_]+)\}`)
+
+// openAPIPath spells the variables of a path template the way the OpenAPI
+// spec does, with the JSON names of their fields.
+func openAPIPath(path string) string {
+	return pathVariable.ReplaceAllStringFunc(path, func(variable string) string {
+		parts := strings.Split(strings.Trim(variable, "{}"), "_")
+		for i := 1; i < len(parts); i++ {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+		return "{" + strings.Join(parts, "") + "}"
+	})
+}
+
+// protoRoutes returns the REST routes the playground.v1 services declare,
+// keyed by method and OpenAPI path.
+func protoRoutes(t *testing.T) map[string]httpRoute {
+	t.Helper()
+	routes := map[string]httpRoute{}
+	add := func(service protoreflect.ServiceDescriptor, method protoreflect.MethodDescriptor, rule *annotations.HttpRule) {
+		route := httpRoute{
+			operationID: string(service.Name()) + "_" + string(method.Name()),
+			streaming:   method.IsStreamingServer(),
+		}
+		switch pattern := rule.GetPattern().(type) {
+		case *annotations.HttpRule_Get:
+			route.method, route.path = http.MethodGet, pattern.Get
+		case *annotations.HttpRule_Put:
+			route.method, route.path = http.MethodPut, pattern.Put
+		case *annotations.HttpRule_Post:
+			route.method, route.path = http.MethodPost, pattern.Post
+		case *annotations.HttpRule_Delete:
+			route.method, route.path = http.MethodDelete, pattern.Delete
+		case *annotations.HttpRule_Patch:
+			route.method, route.path = http.MethodPatch, pattern.Patch
+		default:
+			t.Fatalf("unsupported HTTP rule on %s", method.FullName())
+		}
+		route.path = openAPIPath(route.path)
+		routes[route.method+" "+route.path] = route
+	}
+
+	protoregistry.GlobalFiles.RangeFilesByPackage("playground.v1", func(file protoreflect.FileDescriptor) bool {
+		for i := range file.Services().Len() {
+			service := file.Services().Get(i)
+			for j := range service.Methods().Len() {
+				method := service.Methods().Get(j)
+				rule, ok := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+				if !ok || rule == nil {
+					continue
+				}
+				add(service, method, rule)
+				for _, binding := range rule.GetAdditionalBindings() {
+					add(service, method, binding)
+				}
+			}
+		}
+		return true
+	})
+	return routes
+}
+
+// specRoutes returns the operations of the OpenAPI spec the server serves,
+// keyed by method and path.
+func specRoutes(t *testing.T, s *testServer) map[string]httpRoute {
+	t.Helper()
+	response, err := http.Get(s.URL + "/openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected the spec, got %d: %s", response.StatusCode, body)
+	}
+
+	var document struct {
+		Paths map[string]map[string]yaml.Node `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(body, &document); err != nil {
+		t.Fatal(err)
+	}
+	methods := []string{http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodPatch}
+	routes := map[string]httpRoute{}
+	for path, item := range document.Paths {
+		for key, node := range item {
+			method := strings.ToUpper(key)
+			if !slices.Contains(methods, method) {
+				continue
+			}
+			var operation struct {
+				OperationID string `yaml:"operationId"`
+			}
+			if err := node.Decode(&operation); err != nil {
+				t.Fatal(err)
+			}
+			routes[method+" "+path] = httpRoute{method: method, path: path, operationID: operation.OperationID}
+		}
+	}
+	return routes
+}
+
+func TestOpenAPISpecMatchesProtos(t *testing.T) {
+	s := newTestServer(t, Config{})
+	declared := protoRoutes(t)
+	served := specRoutes(t, s)
+	if len(declared) == 0 {
+		t.Fatal("expected the protos to declare REST routes")
+	}
+
+	for key, route := range declared {
+		specified, ok := served[key]
+		switch {
+		case !ok:
+			t.Errorf("%s (%s) is missing from the OpenAPI spec, regenerate it", key, route.operationID)
+		case specified.operationID != route.operationID:
+			t.Errorf("%s is %s in the OpenAPI spec but %s in the protos", key, specified.operationID, route.operationID)
+		}
+	}
+	for key, route := range served {
+		if _, ok := declared[key]; !ok {
+			t.Errorf("%s (%s) is in the OpenAPI spec but no proto declares it", key, route.operationID)
+		}
+	}
+}
+
+func TestOpenAPIRoutesAreServed(t *testing.T) {
+	s := newTestServer(t, Config{})
+
+	for key, route := range protoRoutes(t) {
+		if route.streaming {
+			// event streams are served by their own handler and never end
+			continue
+		}
+		t.Run(route.operationID, func(t *testing.T) {
+			base := s.URL
+			if strings.HasPrefix(route.path, adminPathPrefix) {
+				base = s.AdminURL
+			}
+			path := regexp.MustCompile(`\{[A-Za-z]+\}`).ReplaceAllString(route.path, "00000000-0000-4000-8000-000000000000")
+
+			var body io.Reader
+			if route.method != http.MethodGet && route.method != http.MethodDelete {
+				body = strings.NewReader("{}")
+			}
+			request, err := http.NewRequest(route.method, base+path, body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if body != nil {
+				request.Header.Set("Content-Type", "application/json")
+			}
+			response, err := http.DefaultClient.Do(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			content, _ := io.ReadAll(response.Body)
+
+			// routes the transcoder doesn't know fall through to plain text
+			// 404 and 405 responses, while everything it routes answers in
+			// JSON, if only with an error
+			if strings.HasPrefix(response.Header.Get("Content-Type"), "text/plain") {
+				t.Errorf("%s is not routed by the server: %d %s", key, response.StatusCode, content)
+			}
+		})
+	}
+}
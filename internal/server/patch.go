@@ -0,0 +1,43 @@
+package server
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+const updateMaskField = "update_mask"
+
+// updatePaths returns which of the updatable paths an update request replaces.
+// Those are the paths of its update mask when it has one, and otherwise the
+// top-level fields it sets, so that fields left out of a PATCH body keep their
+// values. A mask naming a path that can't be updated is a violation.
+func updatePaths(msg proto.Message, mask *fieldmaskpb.FieldMask, updatable ...string) (map[string]bool, error) {
+	paths := map[string]bool{}
+
+	if len(mask.GetPaths()) == 0 {
+		reflected := msg.ProtoReflect()
+		fields := reflected.Descriptor().Fields()
+		for _, path := range updatable {
+			if strings.Contains(path, ".") {
+				continue
+			}
+			if field := fields.ByName(protoreflect.Name(path)); field != nil && reflected.Has(field) {
+				paths[path] = true
+			}
+		}
+		return paths, nil
+	}
+
+	for _, path := range mask.GetPaths() {
+		if !slices.Contains(updatable, path) {
+			return nil, violationsError(fieldViolation(msg, updateMaskField, "", "update_mask.paths", fmt.Sprintf("%q can't be updated, only %s", path, strings.Join(updatable, ", "))))
+		}
+		paths[path] = true
+	}
+	return paths, nil
+}
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// protobufContentType is the media type REST clients accept to get binary
+// protobuf rather than JSON.
+const protobufContentType = "application/x-protobuf"
+
+type procedureKey struct{}
+
+// recordProcedure wraps a service handler to note which procedure serves a
+// request, in the holder protobufResponses puts in the request's context. The
+// transcoder calls handlers by procedure path, so this is where a REST route
+// is matched to its RPC.
+func recordProcedure(path string, handler http.Handler) (string, http.Handler) {
+	return path, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if procedure, ok := r.Context().Value(procedureKey{}).(*string); ok {
+			*procedure = r.URL.Path
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// acceptsProtobuf reports whether a REST request asks for binary protobuf.
+func acceptsProtobuf(header http.Header) bool {
+	for _, value := range header.Values("Accept") {
+		for mediaRange := range strings.SplitSeq(value, ",") {
+			mediaType, params, err := mime.ParseMediaType(mediaRange)
+			if err != nil || mediaType != protobufContentType {
+				continue
+			}
+			if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+				continue
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// protobufResponses re-encodes the JSON the transcoder answers REST requests
+// with as binary protobuf for clients that accept it. Errors become a binary
+// google.rpc.Status, and HttpBody responses are left as they are.
+func protobufResponses(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept")
+		if !acceptsProtobuf(r.Header) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		var procedure string
+		r = r.Clone(context.WithValue(r.Context(), procedureKey{}, &procedure))
+		// the JSON can only be re-encoded uncompressed, so the response is
+		// compressed after it is, by compressResponses
+		r.Header.Del("Accept-Encoding")
+
+		recorded := &bufferedResponseWriter{header: http.Header{}}
+		next.ServeHTTP(recorded, r)
+		if recorded.status == 0 {
+			recorded.status = http.StatusOK
+		}
+
+		copyHeader(w.Header(), recorded.header)
+		body := recorded.body.Bytes()
+		if encoded, ok := jsonToProtobuf(procedure, recorded.status, recorded.header, body); ok {
+			body = encoded
+			w.Header().Set("Content-Type", protobufContentType)
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		}
+		w.WriteHeader(recorded.status)
+		_, _ = w.Write(body)
+	})
+}
+
+// jsonToProtobuf re-encodes a JSON response of a procedure as binary
+// protobuf, reporting false for responses that aren't JSON messages.
+func jsonToProtobuf(procedure string, code int, header http.Header, body []byte) ([]byte, bool) {
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return nil, false
+	}
+
+	var msg proto.Message
+	if code >= http.StatusBadRequest {
+		msg = &status.Status{}
+	} else {
+		name := protoreflect.FullName(strings.ReplaceAll(strings.TrimPrefix(procedure, "/"), "/", "."))
+		descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+		if err != nil {
+			return nil, false
+		}
+		method, ok := descriptor.(protoreflect.MethodDescriptor)
+		if !ok {
+			return nil, false
+		}
+		output := method.Output()
+		if output.FullName() == (&httpbody.HttpBody{}).ProtoReflect().Descriptor().FullName() {
+			return nil, false
+		}
+		messageType, err := protoregistry.GlobalTypes.FindMessageByName(output.FullName())
+		if err != nil {
+			return nil, false
+		}
+		msg = messageType.New().Interface()
+	}
+
+	if err := protojson.Unmarshal(body, msg); err != nil {
+		return nil, false
+	}
+	encoded, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, false
+	}
+	return encoded, true
+}
@@ -0,0 +1,241 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+func recipientFromModel(model models.Recipient) *playgroundv1.Recipient {
+	return &playgroundv1.Recipient{
+		RecipientId: model.ID,
+		Address:     model.Address,
+		Channel:     playgroundv1.Channel(playgroundv1.Channel_value[model.Channel]),
+	}
+}
+
+// fanOut creates a child operation for each recipient under the parent
+// operation, returning the workflow inputs to schedule once the transaction
+// commits.
+func fanOut(ctx context.Context, queries *models.Queries, parent models.SentMessage, recipientIDs []string, io *playgroundv1.SendMessageState) ([]*playgroundv1.SendMessageState, error) {
+	var children []*playgroundv1.SendMessageState
+	for _, recipientID := range recipientIDs {
+		recipient, err := queries.GetRecipient(ctx, models.GetRecipientParams{
+			TenantID: parent.TenantID,
+			ID:       recipientID,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, notFoundError(resourceRecipient, recipientID, fmt.Errorf("recipient with ID %q not found", recipientID))
+			}
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		child, err := queries.CreateSentMessage(ctx, models.CreateSentMessageParams{
+			TenantID:  parent.TenantID,
+			ID:        uuid.New().String(),
+			MessageID: parent.MessageID,
+			Text:      parent.Text,
+			Result:    playgroundv1.MessageState_SENDING.String(),
+		})
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		// the address is copied so later changes to the recipient don't
+		// affect sends already in flight
+		if _, err := queries.CreateOperationRecipient(ctx, models.CreateOperationRecipientParams{
+			TenantID:    parent.TenantID,
+			OperationID: child.ID,
+			ParentID:    parent.ID,
+			RecipientID: recipient.ID,
+			Address:     recipient.Address,
+			Channel:     recipient.Channel,
+		}); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+
+		children = append(children, &playgroundv1.SendMessageState{
+			TenantId:    parent.TenantID,
+			OperationId: child.ID,
+			Fault:       io.Fault,
+			TemplateId:  io.TemplateId,
+			Variables:   io.Variables,
+			RecipientId: recipient.ID,
+		})
+	}
+	return children, nil
+}
+
+// aggregateState derives the state of a parent operation from the states of
+// its children.
+func aggregateState(children []models.ListChildOperationsRow) playgroundv1.MessageState {
+	var succeeded, canceled int
+	for _, child := range children {
+		switch child.SentMessage.Result {
+		case playgroundv1.MessageState_SENDING.String():
+			return playgroundv1.MessageState_SENDING
+		case playgroundv1.MessageState_SUCCEEDED.String():
+			succeeded++
+		case playgroundv1.MessageState_CANCELED.String():
+			canceled++
+		}
+	}
+
+	switch {
+	case succeeded == len(children):
+		return playgroundv1.MessageState_SUCCEEDED
+	case succeeded > 0:
+		return playgroundv1.MessageState_PARTIALLY_SUCCEEDED
+	case canceled == len(children):
+		return playgroundv1.MessageState_CANCELED
+	default:
+		return playgroundv1.MessageState_FAILED
+	}
+}
+
+// updateParent recomputes the state of the given parent operation from its
+// children.
+func updateParent(ctx context.Context, queries *models.Queries, tenant, parentID string) error {
+	children, err := queries.ListChildOperations(ctx, models.ListChildOperationsParams{
+		TenantID: tenant,
+		ParentID: parentID,
+	})
+	if err != nil {
+		return err
+	}
+	if len(children) == 0 {
+		return nil
+	}
+
+	parent, err := queries.GetSentMessageByID(ctx, models.GetSentMessageByIDParams{
+		TenantID: tenant,
+		ID:       parentID,
+	})
+	if err != nil {
+		return err
+	}
+
+	state := aggregateState(children)
+	if parent.Result == state.String() {
+		return nil
+	}
+
+	_, err = setOperationState(ctx, queries, tenant, parentID, state)
+	return err
+}
+
+// updateParentOf recomputes the state of the parent of a child operation once
+// the child is terminal. It does nothing for operations without a parent.
+func (h *handler) updateParentOf(ctx context.Context, io *playgroundv1.SendMessageState) error {
+	if io.RecipientId == "" {
+		return nil
+	}
+
+	tx, queries, err := h.backend.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	link, err := queries.GetOperationRecipient(ctx, models.GetOperationRecipientParams{
+		TenantID:    io.TenantId,
+		OperationID: io.OperationId,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := updateParent(ctx, queries, io.TenantId, link.ParentID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func recipientStatuses(children []models.ListChildOperationsRow) []*playgroundv1.RecipientStatus {
+	var statuses []*playgroundv1.RecipientStatus
+	for _, child := range children {
+		statuses = append(statuses, &playgroundv1.RecipientStatus{
+			Recipient: &playgroundv1.Recipient{
+				RecipientId: child.OperationRecipient.RecipientID,
+				Address:     child.OperationRecipient.Address,
+				Channel:     playgroundv1.Channel(playgroundv1.Channel_value[child.OperationRecipient.Channel]),
+			},
+			OperationId: child.SentMessage.ID,
+			State:       child.SentMessage.Result,
+			CurrentStep: child.SentMessage.Step,
+		})
+	}
+	return statuses
+}
+
+func (h *handler) CreateRecipient(ctx context.Context, req *connect.Request[playgroundv1.CreateRecipientRequest]) (*connect.Response[playgroundv1.CreateRecipientResponse], error) {
+	model, err := h.backend.CreateRecipient(ctx, models.CreateRecipientParams{
+		TenantID: tenantFromContext(ctx),
+		ID:       uuid.New().String(),
+		Address:  req.Msg.Address,
+		Channel:  req.Msg.Channel.String(),
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&playgroundv1.CreateRecipientResponse{
+		RecipientId: model.ID,
+	}), nil
+}
+
+func (h *handler) GetRecipient(ctx context.Context, req *connect.Request[playgroundv1.GetRecipientRequest]) (*connect.Response[playgroundv1.GetRecipientResponse], error) {
+	model, err := h.backend.GetRecipient(ctx, models.GetRecipientParams{
+		TenantID: tenantFromContext(ctx),
+		ID:       req.Msg.RecipientId,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFoundError(resourceRecipient, req.Msg.RecipientId, fmt.Errorf("recipient with ID %q not found", req.Msg.RecipientId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&playgroundv1.GetRecipientResponse{
+		Recipient: recipientFromModel(model),
+	}), nil
+}
+
+func (h *handler) ListRecipients(ctx context.Context, _ *connect.Request[playgroundv1.ListRecipientsRequest]) (*connect.Response[playgroundv1.ListRecipientsResponse], error) {
+	queried, err := h.backend.ListRecipients(ctx, tenantFromContext(ctx))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	var recipients []*playgroundv1.Recipient
+	for _, model := range queried {
+		recipients = append(recipients, recipientFromModel(model))
+	}
+
+	return connect.NewResponse(&playgroundv1.ListRecipientsResponse{
+		Recipients: recipients,
+	}), nil
+}
+
+func (h *handler) DeleteRecipient(ctx context.Context, req *connect.Request[playgroundv1.DeleteRecipientRequest]) (*connect.Response[playgroundv1.DeleteRecipientResponse], error) {
+	rows, err := h.backend.DeleteRecipient(ctx, models.DeleteRecipientParams{
+		TenantID: tenantFromContext(ctx),
+		ID:       req.Msg.RecipientId,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if rows == 0 {
+		return nil, notFoundError(resourceRecipient, req.Msg.RecipientId, fmt.Errorf("recipient with ID %q not found", req.Msg.RecipientId))
+	}
+	return connect.NewResponse(&playgroundv1.DeleteRecipientResponse{}), nil
+}
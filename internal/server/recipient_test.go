@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+// fanOutAndWait sends a message to two recipients with the given fault and
+// waits for every child to finish, returning the sent message and operation.
+func fanOutAndWait(t *testing.T, s *testServer, fault *playgroundv1.FaultSpec) (string, string, *playgroundv1.MessageStatusResponse) {
+	t.Helper()
+	c := s.client(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var recipients []string
+	for _, address := range []string{"a@example.com", "b@example.com"} {
+		recipient, err := c.CreateRecipient(ctx, connect.NewRequest(&playgroundv1.CreateRecipientRequest{Address: address}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		recipients = append(recipients, recipient.Msg.RecipientId)
+	}
+	sent, err := c.SendMessage(ctx, connect.NewRequest(&playgroundv1.SendMessageRequest{
+		MessageId:  createMessage(t, c, "hello"),
+		Recipients: recipients,
+		Fault:      fault,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err := c.WaitForOperation(ctx, sent.Msg.MessageId, sent.Msg.OperationId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Recipients) != len(recipients) {
+		t.Fatalf("expected a status per recipient, got %v", status.Recipients)
+	}
+	return sent.Msg.MessageId, sent.Msg.OperationId, status
+}
+
+func TestFanOutSucceeds(t *testing.T) {
+	s := newTestServer(t, Config{})
+	_, _, status := fanOutAndWait(t, s, nil)
+	if status.State != playgroundv1.MessageState_SUCCEEDED.String() {
+		t.Fatalf("expected the fan-out to succeed, got %s", status.State)
+	}
+	for _, recipient := range status.Recipients {
+		if recipient.State != playgroundv1.MessageState_SUCCEEDED.String() {
+			t.Errorf("expected every child to succeed, got %v", recipient)
+		}
+	}
+
+	// every child was scheduled, so none is left to the give-up sweep
+	pending, err := s.handler.backend.ListPendingWorkflows(context.Background(), time.Now().UTC().Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("expected no pending workflows, got %+v", pending)
+	}
+}
+
+func TestFanOutFails(t *testing.T) {
+	s := newTestServer(t, Config{AllowFaultInjection: true})
+	_, _, status := fanOutAndWait(t, s, &playgroundv1.FaultSpec{NonRetryable: true})
+	if status.State != playgroundv1.MessageState_FAILED.String() {
+		t.Fatalf("expected the fan-out to fail, got %s", status.State)
+	}
+}
+
+func TestFanOutPartiallySucceeds(t *testing.T) {
+	s := newTestServer(t, Config{})
+	messageID, operationID, status := fanOutAndWait(t, s, nil)
+
+	// fail one of the children, as if its delivery had been refused
+	ctx := context.Background()
+	tx, queries, err := s.handler.backend.Tx(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	if _, err := setOperationState(ctx, queries, DefaultTenant, status.Recipients[0].OperationId, playgroundv1.MessageState_FAILED); err != nil {
+		t.Fatal(err)
+	}
+	if err := updateParent(ctx, queries, DefaultTenant, operationID); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := s.client(t).MessageStatus(ctx, connect.NewRequest(&playgroundv1.MessageStatusRequest{
+		MessageId:   messageID,
+		OperationId: operationID,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Msg.State != playgroundv1.MessageState_PARTIALLY_SUCCEEDED.String() {
+		t.Fatalf("expected the fan-out to partially succeed, got %s", updated.Msg.State)
+	}
+}
+
+func TestAggregateState(t *testing.T) {
+	for _, test := range []struct {
+		children []playgroundv1.MessageState
+		want     playgroundv1.MessageState
+	}{
+		{children: []playgroundv1.MessageState{playgroundv1.MessageState_SUCCEEDED, playgroundv1.MessageState_SUCCEEDED}, want: playgroundv1.MessageState_SUCCEEDED},
+		{children: []playgroundv1.MessageState{playgroundv1.MessageState_SUCCEEDED, playgroundv1.MessageState_FAILED}, want: playgroundv1.MessageState_PARTIALLY_SUCCEEDED},
+		{children: []playgroundv1.MessageState{playgroundv1.MessageState_SUCCEEDED, playgroundv1.MessageState_CANCELED}, want: playgroundv1.MessageState_PARTIALLY_SUCCEEDED},
+		{children: []playgroundv1.MessageState{playgroundv1.MessageState_FAILED, playgroundv1.MessageState_FAILED}, want: playgroundv1.MessageState_FAILED},
+		{children: []playgroundv1.MessageState{playgroundv1.MessageState_FAILED, playgroundv1.MessageState_CANCELED}, want: playgroundv1.MessageState_FAILED},
+		{children: []playgroundv1.MessageState{playgroundv1.MessageState_CANCELED, playgroundv1.MessageState_CANCELED}, want: playgroundv1.MessageState_CANCELED},
+		{children: []playgroundv1.MessageState{playgroundv1.MessageState_SUCCEEDED, playgroundv1.MessageState_SENDING}, want: playgroundv1.MessageState_SENDING},
+	} {
+		var children []models.ListChildOperationsRow
+		for _, state := range test.children {
+			children = append(children, models.ListChildOperationsRow{SentMessage: models.SentMessage{Result: state.String()}})
+		}
+		if got := aggregateState(children); got != test.want {
+			t.Errorf("expected children %v to aggregate to %s, got %s", test.children, test.want, got)
+		}
+	}
+}
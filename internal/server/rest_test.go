@@ -0,0 +1,292 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+)
+
+// present matches any value of a response field, as long as it is set.
+var present = &struct{}{}
+
+// restCase is one REST call of the conformance suite, made against the state
+// the cases before it left behind.
+type restCase struct {
+	name   string
+	method string
+	// path has {message} replaced by the ID of the message the suite starts
+	// with
+	path  string
+	body  string
+	admin bool
+
+	status int
+	// code, reason and field describe an error response: its google.rpc.Code,
+	// the reason of its ErrorInfo and the field its BadRequest names
+	code   connect.Code
+	reason string
+	field  string
+	// want maps dotted paths into a successful response to the values they
+	// should have, nil for fields that should be absent
+	want map[string]any
+}
+
+func TestRESTConformance(t *testing.T) {
+	s := newTestServer(t, Config{AdminToken: testAdminToken})
+	created, err := s.client(t).CreateMessage(context.Background(), connect.NewRequest(&playgroundv1.CreateMessageRequest{
+		Text:        "hello",
+		ContentType: playgroundv1.ContentType_MARKDOWN,
+		Labels:      map[string]string{"team": "a"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	messageID := created.Msg.MessageId
+	missing := "00000000-0000-4000-8000-000000000000"
+
+	for _, test := range []restCase{{
+		name:   "create takes camelCase fields",
+		method: http.MethodPost, path: "/v1/messages",
+		body:   `{"text":"hi","contentType":"MARKDOWN","labels":{"team":"a"}}`,
+		status: http.StatusOK,
+		want:   map[string]any{"messageId": present},
+	}, {
+		name:   "create takes proto field names",
+		method: http.MethodPost, path: "/v1/messages",
+		body:   `{"text":"hi","content_type":"MARKDOWN"}`,
+		status: http.StatusOK,
+		want:   map[string]any{"messageId": present},
+	}, {
+		name:   "create without text",
+		method: http.MethodPost, path: "/v1/messages",
+		body:   `{}`,
+		status: http.StatusBadRequest, code: connect.CodeInvalidArgument, reason: reasonValidation, field: "text",
+	}, {
+		name:   "create with malformed JSON",
+		method: http.MethodPost, path: "/v1/messages",
+		body:   `{"text":`,
+		status: http.StatusBadRequest, code: connect.CodeInvalidArgument,
+	}, {
+		name:   "get",
+		method: http.MethodGet, path: "/v1/messages/{message}",
+		status: http.StatusOK,
+		want: map[string]any{
+			"message.messageId":   messageID,
+			"message.text":        "hello",
+			"message.contentType": "MARKDOWN",
+			"message.labels.team": "a",
+		},
+	}, {
+		name:   "get a missing message",
+		method: http.MethodGet, path: "/v1/messages/" + missing,
+		status: http.StatusNotFound, code: connect.CodeNotFound, reason: reasonNotFound,
+	}, {
+		name:   "get with an invalid ID",
+		method: http.MethodGet, path: "/v1/messages/not-a-uuid",
+		status: http.StatusBadRequest, code: connect.CodeInvalidArgument, reason: reasonValidation, field: "message_id",
+	}, {
+		name:   "patch without a mask replaces the fields set",
+		method: http.MethodPatch, path: "/v1/messages/{message}",
+		body:   `{"text":"updated"}`,
+		status: http.StatusOK,
+		want: map[string]any{
+			"message.text":        "updated",
+			"message.contentType": "MARKDOWN",
+			"message.labels.team": "a",
+		},
+	}, {
+		name:   "patch with a query mask only replaces the masked fields",
+		method: http.MethodPatch, path: "/v1/messages/{message}?updateMask=labels",
+		body:   `{"text":"ignored","labels":{"team":"b"}}`,
+		status: http.StatusOK,
+		want: map[string]any{
+			"message.text":        "updated",
+			"message.labels.team": "b",
+		},
+	}, {
+		name:   "patch with a body mask clears masked fields left out",
+		method: http.MethodPatch, path: "/v1/messages/{message}",
+		body:   `{"updateMask":"labels"}`,
+		status: http.StatusOK,
+		want: map[string]any{
+			"message.text":   "updated",
+			"message.labels": nil,
+		},
+	}, {
+		name:   "patch masking a field that can't be updated",
+		method: http.MethodPatch, path: "/v1/messages/{message}?updateMask=messageId",
+		body:   `{}`,
+		status: http.StatusBadRequest, code: connect.CodeInvalidArgument, reason: reasonValidation, field: updateMaskField,
+	}, {
+		name:   "patch a missing message",
+		method: http.MethodPatch, path: "/v1/messages/" + missing,
+		body:   `{"text":"updated"}`,
+		status: http.StatusNotFound, code: connect.CodeNotFound, reason: reasonNotFound,
+	}, {
+		name:   "create a webhook with an invalid URL",
+		method: http.MethodPost, path: "/v1/webhooks",
+		body:   `{"url":"ftp://example.com"}`,
+		status: http.StatusBadRequest, code: connect.CodeInvalidArgument, reason: reasonValidation, field: "url",
+	}, {
+		name:   "create a tenant without the admin token",
+		method: http.MethodPost, path: "/v1/tenants",
+		body:   `{"tenantId":"acme"}`,
+		status: http.StatusUnauthorized, code: connect.CodeUnauthenticated, reason: reasonTokenRequired,
+	}, {
+		name:   "create a tenant",
+		method: http.MethodPost, path: "/v1/tenants",
+		body:   `{"tenantId":"acme","displayName":"Acme"}`,
+		admin:  true,
+		status: http.StatusOK,
+		want: map[string]any{
+			"tenant.tenantId":    "acme",
+			"tenant.displayName": "Acme",
+			"token":              present,
+		},
+	}, {
+		name:   "create a tenant that exists",
+		method: http.MethodPost, path: "/v1/tenants",
+		body:   `{"tenantId":"acme"}`,
+		admin:  true,
+		status: http.StatusConflict, code: connect.CodeAlreadyExists,
+	}, {
+		name:   "patch a nested field of a tenant",
+		method: http.MethodPatch, path: "/v1/tenants/acme",
+		body:   `{"quota":{"maxMessages":"5"},"updateMask":"quota.maxMessages"}`,
+		admin:  true,
+		status: http.StatusOK,
+		want: map[string]any{
+			"tenant.displayName":       "Acme",
+			"tenant.quota.maxMessages": "5",
+		},
+	}, {
+		name:   "delete",
+		method: http.MethodDelete, path: "/v1/messages/{message}",
+		status: http.StatusOK,
+	}, {
+		name:   "get a deleted message",
+		method: http.MethodGet, path: "/v1/messages/{message}",
+		status: http.StatusNotFound, code: connect.CodeNotFound, reason: reasonNotFound,
+	}, {
+		name:   "method without a route",
+		method: http.MethodPut, path: "/v1/messages",
+		body:   `{}`,
+		status: http.StatusMethodNotAllowed,
+	}, {
+		name:   "path without a route",
+		method: http.MethodGet, path: "/v1/nothing-here",
+		status: http.StatusNotFound,
+	}} {
+		ok := t.Run(test.name, func(t *testing.T) {
+			var body io.Reader
+			if test.body != "" {
+				body = strings.NewReader(test.body)
+			}
+			request, err := http.NewRequest(test.method, s.URL+strings.ReplaceAll(test.path, "{message}", messageID), body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if body != nil {
+				request.Header.Set("Content-Type", "application/json")
+			}
+			if test.admin {
+				request.Header.Set("Authorization", "Bearer "+testAdminToken)
+			}
+			response, err := http.DefaultClient.Do(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			content, err := io.ReadAll(response.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if response.StatusCode != test.status {
+				t.Fatalf("expected status %d, got %d: %s", test.status, response.StatusCode, content)
+			}
+			if test.code == 0 && test.want == nil {
+				return
+			}
+			var decoded map[string]any
+			if err := json.Unmarshal(content, &decoded); err != nil {
+				t.Fatalf("expected a JSON body, got %q: %v", content, err)
+			}
+			if test.code != 0 {
+				assertRESTError(t, decoded, test)
+			}
+			for path, want := range test.want {
+				got, found := lookupJSON(decoded, path)
+				switch {
+				case want == nil && found:
+					t.Errorf("expected %s to be absent, got %v", path, got)
+				case want == present && !found:
+					t.Errorf("expected %s to be set in %s", path, content)
+				case want != nil && want != present && !reflect.DeepEqual(got, want):
+					t.Errorf("expected %s to be %v, got %v", path, want, got)
+				}
+			}
+		})
+		if !ok {
+			// later cases build on the state this one should have left
+			break
+		}
+	}
+}
+
+// assertRESTError checks a google.rpc.Status error body.
+func assertRESTError(t *testing.T, status map[string]any, test restCase) {
+	t.Helper()
+	if code, _ := status["code"].(float64); connect.Code(code) != test.code {
+		t.Errorf("expected code %s, got %v", test.code, status["code"])
+	}
+	if message, _ := status["message"].(string); message == "" {
+		t.Error("expected the error to have a message")
+	}
+
+	var reasons, fields []string
+	details, _ := status["details"].([]any)
+	for _, detail := range details {
+		detail, _ := detail.(map[string]any)
+		switch detail["@type"] {
+		case "type.googleapis.com/google.rpc.ErrorInfo":
+			reason, _ := detail["reason"].(string)
+			reasons = append(reasons, reason)
+		case "type.googleapis.com/google.rpc.BadRequest":
+			violations, _ := detail["fieldViolations"].([]any)
+			for _, violation := range violations {
+				violation, _ := violation.(map[string]any)
+				field, _ := violation["field"].(string)
+				fields = append(fields, field)
+			}
+		}
+	}
+	if test.reason != "" && (len(reasons) != 1 || reasons[0] != test.reason) {
+		t.Errorf("expected the reason %s, got %v", test.reason, reasons)
+	}
+	if test.field != "" && (len(fields) != 1 || fields[0] != test.field) {
+		t.Errorf("expected a violation on %s, got %v", test.field, fields)
+	}
+}
+
+// lookupJSON follows a dotted path into a decoded JSON object.
+func lookupJSON(value any, path string) (any, bool) {
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
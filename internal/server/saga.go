@@ -0,0 +1,220 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"connectrpc.com/connect"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+// compensateStep is the final workflow step, which runs the compensations
+// below.
+const compensateStep = "compensate"
+
+// compensations declares the action that undoes each workflow step with side
+// effects. They must be idempotent since they can run against steps that
+// never completed.
+func (h *handler) compensations() map[string]func(context.Context, models.SentMessage) error {
+	return map[string]func(context.Context, models.SentMessage) error{
+		"reserve": h.releaseQuota,
+		"deliver": h.retractDelivery,
+		"bill":    h.refundBilling,
+	}
+}
+
+func (h *handler) releaseQuota(ctx context.Context, operation models.SentMessage) error {
+	return h.backend.DeleteQuotaReservation(ctx, models.DeleteQuotaReservationParams{
+		TenantID:    operation.TenantID,
+		OperationID: operation.ID,
+	})
+}
+
+func (h *handler) retractDelivery(ctx context.Context, operation models.SentMessage) error {
+	return h.backend.UpdateSentMessageReceipt(ctx, models.UpdateSentMessageReceiptParams{
+		TenantID: operation.TenantID,
+		ID:       operation.ID,
+	})
+}
+
+func (h *handler) refundBilling(ctx context.Context, operation models.SentMessage) error {
+	return h.backend.RefundBillingRecord(ctx, models.RefundBillingRecordParams{
+		TenantID:    operation.TenantID,
+		OperationID: operation.ID,
+	})
+}
+
+// compensate runs, in reverse step order, the compensations for every step
+// the operation reached once it has failed or been canceled. Compensations
+// that already succeeded are skipped, so a failed compensation can be retried
+// by the workflow engine without undoing anything twice.
+func (h *handler) compensate(ctx context.Context, io *playgroundv1.SendMessageState) error {
+	operation, err := h.backend.GetSentMessageByID(ctx, models.GetSentMessageByIDParams{
+		TenantID: io.TenantId,
+		ID:       io.OperationId,
+	})
+	if err != nil {
+		return err
+	}
+
+	switch operation.Result {
+	case playgroundv1.MessageState_FAILED.String(), playgroundv1.MessageState_CANCELED.String():
+	default:
+		return nil
+	}
+	io.State = playgroundv1.MessageState(playgroundv1.MessageState_value[operation.Result])
+
+	outcomes, err := h.backend.ListOperationCompensations(ctx, models.ListOperationCompensationsParams{
+		TenantID:    operation.TenantID,
+		OperationID: operation.ID,
+	})
+	if err != nil {
+		return err
+	}
+	compensated := map[string]bool{}
+	for _, outcome := range outcomes {
+		if !outcome.Error.Valid {
+			compensated[outcome.Step] = true
+		}
+	}
+
+	names := steps()
+	reached := slices.Index(names, operation.Step)
+	compensations := h.compensations()
+	for i := reached; i >= 0; i-- {
+		step := names[i]
+		fn, ok := compensations[step]
+		if !ok || compensated[step] {
+			continue
+		}
+
+		count, err := h.backend.CountOperationCompensations(ctx, models.CountOperationCompensationsParams{
+			TenantID:    operation.TenantID,
+			OperationID: operation.ID,
+			Step:        step,
+		})
+		if err != nil {
+			return err
+		}
+
+		compensationErr := fn(ctx, operation)
+
+		var lastError sql.NullString
+		if compensationErr != nil {
+			lastError = sql.NullString{String: compensationErr.Error(), Valid: true}
+		}
+		if _, err := h.backend.CreateOperationCompensation(ctx, models.CreateOperationCompensationParams{
+			TenantID:    operation.TenantID,
+			OperationID: operation.ID,
+			Step:        step,
+			Attempt:     count + 1,
+			Error:       lastError,
+		}); err != nil {
+			return errors.Join(compensationErr, err)
+		}
+
+		if compensationErr != nil {
+			return fmt.Errorf("compensating step %q: %w", step, compensationErr)
+		}
+	}
+
+	return nil
+}
+
+func (h *handler) CancelSend(ctx context.Context, req *connect.Request[playgroundv1.CancelSendRequest]) (*connect.Response[playgroundv1.CancelSendResponse], error) {
+	tenant := tenantFromContext(ctx)
+
+	tx, queries, err := h.backend.Tx(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer tx.Rollback()
+
+	operation, err := queries.GetSentMessage(ctx, models.GetSentMessageParams{
+		TenantID:  tenant,
+		ID:        req.Msg.OperationId,
+		MessageID: req.Msg.MessageId,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFoundError(resourceOperation, req.Msg.OperationId, fmt.Errorf("message with ID %q has no operation with ID %q not found", req.Msg.MessageId, req.Msg.OperationId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if operation.Result != playgroundv1.MessageState_SENDING.String() {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("operation with ID %q is already %s", operation.ID, operation.Result))
+	}
+
+	children, err := queries.ListChildOperations(ctx, models.ListChildOperationsParams{
+		TenantID: tenant,
+		ParentID: operation.ID,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if len(children) > 0 {
+		return h.cancelChildren(ctx, tx, queries, operation, children)
+	}
+
+	// the running workflow skips its remaining steps once the operation is
+	// no longer SENDING and compensates whatever it already did
+	operation, err = setOperationState(ctx, queries, tenant, operation.ID, playgroundv1.MessageState_CANCELED)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := recordAudit(ctx, queries, ""); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&playgroundv1.CancelSendResponse{
+		State: operation.Result,
+	}), nil
+}
+
+// cancelChildren cancels every child of a send to multiple recipients that is
+// still SENDING, leaving the ones that already finished as they are.
+func (h *handler) cancelChildren(ctx context.Context, tx *sql.Tx, queries *models.Queries, parent models.SentMessage, children []models.ListChildOperationsRow) (*connect.Response[playgroundv1.CancelSendResponse], error) {
+	for _, child := range children {
+		if child.SentMessage.Result != playgroundv1.MessageState_SENDING.String() {
+			continue
+		}
+		if _, err := setOperationState(ctx, queries, parent.TenantID, child.SentMessage.ID, playgroundv1.MessageState_CANCELED); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := updateParent(ctx, queries, parent.TenantID, parent.ID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	parent, err := queries.GetSentMessageByID(ctx, models.GetSentMessageByIDParams{
+		TenantID: parent.TenantID,
+		ID:       parent.ID,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := recordAudit(ctx, queries, ""); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&playgroundv1.CancelSendResponse{
+		State: parent.Result,
+	}), nil
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+const defaultSearchPageSize = 20
+
+// encodePageToken returns an opaque page token for the given result offset.
+func encodePageToken(offset int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(offset, 10)))
+}
+
+func decodePageToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, errors.New("invalid page token")
+	}
+	offset, err := strconv.ParseInt(string(decoded), 10, 64)
+	if err != nil || offset < 0 {
+		return 0, errors.New("invalid page token")
+	}
+	return offset, nil
+}
+
+func (h *handler) SearchMessages(ctx context.Context, req *connect.Request[playgroundv1.SearchMessagesRequest]) (*connect.Response[playgroundv1.SearchMessagesResponse], error) {
+	offset, err := decodePageToken(req.Msg.PageToken)
+	if err != nil {
+		return nil, violationsError(fieldViolation(req.Msg, "page_token", "", "page_token.invalid", err.Error()))
+	}
+
+	pageSize := int64(req.Msg.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultSearchPageSize
+	}
+
+	// fetch one extra result to know whether there is another page
+	queried, err := h.backend.SearchMessages(ctx, models.SearchMessagesParams{
+		TenantID:       tenantFromContext(ctx),
+		HighlightStart: "<mark>",
+		HighlightEnd:   "</mark>",
+		Query:          req.Msg.Q,
+		Offset:         offset,
+		Limit:          pageSize + 1,
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "fts5:") {
+			return nil, violationsError(fieldViolation(req.Msg, "q", "", "q.syntax", fmt.Sprintf("invalid search query: %v", err)))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	response := &playgroundv1.SearchMessagesResponse{}
+	if int64(len(queried)) > pageSize {
+		queried = queried[:pageSize]
+		response.NextPageToken = encodePageToken(offset + pageSize)
+	}
+
+	for _, result := range queried {
+		message, err := messageFromModel(result.Message)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		response.Results = append(response.Results, &playgroundv1.SearchResult{
+			Message: message,
+			Snippet: result.Snippet,
+			Rank:    result.Score,
+		})
+	}
+
+	return connect.NewResponse(response), nil
+}
@@ -3,9 +3,12 @@ package server
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	"connectrpc.com/connect"
@@ -14,7 +17,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/andrewstucki/vanguard-playground/internal/blob"
 	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
 	"github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1/playgroundv1connect"
 	"github.com/andrewstucki/vanguard-playground/internal/models"
@@ -23,90 +28,396 @@ import (
 type handler struct {
 	logger zerolog.Logger
 
-	backend *models.Backend
+	backend             *models.Backend
+	allowFaultInjection bool
+	tenancy             TenancyMode
+	maxMessageBytes     int
+	maxAttachmentBytes  int64
+	blobs               blob.Store
+	webhookClient       *http.Client
+	webhooks            webhookPolicy
 }
 
 var _ playgroundv1connect.MessageServiceHandler = (*handler)(nil)
 
 func (h *handler) GetMessage(ctx context.Context, req *connect.Request[playgroundv1.GetMessageRequest]) (*connect.Response[playgroundv1.GetMessageResponse], error) {
-	message, err := h.backend.GetMessage(ctx, req.Msg.MessageId)
+	tenant := tenantFromContext(ctx)
+
+	message, err := h.backend.GetMessage(ctx, models.GetMessageParams{
+		TenantID: tenant,
+		ID:       req.Msg.MessageId,
+	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("message with ID %q not found", req.Msg.MessageId))
+			return nil, notFoundError(resourceMessage, req.Msg.MessageId, fmt.Errorf("message with ID %q not found", req.Msg.MessageId))
 		}
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	return connect.NewResponse(&playgroundv1.GetMessageResponse{
-		Message: &playgroundv1.Message{
-			MessageId: message.ID,
-			Text:      message.Text,
-		},
-	}), nil
+	response, err := messageWithAttachments(ctx, h.backend.Queries, message)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	tag, err := etag(response)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	res := connect.NewResponse(&playgroundv1.GetMessageResponse{
+		Message: response,
+	})
+	setCacheHeaders(res.Header(), tag)
+	return res, nil
+}
+
+// messageWithAttachments converts a message as GetMessage returns it, which
+// is also what its entity tag is computed from.
+func messageWithAttachments(ctx context.Context, queries *models.Queries, model models.Message) (*playgroundv1.Message, error) {
+	message, err := messageFromModel(model)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := queries.ListAttachments(ctx, models.ListAttachmentsParams{
+		TenantID:  model.TenantID,
+		MessageID: model.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	message.Attachments = attachmentsFromModels(attachments)
+	return message, nil
 }
 
 func (h *handler) CreateMessage(ctx context.Context, req *connect.Request[playgroundv1.CreateMessageRequest]) (*connect.Response[playgroundv1.CreateMessageResponse], error) {
+	if size := len(req.Msg.Text) + len(req.Msg.Payload); size > h.maxMessageBytes {
+		return nil, violationsError(fieldViolation(req.Msg, "text", "", "message.size", fmt.Sprintf("text and payload are %d bytes, more than the maximum of %d", size, h.maxMessageBytes)))
+	}
+	if req.Msg.ContentType == playgroundv1.ContentType_JSON && !json.Valid([]byte(req.Msg.Text)) {
+		return nil, violationsError(fieldViolation(req.Msg, "text", "", "message.text.json", "text must be valid JSON"))
+	}
+
+	labels, err := json.Marshal(req.Msg.Labels)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	tenant := tenantFromContext(ctx)
+
+	tx, queries, err := h.backend.Tx(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer tx.Rollback()
+
+	if err := checkMessageQuota(ctx, queries, tenant); err != nil {
+		return nil, err
+	}
+
 	id := uuid.New().String()
 
-	message, err := h.backend.CreateMessage(ctx, models.CreateMessageParams{
-		ID:   id,
-		Text: req.Msg.Text,
+	message, err := queries.CreateMessage(ctx, models.CreateMessageParams{
+		TenantID:    tenant,
+		ID:          id,
+		Text:        req.Msg.Text,
+		ContentType: req.Msg.ContentType.String(),
+		Labels:      string(labels),
+		Payload:     req.Msg.Payload,
 	})
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
+	created, err := messageFromModel(message)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if _, err := recordEvent(ctx, queries, tenant, messageEvent(eventMessageCreated, created)); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := recordAudit(ctx, queries, message.ID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
 	return connect.NewResponse(&playgroundv1.CreateMessageResponse{
 		MessageId: message.ID,
 	}), nil
 }
 
+func (h *handler) UpdateMessage(ctx context.Context, req *connect.Request[playgroundv1.UpdateMessageRequest]) (*connect.Response[playgroundv1.UpdateMessageResponse], error) {
+	paths, err := updatePaths(req.Msg, req.Msg.UpdateMask, "text", "labels", "content_type", "payload")
+	if err != nil {
+		return nil, err
+	}
+
+	tenant := tenantFromContext(ctx)
+
+	tx, queries, err := h.backend.Tx(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer tx.Rollback()
+
+	current, err := queries.GetMessage(ctx, models.GetMessageParams{
+		TenantID: tenant,
+		ID:       req.Msg.MessageId,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFoundError(resourceMessage, req.Msg.MessageId, fmt.Errorf("message with ID %q not found", req.Msg.MessageId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	existing, err := messageWithAttachments(ctx, queries, current)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if err := checkIfMatch(req.Header(), existing); err != nil {
+		return nil, err
+	}
+
+	text, contentType, labels, payload := current.Text, current.ContentType, current.Labels, current.Payload
+	if paths["text"] {
+		text = req.Msg.Text
+	}
+	if paths["content_type"] {
+		contentType = req.Msg.ContentType.String()
+	}
+	if paths["labels"] {
+		encoded, err := json.Marshal(req.Msg.Labels)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		labels = string(encoded)
+	}
+	if paths["payload"] {
+		payload = req.Msg.Payload
+	}
+
+	if text == "" {
+		return nil, violationsError(fieldViolation(req.Msg, "text", "", "required", "value is required"))
+	}
+	if size := len(text) + len(payload); size > h.maxMessageBytes {
+		return nil, violationsError(fieldViolation(req.Msg, "text", "", "message.size", fmt.Sprintf("text and payload are %d bytes, more than the maximum of %d", size, h.maxMessageBytes)))
+	}
+	if contentType == playgroundv1.ContentType_JSON.String() && !json.Valid([]byte(text)) {
+		return nil, violationsError(fieldViolation(req.Msg, "text", "", "message.text.json", "text must be valid JSON"))
+	}
+
+	model, err := queries.UpdateMessage(ctx, models.UpdateMessageParams{
+		TenantID:    tenant,
+		ID:          current.ID,
+		Text:        text,
+		ContentType: contentType,
+		Labels:      labels,
+		Payload:     payload,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	updated, err := messageFromModel(model)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if _, err := recordEvent(ctx, queries, tenant, messageEvent(eventMessageUpdated, updated)); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	response, err := messageWithAttachments(ctx, queries, model)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	tag, err := etag(response)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := recordAudit(ctx, queries, model.ID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	res := connect.NewResponse(&playgroundv1.UpdateMessageResponse{
+		Message: response,
+	})
+	// the new entity tag lets the client make its next update conditional
+	// without reading the message again
+	res.Header().Set("ETag", tag)
+	return res, nil
+}
+
 func (h *handler) DeleteMessage(ctx context.Context, req *connect.Request[playgroundv1.DeleteMessageRequest]) (*connect.Response[playgroundv1.DeleteMessageResponse], error) {
-	if err := h.backend.DeleteMessage(ctx, req.Msg.MessageId); err != nil {
+	tenant := tenantFromContext(ctx)
+
+	tx, queries, err := h.backend.Tx(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer tx.Rollback()
+
+	message, err := queries.GetMessage(ctx, models.GetMessageParams{
+		TenantID: tenant,
+		ID:       req.Msg.MessageId,
+	})
+	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("message with ID %q not found", req.Msg.MessageId))
+			return nil, notFoundError(resourceMessage, req.Msg.MessageId, fmt.Errorf("message with ID %q not found", req.Msg.MessageId))
 		}
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+
+	current, err := messageWithAttachments(ctx, queries, message)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if err := checkIfMatch(req.Header(), current); err != nil {
+		return nil, err
+	}
+
+	attachments, err := queries.ListAttachments(ctx, models.ListAttachmentsParams{
+		TenantID:  tenant,
+		MessageID: message.ID,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if err := deleteAttachments(ctx, queries, tenant, message.ID, ""); err != nil {
+		return nil, err
+	}
+
+	if err := queries.DeleteMessage(ctx, models.DeleteMessageParams{
+		TenantID: tenant,
+		ID:       message.ID,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	deleted, err := messageFromModel(message)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if _, err := recordEvent(ctx, queries, tenant, messageEvent(eventMessageDeleted, deleted)); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := recordAudit(ctx, queries, message.ID); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	h.deleteContent(ctx, attachments)
+
 	return connect.NewResponse(&playgroundv1.DeleteMessageResponse{}), nil
 }
 
-func (h *handler) ListMessages(ctx context.Context, _ *connect.Request[playgroundv1.ListMessagesRequest]) (*connect.Response[playgroundv1.ListMessagesResponse], error) {
-	queried, err := h.backend.ListMessages(ctx)
+func (h *handler) ListMessages(ctx context.Context, req *connect.Request[playgroundv1.ListMessagesRequest]) (*connect.Response[playgroundv1.ListMessagesResponse], error) {
+	selector := map[string]string{}
+	for _, label := range req.Msg.Labels {
+		key, value, _ := strings.Cut(label, "=")
+		selector[key] = value
+	}
+
+	queried, err := h.backend.ListMessages(ctx, tenantFromContext(ctx))
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 	var messages []*playgroundv1.Message
 	for _, model := range queried {
-		messages = append(messages, &playgroundv1.Message{
-			MessageId: model.ID,
-			Text:      model.Text,
-		})
+		message, err := messageFromModel(model)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		if !matchesLabels(message.Labels, selector) {
+			continue
+		}
+		messages = append(messages, message)
 	}
 
-	return connect.NewResponse(&playgroundv1.ListMessagesResponse{
+	response := &playgroundv1.ListMessagesResponse{
 		Messages: messages,
-	}), nil
+	}
+	tag, err := etag(response)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	res := connect.NewResponse(response)
+	setCacheHeaders(res.Header(), tag)
+	return res, nil
 }
 
 func (h *handler) SendMessage(ctx context.Context, req *connect.Request[playgroundv1.SendMessageRequest]) (*connect.Response[playgroundv1.SendMessageResponse], error) {
+	if req.Msg.Fault != nil && !h.allowFaultInjection {
+		return nil, withReason(connect.NewError(connect.CodeFailedPrecondition, errors.New("fault injection is disabled on this server")), reasonFaultInjectionDisabled, nil)
+	}
+
+	tenant := tenantFromContext(ctx)
+
 	tx, queries, err := h.backend.Tx(ctx)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 	defer tx.Rollback()
 
-	message, err := queries.GetMessage(ctx, req.Msg.MessageId)
+	message, err := queries.GetMessage(ctx, models.GetMessageParams{
+		TenantID: tenant,
+		ID:       req.Msg.MessageId,
+	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("message with ID %q not found", req.Msg.MessageId))
+			return nil, notFoundError(resourceMessage, req.Msg.MessageId, fmt.Errorf("message with ID %q not found", req.Msg.MessageId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if req.Msg.TemplateId != "" {
+		template, err := queries.GetTemplate(ctx, models.GetTemplateParams{
+			TenantID: tenant,
+			ID:       req.Msg.TemplateId,
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, notFoundError(resourceTemplate, req.Msg.TemplateId, fmt.Errorf("template with ID %q not found", req.Msg.TemplateId))
+			}
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		violations, err := templateVariableViolations(req.Msg, template, req.Msg.Variables)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		if len(violations) > 0 {
+			return nil, violationsError(violations...)
 		}
+	} else if len(req.Msg.Variables) > 0 {
+		return nil, violationsError(fieldViolation(req.Msg, "variables", "", "template.variables.without_template", "variables can only be set with template_id"))
+	}
+
+	// each recipient gets its own operation, and so uses its own unit of quota
+	units := int64(max(len(req.Msg.Recipients), 1))
+	exceeded, limit, err := sendQuotaExceeded(ctx, queries, tenant, units)
+	if err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
+	if exceeded {
+		return nil, withReason(connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("tenant %q has reached its quota of %d sends", tenant, limit)), reasonQuotaExceeded, map[string]string{"tenant": tenant, "quota": "max_sends"})
+	}
 
 	operationID := uuid.New().String()
 
-	_, err = queries.CreateSentMessage(ctx, models.CreateSentMessageParams{
+	operation, err := queries.CreateSentMessage(ctx, models.CreateSentMessageParams{
+		TenantID:  tenant,
 		ID:        operationID,
 		MessageID: message.ID,
 		Text:      message.Text,
@@ -116,17 +427,65 @@ func (h *handler) SendMessage(ctx context.Context, req *connect.Request[playgrou
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
+	io := &playgroundv1.SendMessageState{
+		TenantId:    tenant,
+		OperationId: operationID,
+		Fault:       req.Msg.Fault,
+		TemplateId:  req.Msg.TemplateId,
+		Variables:   req.Msg.Variables,
+	}
+	workflows := []*playgroundv1.SendMessageState{io}
+	if len(req.Msg.Recipients) > 0 {
+		// the operation only tracks its children, which each run their own
+		// workflow
+		workflows, err = fanOut(ctx, queries, operation, req.Msg.Recipients, io)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := recordEvent(ctx, queries, tenant, operationEvent(eventMessageSent, operation)); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	// children include the attachments too since they are what get delivered
+	operations := []string{operationID}
+	for _, workflow := range workflows {
+		if workflow.OperationId != operationID {
+			operations = append(operations, workflow.OperationId)
+		}
+	}
+	for _, id := range operations {
+		if err := queries.AttachMessageAttachments(ctx, models.AttachMessageAttachmentsParams{
+			TenantID:    tenant,
+			OperationID: id,
+			MessageID:   message.ID,
+		}); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	// the workflows are kept pending until they are scheduled, so that any
+	// that fail to be are left to the give-up sweep rather than failing a
+	// send whose other children are already running
+	for _, workflow := range workflows {
+		if err := keepPending(ctx, queries, workflow); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	if err := recordAudit(ctx, queries, ""); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
 	if err := tx.Commit(); err != nil {
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	_, err = h.backend.RunWorkflow(context.Background(), playgroundv1.SendMessageStateWorkflow, playgroundv1.SendMessageState{
-		OperationId:     operationID,
-		SimulateFailure: req.Msg.SimulateFailure,
-		State:           playgroundv1.MessageState_SENDING,
-	})
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("error scheduling workflow: %w", err))
+	for _, workflow := range workflows {
+		if err := h.schedulePending(context.WithoutCancel(ctx), workflow); err != nil {
+			h.logger.Err(err).Str("operation", workflow.OperationId).Msg("Error scheduling workflow, leaving it to the give-up sweep")
+		}
 	}
 
 	return connect.NewResponse(&playgroundv1.SendMessageResponse{
@@ -136,60 +495,190 @@ func (h *handler) SendMessage(ctx context.Context, req *connect.Request[playgrou
 }
 
 func (h *handler) MessageStatus(ctx context.Context, req *connect.Request[playgroundv1.MessageStatusRequest]) (*connect.Response[playgroundv1.MessageStatusResponse], error) {
+	tenant := tenantFromContext(ctx)
+
 	operation, err := h.backend.GetSentMessage(ctx, models.GetSentMessageParams{
+		TenantID:  tenant,
 		ID:        req.Msg.OperationId,
 		MessageID: req.Msg.MessageId,
 	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("message with ID %q has no operation with ID %q not found", req.Msg.MessageId, req.Msg.OperationId))
+			return nil, notFoundError(resourceOperation, req.Msg.OperationId, fmt.Errorf("message with ID %q has no operation with ID %q not found", req.Msg.MessageId, req.Msg.OperationId))
 		}
 		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	return connect.NewResponse(&playgroundv1.MessageStatusResponse{
-		State: operation.Result,
-	}), nil
-}
+	attachments, err := h.backend.ListSentMessageAttachments(ctx, models.ListSentMessageAttachmentsParams{
+		TenantID:    tenant,
+		OperationID: operation.ID,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
 
-func (h *handler) Do(io *playgroundv1.SendMessageState) error {
-	ctx := context.Background()
+	children, err := h.backend.ListChildOperations(ctx, models.ListChildOperationsParams{
+		TenantID: tenant,
+		ParentID: operation.ID,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if len(children) > 0 {
+		return connect.NewResponse(&playgroundv1.MessageStatusResponse{
+			State:       operation.Result,
+			Recipients:  recipientStatuses(children),
+			Attachments: attachmentsFromModels(attachments),
+		}), nil
+	}
 
-	tx, queries, err := h.backend.Tx(ctx)
+	attempts, err := h.backend.ListOperationAttempts(ctx, models.ListOperationAttemptsParams{
+		TenantID:    tenant,
+		OperationID: operation.ID,
+	})
 	if err != nil {
-		return err
+		return nil, connect.NewError(connect.CodeInternal, err)
 	}
-	defer tx.Rollback()
 
-	msg, err := queries.GetSentMessageByID(ctx, io.OperationId)
+	compensations, err := h.backend.ListOperationCompensations(ctx, models.ListOperationCompensationsParams{
+		TenantID:    tenant,
+		OperationID: operation.ID,
+	})
 	if err != nil {
-		return err
+		return nil, connect.NewError(connect.CodeInternal, err)
 	}
 
-	if msg.Result != playgroundv1.MessageState_SENDING.String() {
-		// no-op since this is already processed
-		return nil
+	response := &playgroundv1.MessageStatusResponse{
+		State:       operation.Result,
+		CurrentStep: operation.Step,
+		Steps:       stepHistory(attempts),
+		Attachments: attachmentsFromModels(attachments),
+	}
+	for _, compensation := range compensations {
+		response.Compensations = append(response.Compensations, operationCompensationFromModel(compensation))
 	}
 
-	nextState := playgroundv1.MessageState_SUCCEEDED
-	if io.SimulateFailure {
-		nextState = playgroundv1.MessageState_FAILED
+	return connect.NewResponse(response), nil
+}
+
+func messageFromModel(model models.Message) (*playgroundv1.Message, error) {
+	var labels map[string]string
+	if err := json.Unmarshal([]byte(model.Labels), &labels); err != nil {
+		return nil, err
 	}
 
-	io.State = nextState
+	return &playgroundv1.Message{
+		MessageId:   model.ID,
+		Text:        model.Text,
+		CreateTime:  timestamppb.New(model.CreatedAt),
+		UpdateTime:  timestamppb.New(model.UpdatedAt),
+		Labels:      labels,
+		ContentType: playgroundv1.ContentType(playgroundv1.ContentType_value[model.ContentType]),
+		Payload:     model.Payload,
+	}, nil
+}
+
+// matchesLabels reports whether labels has every key and value in selector.
+func matchesLabels(labels, selector map[string]string) bool {
+	for key, value := range selector {
+		if actual, ok := labels[key]; !ok || actual != value {
+			return false
+		}
+	}
+	return true
+}
 
-	_, err = queries.UpdateSentMessage(ctx, models.UpdateSentMessageParams{
-		ID:     io.OperationId,
-		Result: nextState.String(),
+// runSendWorkflow schedules a SendMessageState workflow for an operation that
+// has already been persisted in the SENDING state.
+func (h *handler) runSendWorkflow(io *playgroundv1.SendMessageState) error {
+	_, err := h.startSendWorkflow(context.Background(), io)
+	return err
+}
+
+// startSendWorkflow schedules the workflow of a send operation, indexing the
+// new instance so that the admin service can find it, and returns its ID.
+// Every workflow started is a new run, whose attempts are counted from the
+// first.
+func (h *handler) startSendWorkflow(ctx context.Context, io *playgroundv1.SendMessageState) (string, error) {
+	future, err := h.backend.RunWorkflow(ctx, playgroundv1.SendMessageStateWorkflow, playgroundv1.SendMessageState{
+		TenantId:    io.TenantId,
+		OperationId: io.OperationId,
+		State:       playgroundv1.MessageState_SENDING,
+		Fault:       io.Fault,
+		TemplateId:  io.TemplateId,
+		Variables:   io.Variables,
+		RecipientId: io.RecipientId,
+		RunId:       uuid.NewString(),
 	})
 	if err != nil {
-		return err
+		return "", fmt.Errorf("error scheduling workflow: %w", err)
 	}
+	if err := h.backend.CreateWorkflowInstance(ctx, models.CreateWorkflowInstanceParams{
+		InstanceID:  future.ID(),
+		TenantID:    io.TenantId,
+		Name:        playgroundv1.SendMessageStateWorkflow,
+		OperationID: io.OperationId,
+	}); err != nil {
+		return "", fmt.Errorf("error indexing workflow: %w", err)
+	}
+	return future.ID(), nil
+}
 
-	return tx.Commit()
+type Config struct {
+	Port       int
+	Persistent bool
+	// DatabaseURL is the libsql database of a persistent server, a sqld
+	// server on localhost:8080 when empty
+	DatabaseURL string
+	// AllowFaultInjection enables the fault field on SendMessage requests
+	AllowFaultInjection bool
+	// MaxMessageBytes limits the combined size of a message's text and
+	// payload, defaulting to DefaultMaxMessageBytes
+	MaxMessageBytes int
+	// MaxAttachmentBytes limits the size of each attachment, defaulting to
+	// DefaultMaxAttachmentBytes
+	MaxAttachmentBytes int64
+	// Blobs stores attachment content. When nil, attachments are stored in
+	// BlobDir, which a persistent server requires and its workers share, or
+	// else in a temporary directory removed on shutdown.
+	Blobs   blob.Store
+	BlobDir string
+	// WebhookClient makes webhook deliveries, defaulting to one that only
+	// connects to public addresses and doesn't follow redirects
+	WebhookClient *http.Client
+	// AllowPrivateWebhooks lets the default webhook client deliver to
+	// loopback, private and link-local addresses, for receivers on the same
+	// host or network as the server
+	AllowPrivateWebhooks bool
+	// Tenancy decides which tenant requests without a tenant token act as,
+	// the default tenant when empty like TenancySingle
+	Tenancy TenancyMode
+	// AdminToken guards the TenantService, the AuditService and the
+	// AdminService. When empty, they refuse every caller.
+	AdminToken string
+	// AdminPort is where the admin listener serving the AdminService binds,
+	// which is disabled when 0
+	AdminPort int
+	// CORS lets browser apps on other origins call both listeners
+	CORS CORSConfig
+	// Reload tells the server to read its configuration files again, which
+	// is only the CORS origins file so far
+	Reload <-chan struct{}
+	// CompressMinBytes is the size below which responses go uncompressed,
+	// defaulting to DefaultCompressMinBytes
+	CompressMinBytes int
+
+	// webhookPolicy replaces defaultWebhookPolicy, for tests
+	webhookPolicy *webhookPolicy
 }
 
-func Run(ctx context.Context, port int, persist bool) (ret error) {
+const DefaultMaxMessageBytes = 64 * 1024
+
+// DefaultCompressMinBytes leaves responses smaller than a kilobyte
+// uncompressed, as compressing them costs more than it saves.
+const DefaultCompressMinBytes = 1024
+
+func Run(ctx context.Context, config Config) (ret error) {
 	logger, writer := NewLogger()
 	defer func() {
 		if err := writer.Close(); err != nil {
@@ -199,67 +688,288 @@ func Run(ctx context.Context, port int, persist bool) (ret error) {
 
 	logger = logger.With().Str("component", "server").Logger()
 
-	validator, err := validate.NewInterceptor()
+	srv, err := newServer(ctx, logger, config)
 	if err != nil {
-		logger.Err(err).Msg("error creating interceptor")
 		return err
 	}
+	defer func() {
+		ret = errors.Join(ret, srv.close())
+	}()
+
+	// gRPC only runs over HTTP/2, which without TLS means cleartext HTTP/2
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+
+	servers := []*http.Server{{Addr: fmt.Sprintf("localhost:%d", config.Port), Handler: srv.main, Protocols: protocols}}
+	if srv.admin != nil {
+		servers = append(servers, &http.Server{Addr: fmt.Sprintf("localhost:%d", config.AdminPort), Handler: srv.admin, Protocols: protocols})
+	}
 
+	errCh := make(chan error, len(servers))
+	for _, server := range servers {
+		go func() {
+			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				errCh <- err
+			}
+		}()
+	}
+
+	var serveErr error
+	select {
+	case <-ctx.Done():
+	case serveErr = <-errCh:
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	logger.Debug().Msg("Shutting down server")
+	for _, server := range servers {
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Err(err).Msg("Error shutting server down cleanly")
+			serveErr = errors.Join(serveErr, err)
+		}
+	}
+	return serveErr
+}
+
+// server is what Run serves: the handlers of its listeners, and the workflow
+// processor and workers behind them.
+type server struct {
+	handler *handler
+	// main serves the main listener, and admin the admin listener when
+	// there is one
+	main  http.Handler
+	admin http.Handler
+
+	closers []func() error
+}
+
+// close stops everything the server started, in the reverse of the order it
+// was started in.
+func (s *server) close() error {
+	var err error
+	for i := len(s.closers) - 1; i >= 0; i-- {
+		err = errors.Join(err, s.closers[i]())
+	}
+	return err
+}
+
+// background runs fn until the server is closed.
+func (s *server) background(ctx context.Context, fn func(context.Context)) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		fn(ctx)
+	}()
+	s.closers = append(s.closers, func() error {
+		cancel()
+		<-done
+		return nil
+	})
+}
+
+// newServer builds the handlers of the server's listeners and starts the
+// work behind them, which runs until ctx is done or the server is closed.
+// newHandler builds the handler and backend that run workflows, which the
+// server and standalone workers build alike so that the steps they run see
+// the same blobs, limits and webhook settings. The closers it returns undo
+// whatever it got to, even when it fails.
+func newHandler(logger zerolog.Logger, config Config) (_ *handler, closers []func() error, err error) {
 	handler := &handler{
-		logger: logger,
+		logger:              logger,
+		allowFaultInjection: config.AllowFaultInjection,
+		tenancy:             config.Tenancy,
+		maxMessageBytes:     config.MaxMessageBytes,
+		webhookClient:       config.WebhookClient,
+		webhooks:            defaultWebhookPolicy,
+	}
+	if handler.webhookClient == nil {
+		handler.webhookClient = newWebhookClient(config.AllowPrivateWebhooks)
+	}
+	if config.webhookPolicy != nil {
+		handler.webhooks = *config.webhookPolicy
+	}
+	if handler.maxMessageBytes <= 0 {
+		handler.maxMessageBytes = DefaultMaxMessageBytes
+	}
+	handler.maxAttachmentBytes = config.MaxAttachmentBytes
+	if handler.maxAttachmentBytes <= 0 {
+		handler.maxAttachmentBytes = DefaultMaxAttachmentBytes
+	}
+
+	handler.blobs = config.Blobs
+	if handler.blobs == nil {
+		dir := config.BlobDir
+		if dir == "" {
+			// attachments of a database that outlives the server need
+			// content that does too
+			if config.Persistent {
+				err := errors.New("a persistent database needs a blob directory for attachment content")
+				logger.Err(err).Msg("Error creating blob store")
+				return nil, closers, err
+			}
+			dir, err = os.MkdirTemp("", "vanguard-playground-attachments-")
+			if err != nil {
+				return nil, closers, err
+			}
+			closers = append(closers, func() error {
+				return os.RemoveAll(dir)
+			})
+		}
+		handler.blobs, err = blob.NewLocalStore(dir)
+		if err != nil {
+			logger.Err(err).Msg("Error creating blob store")
+			return nil, closers, err
+		}
 	}
 
 	backend, err := models.NewBackend(models.BackendConfig{
-		Logger:     logger,
-		Persistent: persist,
-		Handler:    handler,
+		Logger:      logger,
+		Persistent:  config.Persistent,
+		DatabaseURL: config.DatabaseURL,
+		Handler:     handler,
 	})
 	if err != nil {
-		return err
+		return nil, closers, err
 	}
 	handler.backend = backend
-	defer func() {
+	closers = append(closers, func() error {
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
 		if err := handler.backend.Shutdown(shutdownCtx); err != nil {
 			logger.Err(err).Msg("Error shutting processor down cleanly")
-			ret = errors.Join(ret, err)
+			return err
+		}
+		return nil
+	})
+	return handler, closers, nil
+}
+
+func newServer(ctx context.Context, logger zerolog.Logger, config Config) (_ *server, ret error) {
+	srv := &server{}
+	defer func() {
+		if ret != nil {
+			ret = errors.Join(ret, srv.close())
 		}
-		cancel()
 	}()
 
+	if err := config.Tenancy.validate(); err != nil {
+		logger.Err(err).Msg("Invalid tenancy mode")
+		return nil, err
+	}
+	if config.Tenancy == "" {
+		config.Tenancy = TenancySingle
+	}
+	if config.AdminToken == "" {
+		logger.Warn().Msg("No admin token set, the tenant, audit and admin services refuse every call")
+	}
+
+	validator, err := validate.NewInterceptor()
+	if err != nil {
+		logger.Err(err).Msg("error creating interceptor")
+		return nil, err
+	}
+
+	handler, closers, err := newHandler(logger, config)
+	srv.closers = append(srv.closers, closers...)
+	if err != nil {
+		return nil, err
+	}
+	srv.handler = handler
+
 	if err := handler.backend.Start(ctx); err != nil {
-		return err
+		return nil, err
 	}
 
-	service := vanguard.NewService(playgroundv1connect.NewMessageServiceHandler(handler, connect.WithInterceptors(validator)))
-	transcoder, err := vanguard.NewTranscoder([]*vanguard.Service{service})
+	srv.background(ctx, handler.runWebhookDeliveries)
+	srv.background(ctx, handler.runGiveUpSweeps)
+
+	compressMinBytes := config.CompressMinBytes
+	if compressMinBytes == 0 {
+		compressMinBytes = DefaultCompressMinBytes
+	}
+	compress := compressionHandlerOption(compressMinBytes)
+
+	admin := &adminInterceptor{token: config.AdminToken}
+	errorMapper := &errorInterceptor{logger: logger}
+	service := vanguard.NewService(recordProcedure(playgroundv1connect.NewMessageServiceHandler(handler, compress, connect.WithInterceptors(
+		errorMapper,
+		&tenantInterceptor{handler: handler},
+		&auditInterceptor{logger: logger, backend: handler.backend},
+		validator,
+	))))
+	tenants := vanguard.NewService(recordProcedure(playgroundv1connect.NewTenantServiceHandler(&tenantAdmin{backend: handler.backend}, compress, connect.WithInterceptors(
+		errorMapper,
+		admin,
+		&auditInterceptor{logger: logger, backend: handler.backend, admin: true},
+		validator,
+	))))
+	audit := vanguard.NewService(recordProcedure(playgroundv1connect.NewAuditServiceHandler(&auditLog{backend: handler.backend}, compress, connect.WithInterceptors(errorMapper, admin, validator))))
+	transcoder, err := vanguard.NewTranscoder([]*vanguard.Service{service, tenants, audit}, compressionTranscoderOptions()...)
 	if err != nil {
 		logger.Err(err).Msg("Error creating transcoder")
-		return err
+		return nil, err
 	}
 
-	server := &http.Server{Addr: fmt.Sprintf("localhost:%d", port), Handler: transcoder}
+	spec, err := newOpenAPISpec(config)
+	if err != nil {
+		logger.Err(err).Msg("Error loading OpenAPI spec")
+		return nil, err
+	}
 
-	errCh := make(chan error, 1)
-	go func() {
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			errCh <- err
+	crossOrigin, err := newCORS(logger, config.CORS)
+	if err != nil {
+		logger.Err(err).Msg("Error loading CORS origins")
+		return nil, err
+	}
+	srv.background(ctx, func(ctx context.Context) {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-config.Reload:
+				if err := crossOrigin.reload(); err != nil {
+					logger.Err(err).Msg("Error reloading CORS origins")
+				}
+			}
 		}
-	}()
-
-	select {
-	case <-ctx.Done():
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-		defer cancel()
+	})
 
-		logger.Debug().Msg("Shutting down server")
-		if err := server.Shutdown(shutdownCtx); err != nil {
-			log.Err(err).Msg("Error shutting server down cleanly")
-			return err
+	mux := http.NewServeMux()
+	mux.Handle("GET /v1/events", handler.eventsHTTPHandler(errorMapper))
+	mux.Handle("GET /openapi.yaml", compressResponses(compressMinBytes, spec))
+	mux.Handle("GET /openapi.json", compressResponses(compressMinBytes, spec))
+	mux.Handle("GET /ui/", compressResponses(compressMinBytes, uiHandler()))
+	mux.Handle("GET /ui", http.RedirectHandler("/ui/", http.StatusMovedPermanently))
+	// only REST routes answer with 304 or protobuf, as Connect and gRPC
+	// clients don't expect either
+	mux.Handle("GET /v1/", conditionalGET(representationETags(compressResponses(compressMinBytes, protobufResponses(transcoder)))))
+	mux.Handle("/v1/", representationETags(compressResponses(compressMinBytes, protobufResponses(transcoder))))
+	mux.Handle("/", representationETags(transcoder))
+	srv.main = crossOrigin.handler(mux)
+
+	if config.AdminPort != 0 {
+		// the admin service can stop and rewrite any tenant's work, so it
+		// only listens on its own port
+		adminService := vanguard.NewService(recordProcedure(playgroundv1connect.NewAdminServiceHandler(&workflowAdmin{handler: handler}, compress, connect.WithInterceptors(
+			errorMapper,
+			admin,
+			&auditInterceptor{logger: logger, backend: handler.backend, admin: true},
+			validator,
+		))))
+		adminTranscoder, err := vanguard.NewTranscoder([]*vanguard.Service{adminService}, compressionTranscoderOptions()...)
+		if err != nil {
+			logger.Err(err).Msg("Error creating admin transcoder")
+			return nil, err
 		}
-	case err := <-errCh:
-		return err
+		adminMux := http.NewServeMux()
+		adminMux.Handle("/v1/", compressResponses(compressMinBytes, protobufResponses(adminTranscoder)))
+		adminMux.Handle("/", adminTranscoder)
+		srv.admin = crossOrigin.handler(adminMux)
 	}
 
-	return nil
+	return srv, nil
 }
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rs/zerolog"
+
+	"github.com/andrewstucki/vanguard-playground/internal/client"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+)
+
+// testServer is a server on the memory backend whose listeners are httptest
+// servers.
+type testServer struct {
+	*server
+	URL      string
+	AdminURL string
+}
+
+// testLogger only logs when the tests run verbosely.
+func testLogger(t *testing.T) zerolog.Logger {
+	if testing.Verbose() {
+		return zerolog.New(zerolog.NewTestWriter(t))
+	}
+	return zerolog.Nop()
+}
+
+func newTestServer(t *testing.T, config Config) *testServer {
+	t.Helper()
+	if config.AdminPort == 0 {
+		// only whether it's set matters, as httptest picks the port
+		config.AdminPort = -1
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	srv, err := newServer(ctx, testLogger(t), config)
+	if err != nil {
+		cancel()
+		t.Fatal(err)
+	}
+	main := httptest.NewServer(srv.main)
+	admin := httptest.NewServer(srv.admin)
+	t.Cleanup(func() {
+		main.Close()
+		admin.Close()
+		cancel()
+		if err := srv.close(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	return &testServer{server: srv, URL: main.URL, AdminURL: admin.URL}
+}
+
+func (s *testServer) client(t *testing.T, opts ...client.Option) *client.Client {
+	t.Helper()
+	c, err := client.NewClient(append([]client.Option{
+		client.WithBaseURL(s.URL),
+		client.WithPollInterval(100 * time.Millisecond),
+	}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func createMessage(t *testing.T, c *client.Client, text string) string {
+	t.Helper()
+	created, err := c.CreateMessage(context.Background(), connect.NewRequest(&playgroundv1.CreateMessageRequest{
+		Text: text,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return created.Msg.MessageId
+}
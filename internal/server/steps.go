@@ -0,0 +1,248 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"buf.build/go/protovalidate"
+	"github.com/google/uuid"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+var _ playgroundv1.SendMessageStateWorkflowHandler = (*handler)(nil)
+
+func (h *handler) Validate(io *playgroundv1.SendMessageState) error {
+	return h.attempt(io, "validate", h.validate)
+}
+
+func (h *handler) Reserve(io *playgroundv1.SendMessageState) error {
+	return h.attempt(io, "reserve", h.reserve)
+}
+
+func (h *handler) Render(io *playgroundv1.SendMessageState) error {
+	return h.attempt(io, "render", h.render)
+}
+
+func (h *handler) Deliver(io *playgroundv1.SendMessageState) error {
+	return h.attempt(io, "deliver", h.deliver)
+}
+
+func (h *handler) Bill(io *playgroundv1.SendMessageState) error {
+	return h.attempt(io, "bill", h.bill)
+}
+
+func (h *handler) Confirm(io *playgroundv1.SendMessageState) error {
+	return h.attempt(io, "confirm", h.confirm)
+}
+
+func (h *handler) Compensate(io *playgroundv1.SendMessageState) error {
+	ctx := context.Background()
+	// every step before this one is done, so the operation is terminal
+	if err := h.updateParentOf(ctx, io); err != nil {
+		return err
+	}
+	return h.compensate(ctx, io)
+}
+
+// sideEffect runs fn in a transaction only while the operation is still
+// SENDING, so that a concurrent cancel either sees the effect and compensates
+// it or prevents it from happening at all.
+func (h *handler) sideEffect(ctx context.Context, io *playgroundv1.SendMessageState, fn func(*models.Queries, models.SentMessage) error) error {
+	tx, queries, err := h.backend.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	operation, err := queries.GetSentMessageByID(ctx, models.GetSentMessageByIDParams{
+		TenantID: io.TenantId,
+		ID:       io.OperationId,
+	})
+	if err != nil {
+		return err
+	}
+
+	if operation.Result != playgroundv1.MessageState_SENDING.String() {
+		// no-op since this is already processed
+		return nil
+	}
+
+	if err := fn(queries, operation); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// validate checks that the operation has something deliverable before any
+// side effects happen.
+func (h *handler) validate(ctx context.Context, io *playgroundv1.SendMessageState) error {
+	attachments, err := h.backend.ListSentMessageAttachments(ctx, models.ListSentMessageAttachmentsParams{
+		TenantID:    io.TenantId,
+		OperationID: io.OperationId,
+	})
+	if err != nil {
+		return err
+	}
+	for _, attachment := range attachments {
+		if err := h.verifyAttachment(ctx, attachment); err != nil {
+			return err
+		}
+	}
+
+	if io.TemplateId != "" {
+		// the template may have changed since the send was accepted
+		template, err := h.templateForSend(ctx, io)
+		if err != nil {
+			return err
+		}
+		violations, err := templateVariableViolations(&playgroundv1.SendMessageRequest{}, template, io.Variables)
+		if err != nil {
+			return err
+		}
+		if len(violations) > 0 {
+			return &nonRetryableError{err: &protovalidate.ValidationError{Violations: violations}}
+		}
+		return nil
+	}
+
+	operation, err := h.backend.GetSentMessageByID(ctx, models.GetSentMessageByIDParams{
+		TenantID: io.TenantId,
+		ID:       io.OperationId,
+	})
+	if err != nil {
+		return err
+	}
+
+	if operation.Text == "" {
+		return &nonRetryableError{err: errors.New("message text is empty")}
+	}
+
+	return nil
+}
+
+func (h *handler) templateForSend(ctx context.Context, io *playgroundv1.SendMessageState) (models.Template, error) {
+	template, err := h.backend.GetTemplate(ctx, models.GetTemplateParams{
+		TenantID: io.TenantId,
+		ID:       io.TemplateId,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return template, &nonRetryableError{err: fmt.Errorf("template with ID %q not found", io.TemplateId)}
+		}
+		return template, err
+	}
+	return template, nil
+}
+
+// reserve takes a unit of the tenant's send quota for the operation, failing
+// it when the quota is used up.
+func (h *handler) reserve(ctx context.Context, io *playgroundv1.SendMessageState) error {
+	return h.sideEffect(ctx, io, func(queries *models.Queries, operation models.SentMessage) error {
+		_, err := queries.GetQuotaReservation(ctx, models.GetQuotaReservationParams{
+			TenantID:    operation.TenantID,
+			OperationID: operation.ID,
+		})
+		if err == nil {
+			// already reserved by an earlier attempt
+			return nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		exceeded, limit, err := sendQuotaExceeded(ctx, queries, operation.TenantID, 1)
+		if err != nil {
+			return err
+		}
+		if exceeded {
+			return &nonRetryableError{err: fmt.Errorf("tenant %q has reached its quota of %d sends", operation.TenantID, limit)}
+		}
+
+		return queries.CreateQuotaReservation(ctx, models.CreateQuotaReservationParams{
+			TenantID:    operation.TenantID,
+			OperationID: operation.ID,
+			Units:       1,
+		})
+	})
+}
+
+// render produces the final text that will be delivered for the operation,
+// rendering its template when it was sent with one.
+func (h *handler) render(ctx context.Context, io *playgroundv1.SendMessageState) error {
+	if io.TemplateId == "" {
+		// the message text was captured when the send was accepted
+		return nil
+	}
+
+	template, err := h.templateForSend(ctx, io)
+	if err != nil {
+		return err
+	}
+
+	text, err := renderTemplate(template, io.Variables)
+	if err != nil {
+		return &nonRetryableError{err: fmt.Errorf("rendering template: %w", err)}
+	}
+
+	return h.backend.UpdateSentMessageText(ctx, models.UpdateSentMessageTextParams{
+		TenantID: io.TenantId,
+		ID:       io.OperationId,
+		Text:     text,
+	})
+}
+
+// deliver hands the rendered message off and records the delivery receipt.
+// Retries reuse a previously recorded receipt so a message is only delivered
+// once.
+func (h *handler) deliver(ctx context.Context, io *playgroundv1.SendMessageState) error {
+	return h.sideEffect(ctx, io, func(queries *models.Queries, operation models.SentMessage) error {
+		if operation.ReceiptID.Valid {
+			io.ReceiptId = operation.ReceiptID.String
+			return nil
+		}
+
+		receiptID := uuid.New().String()
+		if err := queries.UpdateSentMessageReceipt(ctx, models.UpdateSentMessageReceiptParams{
+			TenantID:  operation.TenantID,
+			ID:        operation.ID,
+			ReceiptID: sql.NullString{String: receiptID, Valid: true},
+		}); err != nil {
+			return err
+		}
+
+		io.ReceiptId = receiptID
+
+		return nil
+	})
+}
+
+// bill records the charge for the delivered message, one unit per character.
+func (h *handler) bill(ctx context.Context, io *playgroundv1.SendMessageState) error {
+	return h.sideEffect(ctx, io, func(queries *models.Queries, operation models.SentMessage) error {
+		return queries.CreateBillingRecord(ctx, models.CreateBillingRecordParams{
+			TenantID:    operation.TenantID,
+			OperationID: operation.ID,
+			Amount:      int64(len(operation.Text)),
+		})
+	})
+}
+
+// confirm waits for the delivery receipt and moves the operation to its
+// terminal state.
+func (h *handler) confirm(ctx context.Context, io *playgroundv1.SendMessageState) error {
+	return h.sideEffect(ctx, io, func(queries *models.Queries, operation models.SentMessage) error {
+		if !operation.ReceiptID.Valid || operation.ReceiptID.String != io.ReceiptId {
+			return fmt.Errorf("delivery receipt %q has not been recorded", io.ReceiptId)
+		}
+
+		io.State = playgroundv1.MessageState_SUCCEEDED
+
+		_, err := setOperationState(ctx, queries, operation.TenantID, operation.ID, io.State)
+		return err
+	})
+}
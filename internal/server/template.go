@@ -0,0 +1,270 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/template"
+
+	"buf.build/go/protovalidate"
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+// parseTemplate parses a template body, checking that it only references the
+// variables it declares.
+func parseTemplate(body string, variables []string) (*template.Template, error) {
+	parsed, err := template.New("message").Option("missingkey=error").Parse(body)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(variables))
+	for _, variable := range variables {
+		values[variable] = ""
+	}
+	if err := parsed.Execute(io.Discard, values); err != nil {
+		return nil, err
+	}
+
+	return parsed, nil
+}
+
+func renderTemplate(model models.Template, values map[string]string) (string, error) {
+	var variables []string
+	if err := json.Unmarshal([]byte(model.Variables), &variables); err != nil {
+		return "", err
+	}
+
+	parsed, err := parseTemplate(model.Body, variables)
+	if err != nil {
+		return "", err
+	}
+
+	var rendered strings.Builder
+	if err := parsed.Execute(&rendered, values); err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
+
+// templateVariableViolations reports every declared variable of the template
+// missing from values and every value that is not a declared variable.
+func templateVariableViolations(msg proto.Message, model models.Template, values map[string]string) ([]*protovalidate.Violation, error) {
+	var variables []string
+	if err := json.Unmarshal([]byte(model.Variables), &variables); err != nil {
+		return nil, err
+	}
+
+	var violations []*protovalidate.Violation
+	for _, variable := range variables {
+		if _, ok := values[variable]; !ok {
+			violations = append(violations, fieldViolation(msg, "variables", variable, "template.variables.missing", fmt.Sprintf("template variable %q is required", variable)))
+		}
+	}
+
+	var extra []string
+	for key := range values {
+		if !slices.Contains(variables, key) {
+			extra = append(extra, key)
+		}
+	}
+	slices.Sort(extra)
+	for _, key := range extra {
+		violations = append(violations, fieldViolation(msg, "variables", key, "template.variables.unknown", fmt.Sprintf("template does not declare variable %q", key)))
+	}
+
+	return violations, nil
+}
+
+func templateFromModel(model models.Template) (*playgroundv1.Template, error) {
+	var variables []string
+	if err := json.Unmarshal([]byte(model.Variables), &variables); err != nil {
+		return nil, err
+	}
+
+	return &playgroundv1.Template{
+		TemplateId: model.ID,
+		Name:       model.Name,
+		Body:       model.Body,
+		Variables:  variables,
+	}, nil
+}
+
+func (h *handler) CreateTemplate(ctx context.Context, req *connect.Request[playgroundv1.CreateTemplateRequest]) (*connect.Response[playgroundv1.CreateTemplateResponse], error) {
+	if _, err := parseTemplate(req.Msg.Body, req.Msg.Variables); err != nil {
+		return nil, violationsError(fieldViolation(req.Msg, "body", "", "template.body.invalid", err.Error()))
+	}
+
+	variables, err := json.Marshal(req.Msg.Variables)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	model, err := h.backend.CreateTemplate(ctx, models.CreateTemplateParams{
+		TenantID:  tenantFromContext(ctx),
+		ID:        uuid.New().String(),
+		Name:      req.Msg.Name,
+		Body:      req.Msg.Body,
+		Variables: string(variables),
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&playgroundv1.CreateTemplateResponse{
+		TemplateId: model.ID,
+	}), nil
+}
+
+func (h *handler) GetTemplate(ctx context.Context, req *connect.Request[playgroundv1.GetTemplateRequest]) (*connect.Response[playgroundv1.GetTemplateResponse], error) {
+	model, err := h.backend.GetTemplate(ctx, models.GetTemplateParams{
+		TenantID: tenantFromContext(ctx),
+		ID:       req.Msg.TemplateId,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFoundError(resourceTemplate, req.Msg.TemplateId, fmt.Errorf("template with ID %q not found", req.Msg.TemplateId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	template, err := templateFromModel(model)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&playgroundv1.GetTemplateResponse{
+		Template: template,
+	}), nil
+}
+
+func (h *handler) ListTemplates(ctx context.Context, _ *connect.Request[playgroundv1.ListTemplatesRequest]) (*connect.Response[playgroundv1.ListTemplatesResponse], error) {
+	queried, err := h.backend.ListTemplates(ctx, tenantFromContext(ctx))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	var templates []*playgroundv1.Template
+	for _, model := range queried {
+		template, err := templateFromModel(model)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		templates = append(templates, template)
+	}
+
+	return connect.NewResponse(&playgroundv1.ListTemplatesResponse{
+		Templates: templates,
+	}), nil
+}
+
+func (h *handler) UpdateTemplate(ctx context.Context, req *connect.Request[playgroundv1.UpdateTemplateRequest]) (*connect.Response[playgroundv1.UpdateTemplateResponse], error) {
+	paths, err := updatePaths(req.Msg, req.Msg.UpdateMask, "name", "body", "variables")
+	if err != nil {
+		return nil, err
+	}
+
+	tenant := tenantFromContext(ctx)
+
+	tx, queries, err := h.backend.Tx(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer tx.Rollback()
+
+	current, err := queries.GetTemplate(ctx, models.GetTemplateParams{
+		TenantID: tenant,
+		ID:       req.Msg.TemplateId,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFoundError(resourceTemplate, req.Msg.TemplateId, fmt.Errorf("template with ID %q not found", req.Msg.TemplateId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	name, body := current.Name, current.Body
+	var variables []string
+	if err := json.Unmarshal([]byte(current.Variables), &variables); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if paths["name"] {
+		name = req.Msg.Name
+	}
+	if paths["body"] {
+		body = req.Msg.Body
+	}
+	if paths["variables"] {
+		variables = req.Msg.Variables
+	}
+
+	var violations []*protovalidate.Violation
+	if name == "" {
+		violations = append(violations, fieldViolation(req.Msg, "name", "", "required", "value is required"))
+	}
+	if body == "" {
+		violations = append(violations, fieldViolation(req.Msg, "body", "", "required", "value is required"))
+	} else if _, err := parseTemplate(body, variables); err != nil {
+		violations = append(violations, fieldViolation(req.Msg, "body", "", "template.body.invalid", err.Error()))
+	}
+	if len(violations) > 0 {
+		return nil, violationsError(violations...)
+	}
+
+	encoded, err := json.Marshal(variables)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	model, err := queries.UpdateTemplate(ctx, models.UpdateTemplateParams{
+		TenantID:  tenant,
+		ID:        req.Msg.TemplateId,
+		Name:      name,
+		Body:      body,
+		Variables: string(encoded),
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := recordAudit(ctx, queries, ""); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	template, err := templateFromModel(model)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&playgroundv1.UpdateTemplateResponse{
+		Template: template,
+	}), nil
+}
+
+func (h *handler) DeleteTemplate(ctx context.Context, req *connect.Request[playgroundv1.DeleteTemplateRequest]) (*connect.Response[playgroundv1.DeleteTemplateResponse], error) {
+	rows, err := h.backend.DeleteTemplate(ctx, models.DeleteTemplateParams{
+		TenantID: tenantFromContext(ctx),
+		ID:       req.Msg.TemplateId,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if rows == 0 {
+		return nil, notFoundError(resourceTemplate, req.Msg.TemplateId, fmt.Errorf("template with ID %q not found", req.Msg.TemplateId))
+	}
+	return connect.NewResponse(&playgroundv1.DeleteTemplateResponse{}), nil
+}
@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"connectrpc.com/connect"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+)
+
+// violations returns the violations of an InvalidArgument error as
+// "field[key]/rule" strings.
+func violations(t *testing.T, err error) []string {
+	t.Helper()
+	if connect.CodeOf(err) != connect.CodeInvalidArgument {
+		t.Fatalf("expected an InvalidArgument error, got %v", err)
+	}
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) {
+		t.Fatalf("expected a connect error, got %v", err)
+	}
+
+	var rows []string
+	for _, detail := range connectErr.Details() {
+		value, err := detail.Value()
+		if err != nil {
+			t.Fatal(err)
+		}
+		detailed, ok := value.(*validate.Violations)
+		if !ok {
+			continue
+		}
+		for _, violation := range detailed.Violations {
+			element := violation.Field.Elements[0]
+			rows = append(rows, fmt.Sprintf("%s[%s]/%s", element.GetFieldName(), element.GetStringKey(), violation.GetRuleId()))
+		}
+	}
+	return rows
+}
+
+func TestSendTemplateVariables(t *testing.T) {
+	s := newTestServer(t, Config{})
+	c := s.client(t)
+	ctx := context.Background()
+
+	template, err := c.CreateTemplate(ctx, connect.NewRequest(&playgroundv1.CreateTemplateRequest{
+		Name:      "greeting",
+		Body:      "{{.greeting}}, {{.name}}",
+		Variables: []string{"greeting", "name"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	messageID := createMessage(t, c, "hello")
+
+	for _, test := range []struct {
+		name      string
+		variables map[string]string
+		want      []string
+	}{
+		{
+			name:      "missing",
+			variables: map[string]string{"greeting": "hi"},
+			want:      []string{"variables[name]/template.variables.missing"},
+		},
+		{
+			name:      "extra",
+			variables: map[string]string{"greeting": "hi", "name": "you", "title": "Dr", "age": "42"},
+			want:      []string{"variables[age]/template.variables.unknown", "variables[title]/template.variables.unknown"},
+		},
+		{
+			name:      "missing and extra",
+			variables: map[string]string{"nmae": "you"},
+			want: []string{
+				"variables[greeting]/template.variables.missing",
+				"variables[name]/template.variables.missing",
+				"variables[nmae]/template.variables.unknown",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := c.SendMessage(ctx, connect.NewRequest(&playgroundv1.SendMessageRequest{
+				MessageId:  messageID,
+				TemplateId: template.Msg.TemplateId,
+				Variables:  test.variables,
+			}))
+			if got := violations(t, err); !slices.Equal(got, test.want) {
+				t.Errorf("expected violations %q, got %q", test.want, got)
+			}
+		})
+	}
+
+	if _, err := c.SendMessage(ctx, connect.NewRequest(&playgroundv1.SendMessageRequest{
+		MessageId: messageID,
+		Variables: map[string]string{"name": "you"},
+	})); !slices.Equal(violations(t, err), []string{"variables[]/template.variables.without_template"}) {
+		t.Errorf("expected variables without a template to be refused, got %v", err)
+	}
+
+	if _, err := c.SendMessage(ctx, connect.NewRequest(&playgroundv1.SendMessageRequest{
+		MessageId:  messageID,
+		TemplateId: template.Msg.TemplateId,
+		Variables:  map[string]string{"greeting": "hi", "name": "you"},
+	})); err != nil {
+		t.Errorf("expected the declared variables to be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,440 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1/playgroundv1connect"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+const (
+	// DefaultTenant owns requests that don't identify a tenant.
+	DefaultTenant = "default"
+	// TenantHeader selects the tenant of a request that isn't authenticated
+	// with a tenant token, when the server runs in open tenancy mode.
+	TenantHeader = "X-Tenant-ID"
+)
+
+// tenantPattern matches the same IDs as the validation rules of
+// CreateTenantRequest.
+var tenantPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+type tenantKey struct{}
+
+func withTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenant)
+}
+
+// tenantFromContext returns the tenant resolved for the request by the tenant
+// interceptor.
+func tenantFromContext(ctx context.Context) string {
+	if tenant, ok := ctx.Value(tenantKey{}).(string); ok {
+		return tenant
+	}
+	return DefaultTenant
+}
+
+func bearerToken(header http.Header) string {
+	scheme, token, ok := strings.Cut(header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// TenancyMode decides which tenant a request without a tenant token acts as.
+type TenancyMode string
+
+const (
+	// TenancyToken requires every request to carry a tenant token, which
+	// servers with several tenants opt into.
+	TenancyToken TenancyMode = "token"
+	// TenancySingle has requests without a token act as the default tenant,
+	// for servers with a single tenant. It is the mode servers run in unless
+	// told otherwise.
+	TenancySingle TenancyMode = "single"
+	// TenancyOpen lets the X-Tenant-ID header select any tenant that hasn't
+	// been issued a token, trusting callers to pick their own tenant.
+	TenancyOpen TenancyMode = "open"
+)
+
+// TenancyModes are the valid tenancy modes.
+var TenancyModes = []TenancyMode{TenancyToken, TenancySingle, TenancyOpen}
+
+func (m TenancyMode) validate() error {
+	if m != "" && !slices.Contains(TenancyModes, m) {
+		return fmt.Errorf("unknown tenancy mode %q", m)
+	}
+	return nil
+}
+
+// resolveTenant works out which tenant a request acts as. A tenant token
+// authenticates as its tenant. Without one, the tenancy mode decides: token
+// mode refuses the request, single mode only allows the default tenant and
+// open mode lets the X-Tenant-ID header select a tenant, defaulting to the
+// default tenant. Either way, tenants that have been issued a token require
+// it.
+func (h *handler) resolveTenant(ctx context.Context, header http.Header) (string, error) {
+	requested := header.Get(TenantHeader)
+
+	if token := bearerToken(header); token != "" {
+		tenant, err := h.backend.GetTenantByToken(ctx, sql.NullString{String: hashToken(token), Valid: true})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", withReason(connect.NewError(connect.CodeUnauthenticated, errors.New("unknown tenant token")), reasonInvalidToken, nil)
+			}
+			return "", connect.NewError(connect.CodeInternal, err)
+		}
+		if requested != "" && requested != tenant.ID {
+			return "", withReason(connect.NewError(connect.CodePermissionDenied, fmt.Errorf("token does not belong to tenant %q", requested)), reasonTenantMismatch, map[string]string{"tenant": requested})
+		}
+		return tenant.ID, nil
+	}
+
+	if requested == "" {
+		requested = DefaultTenant
+	}
+	if !tenantPattern.MatchString(requested) {
+		return "", connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("%s header must match %s", TenantHeader, tenantPattern))
+	}
+
+	switch h.tenancy {
+	case TenancyToken:
+		return "", withReason(connect.NewError(connect.CodeUnauthenticated, errors.New("tenant token required")), reasonTokenRequired, map[string]string{"tenant": requested})
+	case TenancyOpen:
+	default:
+		if requested != DefaultTenant {
+			return "", withReason(connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("tenant %q requires a token", requested)), reasonTokenRequired, map[string]string{"tenant": requested})
+		}
+	}
+
+	tenant, err := h.backend.GetTenant(ctx, requested)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return requested, nil
+	case err != nil:
+		return "", connect.NewError(connect.CodeInternal, err)
+	case tenant.TokenSha256.Valid:
+		return "", withReason(connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("tenant %q requires a token", requested)), reasonTokenRequired, map[string]string{"tenant": requested})
+	}
+	return requested, nil
+}
+
+// tenantInterceptor resolves the tenant of every request before it reaches
+// the handler.
+type tenantInterceptor struct {
+	handler *handler
+}
+
+func (i *tenantInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		tenant, err := i.handler.resolveTenant(ctx, req.Header())
+		if err != nil {
+			return nil, err
+		}
+		return next(withTenant(ctx, tenant), req)
+	}
+}
+
+func (i *tenantInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *tenantInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		tenant, err := i.handler.resolveTenant(ctx, conn.RequestHeader())
+		if err != nil {
+			return err
+		}
+		return next(withTenant(ctx, tenant), conn)
+	}
+}
+
+// adminInterceptor requires the admin token on every request, refusing all of
+// them when the server has no admin token.
+type adminInterceptor struct {
+	token string
+}
+
+func (i *adminInterceptor) authorize(header http.Header) error {
+	if i.token == "" {
+		return withReason(connect.NewError(connect.CodePermissionDenied, errors.New("administration is disabled as the server has no admin token")), reasonAdminDisabled, nil)
+	}
+	if subtle.ConstantTimeCompare([]byte(bearerToken(header)), []byte(i.token)) != 1 {
+		return withReason(connect.NewError(connect.CodeUnauthenticated, errors.New("admin token required")), reasonTokenRequired, nil)
+	}
+	return nil
+}
+
+func (i *adminInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if err := i.authorize(req.Header()); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+func (i *adminInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (i *adminInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := i.authorize(conn.RequestHeader()); err != nil {
+			return err
+		}
+		return next(ctx, conn)
+	}
+}
+
+// tenantQuota returns the quota of a tenant, which is unlimited for tenants
+// that were never created through the TenantService.
+func tenantQuota(ctx context.Context, queries *models.Queries, tenant string) (*playgroundv1.TenantQuota, error) {
+	model, err := queries.GetTenant(ctx, tenant)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &playgroundv1.TenantQuota{}, nil
+		}
+		return nil, err
+	}
+	return &playgroundv1.TenantQuota{
+		MaxMessages: model.MaxMessages,
+		MaxSends:    model.MaxSends,
+	}, nil
+}
+
+// checkMessageQuota fails with ResourceExhausted when the tenant can't store
+// another message.
+func checkMessageQuota(ctx context.Context, queries *models.Queries, tenant string) error {
+	quota, err := tenantQuota(ctx, queries, tenant)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if quota.MaxMessages == 0 {
+		return nil
+	}
+
+	count, err := queries.CountMessages(ctx, tenant)
+	if err != nil {
+		return connect.NewError(connect.CodeInternal, err)
+	}
+	if count >= quota.MaxMessages {
+		return withReason(connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("tenant %q has reached its quota of %d messages", tenant, quota.MaxMessages)), reasonQuotaExceeded, map[string]string{"tenant": tenant, "quota": "max_messages"})
+	}
+	return nil
+}
+
+// sendQuotaExceeded reports whether reserving units more sends would take the
+// tenant over its send quota.
+func sendQuotaExceeded(ctx context.Context, queries *models.Queries, tenant string, units int64) (bool, int64, error) {
+	quota, err := tenantQuota(ctx, queries, tenant)
+	if err != nil {
+		return false, 0, err
+	}
+	if quota.MaxSends == 0 {
+		return false, 0, nil
+	}
+
+	reserved, err := queries.SumQuotaReservations(ctx, tenant)
+	if err != nil {
+		return false, 0, err
+	}
+	return reserved+units > quota.MaxSends, quota.MaxSends, nil
+}
+
+func generateTenantToken() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return "tok_" + base64.RawURLEncoding.EncodeToString(key), nil
+}
+
+// tenantAdmin serves the TenantService.
+type tenantAdmin struct {
+	backend *models.Backend
+}
+
+var _ playgroundv1connect.TenantServiceHandler = (*tenantAdmin)(nil)
+
+func (a *tenantAdmin) tenantFromModel(ctx context.Context, model models.Tenant) (*playgroundv1.Tenant, error) {
+	messages, err := a.backend.CountMessages(ctx, model.ID)
+	if err != nil {
+		return nil, err
+	}
+	sends, err := a.backend.SumQuotaReservations(ctx, model.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &playgroundv1.Tenant{
+		TenantId:    model.ID,
+		DisplayName: model.DisplayName,
+		Quota: &playgroundv1.TenantQuota{
+			MaxMessages: model.MaxMessages,
+			MaxSends:    model.MaxSends,
+		},
+		Usage: &playgroundv1.TenantUsage{
+			Messages: messages,
+			Sends:    sends,
+		},
+		CreateTime: timestamppb.New(model.CreatedAt),
+	}, nil
+}
+
+func (a *tenantAdmin) CreateTenant(ctx context.Context, req *connect.Request[playgroundv1.CreateTenantRequest]) (*connect.Response[playgroundv1.CreateTenantResponse], error) {
+	setAuditTenant(ctx, req.Msg.TenantId)
+
+	token, err := generateTenantToken()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	model, err := a.backend.CreateTenant(ctx, models.CreateTenantParams{
+		ID:          req.Msg.TenantId,
+		DisplayName: req.Msg.DisplayName,
+		TokenSha256: sql.NullString{String: hashToken(token), Valid: true},
+		MaxMessages: req.Msg.Quota.GetMaxMessages(),
+		MaxSends:    req.Msg.Quota.GetMaxSends(),
+	})
+	if err != nil {
+		// the insert is what decides, so that concurrent creates can't both
+		// pass a check made before it
+		if uniqueViolation(err) {
+			return nil, connect.NewError(connect.CodeAlreadyExists, fmt.Errorf("tenant %q already exists", req.Msg.TenantId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	tenant, err := a.tenantFromModel(ctx, model)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&playgroundv1.CreateTenantResponse{
+		Tenant: tenant,
+		Token:  token,
+	}), nil
+}
+
+func (a *tenantAdmin) GetTenant(ctx context.Context, req *connect.Request[playgroundv1.GetTenantRequest]) (*connect.Response[playgroundv1.GetTenantResponse], error) {
+	model, err := a.backend.GetTenant(ctx, req.Msg.TenantId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFoundError(resourceTenant, req.Msg.TenantId, fmt.Errorf("tenant %q not found", req.Msg.TenantId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	tenant, err := a.tenantFromModel(ctx, model)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&playgroundv1.GetTenantResponse{
+		Tenant: tenant,
+	}), nil
+}
+
+func (a *tenantAdmin) ListTenants(ctx context.Context, _ *connect.Request[playgroundv1.ListTenantsRequest]) (*connect.Response[playgroundv1.ListTenantsResponse], error) {
+	queried, err := a.backend.ListTenants(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	var tenants []*playgroundv1.Tenant
+	for _, model := range queried {
+		tenant, err := a.tenantFromModel(ctx, model)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		tenants = append(tenants, tenant)
+	}
+
+	return connect.NewResponse(&playgroundv1.ListTenantsResponse{
+		Tenants: tenants,
+	}), nil
+}
+
+func (a *tenantAdmin) UpdateTenant(ctx context.Context, req *connect.Request[playgroundv1.UpdateTenantRequest]) (*connect.Response[playgroundv1.UpdateTenantResponse], error) {
+	setAuditTenant(ctx, req.Msg.TenantId)
+
+	paths, err := updatePaths(req.Msg, req.Msg.UpdateMask, "display_name", "quota", "quota.max_messages", "quota.max_sends")
+	if err != nil {
+		return nil, err
+	}
+
+	tx, queries, err := a.backend.Tx(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer tx.Rollback()
+
+	current, err := queries.GetTenant(ctx, req.Msg.TenantId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFoundError(resourceTenant, req.Msg.TenantId, fmt.Errorf("tenant %q not found", req.Msg.TenantId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	params := models.UpdateTenantParams{
+		ID:          current.ID,
+		DisplayName: current.DisplayName,
+		MaxMessages: current.MaxMessages,
+		MaxSends:    current.MaxSends,
+	}
+	if paths["display_name"] {
+		params.DisplayName = req.Msg.DisplayName
+	}
+	if paths["quota"] || paths["quota.max_messages"] {
+		params.MaxMessages = req.Msg.Quota.GetMaxMessages()
+	}
+	if paths["quota"] || paths["quota.max_sends"] {
+		params.MaxSends = req.Msg.Quota.GetMaxSends()
+	}
+
+	model, err := queries.UpdateTenant(ctx, params)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := recordAudit(ctx, queries, ""); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	tenant, err := a.tenantFromModel(ctx, model)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&playgroundv1.UpdateTenantResponse{
+		Tenant: tenant,
+	}), nil
+}
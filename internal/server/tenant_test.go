@@ -0,0 +1,141 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+
+	"github.com/andrewstucki/vanguard-playground/internal/client"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+)
+
+const testAdminToken = "admin-secret"
+
+// createTenant creates a tenant through the TenantService and returns its
+// token.
+func createTenant(t *testing.T, s *testServer, tenant string) string {
+	t.Helper()
+	created, err := s.client(t, client.WithToken(testAdminToken)).CreateTenant(context.Background(), connect.NewRequest(&playgroundv1.CreateTenantRequest{
+		TenantId: tenant,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return created.Msg.Token
+}
+
+func listMessages(c *client.Client) error {
+	_, err := c.ListMessages(context.Background(), connect.NewRequest(&playgroundv1.ListMessagesRequest{}))
+	return err
+}
+
+func TestAdminServicesFailClosed(t *testing.T) {
+	s := newTestServer(t, Config{})
+	ctx := context.Background()
+
+	c := s.client(t, client.WithToken("guess"))
+	if _, err := c.ListTenants(ctx, connect.NewRequest(&playgroundv1.ListTenantsRequest{})); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("expected listing tenants without an admin token to be PermissionDenied, got %v", err)
+	}
+	if _, err := c.ListAuditEvents(ctx, connect.NewRequest(&playgroundv1.ListAuditEventsRequest{})); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("expected listing audit events without an admin token to be PermissionDenied, got %v", err)
+	}
+	admin, err := client.NewAdminClient(client.WithBaseURL(s.AdminURL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := admin.ListWorkflowInstances(ctx, connect.NewRequest(&playgroundv1.ListWorkflowInstancesRequest{})); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Errorf("expected listing workflows without an admin token to be PermissionDenied, got %v", err)
+	}
+}
+
+func TestAdminToken(t *testing.T) {
+	s := newTestServer(t, Config{AdminToken: testAdminToken})
+	ctx := context.Background()
+
+	if _, err := s.client(t).ListTenants(ctx, connect.NewRequest(&playgroundv1.ListTenantsRequest{})); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("expected listing tenants without a token to be Unauthenticated, got %v", err)
+	}
+	if _, err := s.client(t, client.WithToken("guess")).ListTenants(ctx, connect.NewRequest(&playgroundv1.ListTenantsRequest{})); connect.CodeOf(err) != connect.CodeUnauthenticated {
+		t.Errorf("expected listing tenants with the wrong token to be Unauthenticated, got %v", err)
+	}
+	if _, err := s.client(t, client.WithToken(testAdminToken)).ListTenants(ctx, connect.NewRequest(&playgroundv1.ListTenantsRequest{})); err != nil {
+		t.Errorf("expected the admin token to list tenants, got %v", err)
+	}
+}
+
+func TestTenancyModes(t *testing.T) {
+	for _, test := range []struct {
+		mode TenancyMode
+		// whether calls without a token may act as the default tenant and
+		// as another tenant named by the header
+		defaultTenant bool
+		headerTenant  bool
+	}{
+		{mode: TenancyToken},
+		{mode: TenancySingle, defaultTenant: true},
+		{mode: TenancyOpen, defaultTenant: true, headerTenant: true},
+		// a server that isn't told otherwise runs in single mode
+		{defaultTenant: true},
+	} {
+		t.Run(cmp.Or(string(test.mode), "unset"), func(t *testing.T) {
+			s := newTestServer(t, Config{Tenancy: test.mode, AdminToken: testAdminToken})
+			token := createTenant(t, s, "acme")
+
+			assertAllowed := func(name string, allowed bool, err error) {
+				t.Helper()
+				switch {
+				case allowed && err != nil:
+					t.Errorf("expected %s to be allowed, got %v", name, err)
+				case !allowed && connect.CodeOf(err) != connect.CodeUnauthenticated:
+					t.Errorf("expected %s to be Unauthenticated, got %v", name, err)
+				}
+			}
+			assertAllowed("a call without a token", test.defaultTenant, listMessages(s.client(t)))
+			assertAllowed("a call with the default tenant header", test.defaultTenant, listMessages(s.client(t, client.WithTenant(DefaultTenant))))
+			assertAllowed("a call with another tenant's header", test.headerTenant, listMessages(s.client(t, client.WithTenant("other"))))
+			// a tenant with a token always requires it
+			assertAllowed("a call with a tokened tenant's header", false, listMessages(s.client(t, client.WithTenant("acme"))))
+			assertAllowed("a call with a tenant token", true, listMessages(s.client(t, client.WithToken(token))))
+
+			err := listMessages(s.client(t, client.WithToken(token), client.WithTenant("other")))
+			if connect.CodeOf(err) != connect.CodePermissionDenied {
+				t.Errorf("expected a token for another tenant to be PermissionDenied, got %v", err)
+			}
+		})
+	}
+
+	if _, err := newServer(context.Background(), testLogger(t), Config{Tenancy: "shared"}); err == nil {
+		t.Error("expected an unknown tenancy mode to be refused")
+	}
+}
+
+func TestCreateTenantOnce(t *testing.T) {
+	s := newTestServer(t, Config{AdminToken: testAdminToken})
+	c := s.client(t, client.WithToken(testAdminToken))
+
+	// of concurrent creates of a tenant, one succeeds and the rest find it
+	// already exists
+	const creates = 4
+	results := make(chan error, creates)
+	for range creates {
+		go func() {
+			_, err := c.CreateTenant(context.Background(), connect.NewRequest(&playgroundv1.CreateTenantRequest{TenantId: "acme"}))
+			results <- err
+		}()
+	}
+	created := 0
+	for range creates {
+		switch err := <-results; {
+		case err == nil:
+			created++
+		case connect.CodeOf(err) != connect.CodeAlreadyExists:
+			t.Errorf("expected the tenant to be created or already exist, got %v", err)
+		}
+	}
+	if created != 1 {
+		t.Errorf("expected the tenant to be created once, got %d", created)
+	}
+}
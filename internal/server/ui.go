@@ -0,0 +1,23 @@
+package server
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// uiFiles is the support dashboard. It is plain HTML and JavaScript that calls
+// the REST routes, so it needs neither a build step nor anything from a CDN.
+//
+//go:embed ui
+var uiFiles embed.FS
+
+// uiHandler serves the dashboard under /ui/.
+func uiHandler() http.Handler {
+	files, err := fs.Sub(uiFiles, "ui")
+	if err != nil {
+		// the directory is embedded, so this can only fail on a bad build
+		panic(err)
+	}
+	return http.StripPrefix("/ui/", http.FileServerFS(files))
+}
@@ -0,0 +1,219 @@
+"use strict";
+
+// operations holds the latest known state of every operation, keyed by ID.
+const operations = new Map();
+
+// api calls a REST route with an optional JSON body, turning the JSON error
+// the transcoder writes into an exception.
+async function api(method, path, body) {
+  const init = { method, headers: headers() };
+  if (body !== undefined) {
+    init.body = JSON.stringify(body);
+    init.headers["Content-Type"] = "application/json";
+  }
+  const res = await fetch(path, init);
+  const text = await res.text();
+  const result = text ? JSON.parse(text) : {};
+  if (!res.ok) {
+    throw new Error(`${res.status}: ${result.message || res.statusText}`);
+  }
+  return result;
+}
+
+function cell(row, content) {
+  const td = row.insertCell();
+  if (content instanceof Node) {
+    td.append(content);
+  } else {
+    td.textContent = content ?? "";
+  }
+  return td;
+}
+
+function code(text) {
+  const el = document.createElement("code");
+  el.textContent = text;
+  return el;
+}
+
+function badge(state) {
+  const el = document.createElement("span");
+  el.className = "badge state-" + state;
+  el.textContent = state;
+  return el;
+}
+
+function button(label, onClick) {
+  const el = document.createElement("button");
+  el.type = "button";
+  el.textContent = label;
+  el.addEventListener("click", (event) => {
+    event.stopPropagation();
+    onClick().catch(showError);
+  });
+  return el;
+}
+
+function time(value) {
+  return value ? new Date(value).toLocaleString() : "";
+}
+
+async function loadMessages() {
+  const { messages = [] } = await api("GET", "/v1/messages");
+  const body = document.getElementById("messages");
+  body.replaceChildren();
+  for (const message of messages) {
+    const row = body.insertRow();
+    cell(row, code(message.messageId));
+    cell(row, message.text);
+    cell(row, message.contentType || "PLAIN");
+    cell(row, Object.entries(message.labels || {}).map(([k, v]) => `${k}=${v}`).join(", "));
+    cell(row, time(message.createTime));
+    const actions = cell(row, button("Send", async () => {
+      const { operationId } = await api("POST", `/v1/messages/${message.messageId}/send`, {});
+      trackOperation({ operationId, messageId: message.messageId, state: "SENDING" }, new Date().toISOString());
+    }));
+    actions.append(" ", button("Delete", async () => {
+      if (!confirm(`Delete message ${message.messageId}?`)) {
+        return;
+      }
+      await api("DELETE", `/v1/messages/${message.messageId}`);
+      await loadMessages();
+    }));
+  }
+}
+
+async function loadDeadLetters() {
+  const { deadLetters = [] } = await api("GET", "/v1/dead-letters");
+  const body = document.getElementById("dead-letters");
+  body.replaceChildren();
+  for (const deadLetter of deadLetters) {
+    const row = body.insertRow();
+    cell(row, code(deadLetter.operationId));
+    cell(row, code(deadLetter.messageId));
+    cell(row, deadLetter.step);
+    cell(row, deadLetter.attempts || 0);
+    cell(row, deadLetter.lastError);
+    if (deadLetter.redriveOperationId) {
+      cell(row, "redriven");
+      continue;
+    }
+    cell(row, button("Redrive", async () => {
+      const { operationId } = await api("POST", `/v1/dead-letters/${deadLetter.deadLetterId}:redrive`, {});
+      trackOperation({ operationId, messageId: deadLetter.messageId, state: "SENDING" }, new Date().toISOString());
+      await loadDeadLetters();
+    }));
+  }
+}
+
+function trackOperation(event, updated) {
+  const known = operations.get(event.operationId) || {};
+  operations.set(event.operationId, {
+    operationId: event.operationId,
+    messageId: event.messageId,
+    state: event.state || known.state || "SENDING",
+    updated,
+  });
+  renderOperations();
+}
+
+function renderOperations() {
+  const failedOnly = document.getElementById("failed-only").checked;
+  const body = document.getElementById("operations");
+  body.replaceChildren();
+  const sorted = [...operations.values()].sort((a, b) => b.updated.localeCompare(a.updated));
+  for (const operation of sorted) {
+    if (failedOnly && operation.state !== "FAILED") {
+      continue;
+    }
+    const row = body.insertRow();
+    row.className = "clickable";
+    row.addEventListener("click", () => showOperation(operation).catch(showError));
+    cell(row, code(operation.operationId));
+    cell(row, code(operation.messageId));
+    cell(row, badge(operation.state));
+    cell(row, time(operation.updated));
+  }
+}
+
+async function showOperation(operation) {
+  const status = await api("GET", `/v1/messages/${operation.messageId}/status/${operation.operationId}`);
+  document.getElementById("details-id").textContent = operation.operationId;
+  document.getElementById("details-body").textContent = JSON.stringify(status, null, 2);
+  document.getElementById("details").hidden = false;
+}
+
+// streamEvents follows the event feed, which replays every event after the
+// given offset before waiting for new ones, and reconnects from the last
+// offset it saw whenever the connection drops.
+async function streamEvents() {
+  const indicator = document.getElementById("stream-state");
+  let offset = "0";
+  for (;;) {
+    try {
+      const res = await fetch(`/v1/events?after_offset=${offset}`, { headers: headers() });
+      if (!res.ok) {
+        const body = await res.json().catch(() => ({}));
+        throw new Error(`${res.status}: ${body.message || res.statusText}`);
+      }
+      indicator.textContent = "live";
+      const reader = res.body.pipeThrough(new TextDecoderStream()).getReader();
+      let buffered = "";
+      for (;;) {
+        const { value, done } = await reader.read();
+        if (done) {
+          break;
+        }
+        buffered += value;
+        const lines = buffered.split("\n");
+        buffered = lines.pop();
+        for (const line of lines) {
+          if (!line.trim()) {
+            continue;
+          }
+          const event = JSON.parse(line);
+          offset = event.sequence;
+          handleEvent(event);
+        }
+      }
+    } catch (err) {
+      showError(err);
+    }
+    indicator.textContent = "reconnecting";
+    await new Promise((resolve) => setTimeout(resolve, 2000));
+  }
+}
+
+function handleEvent(event) {
+  switch (event.type) {
+    case "playground.v1.message.sent":
+    case "playground.v1.operation.state_changed":
+      trackOperation(event.data, event.time);
+      break;
+  }
+}
+
+function init() {
+  bindSettings();
+
+  const create = document.getElementById("create");
+  create.addEventListener("submit", (event) => {
+    event.preventDefault();
+    api("POST", "/v1/messages", { text: create.text.value, contentType: create.contentType.value })
+      .then(() => {
+        create.reset();
+        return loadMessages();
+      })
+      .catch(showError);
+  });
+
+  document.getElementById("refresh-messages").addEventListener("click", () => loadMessages().catch(showError));
+  document.getElementById("refresh-dead-letters").addEventListener("click", () => loadDeadLetters().catch(showError));
+  document.getElementById("failed-only").addEventListener("change", renderOperations);
+
+  loadMessages().catch(showError);
+  loadDeadLetters().catch(showError);
+  streamEvents();
+}
+
+init();
@@ -0,0 +1,40 @@
+"use strict";
+
+// Settings are kept in local storage so that support only enters them once.
+const settings = {
+  get tenant() { return localStorage.getItem("tenant") || ""; },
+  get token() { return localStorage.getItem("token") || ""; },
+};
+
+function headers() {
+  const result = {};
+  if (settings.tenant) {
+    result["X-Tenant-ID"] = settings.tenant;
+  }
+  if (settings.token) {
+    result["Authorization"] = "Bearer " + settings.token;
+  }
+  return result;
+}
+
+function showError(err) {
+  const box = document.getElementById("error");
+  box.textContent = err.message;
+  box.hidden = false;
+  clearTimeout(showError.timer);
+  showError.timer = setTimeout(() => { box.hidden = true; }, 8000);
+}
+
+// bindSettings fills the settings form from local storage and saves it back,
+// reloading the page since what it shows belongs to the old tenant.
+function bindSettings() {
+  const form = document.getElementById("settings");
+  form.tenant.value = settings.tenant;
+  form.token.value = settings.token;
+  form.addEventListener("submit", (event) => {
+    event.preventDefault();
+    localStorage.setItem("tenant", form.tenant.value.trim());
+    localStorage.setItem("token", form.token.value.trim());
+    window.location.reload();
+  });
+}
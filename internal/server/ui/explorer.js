@@ -0,0 +1,139 @@
+"use strict";
+
+const methods = ["get", "post", "put", "patch", "delete"];
+
+function element(tag, attributes, ...children) {
+  const el = document.createElement(tag);
+  Object.assign(el, attributes);
+  el.append(...children);
+  return el;
+}
+
+// operationURL fills the path parameters of an operation into its path and
+// adds the rest of the filled in parameters as the query.
+function operationURL(server, path, parameters, inputs) {
+  let filled = path;
+  const query = new URLSearchParams();
+  for (const parameter of parameters) {
+    const value = inputs.get(parameter.name).value;
+    if (parameter.in === "path") {
+      filled = filled.replace(`{${parameter.name}}`, encodeURIComponent(value));
+    } else if (parameter.in === "query" && value !== "") {
+      for (const item of parameter.schema?.type === "array" ? value.split(",") : [value]) {
+        query.append(parameter.name, item.trim());
+      }
+    }
+  }
+  const url = new URL(server + filled, window.location.origin);
+  url.search = query.toString();
+  return url;
+}
+
+function renderOperation(spec, path, pathItem, method, operation) {
+  const server = (pathItem.servers || spec.servers || [{ url: "" }])[0].url;
+  const parameters = operation.parameters || [];
+  const inputs = new Map();
+
+  const form = element("form", { className: "operation-form" });
+  for (const parameter of parameters) {
+    const input = element("input", {
+      name: parameter.name,
+      required: parameter.required || false,
+      placeholder: parameter.schema?.type === "array" ? "comma separated" : (parameter.schema?.format || parameter.schema?.type || ""),
+    });
+    inputs.set(parameter.name, input);
+    form.append(element("label", { title: parameter.description || "" }, `${parameter.name} (${parameter.in})`, input));
+  }
+  let body;
+  if (operation.requestBody) {
+    body = element("textarea", { rows: 4, placeholder: "JSON request body" });
+    form.append(element("label", {}, "body", body));
+  }
+  form.append(element("button", { type: "submit" }, "Send"));
+
+  const result = element("pre", { hidden: true });
+  form.addEventListener("submit", async (event) => {
+    event.preventDefault();
+    const url = operationURL(server, path, parameters, inputs);
+    const init = { method: method.toUpperCase(), headers: headers() };
+    if (body && body.value.trim()) {
+      init.body = body.value;
+      init.headers["Content-Type"] = "application/json";
+    }
+    try {
+      const res = await fetch(url, init);
+      let text = await res.text();
+      try {
+        text = JSON.stringify(JSON.parse(text), null, 2);
+      } catch {
+        // show bodies that aren't JSON as they are
+      }
+      result.textContent = `${init.method} ${url}\n${res.status} ${res.statusText}\n\n${text}`;
+      result.hidden = false;
+    } catch (err) {
+      showError(err);
+    }
+  });
+
+  const summary = element("summary", {},
+    element("span", { className: "method method-" + method }, method.toUpperCase()), " ",
+    element("code", {}, path), " ",
+    operation.description || operation.operationId || "",
+  );
+  const details = element("details", { className: "operation" }, summary, form, result);
+  details.dataset.search = `${method} ${path} ${operation.operationId || ""}`.toLowerCase();
+  return details;
+}
+
+async function loadSpec() {
+  const res = await fetch("/openapi.json");
+  if (!res.ok) {
+    throw new Error(`${res.status}: ${res.statusText}`);
+  }
+  const spec = await res.json();
+  document.getElementById("title").textContent = `${spec.info?.title || "API"} ${spec.info?.version || ""}`;
+
+  // group the operations by their service
+  const groups = new Map();
+  for (const tag of spec.tags || []) {
+    groups.set(tag.name, { description: tag.description, operations: [] });
+  }
+  for (const [path, pathItem] of Object.entries(spec.paths || {})) {
+    for (const method of methods) {
+      const operation = pathItem[method];
+      if (!operation) {
+        continue;
+      }
+      const tag = operation.tags?.[0] || "Other";
+      if (!groups.has(tag)) {
+        groups.set(tag, { operations: [] });
+      }
+      groups.get(tag).operations.push(renderOperation(spec, path, pathItem, method, operation));
+    }
+  }
+
+  const container = document.getElementById("operations");
+  for (const [name, group] of groups) {
+    if (group.operations.length === 0) {
+      continue;
+    }
+    container.append(element("section", {},
+      element("h2", {}, name),
+      element("p", {}, group.description || ""),
+      ...group.operations,
+    ));
+  }
+}
+
+function init() {
+  bindSettings();
+  document.getElementById("filter").addEventListener("input", (event) => {
+    const filter = event.target.value.toLowerCase();
+    for (const operation of document.querySelectorAll(".operation")) {
+      operation.hidden = !operation.dataset.search.includes(filter);
+    }
+  });
+  loadSpec().catch(showError);
+}
+
+init();
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"buf.build/go/protovalidate"
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// fieldViolation builds a violation against a field of msg for checks that
+// protovalidate cannot express statically, such as ones that need the
+// database. A non-empty key addresses a single entry of a map field.
+func fieldViolation(msg proto.Message, name protoreflect.Name, key, ruleID, message string) *protovalidate.Violation {
+	field := msg.ProtoReflect().Descriptor().Fields().ByName(name)
+
+	element := &validate.FieldPathElement{
+		FieldNumber: proto.Int32(int32(field.Number())),
+		FieldName:   proto.String(string(field.Name())),
+		FieldType:   descriptorpb.FieldDescriptorProto_Type(field.Kind()).Enum(),
+	}
+	if field.IsMap() && key != "" {
+		element.KeyType = descriptorpb.FieldDescriptorProto_Type(field.MapKey().Kind()).Enum()
+		element.ValueType = descriptorpb.FieldDescriptorProto_Type(field.MapValue().Kind()).Enum()
+		element.Subscript = &validate.FieldPathElement_StringKey{StringKey: key}
+	}
+
+	return &protovalidate.Violation{
+		Proto: &validate.Violation{
+			Field:   &validate.FieldPath{Elements: []*validate.FieldPathElement{element}},
+			RuleId:  proto.String(ruleID),
+			Message: proto.String(message),
+		},
+		FieldDescriptor: field,
+	}
+}
+
+// violationsError reports violations the same way the validate interceptor
+// does, as an InvalidArgument error carrying a buf.validate.Violations detail.
+func violationsError(violations ...*protovalidate.Violation) error {
+	validationErr := &protovalidate.ValidationError{Violations: violations}
+	connectErr := connect.NewError(connect.CodeInvalidArgument, validationErr)
+	if detail, err := connect.NewErrorDetail(validationErr.ToProto()); err == nil {
+		connectErr.AddDetail(detail)
+	}
+	return connectErr
+}
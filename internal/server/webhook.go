@@ -0,0 +1,779 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	mathrand "math/rand/v2"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+const (
+	eventWebhookTest = "playground.v1.webhook.test"
+
+	// webhookSecretPrefix marks signing secrets, which follow the Standard
+	// Webhooks format of a prefixed base64 key.
+	webhookSecretPrefix = "whsec_"
+
+	// webhookBatchSize is the most deliveries the worker looks at per poll,
+	// one per subscription.
+	webhookBatchSize = 100
+)
+
+// webhookPolicy paces the delivery worker and decides when it gives up.
+type webhookPolicy struct {
+	// pollInterval is how often the worker looks for due deliveries.
+	pollInterval time.Duration
+	// concurrency bounds the deliveries in flight at once. A subscription
+	// has at most one in flight, so a slow receiver only holds up itself.
+	concurrency int
+	// timeout bounds a single delivery attempt.
+	timeout time.Duration
+	// maxAttempts is how many times a delivery is attempted before it is
+	// abandoned.
+	maxAttempts int64
+	// minBackoff and maxBackoff bound the delay between attempts, which
+	// doubles after each failure.
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	// disableAfter is how many attempts in a row can fail before the
+	// subscription is disabled, three deliveries' worth by default.
+	disableAfter int64
+}
+
+var defaultWebhookPolicy = webhookPolicy{
+	pollInterval: time.Second,
+	concurrency:  8,
+	timeout:      10 * time.Second,
+	maxAttempts:  8,
+	minBackoff:   time.Second,
+	maxBackoff:   5 * time.Minute,
+	disableAfter: 24,
+}
+
+// blockedWebhookPrefixes are the special purpose ranges, beyond the loopback,
+// private and link-local ones, that webhooks can't be delivered to.
+var blockedWebhookPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// checkWebhookAddress refuses connections to anything but public unicast
+// addresses. It runs once the host is resolved, so a name that resolves to an
+// internal address is refused too.
+func checkWebhookAddress(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	ip = ip.Unmap()
+
+	blocked := !ip.IsGlobalUnicast() || ip.IsPrivate()
+	for _, prefix := range blockedWebhookPrefixes {
+		blocked = blocked || prefix.Contains(ip)
+	}
+	if blocked {
+		return fmt.Errorf("webhooks can't be delivered to non-public address %s", ip)
+	}
+	return nil
+}
+
+// newWebhookClient returns the client webhooks are delivered with. Receivers
+// are given by callers, so unless allowPrivate is set it only connects to
+// public addresses, and it never follows redirects, which could lead
+// anywhere.
+func newWebhookClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+	if !allowPrivate {
+		dialer.Control = checkWebhookAddress
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would be dialed instead of the receiver, escaping the check
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func isTerminal(state playgroundv1.MessageState) bool {
+	return state != playgroundv1.MessageState_SENDING
+}
+
+func webhookStates(model models.WebhookSubscription) ([]playgroundv1.MessageState, error) {
+	var names []string
+	if err := json.Unmarshal([]byte(model.States), &names); err != nil {
+		return nil, err
+	}
+
+	states := make([]playgroundv1.MessageState, 0, len(names))
+	for _, name := range names {
+		states = append(states, playgroundv1.MessageState(playgroundv1.MessageState_value[name]))
+	}
+	return states, nil
+}
+
+func encodeWebhookStates(states []playgroundv1.MessageState) (string, error) {
+	names := make([]string, 0, len(states))
+	for _, state := range states {
+		names = append(names, state.String())
+	}
+	encoded, err := json.Marshal(names)
+	return string(encoded), err
+}
+
+// webhookFromModel converts a subscription, leaving out its secret.
+func webhookFromModel(model models.WebhookSubscription) (*playgroundv1.WebhookSubscription, error) {
+	states, err := webhookStates(model)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &playgroundv1.WebhookSubscription{
+		SubscriptionId:      model.ID,
+		Url:                 model.Url,
+		States:              states,
+		CreateTime:          timestamppb.New(model.CreatedAt),
+		ConsecutiveFailures: int32(model.ConsecutiveFailures),
+	}
+	if model.DisabledAt.Valid {
+		subscription.DisableTime = timestamppb.New(model.DisabledAt.Time)
+	}
+	return subscription, nil
+}
+
+func webhookDeliveryFromModel(model models.WebhookDelivery) *playgroundv1.WebhookDelivery {
+	delivery := &playgroundv1.WebhookDelivery{
+		DeliveryId:     model.ID,
+		SubscriptionId: model.SubscriptionID,
+		EventId:        model.EventID,
+		OperationId:    model.OperationID,
+		State:          playgroundv1.WebhookDeliveryState(playgroundv1.WebhookDeliveryState_value[model.State]),
+		Attempts:       int32(model.Attempts),
+		LastStatusCode: int32(model.LastStatusCode),
+		LastError:      model.LastError,
+		CreateTime:     timestamppb.New(model.CreatedAt),
+		UpdateTime:     timestamppb.New(model.UpdatedAt),
+	}
+	if delivery.State == playgroundv1.WebhookDeliveryState_PENDING {
+		delivery.NextAttemptTime = timestamppb.New(model.NextAttemptAt)
+	}
+	return delivery
+}
+
+// webhookURLViolation rejects URLs that can't be delivered to, which the uri
+// rule alone allows.
+func webhookURLViolation(msg proto.Message, raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return violationsError(fieldViolation(msg, "url", "", "webhook.url.scheme", "value must be an absolute http or https URL"))
+	}
+	return nil
+}
+
+// webhookSecretViolation rejects secrets whose key doesn't decode, which the
+// pattern rule alone allows.
+func webhookSecretViolation(msg proto.Message, secret string) error {
+	if _, err := base64.StdEncoding.Strict().DecodeString(strings.TrimPrefix(secret, webhookSecretPrefix)); err != nil {
+		return violationsError(fieldViolation(msg, "secret", "", "webhook.secret.base64", "value must be whsec_ followed by a base64 encoded key"))
+	}
+	return nil
+}
+
+func generateWebhookSecret() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return webhookSecretPrefix + base64.StdEncoding.EncodeToString(key), nil
+}
+
+// signWebhook signs a delivery the way Standard Webhooks does, returning the
+// value of the webhook-signature header.
+func signWebhook(secret, id string, timestamp time.Time, body []byte) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, webhookSecretPrefix))
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "%s.%d.", id, timestamp.Unix())
+	mac.Write(body)
+	return "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// enqueueWebhookDeliveries queues a delivery of a state change event to every
+// subscription interested in the operation's new state. queries should be the
+// transaction that changed the state.
+func enqueueWebhookDeliveries(ctx context.Context, queries *models.Queries, tenant string, event models.Event, operation models.SentMessage) error {
+	subscriptions, err := queries.ListWebhookSubscriptions(ctx, tenant)
+	if err != nil {
+		return err
+	}
+
+	state := playgroundv1.MessageState(playgroundv1.MessageState_value[operation.Result])
+	for _, subscription := range subscriptions {
+		if subscription.DisabledAt.Valid {
+			continue
+		}
+		states, err := webhookStates(subscription)
+		if err != nil {
+			return err
+		}
+		if len(states) > 0 && !slices.Contains(states, state) {
+			continue
+		}
+
+		if _, err := queries.CreateWebhookDelivery(ctx, models.CreateWebhookDeliveryParams{
+			TenantID:       tenant,
+			ID:             uuid.New().String(),
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			OperationID:    operation.ID,
+			State:          playgroundv1.WebhookDeliveryState_PENDING.String(),
+			NextAttemptAt:  time.Now().UTC(),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// postWebhook makes a single signed delivery attempt, returning the status
+// code of the response if there was one.
+func (h *handler) postWebhook(ctx context.Context, subscription models.WebhookSubscription, id string, body []byte) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, h.webhooks.timeout)
+	defer cancel()
+
+	// the event stream's newline delimiter isn't part of the event
+	body = bytes.TrimSuffix(body, []byte("\n"))
+
+	timestamp := time.Now()
+	signature, err := signWebhook(subscription.Secret, id, timestamp, body)
+	if err != nil {
+		return 0, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/cloudevents+json")
+	request.Header.Set("Webhook-Id", id)
+	request.Header.Set("Webhook-Timestamp", strconv.FormatInt(timestamp.Unix(), 10))
+	request.Header.Set("Webhook-Signature", signature)
+
+	response, err := h.webhookClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("receiver responded with %s", response.Status)
+	}
+	return response.StatusCode, nil
+}
+
+// backoff returns the delay before the next attempt after the given number
+// of failed ones, with jitter so that deliveries to a receiver that comes
+// back don't all retry at once.
+func (p webhookPolicy) backoff(attempts int64) time.Duration {
+	backoff := p.maxBackoff
+	if attempts < 32 {
+		backoff = min(p.minBackoff<<(attempts-1), p.maxBackoff)
+	}
+	return backoff/2 + mathrand.N(backoff/2+1)
+}
+
+// deliverWebhook makes the next attempt of a delivery and records its
+// outcome, counting it against the subscription.
+func (h *handler) deliverWebhook(ctx context.Context, delivery models.WebhookDelivery) error {
+	subscription, err := h.backend.GetWebhookSubscription(ctx, models.GetWebhookSubscriptionParams{
+		TenantID: delivery.TenantID,
+		ID:       delivery.SubscriptionID,
+	})
+	if err != nil {
+		return err
+	}
+	if subscription.DisabledAt.Valid {
+		// disabled since the delivery was listed
+		return nil
+	}
+
+	model, err := h.backend.GetEvent(ctx, models.GetEventParams{
+		TenantID: delivery.TenantID,
+		ID:       delivery.EventID,
+	})
+	if err != nil {
+		return err
+	}
+	event, err := eventFromModel(model)
+	if err != nil {
+		return err
+	}
+	body, err := cloudEvent(event)
+	if err != nil {
+		return err
+	}
+
+	status, deliveryErr := h.postWebhook(ctx, subscription, delivery.ID, body.Data)
+	if ctx.Err() != nil {
+		// shutting down, so leave the delivery to be attempted again
+		return nil
+	}
+
+	tx, queries, err := h.backend.Tx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	update := models.UpdateWebhookDeliveryParams{
+		TenantID:       delivery.TenantID,
+		ID:             delivery.ID,
+		State:          playgroundv1.WebhookDeliveryState_DELIVERED.String(),
+		Attempts:       delivery.Attempts + 1,
+		LastStatusCode: int64(status),
+		NextAttemptAt:  delivery.NextAttemptAt,
+	}
+	if deliveryErr != nil {
+		update.LastError = deliveryErr.Error()
+		if update.Attempts >= h.webhooks.maxAttempts {
+			update.State = playgroundv1.WebhookDeliveryState_ABANDONED.String()
+		} else {
+			update.State = playgroundv1.WebhookDeliveryState_PENDING.String()
+			update.NextAttemptAt = time.Now().UTC().Add(h.webhooks.backoff(update.Attempts))
+		}
+
+		failing, err := queries.RecordWebhookFailure(ctx, models.RecordWebhookFailureParams{
+			TenantID:     subscription.TenantID,
+			ID:           subscription.ID,
+			DisableAfter: h.webhooks.disableAfter,
+			Now:          sql.NullTime{Time: time.Now().UTC(), Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		if failing.DisabledAt.Valid && !subscription.DisabledAt.Valid {
+			h.logger.Warn().Str("subscription", subscription.ID).Int64("failures", failing.ConsecutiveFailures).Msg("Disabled failing webhook subscription")
+		}
+	} else if err := queries.RecordWebhookSuccess(ctx, models.RecordWebhookSuccessParams{
+		TenantID: subscription.TenantID,
+		ID:       subscription.ID,
+	}); err != nil {
+		return err
+	}
+
+	if _, err := queries.UpdateWebhookDelivery(ctx, update); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// runWebhookDeliveries attempts due deliveries until the context is done.
+// Deliveries are stored with the state change that caused them, so any left
+// pending by a restart are picked up again.
+func (h *handler) runWebhookDeliveries(ctx context.Context) {
+	ticker := time.NewTicker(h.webhooks.pollInterval)
+	defer ticker.Stop()
+
+	// the subscriptions with a delivery in flight, which get no other until
+	// it's done
+	var mu sync.Mutex
+	inFlight := map[string]bool{}
+	slots := make(chan struct{}, h.webhooks.concurrency)
+	// signalled as deliveries finish, so that the subscription's next one is
+	// looked for straight away
+	finished := make(chan struct{}, 1)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		deliveries, err := h.backend.ListDueWebhookDeliveries(ctx, models.ListDueWebhookDeliveriesParams{
+			State: playgroundv1.WebhookDeliveryState_PENDING.String(),
+			Now:   time.Now().UTC(),
+			Limit: webhookBatchSize,
+		})
+		if err != nil && ctx.Err() == nil {
+			h.logger.Err(err).Msg("Error listing webhook deliveries")
+		}
+
+		for _, delivery := range deliveries {
+			key := delivery.TenantID + "/" + delivery.SubscriptionID
+			mu.Lock()
+			busy := inFlight[key]
+			inFlight[key] = true
+			mu.Unlock()
+			if busy {
+				continue
+			}
+
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := h.deliverWebhook(ctx, delivery); err != nil && ctx.Err() == nil {
+					h.logger.Err(err).Str("delivery", delivery.ID).Msg("Error delivering webhook")
+				}
+
+				mu.Lock()
+				delete(inFlight, key)
+				mu.Unlock()
+				<-slots
+				select {
+				case finished <- struct{}{}:
+				default:
+				}
+			}()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-finished:
+		}
+	}
+}
+
+func (h *handler) CreateWebhookSubscription(ctx context.Context, req *connect.Request[playgroundv1.CreateWebhookSubscriptionRequest]) (*connect.Response[playgroundv1.CreateWebhookSubscriptionResponse], error) {
+	if err := webhookURLViolation(req.Msg, req.Msg.Url); err != nil {
+		return nil, err
+	}
+
+	secret := req.Msg.Secret
+	if secret != "" {
+		if err := webhookSecretViolation(req.Msg, secret); err != nil {
+			return nil, err
+		}
+	}
+
+	states, err := encodeWebhookStates(req.Msg.States)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if secret == "" {
+		if secret, err = generateWebhookSecret(); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+
+	model, err := h.backend.CreateWebhookSubscription(ctx, models.CreateWebhookSubscriptionParams{
+		TenantID: tenantFromContext(ctx),
+		ID:       uuid.New().String(),
+		Url:      req.Msg.Url,
+		States:   states,
+		Secret:   secret,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	subscription, err := webhookFromModel(model)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	subscription.Secret = model.Secret
+
+	return connect.NewResponse(&playgroundv1.CreateWebhookSubscriptionResponse{
+		Subscription: subscription,
+	}), nil
+}
+
+func (h *handler) GetWebhookSubscription(ctx context.Context, req *connect.Request[playgroundv1.GetWebhookSubscriptionRequest]) (*connect.Response[playgroundv1.GetWebhookSubscriptionResponse], error) {
+	model, err := h.backend.GetWebhookSubscription(ctx, models.GetWebhookSubscriptionParams{
+		TenantID: tenantFromContext(ctx),
+		ID:       req.Msg.SubscriptionId,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFoundError(resourceWebhook, req.Msg.SubscriptionId, fmt.Errorf("webhook subscription with ID %q not found", req.Msg.SubscriptionId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	subscription, err := webhookFromModel(model)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&playgroundv1.GetWebhookSubscriptionResponse{
+		Subscription: subscription,
+	}), nil
+}
+
+func (h *handler) ListWebhookSubscriptions(ctx context.Context, _ *connect.Request[playgroundv1.ListWebhookSubscriptionsRequest]) (*connect.Response[playgroundv1.ListWebhookSubscriptionsResponse], error) {
+	queried, err := h.backend.ListWebhookSubscriptions(ctx, tenantFromContext(ctx))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	var subscriptions []*playgroundv1.WebhookSubscription
+	for _, model := range queried {
+		subscription, err := webhookFromModel(model)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return connect.NewResponse(&playgroundv1.ListWebhookSubscriptionsResponse{
+		Subscriptions: subscriptions,
+	}), nil
+}
+
+func (h *handler) UpdateWebhookSubscription(ctx context.Context, req *connect.Request[playgroundv1.UpdateWebhookSubscriptionRequest]) (*connect.Response[playgroundv1.UpdateWebhookSubscriptionResponse], error) {
+	paths, err := updatePaths(req.Msg, req.Msg.UpdateMask, "url", "states", "secret", "disabled")
+	if err != nil {
+		return nil, err
+	}
+	if paths["url"] {
+		if err := webhookURLViolation(req.Msg, req.Msg.Url); err != nil {
+			return nil, err
+		}
+	}
+	if paths["secret"] && req.Msg.Secret != "" {
+		if err := webhookSecretViolation(req.Msg, req.Msg.Secret); err != nil {
+			return nil, err
+		}
+	}
+
+	tenant := tenantFromContext(ctx)
+
+	tx, queries, err := h.backend.Tx(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer tx.Rollback()
+
+	current, err := queries.GetWebhookSubscription(ctx, models.GetWebhookSubscriptionParams{
+		TenantID: tenant,
+		ID:       req.Msg.SubscriptionId,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFoundError(resourceWebhook, req.Msg.SubscriptionId, fmt.Errorf("webhook subscription with ID %q not found", req.Msg.SubscriptionId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	params := models.UpdateWebhookSubscriptionParams{
+		TenantID:            tenant,
+		ID:                  current.ID,
+		Url:                 current.Url,
+		States:              current.States,
+		Secret:              current.Secret,
+		ConsecutiveFailures: current.ConsecutiveFailures,
+		DisabledAt:          current.DisabledAt,
+	}
+	if paths["url"] {
+		params.Url = req.Msg.Url
+	}
+	if paths["states"] {
+		if params.States, err = encodeWebhookStates(req.Msg.States); err != nil {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+	if paths["secret"] {
+		// deliveries already pending are signed with the new secret too
+		params.Secret = req.Msg.Secret
+		if params.Secret == "" {
+			if params.Secret, err = generateWebhookSecret(); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		}
+	}
+	if paths["disabled"] {
+		if !req.Msg.Disabled {
+			// enabling a subscription gives its receiver a fresh start, and
+			// its pending deliveries are attempted again
+			params.ConsecutiveFailures = 0
+			params.DisabledAt = sql.NullTime{}
+		} else if !current.DisabledAt.Valid {
+			params.DisabledAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+		}
+	}
+
+	model, err := queries.UpdateWebhookSubscription(ctx, params)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := recordAudit(ctx, queries, ""); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	subscription, err := webhookFromModel(model)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if paths["secret"] {
+		subscription.Secret = model.Secret
+	}
+
+	return connect.NewResponse(&playgroundv1.UpdateWebhookSubscriptionResponse{
+		Subscription: subscription,
+	}), nil
+}
+
+func (h *handler) DeleteWebhookSubscription(ctx context.Context, req *connect.Request[playgroundv1.DeleteWebhookSubscriptionRequest]) (*connect.Response[playgroundv1.DeleteWebhookSubscriptionResponse], error) {
+	tenant := tenantFromContext(ctx)
+
+	tx, queries, err := h.backend.Tx(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	defer tx.Rollback()
+
+	rows, err := queries.DeleteWebhookSubscription(ctx, models.DeleteWebhookSubscriptionParams{
+		TenantID: tenant,
+		ID:       req.Msg.SubscriptionId,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	if rows == 0 {
+		return nil, notFoundError(resourceWebhook, req.Msg.SubscriptionId, fmt.Errorf("webhook subscription with ID %q not found", req.Msg.SubscriptionId))
+	}
+
+	// pending deliveries go with the subscription since there's nowhere left
+	// to deliver them
+	if err := queries.DeleteWebhookDeliveries(ctx, models.DeleteWebhookDeliveriesParams{
+		TenantID:       tenant,
+		SubscriptionID: req.Msg.SubscriptionId,
+	}); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := recordAudit(ctx, queries, ""); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&playgroundv1.DeleteWebhookSubscriptionResponse{}), nil
+}
+
+func (h *handler) ListWebhookDeliveries(ctx context.Context, req *connect.Request[playgroundv1.ListWebhookDeliveriesRequest]) (*connect.Response[playgroundv1.ListWebhookDeliveriesResponse], error) {
+	tenant := tenantFromContext(ctx)
+
+	if _, err := h.backend.GetWebhookSubscription(ctx, models.GetWebhookSubscriptionParams{
+		TenantID: tenant,
+		ID:       req.Msg.SubscriptionId,
+	}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFoundError(resourceWebhook, req.Msg.SubscriptionId, fmt.Errorf("webhook subscription with ID %q not found", req.Msg.SubscriptionId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	queried, err := h.backend.ListWebhookDeliveries(ctx, models.ListWebhookDeliveriesParams{
+		TenantID:       tenant,
+		SubscriptionID: req.Msg.SubscriptionId,
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	var deliveries []*playgroundv1.WebhookDelivery
+	for _, model := range queried {
+		deliveries = append(deliveries, webhookDeliveryFromModel(model))
+	}
+
+	return connect.NewResponse(&playgroundv1.ListWebhookDeliveriesResponse{
+		Deliveries: deliveries,
+	}), nil
+}
+
+// TestWebhook synchronously delivers a test event to a subscription. The
+// delivery isn't retried or logged.
+func (h *handler) TestWebhook(ctx context.Context, req *connect.Request[playgroundv1.TestWebhookRequest]) (*connect.Response[playgroundv1.TestWebhookResponse], error) {
+	subscription, err := h.backend.GetWebhookSubscription(ctx, models.GetWebhookSubscriptionParams{
+		TenantID: tenantFromContext(ctx),
+		ID:       req.Msg.SubscriptionId,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, notFoundError(resourceWebhook, req.Msg.SubscriptionId, fmt.Errorf("webhook subscription with ID %q not found", req.Msg.SubscriptionId))
+		}
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	id := uuid.New().String()
+	body, err := cloudEvent(&playgroundv1.Event{
+		EventId: id,
+		Type:    eventWebhookTest,
+		Subject: subscription.ID,
+		Time:    timestamppb.Now(),
+	})
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	start := time.Now()
+	status, err := h.postWebhook(ctx, subscription, id, body.Data)
+
+	response := &playgroundv1.TestWebhookResponse{
+		StatusCode: int32(status),
+		Latency:    durationpb.New(time.Since(start)),
+	}
+	if err != nil {
+		response.Error = err.Error()
+	}
+	return connect.NewResponse(response), nil
+}
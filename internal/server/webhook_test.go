@@ -0,0 +1,355 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/andrewstucki/vanguard-playground/internal/client"
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+)
+
+// testWebhookPolicy retries and gives up quickly enough for tests.
+var testWebhookPolicy = webhookPolicy{
+	pollInterval: 20 * time.Millisecond,
+	concurrency:  4,
+	timeout:      5 * time.Second,
+	maxAttempts:  3,
+	minBackoff:   10 * time.Millisecond,
+	maxBackoff:   20 * time.Millisecond,
+	disableAfter: 5,
+}
+
+// receiver is a webhook receiver that answers with the current status, once
+// the given number of failures are used up, and keeps every request it gets.
+type receiver struct {
+	*httptest.Server
+	status   atomic.Int32
+	failures atomic.Int32
+
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newReceiver(t *testing.T) *receiver {
+	r := &receiver{}
+	r.status.Store(http.StatusNoContent)
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		r.requests = append(r.requests, req)
+		r.bodies = append(r.bodies, body)
+		r.mu.Unlock()
+		if r.failures.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(int(r.status.Load()))
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) received() ([]*http.Request, [][]byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*http.Request(nil), r.requests...), append([][]byte(nil), r.bodies...)
+}
+
+func createWebhook(t *testing.T, c *client.Client, url string) *playgroundv1.WebhookSubscription {
+	t.Helper()
+	created, err := c.CreateWebhookSubscription(context.Background(), connect.NewRequest(&playgroundv1.CreateWebhookSubscriptionRequest{
+		Url: url,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return created.Msg.Subscription
+}
+
+// verifySignature checks a delivery's signature the way a Standard Webhooks
+// receiver would.
+func verifySignature(t *testing.T, secret string, request *http.Request, body []byte) {
+	t.Helper()
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, webhookSecretPrefix))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mac := hmac.New(sha256.New, key)
+	fmt.Fprintf(mac, "%s.%s.", request.Header.Get("Webhook-Id"), request.Header.Get("Webhook-Timestamp"))
+	mac.Write(body)
+	expected := "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	if signature := request.Header.Get("Webhook-Signature"); signature != expected {
+		t.Fatalf("expected signature %s, got %s", expected, signature)
+	}
+}
+
+// waitFor polls until condition holds, failing the test after ten seconds.
+func waitFor(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func listDeliveries(t *testing.T, c *client.Client, subscriptionID string) []*playgroundv1.WebhookDelivery {
+	t.Helper()
+	response, err := c.ListWebhookDeliveries(context.Background(), connect.NewRequest(&playgroundv1.ListWebhookDeliveriesRequest{
+		SubscriptionId: subscriptionID,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return response.Msg.Deliveries
+}
+
+func getWebhook(t *testing.T, c *client.Client, subscriptionID string) *playgroundv1.WebhookSubscription {
+	t.Helper()
+	response, err := c.GetWebhookSubscription(context.Background(), connect.NewRequest(&playgroundv1.GetWebhookSubscriptionRequest{
+		SubscriptionId: subscriptionID,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return response.Msg.Subscription
+}
+
+func TestWebhookDeliversSignedEvents(t *testing.T) {
+	s := newTestServer(t, Config{AllowPrivateWebhooks: true, webhookPolicy: &testWebhookPolicy})
+	c := s.client(t)
+	r := newReceiver(t)
+	subscription := createWebhook(t, c, r.URL)
+
+	operationID, _ := sendAndWait(t, s, nil)
+	waitFor(t, "the delivery", func() bool {
+		requests, _ := r.received()
+		return len(requests) == 1
+	})
+
+	requests, bodies := r.received()
+	verifySignature(t, subscription.Secret, requests[0], bodies[0])
+	var event struct {
+		Type string `json:"type"`
+		Data struct {
+			OperationID string `json:"operationId"`
+			State       string `json:"state"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(bodies[0], &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Type != eventOperationStateChanged || event.Data.OperationID != operationID || event.Data.State != "SUCCEEDED" {
+		t.Fatalf("unexpected event %s", bodies[0])
+	}
+
+	waitFor(t, "the delivery to be recorded", func() bool {
+		deliveries := listDeliveries(t, c, subscription.SubscriptionId)
+		return len(deliveries) == 1 && deliveries[0].State == playgroundv1.WebhookDeliveryState_DELIVERED
+	})
+}
+
+func TestWebhookRetriesAndDisables(t *testing.T) {
+	s := newTestServer(t, Config{AllowPrivateWebhooks: true, webhookPolicy: &testWebhookPolicy})
+	c := s.client(t)
+	ctx := context.Background()
+	r := newReceiver(t)
+	subscription := createWebhook(t, c, r.URL)
+
+	// the receiver fails twice, which the third attempt gets past
+	r.status.Store(http.StatusOK)
+	r.failures.Store(2)
+	sendAndWait(t, s, nil)
+	waitFor(t, "the retried delivery", func() bool {
+		deliveries := listDeliveries(t, c, subscription.SubscriptionId)
+		return len(deliveries) == 1 && deliveries[0].State == playgroundv1.WebhookDeliveryState_DELIVERED
+	})
+	if delivery := listDeliveries(t, c, subscription.SubscriptionId)[0]; delivery.Attempts != 3 || delivery.LastStatusCode != http.StatusOK {
+		t.Fatalf("expected the delivery to succeed on attempt 3, got %+v", delivery)
+	}
+	if failures := getWebhook(t, c, subscription.SubscriptionId).ConsecutiveFailures; failures != 0 {
+		t.Fatalf("expected the success to reset the failures, got %d", failures)
+	}
+
+	// once it always fails, the first delivery is abandoned after three
+	// attempts and the subscription disabled two attempts into the next
+	r.status.Store(http.StatusInternalServerError)
+	sendAndWait(t, s, nil)
+	sendAndWait(t, s, nil)
+	waitFor(t, "the subscription to be disabled", func() bool {
+		return getWebhook(t, c, subscription.SubscriptionId).DisableTime != nil
+	})
+	disabled := getWebhook(t, c, subscription.SubscriptionId)
+	if disabled.ConsecutiveFailures != 5 {
+		t.Fatalf("expected the subscription to be disabled after 5 failures, got %d", disabled.ConsecutiveFailures)
+	}
+	states := map[playgroundv1.WebhookDeliveryState]int{}
+	for _, delivery := range listDeliveries(t, c, subscription.SubscriptionId) {
+		states[delivery.State]++
+	}
+	if states[playgroundv1.WebhookDeliveryState_ABANDONED] != 1 || states[playgroundv1.WebhookDeliveryState_PENDING] != 1 {
+		t.Fatalf("expected one abandoned and one pending delivery, got %v", states)
+	}
+
+	// nothing more is queued or attempted while it's disabled
+	requests, _ := r.received()
+	sendAndWait(t, s, nil)
+	time.Sleep(10 * testWebhookPolicy.pollInterval)
+	if after, _ := r.received(); len(after) != len(requests) {
+		t.Fatalf("expected no attempts while disabled, got %d more", len(after)-len(requests))
+	}
+	if deliveries := listDeliveries(t, c, subscription.SubscriptionId); len(deliveries) != 3 {
+		t.Fatalf("expected no delivery to be queued while disabled, got %d deliveries", len(deliveries))
+	}
+
+	// enabling it resumes the pending delivery
+	r.status.Store(http.StatusOK)
+	enabled, err := c.UpdateWebhookSubscription(ctx, connect.NewRequest(&playgroundv1.UpdateWebhookSubscriptionRequest{
+		SubscriptionId: subscription.SubscriptionId,
+		UpdateMask:     &fieldmaskpb.FieldMask{Paths: []string{"disabled"}},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if enabled.Msg.Subscription.DisableTime != nil || enabled.Msg.Subscription.ConsecutiveFailures != 0 {
+		t.Fatalf("expected the subscription to be enabled, got %+v", enabled.Msg.Subscription)
+	}
+	waitFor(t, "the pending delivery", func() bool {
+		for _, delivery := range listDeliveries(t, c, subscription.SubscriptionId) {
+			if delivery.State == playgroundv1.WebhookDeliveryState_PENDING {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestWebhookRefusesPrivateAddresses(t *testing.T) {
+	s := newTestServer(t, Config{webhookPolicy: &testWebhookPolicy})
+	c := s.client(t)
+	r := newReceiver(t)
+	subscription := createWebhook(t, c, r.URL)
+
+	tested, err := c.TestWebhook(context.Background(), connect.NewRequest(&playgroundv1.TestWebhookRequest{
+		SubscriptionId: subscription.SubscriptionId,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(tested.Msg.Error, "non-public address 127.0.0.1") {
+		t.Fatalf("expected the loopback receiver to be refused, got %q", tested.Msg.Error)
+	}
+	if requests, _ := r.received(); len(requests) != 0 {
+		t.Fatalf("expected the receiver to get nothing, got %d requests", len(requests))
+	}
+}
+
+func TestWebhookDoesNotFollowRedirects(t *testing.T) {
+	s := newTestServer(t, Config{AllowPrivateWebhooks: true, webhookPolicy: &testWebhookPolicy})
+	c := s.client(t)
+	target := newReceiver(t)
+	redirect := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	t.Cleanup(redirect.Close)
+	subscription := createWebhook(t, c, redirect.URL)
+
+	tested, err := c.TestWebhook(context.Background(), connect.NewRequest(&playgroundv1.TestWebhookRequest{
+		SubscriptionId: subscription.SubscriptionId,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tested.Msg.StatusCode != http.StatusTemporaryRedirect || tested.Msg.Error == "" {
+		t.Fatalf("expected the redirect to fail the delivery, got %d %q", tested.Msg.StatusCode, tested.Msg.Error)
+	}
+	if requests, _ := target.received(); len(requests) != 0 {
+		t.Fatalf("expected the redirect not to be followed, got %d requests", len(requests))
+	}
+}
+
+func TestWebhookSecrets(t *testing.T) {
+	s := newTestServer(t, Config{AllowPrivateWebhooks: true, webhookPolicy: &testWebhookPolicy})
+	c := s.client(t)
+	ctx := context.Background()
+	r := newReceiver(t)
+
+	key := strings.Repeat("AAAA", 8)
+	for _, secret := range []string{
+		webhookSecretPrefix + key + "A",    // not whole quads
+		webhookSecretPrefix + key + "AB==", // padding bits set
+		webhookSecretPrefix + "AAAA",       // too short
+	} {
+		_, err := c.CreateWebhookSubscription(ctx, connect.NewRequest(&playgroundv1.CreateWebhookSubscriptionRequest{
+			Url:    r.URL,
+			Secret: secret,
+		}))
+		if connect.CodeOf(err) != connect.CodeInvalidArgument {
+			t.Errorf("expected secret %q to be InvalidArgument, got %v", secret, err)
+		}
+	}
+
+	subscription := createWebhook(t, c, r.URL)
+	rotated, err := c.UpdateWebhookSubscription(ctx, connect.NewRequest(&playgroundv1.UpdateWebhookSubscriptionRequest{
+		SubscriptionId: subscription.SubscriptionId,
+		UpdateMask:     &fieldmaskpb.FieldMask{Paths: []string{"secret"}},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := rotated.Msg.Subscription.Secret
+	if secret == "" || secret == subscription.Secret {
+		t.Fatalf("expected a new secret, got %q", secret)
+	}
+	if got := getWebhook(t, c, subscription.SubscriptionId).Secret; got != "" {
+		t.Fatalf("expected the secret to only be returned when it changes, got %q", got)
+	}
+
+	if _, err := c.TestWebhook(ctx, connect.NewRequest(&playgroundv1.TestWebhookRequest{
+		SubscriptionId: subscription.SubscriptionId,
+	})); err != nil {
+		t.Fatal(err)
+	}
+	requests, bodies := r.received()
+	if len(requests) != 1 {
+		t.Fatalf("expected one test delivery, got %d", len(requests))
+	}
+	verifySignature(t, secret, requests[0], bodies[0])
+}
+
+func TestWebhookSlowReceiverHoldsUpOnlyItself(t *testing.T) {
+	s := newTestServer(t, Config{AllowPrivateWebhooks: true, webhookPolicy: &testWebhookPolicy})
+	c := s.client(t)
+
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-release
+	}))
+	t.Cleanup(slow.Close)
+	t.Cleanup(func() { close(release) })
+	fast := newReceiver(t)
+	createWebhook(t, c, slow.URL)
+	createWebhook(t, c, fast.URL)
+
+	sendAndWait(t, s, nil)
+	waitFor(t, "the fast receiver's delivery", func() bool {
+		requests, _ := fast.received()
+		return len(requests) == 1
+	})
+}
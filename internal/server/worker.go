@@ -3,12 +3,12 @@ package server
 import (
 	"context"
 	"errors"
-	"time"
-
-	"github.com/andrewstucki/vanguard-playground/internal/models"
 )
 
-func RunWorker(ctx context.Context) (ret error) {
+// RunWorker runs the workflows of a persistent server's database until ctx
+// is done. It takes the server's config, as the steps it runs read the same
+// attachment content and make the same webhook calls.
+func RunWorker(ctx context.Context, config Config) (ret error) {
 	logger, writer := NewLogger()
 	defer func() {
 		if err := writer.Close(); err != nil {
@@ -18,28 +18,17 @@ func RunWorker(ctx context.Context) (ret error) {
 
 	logger = logger.With().Str("component", "worker").Logger()
 
-	handler := &handler{
-		logger: logger,
-	}
-
-	backend, err := models.NewBackend(models.BackendConfig{
-		Logger:  logger,
-		Handler: handler,
-		// can only be run in persistent mode
-		Persistent: true,
-	})
-	if err != nil {
-		return err
-	}
-	handler.backend = backend
+	// can only be run in persistent mode
+	config.Persistent = true
+	handler, closers, err := newHandler(logger, config)
 	defer func() {
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-		if err := handler.backend.Shutdown(shutdownCtx); err != nil {
-			logger.Err(err).Msg("Error shutting processor down cleanly")
-			ret = errors.Join(ret, err)
+		for i := len(closers) - 1; i >= 0; i-- {
+			ret = errors.Join(ret, closers[i]())
 		}
-		cancel()
 	}()
+	if err != nil {
+		return err
+	}
 
 	if err := handler.backend.Start(ctx); err != nil {
 		return err
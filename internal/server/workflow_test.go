@@ -0,0 +1,253 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/microsoft/durabletask-go/api"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	playgroundv1 "github.com/andrewstucki/vanguard-playground/internal/gen/playground/v1"
+	"github.com/andrewstucki/vanguard-playground/internal/models"
+)
+
+// sendAndWait sends a message with the given fault and waits for the
+// workflow engine to finish the operation.
+func sendAndWait(t *testing.T, s *testServer, fault *playgroundv1.FaultSpec) (string, *playgroundv1.MessageStatusResponse) {
+	t.Helper()
+	c := s.client(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	sent, err := c.SendMessage(ctx, connect.NewRequest(&playgroundv1.SendMessageRequest{
+		MessageId: createMessage(t, c, "hello"),
+		Fault:     fault,
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, err := c.WaitForOperation(ctx, sent.Msg.MessageId, sent.Msg.OperationId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sent.Msg.OperationId, status
+}
+
+// attemptRows returns the attempts recorded for an operation as
+// "step/attempt/error" strings, in the order they were made.
+func attemptRows(t *testing.T, s *testServer, operationID string) []string {
+	t.Helper()
+	attempts, err := s.handler.backend.ListOperationAttempts(context.Background(), models.ListOperationAttemptsParams{
+		TenantID:    DefaultTenant,
+		OperationID: operationID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows []string
+	for _, attempt := range attempts {
+		rows = append(rows, fmt.Sprintf("%s/%d/%s", attempt.Step, attempt.Attempt, attempt.Error.String))
+	}
+	return rows
+}
+
+func assertRows(t *testing.T, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected attempts %q, got %q", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected attempts %q, got %q", want, got)
+		}
+	}
+}
+
+func TestSendSucceeds(t *testing.T) {
+	s := newTestServer(t, Config{})
+	operationID, status := sendAndWait(t, s, nil)
+
+	if status.State != playgroundv1.MessageState_SUCCEEDED.String() || status.CurrentStep != "confirm" {
+		t.Fatalf("expected the send to succeed at confirm, got %s at %s", status.State, status.CurrentStep)
+	}
+	assertRows(t, attemptRows(t, s, operationID), []string{
+		"validate/1/", "reserve/1/", "render/1/", "deliver/1/", "bill/1/", "confirm/1/",
+	})
+	if len(status.Compensations) != 0 {
+		t.Errorf("expected no compensations, got %v", status.Compensations)
+	}
+}
+
+func TestSendRetriesFailedAttempts(t *testing.T) {
+	s := newTestServer(t, Config{AllowFaultInjection: true})
+	operationID, status := sendAndWait(t, s, &playgroundv1.FaultSpec{FailAttempts: 1})
+
+	if status.State != playgroundv1.MessageState_SUCCEEDED.String() {
+		t.Fatalf("expected the send to succeed once retried, got %s", status.State)
+	}
+	var want []string
+	for _, step := range []string{"validate", "reserve", "render", "deliver", "bill", "confirm"} {
+		want = append(want,
+			fmt.Sprintf("%s/1/injected failure in step %q on attempt 1", step, step),
+			fmt.Sprintf("%s/2/", step),
+		)
+	}
+	assertRows(t, attemptRows(t, s, operationID), want)
+}
+
+func TestSendDeadLettersNonRetryableFailures(t *testing.T) {
+	s := newTestServer(t, Config{AllowFaultInjection: true})
+	operationID, status := sendAndWait(t, s, &playgroundv1.FaultSpec{NonRetryable: true})
+
+	if status.State != playgroundv1.MessageState_FAILED.String() || status.CurrentStep != "validate" {
+		t.Fatalf("expected the send to fail at validate, got %s at %s", status.State, status.CurrentStep)
+	}
+	assertRows(t, attemptRows(t, s, operationID), []string{
+		`validate/1/injected non-retryable failure in step "validate"`,
+	})
+
+	deadLetters, err := s.handler.backend.ListDeadLetters(context.Background(), DefaultTenant)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deadLetters) != 1 || deadLetters[0].OperationID != operationID || deadLetters[0].Step != "validate" {
+		t.Fatalf("expected the operation to be dead-lettered at validate, got %+v", deadLetters)
+	}
+}
+
+func TestCancelCompensatesCompletedSteps(t *testing.T) {
+	s := newTestServer(t, Config{AllowFaultInjection: true})
+	c := s.client(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// each step takes a second, so the send can be caught part way through
+	sent, err := c.SendMessage(ctx, connect.NewRequest(&playgroundv1.SendMessageRequest{
+		MessageId: createMessage(t, c, "hello"),
+		Fault:     &playgroundv1.FaultSpec{Latency: durationpb.New(time.Second)},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.WatchOperation(ctx, sent.Msg.MessageId, sent.Msg.OperationId, func(status *playgroundv1.MessageStatusResponse) {
+		if status.CurrentStep != "deliver" {
+			return
+		}
+		if _, err := c.CancelSend(ctx, connect.NewRequest(&playgroundv1.CancelSendRequest{
+			MessageId:   sent.Msg.MessageId,
+			OperationId: sent.Msg.OperationId,
+		})); err != nil && connect.CodeOf(err) != connect.CodeFailedPrecondition {
+			t.Error(err)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the workflow compensates after the operation is terminal
+	var status *playgroundv1.MessageStatusResponse
+	for {
+		response, err := c.MessageStatus(ctx, connect.NewRequest(&playgroundv1.MessageStatusRequest{
+			MessageId:   sent.Msg.MessageId,
+			OperationId: sent.Msg.OperationId,
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		status = response.Msg
+		if len(status.Compensations) >= 2 || ctx.Err() != nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if status.State != playgroundv1.MessageState_CANCELED.String() || status.CurrentStep != "deliver" {
+		t.Fatalf("expected the send to be canceled at deliver, got %s at %s", status.State, status.CurrentStep)
+	}
+	var compensated []string
+	for _, compensation := range status.Compensations {
+		compensated = append(compensated, fmt.Sprintf("%s/%d/%s", compensation.Step, compensation.Attempt, compensation.Error))
+	}
+	assertRows(t, compensated, []string{"deliver/1/", "reserve/1/"})
+
+	if _, err := s.handler.backend.GetQuotaReservation(ctx, models.GetQuotaReservationParams{
+		TenantID:    DefaultTenant,
+		OperationID: sent.Msg.OperationId,
+	}); err == nil {
+		t.Error("expected the quota reservation to be released")
+	}
+}
+
+func TestGiveUpSweepSchedulesPendingWorkflows(t *testing.T) {
+	s := newTestServer(t, Config{})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	messageID := createMessage(t, s.client(t), "hello")
+	operation, err := s.handler.backend.CreateSentMessage(ctx, models.CreateSentMessageParams{
+		TenantID:  DefaultTenant,
+		ID:        "00000000-0000-4000-8000-000000000001",
+		MessageID: messageID,
+		Text:      "hello",
+		Result:    playgroundv1.MessageState_SENDING.String(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the sweep fails the operation and keeps its compensation run pending
+	// in one go, as if scheduling the run then failed
+	input := &playgroundv1.SendMessageState{TenantId: DefaultTenant, OperationId: operation.ID}
+	if err := s.handler.deadLetter(ctx, input, "deliver", errors.New("workflow failed"), true); err != nil {
+		t.Fatal(err)
+	}
+	sending, err := s.handler.backend.ListLatestWorkflowInstances(ctx, playgroundv1.MessageState_SENDING.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sending) != 0 {
+		t.Fatalf("expected no operation to be left SENDING, got %+v", sending)
+	}
+
+	// a sweep leaves the run to its caller for a while
+	if err := s.handler.sweepGivenUp(ctx); err != nil {
+		t.Fatal(err)
+	}
+	pending, err := s.handler.backend.ListPendingWorkflows(ctx, time.Now().UTC().Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 || pending[0].OperationID != operation.ID {
+		t.Fatalf("expected the compensation run to be pending, got %+v", pending)
+	}
+
+	// and schedules it once the grace has passed
+	if err := s.handler.schedulePendingWorkflows(ctx, time.Now().UTC().Add(pendingWorkflowGrace)); err != nil {
+		t.Fatal(err)
+	}
+	pending, err = s.handler.backend.ListPendingWorkflows(ctx, time.Now().UTC().Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("expected the scheduled compensation to be forgotten, got %+v", pending)
+	}
+	instances, err := s.handler.backend.ListWorkflowInstances(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(instances) == 0 || instances[0].OperationID != operation.ID {
+		t.Fatalf("expected a compensation run for the operation, got %+v", instances)
+	}
+	waitFor(t, "the compensation run to complete", func() bool {
+		metadata, err := s.handler.backend.TaskHub.FetchOrchestrationMetadata(ctx, api.InstanceID(instances[0].InstanceID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return metadata.RuntimeStatus == api.RUNTIME_STATUS_COMPLETED
+	})
+}